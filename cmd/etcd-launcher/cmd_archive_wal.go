@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/cmd/etcd-launcher/pkg/etcd"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// walPruneInterval is how often archived WAL segments that are no longer needed
+// by any backup are deleted.
+const walPruneInterval = time.Hour
+
+type archiveWALOptions struct {
+	options
+
+	backupConfig    string
+	epoch           int64
+	segmentInterval time.Duration
+	caBundleFile    string
+
+	encryptionOptions encryptionOptions
+}
+
+func ArchiveWALCommand(logger *zap.SugaredLogger) *cobra.Command {
+	opt := archiveWALOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "archive-wal",
+		Short:        "Continuously archive all changes to the etcd keyspace to S3",
+		RunE:         ArchiveWALFunc(logger, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			if opt.backupConfig == "" {
+				return errors.New("--backup-config cannot be empty")
+			}
+
+			if opt.epoch < 0 {
+				return errors.New("--epoch must not be negative")
+			}
+
+			if opt.segmentInterval <= 0 {
+				return errors.New("--segment-interval must be positive")
+			}
+
+			if err := opt.encryptionOptions.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.backupConfig, "backup-config", "", "name of the EtcdBackupConfig to archive the WAL for")
+	cmd.PersistentFlags().Int64Var(&opt.epoch, "epoch", 0, "epoch of the WAL archive, incremented after every restore of the etcd")
+	cmd.PersistentFlags().DurationVar(&opt.segmentInterval, "segment-interval", kubermaticv1.DefaultWALSegmentInterval, "maximum time span covered by a single WAL segment")
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "/etc/ca-bundle/ca-bundle.pem", "path to the CA bundle used to verify the S3 endpoint")
+	opt.encryptionOptions.AddFlags(cmd.PersistentFlags())
+
+	return cmd
+}
+
+func ArchiveWALFunc(log *zap.SugaredLogger, opt *archiveWALOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("cluster", opt.cluster, "backup-config", opt.backupConfig, "epoch", opt.epoch)
+
+		e := &etcd.Cluster{
+			Cluster:           opt.cluster,
+			EtcdctlAPIVersion: opt.etcdctlAPIVersion,
+
+			CaCertFile:     opt.etcdCAFile,
+			ClientCertFile: opt.etcdCertFile,
+			ClientKeyFile:  opt.etcdKeyFile,
+		}
+
+		cluster, err := e.Init(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to initialize etcd cluster configuration: %w", err)
+		}
+
+		if err := e.SetClusterSize(ctx); err != nil {
+			return fmt.Errorf("failed to set expected cluster size: %w", err)
+		}
+
+		s3Client, bucket, err := newS3ClientFromEnv(opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		dataKey, err := opt.encryptionOptions.DataKey()
+		if err != nil {
+			return fmt.Errorf("failed to load encryption key: %w", err)
+		}
+
+		client, err := e.GetEtcdClient(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to get etcd cluster client: %w", err)
+		}
+		defer client.Close()
+
+		archiver := &etcd.WALArchiver{
+			Client:          client,
+			S3Client:        s3Client,
+			Bucket:          bucket,
+			Prefix:          etcdbackup.WALArchivePrefix(cluster, opt.backupConfig, opt.epoch),
+			SegmentInterval: opt.segmentInterval,
+			DataKey:         dataKey,
+		}
+
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := pruneWAL(ctx, log, e, cluster, opt.backupConfig, s3Client, bucket); err != nil {
+				log.Errorw("failed to prune WAL archive", zap.Error(err))
+			}
+		}, walPruneInterval)
+
+		return archiver.Run(ctx, log)
+	})
+}
+
+// pruneWAL deletes all archived WAL segments that are no longer needed by any backup of the
+// EtcdBackupConfig, so that the archive is subject to the same retention as the backups.
+func pruneWAL(ctx context.Context, log *zap.SugaredLogger, e *etcd.Cluster, cluster *kubermaticv1.Cluster, backupConfigName string, s3Client *minio.Client, bucket string) error {
+	backupConfig, err := e.EtcdBackupConfig(ctx, backupConfigName)
+	if err != nil {
+		return fmt.Errorf("failed to get EtcdBackupConfig: %w", err)
+	}
+
+	for epoch, before := range etcd.WALPruneCutoffs(backupConfig, time.Now()) {
+		prefix := etcdbackup.WALArchivePrefix(cluster, backupConfigName, epoch)
+
+		deleted, err := etcd.PruneWALSegments(ctx, log, s3Client, bucket, prefix, before)
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			log.Infow("pruned WAL archive", "prefix", prefix, "segments", deleted)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
+)
+
+type deleteChunkedOptions struct {
+	options
+
+	backupName   string
+	caBundleFile string
+}
+
+func DeleteChunkedCommand(logger *zap.SugaredLogger) *cobra.Command {
+	opt := deleteChunkedOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "delete-chunked",
+		Short:        "Delete a chunked backup from S3 and garbage collect unreferenced chunks",
+		RunE:         DeleteChunkedFunc(logger, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			if opt.cluster == "" {
+				return errors.New("--cluster cannot be empty")
+			}
+
+			if !chunkstore.IsManifest(opt.backupName) {
+				return fmt.Errorf("--backup-name must end in %q", chunkstore.ManifestSuffix)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.backupName, "backup-name", "", "name of the backup to delete")
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "/etc/ca-bundle/ca-bundle.pem", "path to the CA bundle used to verify the S3 endpoint")
+
+	return cmd
+}
+
+func DeleteChunkedFunc(log *zap.SugaredLogger, opt *deleteChunkedOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("cluster", opt.cluster, "backup", opt.backupName)
+
+		s3Client, bucket, err := newS3ClientFromEnv(opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		store := chunkstore.New(s3Client, bucket, opt.cluster)
+
+		if err := store.Delete(ctx, log, fmt.Sprintf("%s-%s", opt.cluster, opt.backupName)); err != nil {
+			return fmt.Errorf("failed to delete backup: %w", err)
+		}
+
+		log.Info("deleted chunked backup")
+
+		return nil
+	})
+}
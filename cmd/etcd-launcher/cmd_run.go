@@ -178,6 +178,19 @@ func RunFunc(log *zap.SugaredLogger, opt *runOptions) cobraFuncE {
 			log.Panicw("manager thread failed to connect to cluster", zap.Error(err))
 		}
 
+		// if a point-in-time restore is in progress, replay the archived WAL on top of the restored backup.
+		go func() {
+			if err := wait.PollUntilContextCancel(ctx, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+				if err := e.ReplayWALIfNeeded(ctx, log); err != nil {
+					log.Warnw("failed to replay archived WAL", zap.Error(err))
+					return false, nil
+				}
+				return true, nil
+			}); err != nil {
+				log.Warnw("stopped replaying archived WAL", zap.Error(err))
+			}
+		}()
+
 		// reconcile dead members continuously. Initially we did this once as a step at the end of start up. We did that because scale up/down operations required a full restart of the ring with each node add/remove. However, this is no longer the case, so we need to separate the reconcile from the start up process and do it continuously.
 		go func() {
 			wait.Forever(func() {
@@ -30,7 +30,8 @@ import (
 type snapshotCmdOptions struct {
 	options
 
-	snapshotOptions etcd.SnapshotOptions
+	snapshotOptions   etcd.SnapshotOptions
+	encryptionOptions encryptionOptions
 }
 
 func SnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
@@ -48,6 +49,10 @@ func SnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
 				return fmt.Errorf("invalid --compression algorithm, must be one of %v", etcd.ValidCompressions)
 			}
 
+			if err := opt.encryptionOptions.Validate(); err != nil {
+				return err
+			}
+
 			return nil
 		},
 	}
@@ -63,6 +68,7 @@ func SnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&opt.snapshotOptions.Compression, "compress", "", fmt.Sprintf("compression to use (one of: %v)", etcd.ValidCompressions))
 	cmd.PersistentFlags().StringVar(&opt.snapshotOptions.File, "file", "/backup/snapshot.db", "file to save database snapshot to")
+	opt.encryptionOptions.AddFlags(cmd.PersistentFlags())
 
 	return cmd
 }
@@ -85,6 +91,12 @@ func SnapshotFunc(log *zap.SugaredLogger, opt *snapshotCmdOptions) cobraFuncE {
 			return fmt.Errorf("failed to initialize etcd cluster configuration: %w", err)
 		}
 
+		dataKey, err := opt.encryptionOptions.DataKey()
+		if err != nil {
+			return fmt.Errorf("failed to load encryption key: %w", err)
+		}
+		opt.snapshotOptions.EncryptionKey = dataKey
+
 		if err := e.SetClusterSize(ctx); err != nil {
 			return fmt.Errorf("failed to set expected cluster size: %w", err)
 		}
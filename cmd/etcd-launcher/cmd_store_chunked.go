@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
+)
+
+type storeChunkedOptions struct {
+	options
+
+	file         string
+	backupName   string
+	caBundleFile string
+
+	encryptionOptions encryptionOptions
+}
+
+func StoreChunkedCommand(logger *zap.SugaredLogger) *cobra.Command {
+	opt := storeChunkedOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "store-chunked",
+		Short:        "Upload an etcd snapshot as deduplicated chunks plus a manifest to S3",
+		RunE:         StoreChunkedFunc(logger, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			if opt.cluster == "" {
+				return errors.New("--cluster cannot be empty")
+			}
+
+			if !chunkstore.IsManifest(opt.backupName) {
+				return fmt.Errorf("--backup-name must end in %q", chunkstore.ManifestSuffix)
+			}
+
+			if err := opt.encryptionOptions.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.file, "file", "/backup/snapshot.db", "uncompressed database snapshot to upload")
+	cmd.PersistentFlags().StringVar(&opt.backupName, "backup-name", "", "name of the backup to create")
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "/etc/ca-bundle/ca-bundle.pem", "path to the CA bundle used to verify the S3 endpoint")
+	opt.encryptionOptions.AddFlags(cmd.PersistentFlags())
+
+	return cmd
+}
+
+func StoreChunkedFunc(log *zap.SugaredLogger, opt *storeChunkedOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("cluster", opt.cluster, "backup", opt.backupName)
+
+		s3Client, bucket, err := newS3ClientFromEnv(opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		snapshot, err := os.Open(opt.file)
+		if err != nil {
+			return fmt.Errorf("failed to open snapshot: %w", err)
+		}
+		defer snapshot.Close()
+
+		dataKey, err := opt.encryptionOptions.DataKey()
+		if err != nil {
+			return fmt.Errorf("failed to load encryption key: %w", err)
+		}
+
+		store := chunkstore.New(s3Client, bucket, opt.cluster)
+		if dataKey != nil {
+			store = store.WithEncryption(dataKey)
+		}
+
+		manifest, stats, err := store.Upload(ctx, snapshot, fmt.Sprintf("%s-%s", opt.cluster, opt.backupName))
+		if err != nil {
+			return fmt.Errorf("failed to upload snapshot: %w", err)
+		}
+
+		log.Infow("uploaded chunked backup",
+			"size", manifest.Size,
+			"chunks", stats.Chunks,
+			"new-chunks", stats.NewChunks,
+			"uploaded-bytes", stats.UploadedBytes,
+		)
+
+		return nil
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/cmd/etcd-launcher/pkg/etcd"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+)
+
+type verifyBackupOptions struct {
+	options
+
+	backupName   string
+	caBundleFile string
+	dataDir      string
+	resultFile   string
+
+	encryptionOptions encryptionOptions
+}
+
+func VerifyBackupCommand(logger *zap.SugaredLogger) *cobra.Command {
+	opt := verifyBackupOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "verify-backup",
+		Short:        "Verify a backup by restoring it into a throwaway etcd and comparing it with the cluster's etcd",
+		RunE:         VerifyBackupFunc(logger, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			if opt.cluster == "" {
+				return errors.New("--cluster cannot be empty")
+			}
+
+			if opt.backupName == "" {
+				return errors.New("--backup-name cannot be empty")
+			}
+
+			if opt.dataDir == "" {
+				return errors.New("--data-dir cannot be empty")
+			}
+
+			return opt.encryptionOptions.Validate()
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.backupName, "backup-name", "", "name of the backup to verify")
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "/etc/ca-bundle/ca-bundle.pem", "path to the CA bundle used to verify the S3 endpoint")
+	cmd.PersistentFlags().StringVar(&opt.dataDir, "data-dir", "/scratch/etcd", "scratch directory to download and restore the backup into")
+	cmd.PersistentFlags().StringVar(&opt.resultFile, "result-file", "/dev/termination-log", "file to write the verification result to")
+	opt.encryptionOptions.AddFlags(cmd.PersistentFlags())
+
+	return cmd
+}
+
+func VerifyBackupFunc(log *zap.SugaredLogger, opt *verifyBackupOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		log := log.With("cluster", opt.cluster, "backup", opt.backupName)
+
+		result, err := verifyBackup(cmd, log, opt)
+		if err != nil {
+			result = &etcdbackup.BackupVerificationResult{Error: err.Error()}
+		}
+
+		if writeErr := writeVerificationResult(opt.resultFile, result); writeErr != nil {
+			log.Errorw("failed to write verification result", zap.Error(writeErr))
+		}
+
+		if result.Error != "" {
+			return fmt.Errorf("backup verification failed: %s", result.Error)
+		}
+
+		log.Infow("backup verified successfully", "revision", result.Revision, "keys", result.KeyCount)
+
+		return nil
+	})
+}
+
+func verifyBackup(cmd *cobra.Command, log *zap.SugaredLogger, opt *verifyBackupOptions) (*etcdbackup.BackupVerificationResult, error) {
+	ctx := cmd.Context()
+
+	e := &etcd.Cluster{
+		Cluster:           opt.cluster,
+		EtcdctlAPIVersion: opt.etcdctlAPIVersion,
+
+		CaCertFile:     opt.etcdCAFile,
+		ClientCertFile: opt.etcdCertFile,
+		ClientKeyFile:  opt.etcdKeyFile,
+	}
+
+	if _, err := e.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize etcd cluster configuration: %w", err)
+	}
+
+	if err := e.SetClusterSize(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set expected cluster size: %w", err)
+	}
+
+	s3Client, bucket, err := newS3ClientFromEnv(opt.caBundleFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := opt.encryptionOptions.DataKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption key: %w", err)
+	}
+
+	downloadDir := filepath.Join(opt.dataDir, "download")
+	if err := os.MkdirAll(downloadDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	snapshotFile, err := etcd.DownloadSnapshot(ctx, s3Client, bucket, opt.cluster, opt.backupName, dataKey, downloadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd cluster client: %w", err)
+	}
+	defer source.Close()
+
+	return etcd.VerifySnapshot(ctx, log, snapshotFile, filepath.Join(opt.dataDir, "restore"), source)
+}
+
+func writeVerificationResult(filename string, result *etcdbackup.BackupVerificationResult) error {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, encoded, 0644)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+)
+
+// encryptionOptions configure the encryption of backups. Both the key encryption keys and
+// the wrapped data key are mounted from secrets into the backup jobs.
+type encryptionOptions struct {
+	keysDir     string
+	dataKeyFile string
+}
+
+func (o *encryptionOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.keysDir, "encryption-keys-dir", "", "directory containing the key encryption keys, one file per key")
+	fs.StringVar(&o.dataKeyFile, "encryption-data-key", "", "file containing the wrapped data key to encrypt backups with")
+}
+
+func (o *encryptionOptions) Validate() error {
+	if (o.keysDir == "") != (o.dataKeyFile == "") {
+		return errors.New("--encryption-keys-dir and --encryption-data-key must be specified together")
+	}
+
+	return nil
+}
+
+// DataKey returns the unwrapped data key, or nil if encryption is not enabled.
+func (o *encryptionOptions) DataKey() ([]byte, error) {
+	if o.dataKeyFile == "" {
+		return nil, nil
+	}
+
+	return backupcrypto.LoadDataKey(o.keysDir, o.dataKeyFile)
+}
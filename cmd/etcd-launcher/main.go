@@ -106,6 +106,10 @@ func addCommands(cmd *cobra.Command, logger *zap.SugaredLogger, versions kuberma
 		IsRunningCommand(logger),
 		DefragCommand(logger),
 		SnapshotCommand(logger),
+		ArchiveWALCommand(logger),
+		StoreChunkedCommand(logger),
+		DeleteChunkedCommand(logger),
+		VerifyBackupCommand(logger),
 	)
 }
 
@@ -28,7 +28,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
@@ -38,6 +37,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
 	"k8c.io/kubermatic/v2/pkg/util/wait"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -120,6 +120,17 @@ func (e *Cluster) KubermaticCluster(ctx context.Context) (*kubermaticv1.Cluster,
 	return cluster, nil
 }
 
+// EtcdBackupConfig returns the EtcdBackupConfig with the given name from the cluster namespace.
+func (e *Cluster) EtcdBackupConfig(ctx context.Context, name string) (*kubermaticv1.EtcdBackupConfig, error) {
+	config := &kubermaticv1.EtcdBackupConfig{}
+	key := types.NamespacedName{Namespace: e.namespace, Name: name}
+	if err := e.clusterClient.Get(ctx, key, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
 func (e *Cluster) SetInitialState(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
 	// check if the etcd cluster is initialized successfully.
 	if cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) {
@@ -650,24 +661,37 @@ func (e *Cluster) restoreDatadirFromBackupIfNeeded(ctx context.Context, log *zap
 		return fmt.Errorf("failed to get s3 client: %w", err)
 	}
 
-	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), activeRestore.Spec.BackupName)
-	downloadedSnapshotFile := fmt.Sprintf("/tmp/%s", objectName)
-
-	if err := s3Client.FGetObject(ctx, bucketName, objectName, downloadedSnapshotFile, minio.GetObjectOptions{}); err != nil {
-		return fmt.Errorf("failed to download backup (%s/%s): %w", bucketName, objectName, err)
+	dataKey, err := resources.GetEtcdRestoreEncryptionKey(ctx, activeRestore, seedClient, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get encryption key: %w", err)
 	}
 
-	rawBackupFile, err := DecompressSnapshot(downloadedSnapshotFile)
+	rawBackupFile, err := DownloadSnapshot(ctx, s3Client, bucketName, cluster.GetName(), activeRestore.Spec.BackupName, dataKey, "/tmp")
 	if err != nil {
-		return fmt.Errorf("failed to decompress snapshot file %s: %w", objectName, err)
+		return err
+	}
+
+	sp := snapshot.NewV3(log.Desugar())
+
+	// for point-in-time restores, remember the snapshot's revision so that the archived
+	// WAL can later be replayed starting right after it
+	if activeRestore.Spec.PointInTime != nil {
+		status, err := sp.Status(rawBackupFile)
+		if err != nil {
+			return fmt.Errorf("failed to determine snapshot revision: %w", err)
+		}
+
+		if err := e.updateRestoreStatus(ctx, activeRestore, func(restore *kubermaticv1.EtcdRestore) {
+			restore.Status.SnapshotRevision = status.Revision
+		}); err != nil {
+			return fmt.Errorf("failed to record snapshot revision: %w", err)
+		}
 	}
 
 	if err := os.RemoveAll(e.DataDir); err != nil {
 		return fmt.Errorf("error deleting data directory before restore (%s): %w", e.DataDir, err)
 	}
 
-	sp := snapshot.NewV3(log.Desugar())
-
 	return sp.Restore(snapshot.RestoreConfig{
 		SnapshotPath:        rawBackupFile,
 		Name:                e.PodName,
@@ -679,3 +703,90 @@ func (e *Cluster) restoreDatadirFromBackupIfNeeded(ctx context.Context, log *zap
 		SkipHashCheck:       false,
 	})
 }
+
+// ReplayWALIfNeeded replays the archived WAL on top of a restored backup if a point-in-time
+// restore is in progress. To prevent concurrent replays, this is only done by the first member.
+func (e *Cluster) ReplayWALIfNeeded(ctx context.Context, log *zap.SugaredLogger) error {
+	if !strings.HasSuffix(e.PodName, "-0") {
+		return nil
+	}
+
+	restoreList := &kubermaticv1.EtcdRestoreList{}
+	if err := e.clusterClient.List(ctx, restoreList, &ctrlruntimeclient.ListOptions{Namespace: e.namespace}); err != nil {
+		return fmt.Errorf("failed to list EtcdRestores: %w", err)
+	}
+
+	var activeRestore *kubermaticv1.EtcdRestore
+	for _, restore := range restoreList.Items {
+		if restore.Spec.PointInTime == nil || restore.Status.WALReplayed || restore.Status.SnapshotRevision == 0 {
+			continue
+		}
+
+		if restore.Status.Phase == kubermaticv1.EtcdRestorePhaseStsRebuilding || restore.Status.Phase == kubermaticv1.EtcdRestorePhaseWALReplaying {
+			activeRestore = restore.DeepCopy()
+			break
+		}
+	}
+
+	if activeRestore == nil {
+		return nil
+	}
+
+	cluster, err := e.KubermaticCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	s3Client, bucketName, err := resources.GetEtcdRestoreS3Client(ctx, activeRestore, false, e.clusterClient, cluster, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 client: %w", err)
+	}
+
+	dataKey, err := resources.GetEtcdRestoreEncryptionKey(ctx, activeRestore, e.clusterClient, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get encryption key: %w", err)
+	}
+
+	etcdClient, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to get etcd client: %w", err)
+	}
+	defer closeClient(etcdClient, log)
+
+	pointInTime := activeRestore.Spec.PointInTime
+
+	target := WALReplayTarget{}
+	if pointInTime.Revision != nil {
+		target.Revision = *pointInTime.Revision
+	}
+	if pointInTime.Time != nil {
+		target.Time = pointInTime.Time.Time
+	}
+
+	fromRevision := max(activeRestore.Status.SnapshotRevision, activeRestore.Status.ReplayedRevision) + 1
+	prefix := etcdbackup.WALArchivePrefix(cluster, pointInTime.BackupConfig, activeRestore.Status.WALArchiveEpoch)
+
+	log.Infow("replaying archived WAL", "restore", activeRestore.Name, "epoch", activeRestore.Status.WALArchiveEpoch, "from-revision", fromRevision)
+
+	replayed, err := ReplayWAL(ctx, log, etcdClient, s3Client, bucketName, dataKey, prefix, fromRevision, target, func(revision int64) error {
+		return e.updateRestoreStatus(ctx, activeRestore, func(restore *kubermaticv1.EtcdRestore) {
+			restore.Status.ReplayedRevision = revision
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replay WAL: %w", err)
+	}
+
+	log.Infow("finished replaying archived WAL", "restore", activeRestore.Name, "revision", replayed)
+
+	return e.updateRestoreStatus(ctx, activeRestore, func(restore *kubermaticv1.EtcdRestore) {
+		restore.Status.WALReplayed = true
+	})
+}
+
+func (e *Cluster) updateRestoreStatus(ctx context.Context, restore *kubermaticv1.EtcdRestore, modify func(*kubermaticv1.EtcdRestore)) error {
+	oldRestore := restore.DeepCopy()
+	modify(restore)
+
+	return e.clusterClient.Status().Patch(ctx, restore, ctrlruntimeclient.MergeFrom(oldRestore))
+}
@@ -19,20 +19,28 @@ package etcd
 import (
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/minio/minio-go/v7"
 	client "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/etcdutl/v3/snapshot"
 	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
 )
 
 type SnapshotOptions struct {
 	File        string
 	Compression string
+	// EncryptionKey is the data key to encrypt the snapshot with. If nil, the
+	// snapshot is not encrypted.
+	EncryptionKey []byte
 }
 
 var ValidCompressions = []string{"gzip"}
@@ -40,7 +48,7 @@ var ValidCompressions = []string{"gzip"}
 func CreateSnapshot(ctx context.Context, log *zap.SugaredLogger, etcdConfig client.Config, opt *SnapshotOptions) error {
 	snapv3 := snapshot.NewV3(log.Desugar())
 
-	if opt.Compression == "" {
+	if opt.Compression == "" && opt.EncryptionKey == nil {
 		return snapv3.Save(ctx, etcdConfig, opt.File)
 	}
 
@@ -52,11 +60,11 @@ func CreateSnapshot(ctx context.Context, log *zap.SugaredLogger, etcdConfig clie
 		return err
 	}
 
-	compressedFile, err := os.Create(opt.File)
+	outputFile, err := os.Create(opt.File)
 	if err != nil {
 		return err
 	}
-	defer compressedFile.Close()
+	defer outputFile.Close()
 
 	rawFile, err := os.Open(tmpFile)
 	if err != nil {
@@ -64,25 +72,155 @@ func CreateSnapshot(ctx context.Context, log *zap.SugaredLogger, etcdConfig clie
 	}
 	defer rawFile.Close()
 
-	var compressor io.WriteCloser
+	// writers are stacked as raw -> compressor -> encrypter -> file and
+	// must be closed in that order to flush everything
+	var (
+		output  io.Writer = outputFile
+		writers []io.WriteCloser
+	)
+
+	if opt.EncryptionKey != nil {
+		encrypter, err := backupcrypto.NewEncryptWriter(output, opt.EncryptionKey)
+		if err != nil {
+			return err
+		}
+
+		writers = append(writers, encrypter)
+		output = encrypter
+	}
 
 	switch opt.Compression {
+	case "":
 	case "gzip":
-		compressor, err = gzip.NewWriterLevel(compressedFile, gzip.BestCompression)
+		compressor, err := gzip.NewWriterLevel(output, gzip.BestCompression)
 		if err != nil {
 			return err
 		}
+
+		writers = append(writers, compressor)
+		output = compressor
 	default:
 		return fmt.Errorf("unknown compression algorithm %q", opt.Compression)
 	}
 
-	defer compressor.Close()
+	if _, err = io.Copy(output, rawFile); err != nil {
+		return err
+	}
+
+	for i := len(writers) - 1; i >= 0; i-- {
+		if err := writers[i].Close(); err != nil {
+			return err
+		}
+	}
+
+	return outputFile.Close()
+}
+
+// DownloadSnapshot downloads a backup of the given cluster into dir and returns the path to the
+// raw snapshot, i.e. after decrypting and decompressing it. Chunked backups are reassembled from
+// their chunks. The data key is only required for encrypted backups.
+func DownloadSnapshot(ctx context.Context, s3Client *minio.Client, bucketName, clusterName, backupName string, dataKey []byte, dir string) (string, error) {
+	objectName := fmt.Sprintf("%s-%s", clusterName, backupName)
+
+	if chunkstore.IsManifest(objectName) {
+		store := chunkstore.New(s3Client, bucketName, clusterName)
+		if dataKey != nil {
+			store = store.WithEncryption(dataKey)
+		}
+
+		rawBackupFile, err := downloadChunkedSnapshot(ctx, store, objectName, dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to download chunked backup (%s/%s): %w", bucketName, objectName, err)
+		}
+
+		return rawBackupFile, nil
+	}
+
+	downloadedSnapshotFile := filepath.Join(dir, objectName)
+
+	if err := s3Client.FGetObject(ctx, bucketName, objectName, downloadedSnapshotFile, minio.GetObjectOptions{}); err != nil {
+		return "", fmt.Errorf("failed to download backup (%s/%s): %w", bucketName, objectName, err)
+	}
+
+	if err := DecryptSnapshotIfNeeded(downloadedSnapshotFile, dataKey); err != nil {
+		return "", fmt.Errorf("failed to decrypt snapshot file %s: %w", objectName, err)
+	}
+
+	rawBackupFile, err := DecompressSnapshot(downloadedSnapshotFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress snapshot file %s: %w", objectName, err)
+	}
+
+	return rawBackupFile, nil
+}
+
+// downloadChunkedSnapshot reassembles the snapshot referenced by the given manifest into a local file.
+func downloadChunkedSnapshot(ctx context.Context, store *chunkstore.Store, manifestName, dir string) (string, error) {
+	filename := filepath.Join(dir, strings.TrimSuffix(manifestName, chunkstore.ManifestSuffix)+".db")
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := store.Download(ctx, manifestName, f); err != nil {
+		return "", err
+	}
+
+	return filename, f.Close()
+}
+
+// DecryptSnapshotIfNeeded decrypts the given file in place if it is encrypted. Plaintext
+// files are left untouched, so backups taken before encryption was enabled can still be
+// restored.
+func DecryptSnapshotIfNeeded(filename string, key []byte) error {
+	encryptedFile, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer encryptedFile.Close()
+
+	header := make([]byte, len(backupcrypto.Magic))
+	if _, err := io.ReadFull(encryptedFile, header); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	if !backupcrypto.IsEncrypted(header) {
+		return nil
+	}
+
+	if key == nil {
+		return errors.New("backup is encrypted, but no encryption key is available")
+	}
+
+	if _, err := encryptedFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	decrypter, err := backupcrypto.NewDecryptReader(encryptedFile, key)
+	if err != nil {
+		return err
+	}
+
+	tmpFile := filename + ".decrypted"
+	defer os.Remove(tmpFile)
+
+	rawFile, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+	defer rawFile.Close()
+
+	if _, err := io.Copy(rawFile, decrypter); err != nil {
+		return err
+	}
 
-	if _, err = io.Copy(compressor, rawFile); err != nil {
+	if err := rawFile.Close(); err != nil {
 		return err
 	}
 
-	return nil
+	return os.Rename(tmpFile, filename)
 }
 
 func DecompressSnapshot(filename string) (string, error) {
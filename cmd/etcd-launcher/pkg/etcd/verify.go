@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	client "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.uber.org/zap"
+
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/util/wait"
+)
+
+const (
+	verificationMemberName = "verify"
+	// the throwaway etcd only listens on localhost inside the verification pod
+	verificationPeerURL   = "http://127.0.0.1:2390"
+	verificationClientURL = "http://127.0.0.1:2389"
+
+	verificationStartTimeout = 2 * time.Minute
+)
+
+// VerifySnapshot checks the integrity of the given snapshot, restores it into a throwaway
+// single-member etcd in dataDir and compares the restored etcd with the source etcd.
+// Verification failures are reported in the result's Error field; the returned error is
+// only set if the verification itself could not be performed.
+func VerifySnapshot(ctx context.Context, log *zap.SugaredLogger, snapshotFile, dataDir string, source *client.Client) (*etcdbackup.BackupVerificationResult, error) {
+	result := &etcdbackup.BackupVerificationResult{}
+
+	sp := snapshot.NewV3(log.Desugar())
+
+	status, err := sp.Status(snapshotFile)
+	if err != nil {
+		result.Error = fmt.Sprintf("snapshot is corrupted: %v", err)
+		return result, nil
+	}
+
+	log.Infow("snapshot status", "revision", status.Revision, "total-keys", status.TotalKey, "hash", status.Hash)
+
+	if err := os.RemoveAll(dataDir); err != nil {
+		return nil, fmt.Errorf("failed to clean up data directory: %w", err)
+	}
+
+	if err := sp.Restore(snapshot.RestoreConfig{
+		SnapshotPath:        snapshotFile,
+		Name:                verificationMemberName,
+		OutputDataDir:       dataDir,
+		OutputWALDir:        filepath.Join(dataDir, "member", "wal"),
+		PeerURLs:            []string{verificationPeerURL},
+		InitialCluster:      fmt.Sprintf("%s=%s", verificationMemberName, verificationPeerURL),
+		InitialClusterToken: verificationMemberName,
+		SkipHashCheck:       false,
+	}); err != nil {
+		result.Error = fmt.Sprintf("failed to restore snapshot: %v", err)
+		return result, nil
+	}
+
+	cmd, err := startVerificationEtcd(log, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer stopVerificationEtcd(log, cmd)
+
+	restoredClient, err := waitForVerificationEtcd(ctx, log)
+	if err != nil {
+		result.Error = fmt.Sprintf("etcd restored from snapshot did not become ready: %v", err)
+		return result, nil
+	}
+	defer restoredClient.Close()
+
+	resp, err := restoredClient.Get(ctx, "\x00", client.WithFromKey(), client.WithCountOnly())
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read restored etcd: %v", err)
+		return result, nil
+	}
+
+	result.Revision = resp.Header.Revision
+	result.KeyCount = resp.Count
+
+	if result.Revision != status.Revision {
+		result.Error = fmt.Sprintf("restored etcd is at revision %d, but the snapshot has revision %d", result.Revision, status.Revision)
+		return result, nil
+	}
+
+	sourceResp, err := source.Get(ctx, "\x00", client.WithFromKey(), client.WithCountOnly(), client.WithRev(result.Revision))
+	switch {
+	case errors.Is(err, rpctypes.ErrCompacted):
+		// the source's history does not reach back far enough anymore, which
+		// can happen for large snapshots that took long to upload and verify
+		log.Warnw("snapshot revision has been compacted in the source etcd, cannot compare key counts", "revision", result.Revision)
+	case errors.Is(err, rpctypes.ErrFutureRev):
+		result.Error = fmt.Sprintf("snapshot revision %d is newer than the source etcd's revision", result.Revision)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read source etcd: %w", err)
+	default:
+		result.SourceKeyCount = &sourceResp.Count
+
+		if sourceResp.Count != result.KeyCount {
+			result.Error = fmt.Sprintf("restored etcd has %d keys, but the source etcd had %d keys at revision %d", result.KeyCount, sourceResp.Count, result.Revision)
+		}
+	}
+
+	return result, nil
+}
+
+func startVerificationEtcd(log *zap.SugaredLogger, dataDir string) (*exec.Cmd, error) {
+	args := []string{
+		fmt.Sprintf("--name=%s", verificationMemberName),
+		fmt.Sprintf("--data-dir=%s", dataDir),
+		fmt.Sprintf("--initial-cluster=%s=%s", verificationMemberName, verificationPeerURL),
+		fmt.Sprintf("--initial-advertise-peer-urls=%s", verificationPeerURL),
+		fmt.Sprintf("--listen-peer-urls=%s", verificationPeerURL),
+		fmt.Sprintf("--advertise-client-urls=%s", verificationClientURL),
+		fmt.Sprintf("--listen-client-urls=%s", verificationClientURL),
+		"--log-level=warn",
+	}
+
+	if _, err := os.Stat(etcdCommandPath); err != nil {
+		return nil, fmt.Errorf("failed to find etcd executable: %w", err)
+	}
+
+	cmd := exec.Command(etcdCommandPath, args...)
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+
+	log.Infof("starting verification etcd: %s %s", etcdCommandPath, strings.Join(args, " "))
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start etcd: %w", err)
+	}
+
+	return cmd, nil
+}
+
+func waitForVerificationEtcd(ctx context.Context, log *zap.SugaredLogger) (*client.Client, error) {
+	cli, err := client.New(client.Config{
+		Endpoints:   []string{verificationClientURL},
+		DialTimeout: 2 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = wait.PollImmediateLog(ctx, log, 1*time.Second, verificationStartTimeout, func(ctx context.Context) (error, error) {
+		statusCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		_, err := cli.Status(statusCtx, verificationClientURL)
+		return err, nil
+	})
+	if err != nil {
+		cli.Close()
+		return nil, err
+	}
+
+	return cli, nil
+}
+
+func stopVerificationEtcd(log *zap.SugaredLogger, cmd *exec.Cmd) {
+	if err := cmd.Process.Kill(); err != nil {
+		log.Warnw("failed to stop verification etcd", zap.Error(err))
+	}
+
+	// reap the process; it has been killed, so the exit error is expected
+	_ = cmd.Wait()
+}
@@ -0,0 +1,576 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	client "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+)
+
+const (
+	walRecordTypePut    = "PUT"
+	walRecordTypeDelete = "DELETE"
+
+	walSegmentSuffix = ".jsonl.gz"
+
+	// maxWALSegmentSize is the amount of uncompressed record data after which a
+	// segment is uploaded, regardless of the configured segment interval.
+	maxWALSegmentSize = 32 * 1024 * 1024
+)
+
+// WALRecord is a single change to the etcd keyspace, as observed by the WAL archiver.
+// Segments are stored as gzipped JSON lines, one record per line.
+type WALRecord struct {
+	Revision int64     `json:"rev"`
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`
+	Key      []byte    `json:"key"`
+	Value    []byte    `json:"value,omitempty"`
+}
+
+// WALSegment describes an archived WAL segment object.
+type WALSegment struct {
+	ObjectName    string
+	FirstRevision int64
+	LastRevision  int64
+	LastModified  time.Time
+}
+
+func walSegmentName(prefix string, first, last int64) string {
+	return fmt.Sprintf("%s%020d-%020d%s", prefix, first, last, walSegmentSuffix)
+}
+
+func parseWALSegmentName(prefix, objectName string) (*WALSegment, error) {
+	name := strings.TrimPrefix(objectName, prefix)
+	name = strings.TrimSuffix(name, walSegmentSuffix)
+
+	first, last, found := strings.Cut(name, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid WAL segment name %q", objectName)
+	}
+
+	firstRevision, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAL segment name %q: %w", objectName, err)
+	}
+
+	lastRevision, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAL segment name %q: %w", objectName, err)
+	}
+
+	return &WALSegment{
+		ObjectName:    objectName,
+		FirstRevision: firstRevision,
+		LastRevision:  lastRevision,
+	}, nil
+}
+
+// ListWALSegments returns all archived WAL segments below the given prefix, sorted by revision.
+func ListWALSegments(ctx context.Context, s3Client *minio.Client, bucket, prefix string) ([]WALSegment, error) {
+	var segments []WALSegment
+
+	for object := range s3Client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list WAL segments: %w", object.Err)
+		}
+
+		if !strings.HasSuffix(object.Key, walSegmentSuffix) {
+			continue
+		}
+
+		segment, err := parseWALSegmentName(prefix, object.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		segment.LastModified = object.LastModified
+		segments = append(segments, *segment)
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].FirstRevision < segments[j].FirstRevision
+	})
+
+	return segments, nil
+}
+
+// PruneWALSegments deletes all segments below the given prefix that have been uploaded before the given time.
+// It returns the number of deleted segments.
+func PruneWALSegments(ctx context.Context, log *zap.SugaredLogger, s3Client *minio.Client, bucket, prefix string, before time.Time) (int, error) {
+	segments, err := ListWALSegments(ctx, s3Client, bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, segment := range segments {
+		if !segment.LastModified.Before(before) {
+			continue
+		}
+
+		if err := s3Client.RemoveObject(ctx, bucket, segment.ObjectName, minio.RemoveObjectOptions{}); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %w", segment.ObjectName, err)
+		}
+
+		log.Debugw("deleted WAL segment", "object", segment.ObjectName)
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// WALPruneCutoffs determines which archived WAL segments of the given EtcdBackupConfig are no longer
+// needed. A point-in-time restore always starts from a backup and only replays changes archived in the
+// backup's epoch after the backup was taken, so segments uploaded before the oldest backup of an epoch
+// can be deleted once retention has removed all older backups. The result maps each epoch to the time
+// before which its segments can be deleted; epochs that are not part of the result must be kept.
+// Backups that are failed or being deleted are not taken into account.
+func WALPruneCutoffs(config *kubermaticv1.EtcdBackupConfig, now time.Time) map[int64]time.Time {
+	oldest := map[int64]time.Time{}
+
+	for _, backup := range config.Status.CurrentBackups {
+		if backup.BackupPhase == kubermaticv1.BackupStatusPhaseFailed || backup.DeletePhase != "" {
+			continue
+		}
+
+		cutoff, exists := oldest[backup.WALArchiveEpoch]
+		if !exists || backup.ScheduledTime.Time.Before(cutoff) {
+			oldest[backup.WALArchiveEpoch] = backup.ScheduledTime.Time
+		}
+	}
+
+	cutoffs := map[int64]time.Time{}
+
+	for epoch := int64(0); epoch <= config.Status.WALArchiveEpoch; epoch++ {
+		cutoff, exists := oldest[epoch]
+
+		switch {
+		case exists:
+			cutoffs[epoch] = cutoff
+		case epoch < config.Status.WALArchiveEpoch:
+			// previous epochs are not written to anymore and no backup needs them
+			cutoffs[epoch] = now
+		}
+	}
+
+	return cutoffs
+}
+
+// WALArchiver watches the complete etcd keyspace and uploads every change as
+// segments to an S3 bucket.
+type WALArchiver struct {
+	Client          *client.Client
+	S3Client        *minio.Client
+	Bucket          string
+	Prefix          string
+	SegmentInterval time.Duration
+	// DataKey is the key to encrypt segments with. If nil, segments are not encrypted.
+	DataKey []byte
+
+	buffer  bytes.Buffer
+	first   int64
+	last    int64
+	records int
+}
+
+// Run archives changes until the context is cancelled. Archiving resumes after the
+// last archived segment; if the required revisions have been compacted away in the
+// meantime, the gap is logged and archiving continues from the oldest available revision.
+func (a *WALArchiver) Run(ctx context.Context, log *zap.SugaredLogger) error {
+	startRevision, err := a.nextRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		watchOpts := []client.OpOption{client.WithPrefix()}
+		if startRevision > 0 {
+			watchOpts = append(watchOpts, client.WithRev(startRevision))
+		}
+
+		log.Infow("watching etcd keyspace", "revision", startRevision)
+
+		nextRevision, err := a.watch(ctx, log, watchOpts)
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			// try to not lose the changes buffered so far
+			flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			return a.flush(flushCtx, log)
+		}
+
+		startRevision = nextRevision
+	}
+}
+
+// watch consumes a single watch channel and returns the revision to resume watching from
+// once the channel has been closed.
+func (a *WALArchiver) watch(ctx context.Context, log *zap.SugaredLogger, opts []client.OpOption) (int64, error) {
+	ticker := time.NewTicker(a.SegmentInterval)
+	defer ticker.Stop()
+
+	watchCtx, cancel := context.WithCancel(client.WithRequireLeader(ctx))
+	defer cancel()
+
+	watchChan := a.Client.Watch(watchCtx, "", opts...)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, nil
+
+		case <-ticker.C:
+			if err := a.flush(ctx, log); err != nil {
+				log.Errorw("failed to upload WAL segment, will retry", zap.Error(err))
+			}
+
+		case resp, ok := <-watchChan:
+			if !ok {
+				return a.resumeRevision(), nil
+			}
+
+			if resp.CompactRevision > 0 {
+				log.Errorw("required revisions have been compacted, the WAL archive has a gap", "next-revision", a.last+1, "compact-revision", resp.CompactRevision)
+				return resp.CompactRevision, nil
+			}
+
+			if err := resp.Err(); err != nil {
+				log.Warnw("watch failed, restarting", zap.Error(err))
+				return a.resumeRevision(), nil
+			}
+
+			now := time.Now().UTC()
+			for _, event := range resp.Events {
+				if err := a.add(event, now); err != nil {
+					return 0, err
+				}
+			}
+
+			if a.buffer.Len() >= maxWALSegmentSize {
+				if err := a.flush(ctx, log); err != nil {
+					log.Errorw("failed to upload WAL segment, will retry", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// resumeRevision returns the revision to restart watching from, 0 meaning "now"
+// if no change has been observed yet.
+func (a *WALArchiver) resumeRevision() int64 {
+	if a.last == 0 {
+		return 0
+	}
+
+	return a.last + 1
+}
+
+func (a *WALArchiver) add(event *client.Event, now time.Time) error {
+	record := WALRecord{
+		Revision: event.Kv.ModRevision,
+		Time:     now,
+		Key:      event.Kv.Key,
+	}
+
+	switch event.Type {
+	case mvccpb.PUT:
+		record.Type = walRecordTypePut
+		record.Value = event.Kv.Value
+	case mvccpb.DELETE:
+		record.Type = walRecordTypeDelete
+	default:
+		return fmt.Errorf("unknown event type %v", event.Type)
+	}
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode WAL record: %w", err)
+	}
+
+	if a.records == 0 {
+		a.first = record.Revision
+	}
+
+	a.buffer.Write(encoded)
+	a.buffer.WriteByte('\n')
+	a.last = record.Revision
+	a.records++
+
+	return nil
+}
+
+func (a *WALArchiver) flush(ctx context.Context, log *zap.SugaredLogger) error {
+	if a.records == 0 {
+		return nil
+	}
+
+	content, contentType, err := a.encode()
+	if err != nil {
+		return err
+	}
+
+	objectName := walSegmentName(a.Prefix, a.first, a.last)
+	if _, err := a.S3Client.PutObject(ctx, a.Bucket, objectName, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectName, err)
+	}
+
+	log.Infow("uploaded WAL segment", "object", objectName, "records", a.records)
+
+	a.buffer.Reset()
+	a.records = 0
+
+	return nil
+}
+
+// encode compresses and, if a data key is configured, encrypts the buffered records
+// and returns the segment content together with its content type.
+func (a *WALArchiver) encode() ([]byte, string, error) {
+	var compressed bytes.Buffer
+
+	gz, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := gz.Write(a.buffer.Bytes()); err != nil {
+		return nil, "", err
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, "", err
+	}
+
+	content := compressed.Bytes()
+	contentType := "application/gzip"
+
+	if a.DataKey != nil {
+		content, err = backupcrypto.Encrypt(content, a.DataKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encrypt segment: %w", err)
+		}
+
+		contentType = "application/octet-stream"
+	}
+
+	return content, contentType, nil
+}
+
+// nextRevision returns the first revision that has not been archived yet, or 0
+// if nothing has been archived so far.
+func (a *WALArchiver) nextRevision(ctx context.Context) (int64, error) {
+	segments, err := ListWALSegments(ctx, a.S3Client, a.Bucket, a.Prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	var last int64
+	for _, segment := range segments {
+		if segment.LastRevision > last {
+			last = segment.LastRevision
+		}
+	}
+
+	if last == 0 {
+		return 0, nil
+	}
+
+	a.last = last
+
+	return last + 1, nil
+}
+
+// WALReplayTarget describes up to where archived changes should be replayed.
+// Zero values are ignored.
+type WALReplayTarget struct {
+	Revision int64
+	Time     time.Time
+}
+
+func (t WALReplayTarget) includes(record *WALRecord) bool {
+	if t.Revision > 0 && record.Revision > t.Revision {
+		return false
+	}
+
+	if !t.Time.IsZero() && record.Time.After(t.Time) {
+		return false
+	}
+
+	return true
+}
+
+var errWALReplayTargetReached = errors.New("target reached")
+
+// ReplayWAL applies all archived changes with a revision of at least fromRevision on top of the
+// current etcd state, until the target is reached. Leases are not preserved, so keys are restored
+// without their lease attachment. The progress callback is invoked after each segment with the
+// original revision of the last replayed change. The original revision of the last replayed change
+// is returned. Encrypted segments are decrypted with the given data key.
+func ReplayWAL(ctx context.Context, log *zap.SugaredLogger, etcdClient *client.Client, s3Client *minio.Client, bucket string, dataKey []byte, prefix string, fromRevision int64, target WALReplayTarget, progress func(int64) error) (int64, error) {
+	segments, err := ListWALSegments(ctx, s3Client, bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := fromRevision - 1
+	expected := fromRevision
+
+	for _, segment := range segments {
+		if segment.LastRevision < fromRevision {
+			continue
+		}
+
+		// WAL segments are contiguous, except when the archiver was not able to keep up with compaction.
+		if segment.FirstRevision > expected {
+			log.Warnw("archived WAL has a gap, changes in between are lost", "from", expected, "to", segment.FirstRevision-1)
+		}
+
+		log.Infow("replaying WAL segment", "object", segment.ObjectName)
+
+		err := replayWALSegment(ctx, s3Client, bucket, dataKey, segment.ObjectName, func(record *WALRecord) error {
+			if record.Revision < fromRevision {
+				return nil
+			}
+
+			if !target.includes(record) {
+				return errWALReplayTargetReached
+			}
+
+			var err error
+
+			switch record.Type {
+			case walRecordTypePut:
+				_, err = etcdClient.Put(ctx, string(record.Key), string(record.Value))
+			case walRecordTypeDelete:
+				_, err = etcdClient.Delete(ctx, string(record.Key))
+			default:
+				err = fmt.Errorf("unknown record type %q", record.Type)
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to replay revision %d: %w", record.Revision, err)
+			}
+
+			replayed = record.Revision
+
+			return nil
+		})
+
+		targetReached := errors.Is(err, errWALReplayTargetReached)
+		if err != nil && !targetReached {
+			return replayed, err
+		}
+
+		if progress != nil {
+			if err := progress(replayed); err != nil {
+				return replayed, err
+			}
+		}
+
+		if targetReached {
+			break
+		}
+
+		expected = segment.LastRevision + 1
+	}
+
+	return replayed, nil
+}
+
+func replayWALSegment(ctx context.Context, s3Client *minio.Client, bucket string, dataKey []byte, objectName string, apply func(*WALRecord) error) error {
+	object, err := s3Client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", objectName, err)
+	}
+	defer object.Close()
+
+	return readWALSegment(object, dataKey, objectName, apply)
+}
+
+// readWALSegment decrypts (if needed), decompresses and decodes a single segment and
+// calls apply for every record in it.
+func readWALSegment(object io.Reader, dataKey []byte, objectName string, apply func(*WALRecord) error) error {
+	var err error
+
+	content := bufio.NewReader(object)
+
+	var compressed io.Reader = content
+	if header, _ := content.Peek(len(backupcrypto.Magic)); backupcrypto.IsEncrypted(header) {
+		if dataKey == nil {
+			return fmt.Errorf("%s is encrypted, but no encryption key is available", objectName)
+		}
+
+		compressed, err = backupcrypto.NewDecryptReader(content, dataKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", objectName, err)
+		}
+	}
+
+	decompressor, err := gzip.NewReader(compressed)
+	if err != nil {
+		return fmt.Errorf("failed to decompress %s: %w", objectName, err)
+	}
+	defer decompressor.Close()
+
+	reader := bufio.NewReader(decompressor)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := &WALRecord{}
+			if err := json.Unmarshal(line, record); err != nil {
+				return fmt.Errorf("failed to decode record in %s: %w", objectName, err)
+			}
+
+			if err := apply(record); err != nil {
+				return err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", objectName, err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	client "go.etcd.io/etcd/client/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWALSegmentName(t *testing.T) {
+	const prefix = "cluster-abc-daily-wal/"
+
+	name := walSegmentName(prefix, 42, 1337)
+	if expected := "cluster-abc-daily-wal/00000000000000000042-00000000000000001337.jsonl.gz"; name != expected {
+		t.Fatalf("expected segment name %q, got %q", expected, name)
+	}
+
+	segment, err := parseWALSegmentName(prefix, name)
+	if err != nil {
+		t.Fatalf("failed to parse segment name: %v", err)
+	}
+
+	if segment.ObjectName != name || segment.FirstRevision != 42 || segment.LastRevision != 1337 {
+		t.Fatalf("parsed segment does not match, got %+v", segment)
+	}
+
+	for _, invalid := range []string{
+		prefix + "00000000000000000042.jsonl.gz",
+		prefix + "foo-00000000000000001337.jsonl.gz",
+		prefix + "00000000000000000042-bar.jsonl.gz",
+	} {
+		if _, err := parseWALSegmentName(prefix, invalid); err == nil {
+			t.Errorf("expected error when parsing %q, but got none", invalid)
+		}
+	}
+}
+
+func TestWALReplayTargetIncludes(t *testing.T) {
+	target := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name     string
+		target   WALReplayTarget
+		record   WALRecord
+		expected bool
+	}{
+		{
+			name:     "no target includes everything",
+			record:   WALRecord{Revision: 100, Time: target},
+			expected: true,
+		},
+		{
+			name:     "revision up to and including the target",
+			target:   WALReplayTarget{Revision: 100},
+			record:   WALRecord{Revision: 100},
+			expected: true,
+		},
+		{
+			name:     "revision after the target",
+			target:   WALReplayTarget{Revision: 100},
+			record:   WALRecord{Revision: 101},
+			expected: false,
+		},
+		{
+			name:     "time up to and including the target",
+			target:   WALReplayTarget{Time: target},
+			record:   WALRecord{Revision: 100, Time: target},
+			expected: true,
+		},
+		{
+			name:     "time after the target",
+			target:   WALReplayTarget{Time: target},
+			record:   WALRecord{Revision: 100, Time: target.Add(time.Second)},
+			expected: false,
+		},
+		{
+			name:     "whichever target is reached first",
+			target:   WALReplayTarget{Revision: 200, Time: target},
+			record:   WALRecord{Revision: 100, Time: target.Add(time.Second)},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if included := tc.target.includes(&tc.record); included != tc.expected {
+				t.Fatalf("expected includes to return %v, got %v", tc.expected, included)
+			}
+		})
+	}
+}
+
+func TestWALSegmentRoundTrip(t *testing.T) {
+	events := []*client.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/registry/foo"), Value: []byte("foo"), ModRevision: 10}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/registry/bar"), Value: []byte("bar"), ModRevision: 11}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("/registry/foo"), ModRevision: 12}},
+	}
+
+	testcases := []struct {
+		name    string
+		dataKey []byte
+	}{
+		{
+			name: "unencrypted segment",
+		},
+		{
+			name:    "encrypted segment",
+			dataKey: bytes.Repeat([]byte{42}, backupcrypto.KeySize),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			archiver := &WALArchiver{DataKey: tc.dataKey}
+			now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+			for _, event := range events {
+				if err := archiver.add(event, now); err != nil {
+					t.Fatalf("failed to add event: %v", err)
+				}
+			}
+
+			if archiver.first != 10 || archiver.last != 12 || archiver.records != 3 {
+				t.Fatalf("expected revisions 10-12 with 3 records to be buffered, got %d-%d with %d records", archiver.first, archiver.last, archiver.records)
+			}
+
+			content, _, err := archiver.encode()
+			if err != nil {
+				t.Fatalf("failed to encode segment: %v", err)
+			}
+
+			if encrypted := backupcrypto.IsEncrypted(content); encrypted != (tc.dataKey != nil) {
+				t.Fatalf("expected segment to be encrypted: %v, but it is: %v", tc.dataKey != nil, encrypted)
+			}
+
+			var records []*WALRecord
+			if err := readWALSegment(bytes.NewReader(content), tc.dataKey, "segment", func(record *WALRecord) error {
+				records = append(records, record)
+				return nil
+			}); err != nil {
+				t.Fatalf("failed to read segment: %v", err)
+			}
+
+			if len(records) != len(events) {
+				t.Fatalf("expected %d records, got %d", len(events), len(records))
+			}
+
+			for i, event := range events {
+				record := records[i]
+
+				expectedType := walRecordTypePut
+				if event.Type == mvccpb.DELETE {
+					expectedType = walRecordTypeDelete
+				}
+
+				if record.Revision != event.Kv.ModRevision || record.Type != expectedType || !record.Time.Equal(now) ||
+					!bytes.Equal(record.Key, event.Kv.Key) || !bytes.Equal(record.Value, event.Kv.Value) {
+					t.Errorf("record %d does not match event %v, got %+v", i, event, record)
+				}
+			}
+		})
+	}
+}
+
+func TestWALSegmentRequiresDataKey(t *testing.T) {
+	archiver := &WALArchiver{DataKey: bytes.Repeat([]byte{42}, backupcrypto.KeySize)}
+
+	if err := archiver.add(&client.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: 1}}, time.Now()); err != nil {
+		t.Fatalf("failed to add event: %v", err)
+	}
+
+	content, _, err := archiver.encode()
+	if err != nil {
+		t.Fatalf("failed to encode segment: %v", err)
+	}
+
+	if err := readWALSegment(bytes.NewReader(content), nil, "segment", func(*WALRecord) error { return nil }); err == nil {
+		t.Fatal("expected reading an encrypted segment without a data key to fail")
+	}
+}
+
+func TestWALPruneCutoffs(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	day := func(d int) metav1.Time {
+		return metav1.NewTime(time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	config := &kubermaticv1.EtcdBackupConfig{
+		Status: kubermaticv1.EtcdBackupConfigStatus{
+			WALArchiveEpoch: 2,
+			CurrentBackups: []kubermaticv1.BackupStatus{
+				// being deleted by retention
+				{ScheduledTime: day(1), BackupPhase: kubermaticv1.BackupStatusPhaseCompleted, DeletePhase: kubermaticv1.BackupStatusPhaseRunning},
+				// oldest retained backup of epoch 1
+				{ScheduledTime: day(2), BackupPhase: kubermaticv1.BackupStatusPhaseCompleted, WALArchiveEpoch: 1},
+				{ScheduledTime: day(3), BackupPhase: kubermaticv1.BackupStatusPhaseCompleted, WALArchiveEpoch: 1},
+				// failed backups are never restored
+				{ScheduledTime: day(4), BackupPhase: kubermaticv1.BackupStatusPhaseFailed, WALArchiveEpoch: 2},
+				{ScheduledTime: day(5), BackupPhase: kubermaticv1.BackupStatusPhaseRunning, WALArchiveEpoch: 2},
+			},
+		},
+	}
+
+	cutoffs := WALPruneCutoffs(config, now)
+
+	expected := map[int64]time.Time{
+		0: now,
+		1: day(2).Time,
+		2: day(5).Time,
+	}
+
+	if len(cutoffs) != len(expected) {
+		t.Fatalf("expected cutoffs %v, got %v", expected, cutoffs)
+	}
+
+	for epoch, cutoff := range expected {
+		if !cutoffs[epoch].Equal(cutoff) {
+			t.Errorf("expected epoch %d to be pruned before %v, got %v", epoch, cutoff, cutoffs[epoch])
+		}
+	}
+
+	// without any backup, the current epoch must be kept entirely
+	config.Status.CurrentBackups = nil
+	if _, exists := WALPruneCutoffs(config, now)[2]; exists {
+		t.Error("expected the current epoch not to be pruned without any backup")
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/minio/minio-go/v7"
+
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/util/s3"
+)
+
+// newS3ClientFromEnv creates a client for the backup destination that is configured via
+// environment variables on all backup jobs, and returns it together with the bucket name.
+func newS3ClientFromEnv(caBundleFile string) (*minio.Client, string, error) {
+	caBundle, err := os.ReadFile(caBundleFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, "", errors.New("CA bundle does not contain any valid certificates")
+	}
+
+	bucket := os.Getenv(etcdbackup.BucketNameEnvVarKey)
+	if bucket == "" {
+		return nil, "", fmt.Errorf("no bucket configured, $%s is empty", etcdbackup.BucketNameEnvVarKey)
+	}
+
+	client, err := s3.NewClient(
+		os.Getenv(etcdbackup.BackupEndpointEnvVarKey),
+		os.Getenv(etcdbackup.AccessKeyIdEnvVarKey),
+		os.Getenv(etcdbackup.SecretAccessKeyEnvVarKey),
+		pool,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return client, bucket, nil
+}
@@ -25,6 +25,7 @@ import (
 
 	applicationdefinitionsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-definition-synchronizer"
 	applicationsecretsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-secret-synchronizer"
+	clustermigration "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-migration"
 	clustertemplatesynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-template-synchronizer"
 	externalcluster "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/external-cluster"
 	kcstatuscontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/kc-status-controller"
@@ -69,6 +70,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	masterConstraintTemplateSynchronizerFactory := masterConstraintTemplateSynchronizerFactoryCreator(ctrlCtx)
 	userSynchronizerFactory := userSynchronizerFactoryCreator(ctrlCtx)
 	clusterTemplateSynchronizerFactory := clusterTemplateSynchronizerFactoryCreator(ctrlCtx)
+	clusterMigrationFactory := clusterMigrationFactoryCreator(ctrlCtx)
 	userProjectBindingSynchronizerFactory := userProjectBindingSynchronizerFactoryCreator(ctrlCtx)
 	projectSynchronizerFactory := projectSynchronizerFactoryCreator(ctrlCtx)
 	applicationdefinitionsynchronizerFactory := applicationDefinitionSynchronizerFactoryCreator(ctrlCtx)
@@ -90,6 +92,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 		masterConstraintTemplateSynchronizerFactory,
 		userSynchronizerFactory,
 		clusterTemplateSynchronizerFactory,
+		clusterMigrationFactory,
 		userProjectBindingSynchronizerFactory,
 		projectSynchronizerFactory,
 		applicationdefinitionsynchronizerFactory,
@@ -227,6 +230,17 @@ func clusterTemplateSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedc
 	}
 }
 
+func clusterMigrationFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return clustermigration.ControllerName, clustermigration.Add(
+			masterMgr,
+			ctrlCtx.seedsGetter,
+			seedManagerMap,
+			ctrlCtx.log,
+		)
+	}
+}
+
 func userProjectBindingSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
 	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
 		return userprojectbindingsynchronizer.ControllerName, userprojectbindingsynchronizer.Add(
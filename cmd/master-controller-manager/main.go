@@ -51,6 +51,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 const (
@@ -182,6 +183,10 @@ func main() {
 	if err := kubermaticv1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Fatalw("Failed to register scheme", zap.Stringer("api", kubermaticv1.SchemeGroupVersion), zap.Error(err))
 	}
+	// the cluster migration controller reads the expose Gateway on the seeds
+	if err := gatewayapiv1.Install(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", gatewayapiv1.SchemeGroupVersion), zap.Error(err))
+	}
 
 	// these two getters rely on the ctrlruntime manager being started; they
 	// are only used inside controllers
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/zapr"
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/util/s3"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(kubermaticv1.AddToScheme(scheme))
+}
+
+type options struct {
+	prometheusURL  string
+	priceModelFile string
+	caBundleFile   string
+	outputDir      string
+	outputPrefix   string
+	outputFormat   string
+	lastMonth      bool
+	lastDays       int
+}
+
+func main() {
+	ctx := signals.SetupSignalHandler()
+
+	logOpts := kubermaticlog.NewDefaultOptions()
+	logOpts.AddFlags(flag.CommandLine)
+
+	opts := options{}
+	flag.StringVar(&opts.prometheusURL, "prometheus-api", "", "The URL of the metering Prometheus.")
+	flag.StringVar(&opts.priceModelFile, "price-model", "", "Path to a YAML file containing the spec of the PriceModel to use.")
+	flag.StringVar(&opts.caBundleFile, "ca-bundle", "", "Filename of the CA bundle to use for S3 (if not given, default system certificates are used).")
+	flag.StringVar(&opts.outputDir, "output-dir", "", "The directory (object prefix) in the S3 bucket to write the reports to.")
+	flag.StringVar(&opts.outputPrefix, "output-prefix", "", "The prefix of the report file names, usually the seed name.")
+	flag.StringVar(&opts.outputFormat, "output-format", string(kubermaticv1.MeteringReportFormatCSV), "The format of the reports, one of csv or json.")
+	flag.BoolVar(&opts.lastMonth, "last-month", false, "Generate the reports for the previous calendar month.")
+	flag.IntVar(&opts.lastDays, "last-number-of-days", 7, "Generate the reports for the given number of days before today. Ignored if -last-month is set.")
+	flag.Parse()
+
+	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
+	log := rawLog.Sugar()
+
+	// set the logger used by sigs.k8s.io/controller-runtime
+	ctrlruntimelog.SetLogger(zapr.NewLogger(rawLog.WithOptions(zap.AddCallerSkip(1))))
+
+	if opts.prometheusURL == "" || opts.priceModelFile == "" || opts.outputDir == "" {
+		log.Fatal("All of -prometheus-api, -price-model and -output-dir must be set")
+	}
+
+	format := kubermaticv1.MeteringReportFormat(opts.outputFormat)
+	if format != kubermaticv1.MeteringReportFormatCSV && format != kubermaticv1.MeteringReportFormatJSON {
+		log.Fatalw("Invalid output format", "format", opts.outputFormat)
+	}
+
+	priceModel, err := loadPriceModel(opts.priceModelFile)
+	if err != nil {
+		log.Fatalw("Failed to load price model", zap.Error(err))
+	}
+
+	cfg, err := ctrlruntime.GetConfig()
+	if err != nil {
+		log.Fatalw("Failed to get kubeconfig", zap.Error(err))
+	}
+
+	client, err := ctrlruntimeclient.New(cfg, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		log.Fatalw("Failed to create kube client", zap.Error(err))
+	}
+
+	minioClient, bucket, err := newS3Client(opts.caBundleFile)
+	if err != nil {
+		log.Fatalw("Failed to create S3 client", zap.Error(err))
+	}
+
+	from, to, reports, err := generateCostReports(ctx, client, opts, priceModel, format, time.Now())
+	if err != nil {
+		log.Fatalw("Failed to generate cost reports", zap.Error(err))
+	}
+
+	for reportType, report := range reports {
+		objectName := fmt.Sprintf("%s/%s-%s-%s-%s-costs.%s", opts.outputDir, opts.outputPrefix, from.Format(time.DateOnly), to.Format(time.DateOnly), reportType, format)
+
+		if _, err := minioClient.PutObject(ctx, bucket, objectName, bytes.NewReader(report), int64(len(report)), minio.PutObjectOptions{}); err != nil {
+			log.Fatalw("Failed to upload cost report", "object", objectName, zap.Error(err))
+		}
+
+		log.Infow("Uploaded cost report", "object", objectName)
+	}
+}
+
+func loadPriceModel(filename string) (*kubermaticv1.PriceModelSpec, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &kubermaticv1.PriceModelSpec{}
+	if err := yaml.UnmarshalStrict(content, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return spec, nil
+}
+
+func newS3Client(caBundleFile string) (*minio.Client, string, error) {
+	var certPool *x509.CertPool
+	if caBundleFile != "" {
+		bundle, err := certificates.NewCABundleFromFile(caBundleFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load CA bundle: %w", err)
+		}
+
+		certPool = bundle.CertPool()
+	}
+
+	bucket := os.Getenv("S3_BUCKET")
+	if bucket == "" {
+		return nil, "", fmt.Errorf("S3_BUCKET environment variable must be set")
+	}
+
+	client, err := s3.NewClient(os.Getenv("S3_ENDPOINT"), os.Getenv("ACCESS_KEY_ID"), os.Getenv("SECRET_ACCESS_KEY"), certPool)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, bucket, nil
+}
@@ -0,0 +1,33 @@
+//go:build !ee
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func generateCostReports(_ context.Context, _ ctrlruntimeclient.Reader, _ options, _ *kubermaticv1.PriceModelSpec, _ kubermaticv1.MeteringReportFormat, _ time.Time) (time.Time, time.Time, map[string][]byte, error) {
+	return time.Time{}, time.Time{}, nil, errors.New("metering cost reports are only available in the Enterprise Edition")
+}
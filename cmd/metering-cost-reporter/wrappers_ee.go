@@ -0,0 +1,50 @@
+//go:build ee
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/ee/metering/query"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func generateCostReports(ctx context.Context, client ctrlruntimeclient.Reader, opts options, priceModel *kubermaticv1.PriceModelSpec, format kubermaticv1.MeteringReportFormat, now time.Time) (time.Time, time.Time, map[string][]byte, error) {
+	promClient, err := promapi.NewClient(promapi.Config{Address: opts.prometheusURL})
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	from, to := query.ReportTimeRange(now, opts.lastMonth, opts.lastDays)
+
+	reports, err := query.NewQuerier(client, promv1.NewAPI(promClient)).CostReports(ctx, query.Options{
+		From:       from,
+		To:         to,
+		PriceModel: priceModel,
+	}, format)
+
+	return from, to, reports, err
+}
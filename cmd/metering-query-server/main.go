@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimecluster "sigs.k8s.io/controller-runtime/pkg/cluster"
+	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(kubermaticv1.AddToScheme(scheme))
+}
+
+type options struct {
+	listenAddress string
+	prometheusURL string
+}
+
+func main() {
+	ctx := signals.SetupSignalHandler()
+
+	logOpts := kubermaticlog.NewDefaultOptions()
+	logOpts.AddFlags(flag.CommandLine)
+
+	opts := options{}
+	flag.StringVar(&opts.listenAddress, "listen-address", "127.0.0.1:8080", "The address to serve the usage API on.")
+	flag.StringVar(&opts.prometheusURL, "prometheus-url", "", "The URL of the metering Prometheus.")
+	flag.Parse()
+
+	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
+	log := rawLog.Sugar()
+
+	// set the logger used by sigs.k8s.io/controller-runtime
+	ctrlruntimelog.SetLogger(zapr.NewLogger(rawLog.WithOptions(zap.AddCallerSkip(1))))
+
+	if opts.prometheusURL == "" {
+		log.Fatal("-prometheus-url is required")
+	}
+
+	cfg, err := ctrlruntime.GetConfig()
+	if err != nil {
+		log.Fatalw("Failed to get kubeconfig", zap.Error(err))
+	}
+
+	cluster, err := ctrlruntimecluster.New(cfg, func(o *ctrlruntimecluster.Options) {
+		o.Scheme = scheme
+	})
+	if err != nil {
+		log.Fatalw("Failed to create cluster object", zap.Error(err))
+	}
+
+	go func() {
+		if err := cluster.GetCache().Start(ctx); err != nil {
+			log.Fatalw("Failed to start cache", zap.Error(err))
+		}
+	}()
+	if !cluster.GetCache().WaitForCacheSync(ctx) {
+		log.Fatal("Failed to wait for cache sync")
+	}
+
+	usageHandler, err := newUsageHandler(cluster.GetClient(), opts.prometheusURL, log)
+	if err != nil {
+		log.Fatalw("Failed to create usage handler", zap.Error(err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/usage/", usageHandler)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{
+		Addr:              opts.listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Errorw("Failed to shut down server", zap.Error(err))
+		}
+	}()
+
+	log.Infow("Listening…", "address", opts.listenAddress)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalw("Failed to serve usage API", zap.Error(err))
+	}
+}
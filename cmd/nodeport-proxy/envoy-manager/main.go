@@ -27,6 +27,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/util/cli"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrlruntimeconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,6 +39,8 @@ func main() {
 	logOpts := kubermaticlog.NewDefaultOptions()
 	logOpts.AddFlags(flag.CommandLine)
 
+	var ipFamilies string
+
 	srv := Server{}
 	ctrlOpts := envoymanager.Options{}
 	flag.StringVar(&srv.ListenAddress, "listen-address", ":8001", "Address to serve on")
@@ -46,8 +49,12 @@ func main() {
 	flag.IntVar(&ctrlOpts.EnvoyStatsPort, "envoy-stats-port", 8002, "Limited port which should be opened on envoy to expose metrics and the health check. Endpoints are: /healthz & /stats")
 	flag.IntVar(&ctrlOpts.EnvoySNIListenerPort, "envoy-sni-port", 0, "Port used for SNI entry point.")
 	flag.IntVar(&ctrlOpts.EnvoyTunnelingListenerPort, "envoy-tunneling-port", 0, "Port used for HTTP/2 CONNECT termination.")
+	flag.IntVar(&ctrlOpts.MaxConnections, "max-connections", 0, "Maximum number of concurrent connections to each exposed service port. 0 means Envoy's default applies.")
+	flag.IntVar(&ctrlOpts.ConnectionRate, "connection-rate", 0, "Number of new connections per second accepted for each service, shared by all its ports. 0 disables rate limiting.")
+	flag.IntVar(&ctrlOpts.ConnectionBurst, "connection-burst", 0, "Number of new connections that can be accepted at once. Defaults to the connection rate.")
 	flag.StringVar(&ctrlOpts.Namespace, "namespace", "", "The namespace we should use for pods and services. Leave empty for all namespaces.")
 	flag.StringVar(&ctrlOpts.ExposeAnnotationKey, "expose-annotation-key", nodeportproxy.DefaultExposeAnnotationKey, "The annotation key used to determine if a service should be exposed")
+	flag.StringVar(&ipFamilies, "ip-families", string(corev1.IPv4Protocol), "Comma separated list of IP families (IPv4, IPv6) the listeners are bound for. The first one is the primary family.")
 	flag.Parse()
 
 	// setup signal handler
@@ -62,6 +69,12 @@ func main() {
 
 	cli.Hello(log, "Envoy-Manager", logOpts.Debug, nil)
 
+	families, err := envoymanager.ParseIPFamilies(ipFamilies)
+	if err != nil {
+		log.Fatalw("invalid -ip-families", zap.Error(err))
+	}
+	ctrlOpts.IPFamilies = families
+
 	config, err := ctrlruntimeconfig.GetConfig()
 	if err != nil {
 		log.Fatal(err)
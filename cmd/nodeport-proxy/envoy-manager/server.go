@@ -76,6 +76,12 @@ func (s *Server) Start(ctx context.Context) error {
 
 	registerServer(grpcServer, srv3)
 
+	// stop serving when the manager is stopped
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
 	s.Log.Infow("starting management service", "listen-address", s.ListenAddress)
 	if err = grpcServer.Serve(lis); err != nil {
 		return fmt.Errorf("envoy control plane server failed while start serving incoming connections: %w", err)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/go-logr/zapr"
@@ -53,9 +54,11 @@ const (
 )
 
 var (
-	lbName      string
-	lbNamespace string
-	namespaced  bool
+	lbName                  string
+	lbNamespace             string
+	namespaced              bool
+	enableLeaderElection    bool
+	leaderElectionNamespace string
 )
 
 func main() {
@@ -69,6 +72,8 @@ func main() {
 	flag.StringVar(&opts.ExposeAnnotationKey, "expose-annotation-key", nodeportproxy.DefaultExposeAnnotationKey, "The annotation key used to determine if a Service should be exposed")
 	flag.IntVar(&opts.EnvoySNIListenerPort, "envoy-sni-port", 0, "Port used for SNI entry point.")
 	flag.IntVar(&opts.EnvoyTunnelingListenerPort, "envoy-tunneling-port", 0, "Port used for HTTP/2 CONNECT termination.")
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false, "Enable leader election, so that only one replica updates the LoadBalancer service.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "Leader election namespace. In-cluster discovery will be attempted in such case.")
 	flag.Parse()
 
 	// setup signal handler
@@ -99,7 +104,11 @@ func main() {
 	}
 
 	mgr, err := manager.New(config, manager.Options{
-		Cache: cacheOpts,
+		Cache:                         cacheOpts,
+		LeaderElection:                enableLeaderElection,
+		LeaderElectionNamespace:       leaderElectionNamespace,
+		LeaderElectionID:              "nodeport-proxy-lb-updater",
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		log.Fatalw("Failed to construct mgr", zap.Error(err))
@@ -156,6 +165,11 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 		return fmt.Errorf("failed to list services: %w", err)
 	}
 
+	lb := &corev1.Service{}
+	if err := u.client.Get(ctx, types.NamespacedName{Namespace: u.lbNamespace, Name: u.lbName}, lb); err != nil {
+		return fmt.Errorf("failed to get service %s/%s from lister: %w", u.lbNamespace, u.lbName, err)
+	}
+
 	var wantLBPorts []corev1.ServicePort
 	wantLBPorts = append(wantLBPorts, corev1.ServicePort{
 		Name:       "healthz",
@@ -193,6 +207,11 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 			continue
 		}
 
+		if !sharesIPFamily(service.Spec.IPFamilies, lb.Spec.IPFamilies) {
+			serviceLog.Debugw("Skipping service as it shares no IP family with the LoadBalancer", "families", service.Spec.IPFamilies)
+			continue
+		}
+
 		// We require a NodePort because we abuse it as allocation mechanism for a unique port
 		for _, servicePort := range service.Spec.Ports {
 			if servicePort.NodePort == 0 {
@@ -211,11 +230,6 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 		}
 	}
 
-	lb := &corev1.Service{}
-	if err := u.client.Get(ctx, types.NamespacedName{Namespace: u.lbNamespace, Name: u.lbName}, lb); err != nil {
-		return fmt.Errorf("failed to get service %s/%s from lister: %w", u.lbNamespace, u.lbName, err)
-	}
-
 	// We need to sort both port list to be able to compare them for equality
 	sort.Slice(wantLBPorts, func(i, j int) bool {
 		return wantLBPorts[i].Name < wantLBPorts[j].Name
@@ -254,6 +268,21 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 	return nil
 }
 
+// sharesIPFamily returns true if the given IP family lists have at least one
+// family in common. Empty lists are treated as compatible with everything, as
+// older services and LoadBalancers do not have their families defaulted.
+func sharesIPFamily(a, b []corev1.IPFamily) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, family := range a {
+		if slices.Contains(b, family) {
+			return true
+		}
+	}
+	return false
+}
+
 func fillNodePortsAndNames(wantPorts, lbPorts []corev1.ServicePort) []corev1.ServicePort {
 	for wi := range wantPorts {
 		setNodePortAndName(&wantPorts[wi], lbPorts)
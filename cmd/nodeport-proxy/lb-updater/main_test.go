@@ -735,6 +735,40 @@ func TestReconciliation(t *testing.T) {
 	}
 }
 
+func TestSharesIPFamily(t *testing.T) {
+	testCases := []struct {
+		name     string
+		service  []corev1.IPFamily
+		lb       []corev1.IPFamily
+		expected bool
+	}{
+		{
+			name:     "No families defaulted",
+			expected: true,
+		},
+		{
+			name:     "IPv4 service behind IPv6-only LoadBalancer",
+			service:  []corev1.IPFamily{corev1.IPv4Protocol},
+			lb:       []corev1.IPFamily{corev1.IPv6Protocol},
+			expected: false,
+		},
+		{
+			name:     "IPv6 service behind dual-stack LoadBalancer",
+			service:  []corev1.IPFamily{corev1.IPv6Protocol},
+			lb:       []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sharesIPFamily(tc.service, tc.lb); got != tc.expected {
+				t.Errorf("Expected %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
 // These tests are here for good reasons. Do not change them and make sure
 // they continue to pass.
 //
@@ -25,8 +25,10 @@ import (
 	addonutil "k8c.io/kubermatic/v2/pkg/addon"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addon"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addoninstaller"
+	applicationrolloutcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/application-rollout-controller"
 	applicationsecretclustercontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/application-secret-cluster-controller"
 	autoupdatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/auto-update-controller"
+	carotationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/ca-rotation-controller"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
 	clustercredentialscontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-credentials-controller"
 	clusterphasecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-phase-controller"
@@ -38,6 +40,7 @@ import (
 	encryptionatrestcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/encryption-at-rest-controller"
 	etcdbackupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdbackup"
 	etcdrestorecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdrestore"
+	exposegatewaycontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/expose-gateway-controller"
 	initialapplicationinstallationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/initial-application-installation-controller"
 	initialmachinedeployment "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/initial-machinedeployment-controller"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/ipam"
@@ -73,13 +76,16 @@ var AllControllers = map[string]controllerCreator{
 	initialmachinedeployment.ControllerName:                 createInitialMachineDeploymentController,
 	initialapplicationinstallationcontroller.ControllerName: createInitialApplicationInstallationController,
 	cniapplicationinstallationcontroller.ControllerName:     createCNIApplicationInstallationController,
+	applicationrolloutcontroller.ControllerName:             createApplicationRolloutController,
 	mla.ControllerName:                                      createMLAController,
 	clustertemplatecontroller.ControllerName:                createClusterTemplateController,
 	projectcontroller.ControllerName:                        createProjectController,
 	clusterphasecontroller.ControllerName:                   createClusterPhaseController,
+	carotationcontroller.ControllerName:                     createCARotationController,
 	presetcontroller.ControllerName:                         createPresetController,
 	encryptionatrestcontroller.ControllerName:               createEncryptionAtRestController,
 	ipam.ControllerName:                                     createIPAMController,
+	exposegatewaycontroller.ControllerName:                  createExposeGatewayController,
 	clusterstuckcontroller.ControllerName:                   createClusterStuckController,
 	operatingsystemprofilesynchronizer.ControllerName:       createOperatingSystemProfileController,
 	clustercredentialscontroller.ControllerName:             createClusterCredentialsController,
@@ -180,6 +186,8 @@ func createProjectController(ctrlCtx *controllerContext) error {
 }
 
 func createEtcdBackupController(ctrlCtx *controllerContext) error {
+	etcdbackupcontroller.MustRegisterMetrics(prometheus.DefaultRegisterer)
+
 	return etcdbackupcontroller.Add(
 		ctrlCtx.mgr,
 		ctrlCtx.log,
@@ -321,6 +329,16 @@ func createCNIApplicationInstallationController(ctrlCtx *controllerContext) erro
 	)
 }
 
+func createApplicationRolloutController(ctrlCtx *controllerContext) error {
+	return applicationrolloutcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.log,
+	)
+}
+
 func createPvWatcherController(ctrlCtx *controllerContext) error {
 	return pvwatcher.Add(
 		ctrlCtx.log,
@@ -422,6 +440,17 @@ func createEncryptionAtRestController(ctrlCtx *controllerContext) error {
 	)
 }
 
+func createCARotationController(ctrlCtx *controllerContext) error {
+	return carotationcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.versions,
+	)
+}
+
 func createIPAMController(ctrlCtx *controllerContext) error {
 	return ipam.Add(
 		ctrlCtx.mgr,
@@ -432,6 +461,14 @@ func createIPAMController(ctrlCtx *controllerContext) error {
 	)
 }
 
+func createExposeGatewayController(ctrlCtx *controllerContext) error {
+	return exposegatewaycontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.seedGetter,
+	)
+}
+
 func createClusterStuckController(ctrlCtx *controllerContext) error {
 	if !ctrlCtx.runOptions.featureGates.Enabled(features.DevelopmentEnvironment) {
 		return nil
@@ -58,6 +58,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
@@ -154,6 +156,12 @@ func main() {
 	if err := velerov1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Fatalw("Failed to register scheme", zap.Stringer("api", velerov1.SchemeGroupVersion), zap.Error(err))
 	}
+	if err := gatewayapiv1.Install(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", gatewayapiv1.SchemeGroupVersion), zap.Error(err))
+	}
+	if err := gatewayapiv1alpha2.Install(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", gatewayapiv1alpha2.SchemeGroupVersion), zap.Error(err))
+	}
 	// Check if the CRD for the VerticalPodAutoscaler is registered by allocating an informer
 	if err := mgr.GetAPIReader().List(rootCtx, &autoscalingv1.VerticalPodAutoscalerList{}); err != nil {
 		if meta.IsNoMatchError(err) {
@@ -239,6 +247,10 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 		log.Debug("Starting addons collector")
 		collectors.MustRegisterAddonCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
 	}
+	if !slices.Contains(disabledCollectors, string(kubermaticv1.CertificateCollector)) {
+		log.Debug("Starting certificates collector")
+		collectors.MustRegisterCertificateCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
+	}
 	if !slices.Contains(disabledCollectors, string(kubermaticv1.ProjectCollector)) {
 		// The canonical source of projects is the master cluster, but since they are replicated onto
 		// seeds, we start the project collctor on seed clusters as well, just for convenience for the admin.
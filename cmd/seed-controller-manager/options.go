@@ -25,6 +25,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -66,6 +67,7 @@ type controllerRunOptions struct {
 	addonEnforceInterval     int
 	systemAppEnforceInterval int
 	caBundle                 *certificates.CABundle
+	certificateRenewalWindow time.Duration
 
 	// for development purposes, a local configuration file
 	// can be used to provide the KubermaticConfiguration
@@ -137,6 +139,7 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
 	flag.IntVar(&c.systemAppEnforceInterval, "system-app-enforce-interval", 5, "Check and ensure system ApplicationInstallations in user cluster every interval in minutes. Set to 0 to disable.")
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "File containing the PEM-encoded CA bundle for all userclusters")
+	flag.DurationVar(&c.certificateRenewalWindow, "certificate-renewal-window", resources.DefaultCertificateRenewalWindow, "Time before expiry at which control plane leaf certificates are renewed.")
 	flag.Var(&c.tunnelingAgentIP, "tunneling-agent-ip", "The address used by the tunneling agents.")
 	flag.BoolVar(&c.enableUserClusterMLA, "enable-user-cluster-mla", false, "Enables user cluster MLA (Monitoring, Logging & Alerting) stack in the seed.")
 	flag.StringVar(&c.mlaNamespace, "mla-namespace", "mla", "The namespace in which the user cluster MLA stack is running.")
@@ -205,6 +208,10 @@ func (o controllerRunOptions) validate() error {
 		return fmt.Errorf("seed-name is undefined")
 	}
 
+	if err := resources.SetCertificateRenewalWindow(o.certificateRenewalWindow); err != nil {
+		return fmt.Errorf("invalid certificate-renewal-window: %w", err)
+	}
+
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
@@ -109,6 +110,7 @@ type controllerRunOptions struct {
 	applicationCache                  string
 	kubeVirtVMIEvictionController     bool
 	kubeVirtInfraKubeconfig           string
+	certificateRenewalWindow          time.Duration
 }
 
 func main() {
@@ -158,6 +160,7 @@ func main() {
 	flag.StringVar(&runOp.applicationCache, "application-cache", "", "Path to Application cache directory.")
 	flag.BoolVar(&runOp.kubeVirtVMIEvictionController, "kv-vmi-eviction-controller", false, "Start the KubeVirt VMI eviction controller")
 	flag.StringVar(&runOp.kubeVirtInfraKubeconfig, "kv-infra-kubeconfig", "", "Path to the KubeVirt infra kubeconfig.")
+	flag.DurationVar(&runOp.certificateRenewalWindow, "certificate-renewal-window", resources.DefaultCertificateRenewalWindow, "Time before expiry at which certificates are renewed.")
 	flag.Parse()
 
 	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
@@ -213,6 +216,9 @@ func main() {
 	if runOp.applicationCache == "" {
 		log.Fatal("application-cache must be set")
 	}
+	if err := resources.SetCertificateRenewalWindow(runOp.certificateRenewalWindow); err != nil {
+		log.Fatalw("Invalid -certificate-renewal-window", zap.Error(err))
+	}
 
 	nodeLabels := map[string]string{}
 	if runOp.nodelabels != "" {
@@ -34,7 +34,9 @@ import (
 	applicationinstallationmutation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationinstallation/mutation"
 	applicationinstallationvalidation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationinstallation/validation"
 	machinevalidation "k8c.io/kubermatic/v2/pkg/webhook/machine/validation"
+	servicevalidation "k8c.io/kubermatic/v2/pkg/webhook/service/validation"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
@@ -132,7 +134,7 @@ func main() {
 	applicationinstallationvalidation.NewAdmissionHandler(log, seedMgr.GetScheme(), seedMgr.GetClient()).SetupWebhookWithManager(seedMgr)
 
 	// Setup Machine Webhook in user manager.
-	machineValidator, err := machinevalidation.NewValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID)
+	machineValidator, err := machinevalidation.NewValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID, options.datacenter, options.provider)
 	if err != nil {
 		log.Fatalw("Failed to setup Machine validator", zap.Error(err))
 	}
@@ -140,6 +142,24 @@ func main() {
 		log.Fatalw("Failed to setup Machine validation webhook", zap.Error(err))
 	}
 
+	// Setup MachineDeployment Webhook in user manager.
+	machineDeploymentValidator, err := machinevalidation.NewMachineDeploymentValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID, options.datacenter, options.provider)
+	if err != nil {
+		log.Fatalw("Failed to setup MachineDeployment validator", zap.Error(err))
+	}
+	if err := builder.WebhookManagedBy(userMgr).For(&clusterv1alpha1.MachineDeployment{}).WithValidator(machineDeploymentValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup MachineDeployment validation webhook", zap.Error(err))
+	}
+
+	// Setup Service Webhook in user manager.
+	serviceValidator, err := servicevalidation.NewValidator(seedMgr.GetClient(), log, options.projectID, options.datacenter, options.provider)
+	if err != nil {
+		log.Fatalw("Failed to setup Service validator", zap.Error(err))
+	}
+	if err := builder.WebhookManagedBy(userMgr).For(&corev1.Service{}).WithValidator(serviceValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup Service validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// Start managers
 
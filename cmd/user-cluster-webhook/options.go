@@ -35,6 +35,8 @@ type appOptions struct {
 	log         kubermaticlog.Options
 	caBundle    *certificates.CABundle
 	projectID   string
+	datacenter  string
+	provider    string
 }
 
 func initApplicationOptions() (appOptions, error) {
@@ -55,6 +57,8 @@ func initApplicationOptions() (appOptions, error) {
 
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "File containing the PEM-encoded CA bundle for all userclusters")
 	flag.StringVar(&projectID, "project-id", "", "Project ID in which cluster the webhook is running in")
+	flag.StringVar(&c.datacenter, "datacenter", "", "Name of the datacenter of the cluster, used for datacenter-scoped resource quotas")
+	flag.StringVar(&c.provider, "provider", "", "Cloud provider of the cluster, used for provider-scoped resource quotas")
 
 	flag.Parse()
 
@@ -45,11 +45,13 @@ annotation="kubermatic.k8c.io/location"
 locationMap='{
   "applicationdefinitions.apps.kubermatic.k8c.io": "master,seed",
   "applicationinstallations.apps.kubermatic.k8c.io": "usercluster",
+  "applicationrollouts.apps.kubermatic.k8c.io": "seed",
   "addonconfigs.kubermatic.k8c.io": "master",
   "addons.kubermatic.k8c.io": "master,seed",
   "admissionplugins.kubermatic.k8c.io": "master",
   "alertmanagers.kubermatic.k8c.io": "master,seed",
   "allowedregistries.kubermatic.k8c.io": "master",
+  "clustermigrations.kubermatic.k8c.io": "master",
   "clusters.kubermatic.k8c.io": "master,seed",
   "clustertemplateinstances.kubermatic.k8c.io": "master,seed",
   "clustertemplates.kubermatic.k8c.io": "master,seed",
@@ -66,6 +68,7 @@ locationMap='{
   "kubermaticsettings.kubermatic.k8c.io": "master",
   "mlaadminsettings.kubermatic.k8c.io": "master,seed",
   "presets.kubermatic.k8c.io": "master,seed",
+  "pricemodels.kubermatic.k8c.io": "master",
   "projects.kubermatic.k8c.io": "master,seed",
   "resourcequotas.kubermatic.k8c.io": "master,seed",
   "rulegroups.kubermatic.k8c.io": "master,seed",
@@ -105,9 +105,11 @@ func NewTemplateData(
 		ipamAllocationsData = make(map[string]IPAMAllocation, len(ipamAllocations.Items))
 		for _, ipamAllocation := range ipamAllocations.Items {
 			ipamAllocationsData[ipamAllocation.Name] = IPAMAllocation{
-				Type:      ipamAllocation.Spec.Type,
-				CIDR:      ipamAllocation.Spec.CIDR,
-				Addresses: ipamAllocation.Spec.Addresses,
+				Type:          ipamAllocation.Spec.Type,
+				CIDR:          ipamAllocation.Spec.CIDR,
+				Addresses:     ipamAllocation.Spec.Addresses,
+				IPv6CIDR:      ipamAllocation.Spec.IPv6CIDR,
+				IPv6Addresses: ipamAllocation.Spec.IPv6Addresses,
 			}
 		}
 	}
@@ -247,6 +249,9 @@ type IPAMAllocation struct {
 	Type      kubermaticv1.IPAMPoolAllocationType
 	CIDR      kubermaticv1.SubnetCIDR
 	Addresses []string
+	// IPv6CIDR and IPv6Addresses are only set for allocations from dual-stack pools.
+	IPv6CIDR      kubermaticv1.SubnetCIDR
+	IPv6Addresses []string
 }
 
 type CNIPlugin struct {
@@ -80,6 +80,16 @@ func TestNewTemplateData(t *testing.T) {
 					Addresses: []string{"192.168.0.1-192.168.0.8", "192.168.0.10-192.168.0.17"},
 				},
 			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ipam-pool-3",
+				},
+				Spec: kubermaticv1.IPAMAllocationSpec{
+					Type:     "prefix",
+					CIDR:     "192.168.1.0/28",
+					IPv6CIDR: "fd00::/64",
+				},
+			},
 		},
 	}
 
@@ -103,5 +113,10 @@ func TestNewTemplateData(t *testing.T) {
 			Type:      "range",
 			Addresses: []string{"192.168.0.1-192.168.0.8", "192.168.0.10-192.168.0.17"},
 		},
+		"ipam-pool-3": {
+			Type:     "prefix",
+			CIDR:     "192.168.1.0/28",
+			IPv6CIDR: "fd00::/64",
+		},
 	}, templateData.Cluster.Network.IPAMAllocations)
 }
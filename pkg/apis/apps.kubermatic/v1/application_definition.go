@@ -142,19 +142,88 @@ type GitSource struct {
 	Credentials *GitCredentials `json:"credentials,omitempty"`
 }
 
+type OCICredentials struct {
+	// Username holds the ref and key in the secret for the username credential.
+	// The Secret must exist in the namespace where KKP is installed (default is "kubermatic").
+	// The Secret must be annotated with `apps.kubermatic.k8c.io/secret-type:` set to oci
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+
+	// Password holds the ref and key in the secret for the Password credential.
+	// The Secret must exist in the namespace where KKP is installed (default is "kubermatic").
+	// The Secret must be annotated with `apps.kubermatic.k8c.io/secret-type:` set to oci
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+
+	// RegistryConfigFile holds the ref and key in the secret for the registry credential file. The value is dockercfg
+	// file that follows the same format rules as ~/.docker/config.json
+	// The Secret must exist in the namespace where KKP is installed (default is "kubermatic").
+	// The Secret must be annotated with `apps.kubermatic.k8c.io/secret-type:` set to oci
+	RegistryConfigFile *corev1.SecretKeySelector `json:"registryConfigFile,omitempty"`
+}
+
+type OCISignatureVerification struct {
+	// PublicKey holds the ref and key in the secret for the PEM encoded cosign public key. The artifact must have
+	// at least one cosign signature in its repository that can be verified with this key.
+	// The Secret must exist in the namespace where KKP is installed (default is "kubermatic").
+	// The Secret must be annotated with `apps.kubermatic.k8c.io/secret-type:` set to oci
+	PublicKey corev1.SecretKeySelector `json:"publicKey"`
+}
+
+type OCISource struct {
+	// Repository of the artifact without scheme, tag or digest (e.g. registry.example.com/charts/nginx).
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+
+	// Tag of the artifact. If a digest is given as well, the tag must point to this digest.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest pins the artifact (e.g. sha256:1234...). It is recommended over using a tag, as the
+	// content of a digest can never change.
+	// +kubebuilder:validation:Pattern:=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Path of the "source" in the artifact. default is the artifact's root.
+	// It is ignored for Helm charts, which are always downloaded as chart archive.
+	Path string `json:"path,omitempty"`
+
+	// PlainHTTP allows to pull the artifact from a registry that is not served via HTTPS.
+	// +optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+
+	// Credentials are optional and hold the ref to the secret with the registry credentials.
+	// Either username / Password or registryConfigFile can be defined.
+	Credentials *OCICredentials `json:"credentials,omitempty"`
+
+	// Verification optionally requires the artifact to be signed with cosign.
+	Verification *OCISignatureVerification `json:"verification,omitempty"`
+}
+
 type ApplicationSource struct {
 	// Install Application from a Helm repository
 	Helm *HelmSource `json:"helm,omitempty"`
 
 	// Install application from a Git repository
 	Git *GitSource `json:"git,omitempty"`
+
+	// Install application from an OCI artifact. This can be a Helm chart or any other
+	// artifact whose layers are tarballs or files (e.g. pushed with oras or flux).
+	OCI *OCISource `json:"oci,omitempty"`
 }
 
 const (
 	HelmTemplateMethod TemplateMethod = "helm"
+
+	// KustomizeTemplateMethod builds the kustomization in the application's source and applies
+	// the resulting resources using server-side apply.
+	KustomizeTemplateMethod TemplateMethod = "kustomize"
+
+	// ManifestsTemplateMethod applies all YAML and JSON manifests in the application's source
+	// using server-side apply.
+	ManifestsTemplateMethod TemplateMethod = "manifests"
 )
 
-// +kubebuilder:validation:Enum=helm
+// +kubebuilder:validation:Enum=helm;kustomize;manifests
 type TemplateMethod string
 
 type ApplicationTemplate struct {
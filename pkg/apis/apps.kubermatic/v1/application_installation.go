@@ -89,6 +89,24 @@ type ApplicationInstallationSpec struct {
 
 	// DeployOptions holds the settings specific to the templating method used to deploy the application.
 	DeployOptions *DeployOptions `json:"deployOptions,omitempty"`
+
+	// DriftDetection configures the periodic comparison of the application's resources in the user cluster with
+	// the manifests of the application. Detected changes are reported with the Drifted condition.
+	// If not set, drift is checked every 10 minutes and only reported.
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+}
+
+// DriftDetection configures the drift detection of an application.
+type DriftDetection struct {
+	// Disabled turns off the drift detection for this application.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Interval is the interval at which the application's resources are checked for drift. Defaults to 10m.
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// SelfHeal re-applies the application when drift has been detected, reverting the changes made to its
+	// resources and re-creating deleted resources.
+	SelfHeal bool `json:"selfHeal,omitempty"`
 }
 
 // DeployOptions holds the settings specific to the templating method used to deploy the application.
@@ -176,10 +194,37 @@ type ApplicationInstallationStatus struct {
 	// HelmRelease holds the information about the helm release installed by this application. This field is only filled if template method is 'helm'.
 	HelmRelease *HelmRelease `json:"helmRelease,omitempty"`
 
+	// AppliedResources lists the resources applied into the user cluster by this application. This field is only
+	// filled if template method is 'kustomize' or 'manifests'. Resources that are no longer part of the application
+	// are pruned on upgrade.
+	AppliedResources []AppliedResource `json:"appliedResources,omitempty"`
+
 	// Failures counts the number of failed installation or updagrade. it is reset on successful reconciliation.
 	Failures int `json:"failures,omitempty"`
 }
 
+// AppliedResource references a resource applied into the user cluster.
+type AppliedResource struct {
+	// Group is the API group of the resource. It is empty for the core group.
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the resource.
+	Version string `json:"version"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace of the resource. It is empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// FieldsHash is the hash of the fields owned by the application after the resource has been applied.
+	// It is used to detect changes made to these fields by others.
+	FieldsHash string `json:"fieldsHash,omitempty"`
+}
+
 type HelmRelease struct {
 	// Name is the name of the release.
 	Name string `json:"name,omitempty"`
@@ -234,7 +279,7 @@ type ApplicationInstallationCondition struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=ManifestsRetrieved;Ready
+// +kubebuilder:validation:Enum=ManifestsRetrieved;Ready;Drifted
 
 // swagger:enum ApplicationInstallationConditionType
 // All condition types must be registered within the `AllApplicationInstallationConditionTypes` variable.
@@ -246,11 +291,16 @@ const (
 
 	// Ready describes all components have been successfully rolled out and are ready.
 	Ready ApplicationInstallationConditionType = "Ready"
+
+	// Drifted indicates that resources of the application have been modified or deleted in the user cluster
+	// since the application has been installed. The message lists the drifted resources.
+	Drifted ApplicationInstallationConditionType = "Drifted"
 )
 
 var AllApplicationInstallationConditionTypes = []ApplicationInstallationConditionType{
 	ManifestsRetrieved,
 	Ready,
+	Drifted,
 }
 
 // SetCondition of the applicationInstallation. It take care of update LastHeartbeatTime and LastTransitionTime if needed.
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ApplicationRolloutResourceName represents "Resource" defined in Kubernetes.
+	ApplicationRolloutResourceName = "applicationrollouts"
+
+	// ApplicationRolloutKindName represents "Kind" defined in Kubernetes.
+	ApplicationRolloutKindName = "ApplicationRollout"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=approllout
+// +kubebuilder:printcolumn:JSONPath=".spec.applicationDefinition",name="Application",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.targetVersion",name="Target Version",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.currentWave",name="Wave",type="integer"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ApplicationRollout upgrades the ApplicationInstallations of an ApplicationDefinition in the user clusters of a
+// seed to a new version, one wave of clusters after another. Each seed only rolls out to its own clusters.
+type ApplicationRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationRolloutSpec   `json:"spec,omitempty"`
+	Status ApplicationRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationRolloutList contains a list of ApplicationRollouts.
+type ApplicationRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ApplicationRollout `json:"items"`
+}
+
+// ApplicationRolloutSpec describes a rollout of an application version.
+type ApplicationRolloutSpec struct {
+	// ApplicationDefinition is the name of the ApplicationDefinition whose installations are upgraded.
+	// +kubebuilder:validation:MinLength=1
+	ApplicationDefinition string `json:"applicationDefinition"`
+
+	// TargetVersion is the version of the ApplicationDefinition the installations are upgraded to.
+	// +kubebuilder:validation:MinLength=1
+	TargetVersion string `json:"targetVersion"`
+
+	// SourceVersions restricts the rollout to installations of these versions. If empty, all installations
+	// of the ApplicationDefinition are upgraded.
+	SourceVersions []string `json:"sourceVersions,omitempty"`
+
+	// Waves are the groups of clusters that are upgraded one after another. A cluster belongs to the first
+	// wave whose selector matches it. Clusters not matched by any wave are not upgraded.
+	// +kubebuilder:validation:MinItems=1
+	Waves []ApplicationRolloutWave `json:"waves"`
+
+	// Paused stops the rollout from progressing to the next wave. Installations that have already been
+	// upgraded are not changed.
+	Paused bool `json:"paused,omitempty"`
+
+	// AutoRollback reverts all upgraded installations to their previous version as soon as one of them fails.
+	// Otherwise, the rollout pauses until the failed installations have recovered.
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// ApplicationRolloutWave is a group of clusters that is upgraded at once.
+type ApplicationRolloutWave struct {
+	// Name of the wave.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ClusterSelector selects the clusters of this wave by their labels. An empty selector matches
+	// all clusters not selected by a previous wave.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// SoakTime is the time all installations of the wave have to be ready before the next wave is started.
+	SoakTime metav1.Duration `json:"soakTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum="";Progressing;Paused;Completed;RolledBack;Failed
+
+// ApplicationRolloutPhase is the phase of an ApplicationRollout.
+type ApplicationRolloutPhase string
+
+const (
+	// ApplicationRolloutPhaseProgressing means the installations of the current wave are being upgraded.
+	ApplicationRolloutPhaseProgressing ApplicationRolloutPhase = "Progressing"
+	// ApplicationRolloutPhasePaused means the rollout has been paused, either in the spec or because
+	// upgraded installations have failed.
+	ApplicationRolloutPhasePaused ApplicationRolloutPhase = "Paused"
+	// ApplicationRolloutPhaseCompleted means all waves have been upgraded successfully.
+	ApplicationRolloutPhaseCompleted ApplicationRolloutPhase = "Completed"
+	// ApplicationRolloutPhaseRolledBack means an upgraded installation has failed and all upgraded
+	// installations have been reverted to their previous version.
+	ApplicationRolloutPhaseRolledBack ApplicationRolloutPhase = "RolledBack"
+	// ApplicationRolloutPhaseFailed means the rollout cannot be performed, e.g. because the target version
+	// does not exist.
+	ApplicationRolloutPhaseFailed ApplicationRolloutPhase = "Failed"
+)
+
+// ApplicationRolloutStatus is the status of an ApplicationRollout.
+type ApplicationRolloutStatus struct {
+	// Phase of the rollout.
+	Phase ApplicationRolloutPhase `json:"phase,omitempty"`
+
+	// CurrentWave is the index of the wave that is currently upgraded.
+	CurrentWave int `json:"currentWave"`
+
+	// WaveReadyTime is the time at which all installations of the current wave have become ready.
+	WaveReadyTime *metav1.Time `json:"waveReadyTime,omitempty"`
+
+	// Message describes the reason for the current phase.
+	Message string `json:"message,omitempty"`
+
+	// Installations are the installations touched by the rollout.
+	Installations []ApplicationRolloutInstallation `json:"installations,omitempty"`
+
+	// SkippedClusters are the clusters of the started waves that have not been upgraded, because
+	// their reconciliation is paused. They are upgraded once they are unpaused while their wave
+	// or a later one is in progress.
+	SkippedClusters []string `json:"skippedClusters,omitempty"`
+}
+
+// ApplicationRolloutInstallation records an ApplicationInstallation upgraded by a rollout.
+type ApplicationRolloutInstallation struct {
+	// Cluster is the name of the cluster the installation belongs to.
+	Cluster string `json:"cluster"`
+
+	// Namespace of the ApplicationInstallation in the user cluster.
+	Namespace string `json:"namespace"`
+
+	// Name of the ApplicationInstallation in the user cluster.
+	Name string `json:"name"`
+
+	// Wave is the index of the wave the installation has been upgraded in.
+	Wave int `json:"wave"`
+
+	// PreviousVersion is the version of the installation before the rollout. It is restored on rollback.
+	PreviousVersion string `json:"previousVersion"`
+}
@@ -37,4 +37,8 @@ const (
 	// ApplicationTypeCNIValue can be used as a value for the ApplicationTypeLabel to indicate that the
 	// application definition / application installation type if CNI (Container Network Interface).
 	ApplicationTypeCNIValue = "cni"
+
+	// ApplicationSelfHealAnnotation is set to the time drift has been detected on an application installation
+	// whose drift detection has self-healing enabled. Changing it triggers the re-installation of the application.
+	ApplicationSelfHealAnnotation = "apps.kubermatic.k8c.io/self-heal"
 )
@@ -232,6 +232,11 @@ func (in *ApplicationInstallationSpec) DeepCopyInto(out *ApplicationInstallation
 		*out = new(DeployOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationInstallationSpec.
@@ -264,6 +269,11 @@ func (in *ApplicationInstallationStatus) DeepCopyInto(out *ApplicationInstallati
 		*out = new(HelmRelease)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]AppliedResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationInstallationStatus.
@@ -291,6 +301,153 @@ func (in *ApplicationRef) DeepCopy() *ApplicationRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRollout) DeepCopyInto(out *ApplicationRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRollout.
+func (in *ApplicationRollout) DeepCopy() *ApplicationRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRolloutInstallation) DeepCopyInto(out *ApplicationRolloutInstallation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRolloutInstallation.
+func (in *ApplicationRolloutInstallation) DeepCopy() *ApplicationRolloutInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRolloutInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRolloutList) DeepCopyInto(out *ApplicationRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRolloutList.
+func (in *ApplicationRolloutList) DeepCopy() *ApplicationRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRolloutSpec) DeepCopyInto(out *ApplicationRolloutSpec) {
+	*out = *in
+	if in.SourceVersions != nil {
+		in, out := &in.SourceVersions, &out.SourceVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]ApplicationRolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRolloutSpec.
+func (in *ApplicationRolloutSpec) DeepCopy() *ApplicationRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRolloutStatus) DeepCopyInto(out *ApplicationRolloutStatus) {
+	*out = *in
+	if in.WaveReadyTime != nil {
+		in, out := &in.WaveReadyTime, &out.WaveReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Installations != nil {
+		in, out := &in.Installations, &out.Installations
+		*out = make([]ApplicationRolloutInstallation, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedClusters != nil {
+		in, out := &in.SkippedClusters, &out.SkippedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRolloutStatus.
+func (in *ApplicationRolloutStatus) DeepCopy() *ApplicationRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRolloutWave) DeepCopyInto(out *ApplicationRolloutWave) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	out.SoakTime = in.SoakTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRolloutWave.
+func (in *ApplicationRolloutWave) DeepCopy() *ApplicationRolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSource) DeepCopyInto(out *ApplicationSource) {
 	*out = *in
@@ -304,6 +461,11 @@ func (in *ApplicationSource) DeepCopyInto(out *ApplicationSource) {
 		*out = new(GitSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCISource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSource.
@@ -353,6 +515,21 @@ func (in *ApplicationVersion) DeepCopy() *ApplicationVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedResource) DeepCopyInto(out *AppliedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedResource.
+func (in *AppliedResource) DeepCopy() *AppliedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DependencyCredentials) DeepCopyInto(out *DependencyCredentials) {
 	*out = *in
@@ -393,6 +570,22 @@ func (in *DeployOptions) DeepCopy() *DeployOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitCredentials) DeepCopyInto(out *GitCredentials) {
 	*out = *in
@@ -567,3 +760,74 @@ func (in *HelmSource) DeepCopy() *HelmSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCICredentials) DeepCopyInto(out *OCICredentials) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryConfigFile != nil {
+		in, out := &in.RegistryConfigFile, &out.RegistryConfigFile
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCICredentials.
+func (in *OCICredentials) DeepCopy() *OCICredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OCICredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCISignatureVerification) DeepCopyInto(out *OCISignatureVerification) {
+	*out = *in
+	in.PublicKey.DeepCopyInto(&out.PublicKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCISignatureVerification.
+func (in *OCISignatureVerification) DeepCopy() *OCISignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(OCISignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCISource) DeepCopyInto(out *OCISource) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(OCICredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(OCISignatureVerification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCISource.
+func (in *OCISource) DeepCopy() *OCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(OCISource)
+	in.DeepCopyInto(out)
+	return out
+}
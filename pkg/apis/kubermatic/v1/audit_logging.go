@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // +kubebuilder:validation:Enum="";metadata;recommended;minimal
@@ -54,3 +55,127 @@ type AuditLoggingSettings struct {
 	// Optional: Configures the fluent-bit sidecar deployed alongside kube-apiserver.
 	SidecarSettings *AuditSidecarSettings `json:"sidecar,omitempty"`
 }
+
+// AuditLogAggregatorSettings configures the seed-level audit log aggregator. When enabled, the
+// audit-logs sidecars of all user clusters on the seed forward their audit events to the aggregator,
+// which applies the redaction rules and writes the events to the configured sinks.
+type AuditLogAggregatorSettings struct {
+	// Enabled deploys the aggregator and configures the audit-logs sidecars of all user clusters
+	// with audit logging enabled to forward their audit events to it.
+	Enabled bool `json:"enabled,omitempty"`
+	// Optional: Replicas is the number of aggregator replicas. Defaults to 2.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Optional: Resources overrides the default resource requirements of the aggregator.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Optional: BufferSize is the maximum amount of audit events every aggregator replica buffers
+	// per sink while the sink is unavailable. Once the limit is reached, the oldest events are dropped.
+	// Defaults to 1Gi.
+	BufferSize *resource.Quantity `json:"bufferSize,omitempty"`
+	// Optional: RedactionRules are applied to every audit event before it is written to a sink.
+	RedactionRules []AuditLogRedactionRule `json:"redactionRules,omitempty"`
+	// DefaultSink receives the audit events of all clusters whose project has no dedicated sink.
+	DefaultSink AuditLogSink `json:"defaultSink"`
+	// Optional: ProjectSinks routes the audit events of all clusters in a project to a dedicated
+	// sink instead of the default sink. The map is keyed by project ID.
+	ProjectSinks map[string]AuditLogSink `json:"projectSinks,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Remove;Mask
+
+// AuditLogRedactionAction defines how a field matched by a redaction rule is redacted.
+type AuditLogRedactionAction string
+
+const (
+	// AuditLogRedactionRemove removes the field from the audit event.
+	AuditLogRedactionRemove AuditLogRedactionAction = "Remove"
+	// AuditLogRedactionMask replaces the value of the field with a fixed placeholder.
+	AuditLogRedactionMask AuditLogRedactionAction = "Mask"
+)
+
+// AuditLogRedactionRule redacts a single field of every audit event.
+type AuditLogRedactionRule struct {
+	// Path is the dot-separated path of the field in the audit event, for example
+	// `requestObject.data` or `user.extra`.
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`
+	Path string `json:"path"`
+	// Optional: Action defines how the field is redacted. Defaults to Mask.
+	Action AuditLogRedactionAction `json:"action,omitempty"`
+}
+
+// AuditLogSink configures where the aggregator writes audit events to. Exactly one sink must be configured.
+type AuditLogSink struct {
+	// S3 writes the audit events as JSON lines into an S3 bucket.
+	S3 *AuditLogS3Sink `json:"s3,omitempty"`
+	// Loki pushes the audit events to a Loki instance.
+	Loki *AuditLogLokiSink `json:"loki,omitempty"`
+	// Syslog sends the audit events as JSON encoded messages to a syslog server.
+	Syslog *AuditLogSyslogSink `json:"syslog,omitempty"`
+	// HTTP posts the audit events as JSON lines to an HTTP endpoint.
+	HTTP *AuditLogHTTPSink `json:"http,omitempty"`
+}
+
+// AuditLogS3Sink writes audit events into an S3 bucket.
+type AuditLogS3Sink struct {
+	// Bucket is the name of the S3 bucket.
+	Bucket string `json:"bucket"`
+	// Region is the region of the S3 bucket.
+	Region string `json:"region"`
+	// Optional: Endpoint is the URL of an S3 compatible API, for example a MinIO instance.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Optional: KeyPrefix is prepended to the key of all objects written by the aggregator.
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+	// Optional: RoleARN is an IAM role that is assumed to write into the bucket.
+	RoleARN string `json:"roleARN,omitempty"`
+	// Optional: CredentialsSecretRef references a Secret in the namespace of the Seed that contains
+	// the `accessKeyId` and `secretAccessKey` keys. All S3 sinks of a seed must use the same Secret.
+	// If not set, the default AWS credential chain of the aggregator is used.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// AuditLogLokiSink pushes audit events to a Loki instance.
+type AuditLogLokiSink struct {
+	// URL is the base URL of the Loki instance, for example `https://loki.example.com`.
+	URL string `json:"url"`
+	// Optional: TenantID is sent as the tenant of all pushed audit events.
+	TenantID string `json:"tenantID,omitempty"`
+	// Optional: Labels are added as static labels to all pushed audit events, in addition
+	// to the `cluster` and `project` labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Optional: CredentialsSecretRef references a Secret in the namespace of the Seed that contains
+	// the `username` and `password` keys used for basic authentication.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=tcp;udp;tls
+
+// AuditLogSyslogMode is the transport used to send audit events to a syslog server.
+type AuditLogSyslogMode string
+
+const (
+	AuditLogSyslogModeTCP AuditLogSyslogMode = "tcp"
+	AuditLogSyslogModeUDP AuditLogSyslogMode = "udp"
+	AuditLogSyslogModeTLS AuditLogSyslogMode = "tls"
+)
+
+// AuditLogSyslogSink sends audit events to a syslog server.
+type AuditLogSyslogSink struct {
+	// Host is the hostname or IP address of the syslog server.
+	Host string `json:"host"`
+	// Port is the port of the syslog server.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Optional: Mode is the transport used to reach the syslog server. Defaults to tcp.
+	Mode AuditLogSyslogMode `json:"mode,omitempty"`
+}
+
+// AuditLogHTTPSink posts audit events to an HTTP endpoint.
+type AuditLogHTTPSink struct {
+	// URL is the URL the audit events are posted to.
+	URL string `json:"url"`
+	// Optional: Headers are sent with every request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Optional: CredentialsSecretRef references a Secret in the namespace of the Seed that contains
+	// the `username` and `password` keys used for basic authentication.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
@@ -50,6 +50,11 @@ const (
 
 	// PresetInvalidatedAnnotation is key of the annotation used to indicate why the preset was invalidated.
 	PresetInvalidatedAnnotation = "presetInvalidated"
+
+	// RotateCertificateAuthoritiesAnnotation is key of the annotation used to request a rotation of the
+	// cluster's root CA, front-proxy CA and service account signing key. The annotation is removed once
+	// the rotation has been completed.
+	RotateCertificateAuthoritiesAnnotation = "kubermatic.k8c.io/rotate-certificate-authorities"
 )
 
 const (
@@ -176,6 +181,11 @@ type ClusterSpec struct {
 	// Optional: OIDC specifies the OIDC configuration parameters for enabling authentication mechanism for the cluster.
 	OIDC OIDCSettings `json:"oidc,omitempty"`
 
+	// Optional: AuthenticationConfiguration configures structured authentication for kube-apiserver, which
+	// supports multiple JWT issuers with claim mappings and CEL validation rules. It cannot be combined with
+	// `oidc` and requires Kubernetes 1.30 or newer.
+	AuthenticationConfiguration *AuthenticationConfiguration `json:"authenticationConfiguration,omitempty"`
+
 	// A map of optional or early-stage features that can be enabled for the user cluster.
 	// Some feature gates cannot be disabled after being enabled.
 	// The available feature gates vary based on KKP version, Kubernetes version and Seed configuration.
@@ -354,7 +364,7 @@ const (
 	ClusterFeatureEncryptionAtRest = "encryptionAtRest"
 )
 
-// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;
+// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;CARotationControllerReconciledSuccessfully;CertificatesValid;
 
 // ClusterConditionType is used to indicate the type of a cluster condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
@@ -388,6 +398,28 @@ type EncryptionConfiguration struct {
 	// Configuration for the `secretbox` static key encryption scheme as supported by Kubernetes.
 	// More info: https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/#providers
 	Secretbox *SecretboxEncryptionConfiguration `json:"secretbox,omitempty"`
+	// Configuration for envelope encryption through a KMS v2 plugin, which keeps the key encryption
+	// key in an external key management system instead of the seed cluster.
+	// More info: https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/
+	KMS *KMSEncryptionConfiguration `json:"kms,omitempty"`
+}
+
+// KMSEncryptionConfiguration defines envelope encryption based on a KMS v2 plugin. The plugin is run
+// as a sidecar next to kube-apiserver and talks to the external key management system, e.g. the
+// transit engine of a Vault instance or a SoftHSM token for testing. The plugins themselves are
+// configured by the administrator in the datacenter of the cluster.
+type KMSEncryptionConfiguration struct {
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// Name of the KMS provider in the EncryptionConfiguration of kube-apiserver. Changing the name
+	// re-encrypts all configured resources, e.g. after the plugin has been pointed to a new key.
+	Name string `json:"name"`
+	// Plugin is the name of the KMS plugin to use. It must be one of the plugins configured
+	// in the datacenter of the cluster. It cannot be changed while data is encrypted with it,
+	// the data has to be decrypted by disabling encryption first.
+	Plugin string `json:"plugin"`
+	// Timeout for gRPC calls from kube-apiserver to the plugin. Defaults to 3s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // SecretboxEncryptionConfiguration defines static key encryption based on the 'secretbox' solution for Kubernetes.
@@ -444,12 +476,17 @@ const (
 	ClusterConditionClusterInitialized                                  ClusterConditionType = "ClusterInitialized"
 	ClusterConditionIPAMControllerReconcilingSuccess                    ClusterConditionType = "IPAMControllerReconciledSuccessfully"
 	ClusterConditionClusterBackupControllerReconcilingSuccess           ClusterConditionType = "ClusterBackupControllerReconciledSuccessfully"
+	ClusterConditionCARotationControllerReconcilingSuccess              ClusterConditionType = "CARotationControllerReconciledSuccessfully"
 
 	ClusterConditionEtcdClusterInitialized ClusterConditionType = "EtcdClusterInitialized"
 	ClusterConditionEncryptionInitialized  ClusterConditionType = "EncryptionInitialized"
 
 	ClusterConditionUpdateProgress ClusterConditionType = "UpdateProgress"
 
+	// ClusterConditionCertificatesValid is true when no certificate of the control plane expires
+	// within the certificate renewal window. Its message names the certificate that expires next.
+	ClusterConditionCertificatesValid ClusterConditionType = "CertificatesValid"
+
 	// ClusterConditionNone is a special value indicating that no cluster condition should be set.
 	ClusterConditionNone ClusterConditionType = ""
 	// This condition is met when a CSI migration is ongoing and the CSI
@@ -571,6 +608,11 @@ type ClusterStatus struct {
 
 	// ResourceUsage shows the current usage of resources for the cluster.
 	ResourceUsage *ResourceDetails `json:"resourceUsage,omitempty"`
+
+	// CARotation describes the status of the most recent rotation of the cluster's certificate
+	// authorities and service account signing key.
+	// +optional
+	CARotation *ClusterCARotationStatus `json:"caRotation,omitempty"`
 }
 
 // ClusterVersionsStatus contains information regarding the current and desired versions
@@ -644,6 +686,33 @@ const (
 	ClusterEncryptionPhaseEncryptionNeeded ClusterEncryptionPhase = "EncryptionNeeded"
 )
 
+// ClusterCARotationStatus holds status information about a rotation of the cluster's root CA, front-proxy CA
+// and service account signing key.
+type ClusterCARotationStatus struct {
+	// Phase is the current phase of the rotation. Can be one of `Trusting`, `Reissuing`, `Retiring` or `Completed`.
+	// During `Trusting`, the new CAs and key are issued and trusted by all components next to the current ones.
+	// During `Reissuing`, they are used to sign all leaf certificates and service account tokens.
+	// During `Retiring`, the previous CAs and key are no longer trusted.
+	Phase ClusterCARotationPhase `json:"phase"`
+	// StartTime is the time when the rotation was started.
+	StartTime metav1.Time `json:"startTime"`
+	// LastTransitionTime is the time when the rotation entered its current phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Message describes what the rotation is currently waiting for.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Trusting;Reissuing;Retiring;Completed
+type ClusterCARotationPhase string
+
+const (
+	ClusterCARotationPhaseTrusting  ClusterCARotationPhase = "Trusting"
+	ClusterCARotationPhaseReissuing ClusterCARotationPhase = "Reissuing"
+	ClusterCARotationPhaseRetiring  ClusterCARotationPhase = "Retiring"
+	ClusterCARotationPhaseCompleted ClusterCARotationPhase = "Completed"
+)
+
 // OIDCSettings contains OIDC configuration parameters for enabling authentication mechanism for the cluster.
 type OIDCSettings struct {
 	IssuerURL      string `json:"issuerURL,omitempty"`
@@ -657,6 +726,108 @@ type OIDCSettings struct {
 	GroupsPrefix   string `json:"groupsPrefix,omitempty"`
 }
 
+// AuthenticationConfiguration is rendered into the AuthenticationConfiguration file of kube-apiserver.
+// More info: https://kubernetes.io/docs/reference/access-authn-authz/authentication/#using-authentication-configuration
+type AuthenticationConfiguration struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// JWT is a list of authenticators for tokens issued by JWT-compliant issuers, e.g. an OIDC
+	// identity provider or the workload identity of a CI system. Every issuer URL must be unique.
+	JWT []JWTAuthenticator `json:"jwt"`
+}
+
+// JWTAuthenticator configures an authenticator for tokens of a single issuer.
+type JWTAuthenticator struct {
+	// Issuer contains the basic settings of the issuer.
+	Issuer JWTIssuer `json:"issuer"`
+	// ClaimValidationRules are rules applied to the token claims to validate the token.
+	ClaimValidationRules []JWTClaimValidationRule `json:"claimValidationRules,omitempty"`
+	// ClaimMappings describes how the token claims are mapped to the user attributes.
+	ClaimMappings JWTClaimMappings `json:"claimMappings"`
+	// UserValidationRules are CEL rules applied to the final user before authentication completes.
+	UserValidationRules []JWTUserValidationRule `json:"userValidationRules,omitempty"`
+}
+
+// JWTIssuer contains the settings of a JWT issuer.
+type JWTIssuer struct {
+	// URL of the issuer, must use the `https` scheme and match the `iss` claim of the tokens.
+	URL string `json:"url"`
+	// DiscoveryURL overrides the URL used to fetch the discovery information, e.g. if the issuer
+	// is reachable from kube-apiserver under a different address only.
+	DiscoveryURL string `json:"discoveryURL,omitempty"`
+	// CertificateAuthority contains PEM-encoded certificate authority certificates used to validate
+	// the connection to the issuer. If not set, the CA bundle of the cluster is used.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+
+	// Audiences is the set of acceptable audiences the tokens must be issued to.
+	Audiences []string `json:"audiences"`
+	// +kubebuilder:validation:Enum="";MatchAny
+
+	// AudienceMatchPolicy defines how the audiences are matched, must be set to `MatchAny` if
+	// multiple audiences are configured.
+	AudienceMatchPolicy string `json:"audienceMatchPolicy,omitempty"`
+}
+
+// JWTClaimValidationRule validates a single claim, either by a required value or a CEL expression.
+type JWTClaimValidationRule struct {
+	// Claim is the name of a required claim. Mutually exclusive with `expression`.
+	Claim string `json:"claim,omitempty"`
+	// RequiredValue is the value of the required claim.
+	RequiredValue string `json:"requiredValue,omitempty"`
+	// Expression is a CEL expression that must evaluate to true. Claims are available as `claims`.
+	Expression string `json:"expression,omitempty"`
+	// Message customizes the error message when the expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
+
+// JWTClaimMappings maps token claims to the user attributes.
+type JWTClaimMappings struct {
+	// Username is the claim or CEL expression used for the username.
+	Username JWTPrefixedClaimOrExpression `json:"username"`
+	// Groups is the claim or CEL expression used for the groups.
+	Groups JWTPrefixedClaimOrExpression `json:"groups,omitempty"`
+	// UID is the claim or CEL expression used for the user's UID.
+	UID JWTClaimOrExpression `json:"uid,omitempty"`
+	// Extra contains additional attributes of the user.
+	Extra []JWTExtraMapping `json:"extra,omitempty"`
+}
+
+// JWTPrefixedClaimOrExpression refers to a claim, optionally prefixed, or a CEL expression.
+type JWTPrefixedClaimOrExpression struct {
+	// Claim is the name of the claim. Mutually exclusive with `expression`.
+	Claim string `json:"claim,omitempty"`
+	// Prefix is prepended to the claim value. It is required if `claim` is set and can be set to
+	// an empty string to disable prefixing.
+	Prefix *string `json:"prefix,omitempty"`
+	// Expression is a CEL expression that evaluates to the attribute value.
+	Expression string `json:"expression,omitempty"`
+}
+
+// JWTClaimOrExpression refers to a claim or a CEL expression.
+type JWTClaimOrExpression struct {
+	// Claim is the name of the claim. Mutually exclusive with `expression`.
+	Claim string `json:"claim,omitempty"`
+	// Expression is a CEL expression that evaluates to the attribute value.
+	Expression string `json:"expression,omitempty"`
+}
+
+// JWTExtraMapping maps a CEL expression to an extra attribute of the user.
+type JWTExtraMapping struct {
+	// Key is the name of the extra attribute, must be a domain-prefixed path like `example.org/foo`.
+	Key string `json:"key"`
+	// ValueExpression is a CEL expression that evaluates to a string or a list of strings.
+	ValueExpression string `json:"valueExpression"`
+}
+
+// JWTUserValidationRule validates the final user.
+type JWTUserValidationRule struct {
+	// Expression is a CEL expression that must evaluate to true. The user is available as `user`.
+	Expression string `json:"expression"`
+	// Message customizes the error message when the expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
+
 // EventRateLimitConfig configures the `EventRateLimit` admission plugin.
 // More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#eventratelimit
 type EventRateLimitConfig struct {
@@ -938,6 +1109,11 @@ type ClusterAddress struct {
 	// IP is the external IP under which the apiserver is available
 	// +optional
 	IP string `json:"ip"`
+	// IPs are the external IPs under which the apiserver is available, at most one per
+	// IP family. On dual-stack seeds this contains both the IPv4 and the IPv6 address,
+	// the first entry always equals IP.
+	// +optional
+	IPs []string `json:"ips,omitempty"`
 }
 
 // IPVSConfiguration contains ipvs-related configuration details for kube-proxy.
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterMigrationResourceName represents "Resource" defined in Kubernetes.
+	ClusterMigrationResourceName = "clustermigrations"
+
+	// ClusterMigrationKindName represents "Kind" defined in Kubernetes.
+	ClusterMigrationKindName = "ClusterMigration"
+
+	// ClusterMigrationAnnotation is set on the source Cluster of a running migration and contains
+	// the name of the ClusterMigration. While it is set, the cluster's etcd can still be backed up,
+	// even though the cluster is paused.
+	ClusterMigrationAnnotation = "kubermatic.k8c.io/migration"
+)
+
+// +kubebuilder:validation:Enum="";Pending;Running;Completed;Failed
+
+// ClusterMigrationPhase is the lifecycle phase of a ClusterMigration.
+type ClusterMigrationPhase string
+
+const (
+	// ClusterMigrationPhasePending means the migration has not been started yet.
+	ClusterMigrationPhasePending ClusterMigrationPhase = "Pending"
+	// ClusterMigrationPhaseRunning means the migration is in progress. The conditions
+	// indicate which steps have been completed already.
+	ClusterMigrationPhaseRunning ClusterMigrationPhase = "Running"
+	// ClusterMigrationPhaseCompleted means the cluster has been moved to the target seed
+	// and the source seed has been cleaned up.
+	ClusterMigrationPhaseCompleted ClusterMigrationPhase = "Completed"
+	// ClusterMigrationPhaseFailed means the migration cannot continue. The source cluster
+	// is left paused and has to be unpaused manually to be used again.
+	ClusterMigrationPhaseFailed ClusterMigrationPhase = "Failed"
+)
+
+// +kubebuilder:validation:Enum=SourcePaused;BackupCompleted;TargetProvisioned;EtcdRestored;EndpointsSwitched;SourceCleanedUp
+
+// ClusterMigrationConditionType is used to indicate the type of a ClusterMigration condition. For all
+// condition types, the `true` value must indicate success. The conditions are listed in the order
+// in which the migration steps are performed.
+type ClusterMigrationConditionType string
+
+const (
+	// ClusterMigrationConditionSourcePaused indicates that the cluster on the source seed has been
+	// paused and its API server has been scaled down, so that etcd does not change anymore.
+	ClusterMigrationConditionSourcePaused ClusterMigrationConditionType = "SourcePaused"
+	// ClusterMigrationConditionBackupCompleted indicates that the final etcd backup on the source
+	// seed has been taken.
+	ClusterMigrationConditionBackupCompleted ClusterMigrationConditionType = "BackupCompleted"
+	// ClusterMigrationConditionTargetProvisioned indicates that the Cluster object and its secrets
+	// have been recreated on the target seed.
+	ClusterMigrationConditionTargetProvisioned ClusterMigrationConditionType = "TargetProvisioned"
+	// ClusterMigrationConditionEtcdRestored indicates that the final backup has been restored
+	// into the etcd on the target seed.
+	ClusterMigrationConditionEtcdRestored ClusterMigrationConditionType = "EtcdRestored"
+	// ClusterMigrationConditionEndpointsSwitched indicates that the cluster's API server is
+	// healthy on the target seed and its external name resolves to the target seed.
+	ClusterMigrationConditionEndpointsSwitched ClusterMigrationConditionType = "EndpointsSwitched"
+	// ClusterMigrationConditionSourceCleanedUp indicates that the cluster has been removed from
+	// the source seed, without touching its cloud resources or backups.
+	ClusterMigrationConditionSourceCleanedUp ClusterMigrationConditionType = "SourceCleanedUp"
+)
+
+var AllClusterMigrationConditionTypes = []ClusterMigrationConditionType{
+	ClusterMigrationConditionSourcePaused,
+	ClusterMigrationConditionBackupCompleted,
+	ClusterMigrationConditionTargetProvisioned,
+	ClusterMigrationConditionEtcdRestored,
+	ClusterMigrationConditionEndpointsSwitched,
+	ClusterMigrationConditionSourceCleanedUp,
+}
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.clusterName",name="Cluster",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.sourceSeed",name="Source",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.targetSeed",name="Target",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ClusterMigration moves a user cluster's control plane from one seed to another. The cluster is
+// paused, its etcd is backed up and the backup is restored into a recreated cluster on the target
+// seed. The user cluster's nodes and cloud resources are not touched.
+type ClusterMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterMigrationSpec   `json:"spec,omitempty"`
+	Status ClusterMigrationStatus `json:"status,omitempty"`
+}
+
+// ClusterMigrationSpec specifies which cluster to move where.
+type ClusterMigrationSpec struct {
+	// ClusterName is the name of the cluster to migrate. The source seed is determined automatically.
+	ClusterName string `json:"clusterName"`
+	// TargetSeed is the name of the seed to move the cluster to.
+	TargetSeed string `json:"targetSeed"`
+	// TargetDatacenter is the name of the datacenter of the target seed the cluster will belong to.
+	// It must use the same cloud provider as the cluster's current datacenter.
+	TargetDatacenter string `json:"targetDatacenter"`
+	// Destination is the name of the backup destination used to transfer etcd. It must be configured
+	// with the same bucket and credentials in the EtcdBackupRestore settings of both seeds.
+	Destination string `json:"destination"`
+	// TargetExposeStrategy is the expose strategy of the cluster on the target seed. It must match
+	// the cluster's current expose strategy, as changing it would change the address of the API
+	// server. If empty, the cluster's current expose strategy is kept.
+	// +optional
+	TargetExposeStrategy ExposeStrategy `json:"targetExposeStrategy,omitempty"`
+}
+
+// ClusterMigrationStatus reports the progress of a ClusterMigration.
+type ClusterMigrationStatus struct {
+	// Phase is the current phase of the migration.
+	// +optional
+	Phase ClusterMigrationPhase `json:"phase,omitempty"`
+	// SourceSeed is the name of the seed the cluster was running on when the migration started.
+	// +optional
+	SourceSeed string `json:"sourceSeed,omitempty"`
+	// BackupName is the name of the etcd backup that is restored on the target seed.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+	// SourceURL is the URL of the cluster's API server on the source seed.
+	// +optional
+	SourceURL string `json:"sourceURL,omitempty"`
+	// TargetURL is the URL of the cluster's API server on the target seed. It is the same as
+	// SourceURL, the migration fails if the address would change.
+	// +optional
+	TargetURL string `json:"targetURL,omitempty"`
+	// Message describes why the migration failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Conditions contains conditions for each completed migration step.
+	// +optional
+	Conditions map[ClusterMigrationConditionType]ClusterMigrationCondition `json:"conditions,omitempty"`
+}
+
+type ClusterMigrationCondition struct {
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time we got an update on a given condition.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ClusterMigrationList is a list of ClusterMigrations.
+type ClusterMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of ClusterMigrations.
+	Items []ClusterMigration `json:"items"`
+}
+
+// IsConditionTrue returns true if the given migration step has been completed.
+func (m *ClusterMigration) IsConditionTrue(conditionType ClusterMigrationConditionType) bool {
+	return m.Status.Conditions[conditionType].Status == corev1.ConditionTrue
+}
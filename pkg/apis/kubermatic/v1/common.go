@@ -16,10 +16,10 @@ limitations under the License.
 
 package v1
 
-// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling
+// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling;Gateway
 
 // ExposeStrategy is the strategy used to expose a cluster control plane.
-// Possible values are `NodePort`, `LoadBalancer` or `Tunneling` (requires a feature gate).
+// Possible values are `NodePort`, `LoadBalancer`, `Tunneling` or `Gateway`.
 type ExposeStrategy string
 
 const (
@@ -43,6 +43,15 @@ const (
 	// (e.g. Service of type LoadBalancer) without consuming one or more ports
 	// for each user cluster.
 	ExposeStrategyTunneling ExposeStrategy = "Tunneling"
+	// ExposeStrategyGateway exposes the control plane components through a Gateway API
+	// implementation running on the seed cluster, instead of the nodeport-proxy.
+	// All clusters share one Gateway per seed, configured in the Seed's exposeGateway
+	// settings. The apiserver, Konnectivity and the MLA gateway are routed by their
+	// SNI hostname via TLSRoutes on the Gateway's TLS passthrough listener, and the
+	// cluster address points to the Gateway. In-cluster clients reach the apiserver
+	// without SNI, so like for Tunneling an agent in the user cluster accepts their
+	// connections and re-originates them with the apiserver's hostname.
+	ExposeStrategyGateway ExposeStrategy = "Gateway"
 )
 
 // Finalizers should be kept to their controllers. Only if a finalizer is
@@ -42,13 +42,15 @@ const (
 // OperationType is the type defining the operations triggering the compatibility check (CREATE or UPDATE).
 type OperationType string
 
-// +kubebuilder:validation:Enum=Addon;Cluster;ClusterBackup;Project;None
+// +kubebuilder:validation:Enum=Addon;Certificate;Cluster;ClusterBackup;Project;None
 // MetricsCollector is the name of an available metrics collector.
 type MetricsCollector string
 
 const (
 	// AddonCollector is addon metrics collector.
 	AddonCollector MetricsCollector = "Addon"
+	// CertificateCollector is the control plane certificate metrics collector.
+	CertificateCollector MetricsCollector = "Certificate"
 	// ClusterBackupCollector is cluster backup metrics collector.
 	ClusterBackupCollector MetricsCollector = "ClusterBackup"
 	// ClusterCollector is cluster metrics collector.
@@ -224,8 +226,12 @@ type KubermaticSeedControllerConfiguration struct {
 	// Replicas sets the number of pod replicas for the seed-controller-manager.
 	Replicas *int32 `json:"replicas,omitempty"`
 	// DisabledCollectors contains a list of metrics collectors that should be disabled.
-	// Acceptable values are "Addon", "Cluster", "ClusterBackup", "Project", and "None".
+	// Acceptable values are "Addon", "Certificate", "Cluster", "ClusterBackup", "Project", and "None".
 	DisabledCollectors []MetricsCollector `json:"disabledCollectors,omitempty"`
+	// CertificateRenewalWindow is the time before their expiry at which the certificates of user
+	// cluster control planes are renewed. Must not be longer than 180 days. Defaults to 30 days.
+	// +optional
+	CertificateRenewalWindow *metav1.Duration `json:"certificateRenewalWindow,omitempty"`
 }
 
 // KubermaticWebhookConfiguration configures the Kubermatic webhook.
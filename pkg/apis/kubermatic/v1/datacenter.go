@@ -285,6 +285,12 @@ type SeedSpec struct {
 	// NodeportProxy can be used to configure the NodePort proxy service that is
 	// responsible for making user-cluster control planes accessible from the outside.
 	NodeportProxy NodeportProxyConfig `json:"nodeportProxy,omitempty"`
+	// Optional: ExposeGateway configures the Gateway that is used to expose the control
+	// planes of clusters using the `Gateway` expose strategy. It is required for that
+	// strategy and needs the Gateway API CRDs (including the experimental TLSRoute) and a
+	// Gateway API implementation to be installed on the seed cluster. The DNS names of the
+	// clusters (`*.<seed DNS name>.<base domain>`) must resolve to the Gateway's address.
+	ExposeGateway *ExposeGatewayConfig `json:"exposeGateway,omitempty"`
 	// Optional: ProxySettings can be used to configure HTTP proxy settings on the
 	// worker nodes in user clusters. However, proxy settings on nodes take precedence.
 	ProxySettings *ProxySettings `json:"proxySettings,omitempty"`
@@ -312,8 +318,11 @@ type SeedSpec struct {
 	//lint:ignore SA5008 omitcegenyaml is used by the example-yaml-generator
 	KubeLB *KubeLBSettings `json:"kubelb,omitempty,omitcegenyaml"`
 	// DisabledCollectors contains a list of metrics collectors that should be disabled.
-	// Acceptable values are "Addon", "Cluster", "ClusterBackup", "Project", and "None".
+	// Acceptable values are "Addon", "Certificate", "Cluster", "ClusterBackup", "Project", and "None".
 	DisabledCollectors []MetricsCollector `json:"disabledCollectors,omitempty"`
+	// Optional: AuditLogging configures a seed-level aggregator that receives the audit events of
+	// all user clusters on this seed, redacts them and routes them to per-project sinks.
+	AuditLogging *AuditLogAggregatorSettings `json:"auditLogging,omitempty"`
 }
 
 // EtcdBackupRestore holds the configuration of the automatic backup and restores.
@@ -330,6 +339,11 @@ type EtcdBackupRestore struct {
 	// created for every user cluster. Has to correspond to a destination in Destinations.
 	// If removed, it removes the related default etcd backup configs.
 	DefaultDestination string `json:"defaultDestination,omitempty"`
+
+	// DefaultRetention is the tiered retention policy used for the default etcd backup config
+	// of every user cluster. If not set, the default backup config keeps a fixed number of backups.
+	// +optional
+	DefaultRetention *EtcdBackupRetention `json:"defaultRetention,omitempty"`
 }
 
 // BackupDestination defines the bucket name and endpoint as a backup destination, and holds reference to the credentials secret.
@@ -340,6 +354,22 @@ type BackupDestination struct {
 	BucketName string `json:"bucketName"`
 	// Credentials hold the ref to the secret with backup credentials
 	Credentials *corev1.SecretReference `json:"credentials,omitempty"`
+	// Encryption enables client-side envelope encryption of all backups written to this destination.
+	// Each cluster gets its own data key, which is wrapped by the key encryption key configured here.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+// BackupEncryption configures the key encryption keys used to wrap the per-cluster data keys of a backup destination.
+type BackupEncryption struct {
+	// KeyEncryptionKeys references the secret holding the key encryption keys. Like the credentials
+	// secret, it must reside in the kube-system namespace. Every key in the secret is one key encryption
+	// key, which must be exactly 32 bytes long (AES-256).
+	KeyEncryptionKeys *corev1.SecretReference `json:"keyEncryptionKeys"`
+	// ActiveKey is the key in the KeyEncryptionKeys secret that is used to wrap data keys. Changing
+	// it rotates the key encryption key: all data keys are re-wrapped with the new key, without
+	// re-uploading any backups. The previous key must remain in the secret until this has happened.
+	ActiveKey string `json:"activeKey"`
 }
 
 type NodeportProxyConfig struct {
@@ -362,6 +392,38 @@ type NodeportProxyConfig struct {
 	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 	// IPFamilies configures the IP families to use for the LoadBalancer service.
 	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+	// Limits configures connection and rate limits that are applied per user cluster,
+	// to prevent a single user cluster from exhausting the shared Envoy. The limits can
+	// be overridden for individual Services using the nodeport-proxy.k8s.io/max-connections,
+	// nodeport-proxy.k8s.io/connection-rate and nodeport-proxy.k8s.io/connection-burst annotations.
+	Limits *NodeportProxyLimits `json:"limits,omitempty"`
+}
+
+type NodeportProxyLimits struct {
+	// MaxConnections is the maximum number of concurrent connections that Envoy opens to
+	// each exposed port of a user cluster (e.g. the kube-apiserver). Further connections
+	// are rejected until the number of active connections drops again.
+	// +kubebuilder:validation:Minimum=1
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+	// ConnectionRate is the number of new connections per second that are accepted for
+	// each exposed Service of a user cluster, shared by all ports of the Service.
+	// Connections exceeding the rate are closed immediately.
+	// +kubebuilder:validation:Minimum=1
+	ConnectionRate *int32 `json:"connectionRate,omitempty"`
+	// ConnectionBurst is the number of new connections that can be accepted at once,
+	// i.e. the size of the token bucket used for rate limiting. Defaults to ConnectionRate.
+	// +kubebuilder:validation:Minimum=1
+	ConnectionBurst *int32 `json:"connectionBurst,omitempty"`
+}
+
+type ExposeGatewayConfig struct {
+	// GatewayClassName is the name of the GatewayClass used for the Gateway that
+	// is created in the seed's KKP namespace.
+	// +kubebuilder:validation:MinLength=1
+	GatewayClassName string `json:"gatewayClassName"`
+	// Annotations are added to the Gateway, e.g. to further tweak the LoadBalancer
+	// integration of the Gateway API implementation.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 type EnvoyLoadBalancerService struct {
@@ -482,6 +544,46 @@ type DatacenterSpec struct {
 	//nolint:staticcheck
 	//lint:ignore SA5008 omitcegenyaml is used by the example-yaml-generator
 	KubeLB *KubeLBDatacenterSettings `json:"kubelb,omitempty,omitcegenyaml"`
+
+	// Optional: KMSPlugins are the KMS v2 plugins that clusters in this datacenter can use for
+	// encryption at rest. Clusters select one of these plugins by its name.
+	KMSPlugins []KMSPlugin `json:"kmsPlugins,omitempty"`
+}
+
+// KMSPlugin defines a KMS v2 plugin, which is run as a sidecar next to kube-apiserver and talks to
+// an external key management system, e.g. the transit engine of a Vault instance.
+type KMSPlugin struct {
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// Name of the plugin, which is used by clusters to select it.
+	Name string `json:"name"`
+	// Image of the KMS plugin. The plugin must serve the KMS v2 gRPC API on the unix socket given
+	// in the `KMS_PLUGIN_SOCKET` environment variable.
+	Image string `json:"image"`
+	// Command overrides the entrypoint of the plugin image.
+	Command []string `json:"command,omitempty"`
+	// Args are passed to the plugin.
+	Args []string `json:"args,omitempty"`
+	// Env contains additional environment variables for the plugin. Credentials for the key management
+	// system should be provided through the ConfigSecretRef.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// ConfigSecretRef references a Secret in the namespace of the Seed, which is copied into the cluster
+	// namespace and mounted read-only into the plugin at `/etc/kms-plugin`, e.g. to provide credentials,
+	// a configuration file or CA certificates.
+	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
+	// Resources configures the resource requirements of the plugin sidecar.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GetKMSPlugin returns the KMS plugin with the given name or nil if no such plugin is configured.
+func (s *DatacenterSpec) GetKMSPlugin(name string) *KMSPlugin {
+	for i := range s.KMSPlugins {
+		if s.KMSPlugins[i].Name == name {
+			return &s.KMSPlugins[i]
+		}
+	}
+
+	return nil
 }
 
 var (
@@ -944,6 +1046,16 @@ type MeteringConfiguration struct {
 
 	// ReportConfigurations is a map of report configuration definitions.
 	ReportConfigurations map[string]MeteringReportConfiguration `json:"reports,omitempty"`
+
+	// QueryService configures the metering query service, which serves the usage data of the
+	// metering Prometheus per project and cluster for arbitrary time ranges via an HTTP API.
+	// +optional
+	QueryService *MeteringQueryServiceConfiguration `json:"queryService,omitempty"`
+}
+
+type MeteringQueryServiceConfiguration struct {
+	// Enabled deploys the metering query service into the seed's KKP namespace.
+	Enabled bool `json:"enabled"`
 }
 
 // MeteringReportFormat maps directly to the values supported by the kubermatic-metering tool.
@@ -989,6 +1101,11 @@ type MeteringReportConfiguration struct {
 	// Format is the file format of the generated report, one of "csv" or "json" (defaults to "csv").
 	// +kubebuilder:default=csv
 	Format MeteringReportFormat `json:"format,omitempty"`
+
+	// PriceModel is the name of a PriceModel in the master cluster. If set, a cost report with the costs
+	// per project and per cluster is generated in addition to the regular reports.
+	// +optional
+	PriceModel string `json:"priceModel,omitempty"`
 }
 
 // OIDCProviderConfiguration allows to configure OIDC provider at the Seed level. If set, it overwrites the OIDC configuration from the KubermaticConfiguration.
@@ -1051,6 +1168,11 @@ func (s *Seed) IsEtcdAutomaticBackupEnabled() bool {
 	return false
 }
 
+// IsAuditLogAggregatorEnabled returns true if the seed-level audit log aggregator is enabled for the seed.
+func (s *Seed) IsAuditLogAggregatorEnabled() bool {
+	return s.Spec.AuditLogging != nil && s.Spec.AuditLogging.Enabled
+}
+
 // IsDefaultEtcdAutomaticBackupEnabled returns true if etcd automatic backup with default destination is configured for the seed.
 func (s *Seed) IsDefaultEtcdAutomaticBackupEnabled() bool {
 	return s.IsEtcdAutomaticBackupEnabled() && s.Spec.EtcdBackupRestore.DefaultDestination != ""
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,6 +33,12 @@ const (
 	DefaultKeptBackupsCount = 20
 	MaxKeptBackupsCount     = 50
 
+	// DefaultWALSegmentInterval is the default maximum time span covered by a single archived WAL segment.
+	DefaultWALSegmentInterval = 5 * time.Minute
+
+	// DefaultBackupVerificationTimeout is the default time after which a backup verification job is aborted.
+	DefaultBackupVerificationTimeout = 10 * time.Minute
+
 	// BackupStatusPhase value indicating that the corresponding job has started.
 	BackupStatusPhaseRunning = "Running"
 
@@ -70,11 +78,123 @@ type EtcdBackupConfigSpec struct {
 	// once, immediately.
 	Schedule string `json:"schedule,omitempty"`
 	// Keep is the number of backups to keep around before deleting the oldest one
-	// If not set, defaults to DefaultKeptBackupsCount. Only used if Schedule is set.
+	// If not set, defaults to DefaultKeptBackupsCount. Only used if Schedule is set
+	// and Retention is not set.
 	Keep *int `json:"keep,omitempty"`
+	// Retention is a tiered (grandfather-father-son) retention policy. If set, it replaces Keep:
+	// a backup is kept as long as at least one of the tiers selects it, all other backups are
+	// deleted. Only used if Schedule is set.
+	Retention *EtcdBackupRetention `json:"retention,omitempty"`
 	// Destination indicates where the backup will be stored. The destination name must correspond to a destination in
 	// the cluster's Seed.Spec.EtcdBackupRestore.
 	Destination string `json:"destination"`
+	// WALArchive enables continuous archiving of all changes to the etcd keyspace to the same destination
+	// as the snapshots. Together with the snapshots, the archived WAL allows an EtcdRestore to restore the
+	// cluster to any point in time after the archiving started. Only used if Schedule is set.
+	WALArchive *EtcdWALArchiveSettings `json:"walArchive,omitempty"`
+	// Format is the format in which backups are stored. "Snapshot" (the default) stores every backup as
+	// a full, compressed etcd snapshot. "Chunked" splits snapshots into content-defined chunks, which are
+	// stored deduplicated per cluster, and stores every backup as a small manifest referencing its chunks.
+	// Chunked backups are always created and deleted by the etcd-launcher, the store and delete containers
+	// configured in the KubermaticConfiguration are not used for them.
+	// +kubebuilder:validation:Enum="";Snapshot;Chunked
+	Format EtcdBackupFormat `json:"format,omitempty"`
+	// Verification enables the verification of every backup after it has been uploaded. A verification
+	// job downloads the backup, checks the snapshot's integrity, restores it into a throwaway single-member
+	// etcd and compares its revision and key count with the cluster's etcd. The results are reported in
+	// the status of each backup and in the BackupVerified condition.
+	Verification *EtcdBackupVerificationSettings `json:"verification,omitempty"`
+}
+
+// EtcdBackupFormat is the format in which etcd backups are stored.
+type EtcdBackupFormat string
+
+const (
+	// EtcdBackupFormatSnapshot stores every backup as a full, gzip-compressed etcd snapshot.
+	EtcdBackupFormatSnapshot EtcdBackupFormat = "Snapshot"
+
+	// EtcdBackupFormatChunked stores every backup as a manifest referencing deduplicated chunks.
+	EtcdBackupFormatChunked EtcdBackupFormat = "Chunked"
+)
+
+// EtcdWALArchiveSettings configures the continuous archiving of the etcd write-ahead log.
+type EtcdWALArchiveSettings struct {
+	// SegmentInterval is the maximum time span covered by a single archived WAL segment. Changes are
+	// uploaded at least this often, so this is the upper bound of changes that can be lost if the
+	// whole control plane is lost. Defaults to 5 minutes.
+	SegmentInterval *metav1.Duration `json:"segmentInterval,omitempty"`
+}
+
+// EtcdBackupRetention is a tiered retention policy for etcd backups. Every tier keeps the newest
+// backup of each of the last N periods (hours, days, weeks or months) that have a completed backup.
+// Periods are determined in UTC, weeks start on Monday. Each tier keeps at most MaxKeptBackupsCount
+// backups; tiers that are not set keep no backups.
+type EtcdBackupRetention struct {
+	// Hourly is the number of hourly backups to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	Hourly *int `json:"hourly,omitempty"`
+	// Daily is the number of daily backups to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	Daily *int `json:"daily,omitempty"`
+	// Weekly is the number of weekly backups to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	Weekly *int `json:"weekly,omitempty"`
+	// Monthly is the number of monthly backups to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	Monthly *int `json:"monthly,omitempty"`
+}
+
+// EtcdBackupRetentionTier is one of the tiers of an EtcdBackupRetention.
+type EtcdBackupRetentionTier string
+
+const (
+	EtcdBackupRetentionTierHourly  EtcdBackupRetentionTier = "Hourly"
+	EtcdBackupRetentionTierDaily   EtcdBackupRetentionTier = "Daily"
+	EtcdBackupRetentionTierWeekly  EtcdBackupRetentionTier = "Weekly"
+	EtcdBackupRetentionTierMonthly EtcdBackupRetentionTier = "Monthly"
+)
+
+// AllEtcdBackupRetentionTiers lists all retention tiers, from the shortest to the longest period.
+var AllEtcdBackupRetentionTiers = []EtcdBackupRetentionTier{
+	EtcdBackupRetentionTierHourly,
+	EtcdBackupRetentionTierDaily,
+	EtcdBackupRetentionTierWeekly,
+	EtcdBackupRetentionTierMonthly,
+}
+
+// GetTierCount returns the number of backups kept by the given tier.
+func (r *EtcdBackupRetention) GetTierCount(tier EtcdBackupRetentionTier) int {
+	var count *int
+
+	switch tier {
+	case EtcdBackupRetentionTierHourly:
+		count = r.Hourly
+	case EtcdBackupRetentionTierDaily:
+		count = r.Daily
+	case EtcdBackupRetentionTierWeekly:
+		count = r.Weekly
+	case EtcdBackupRetentionTierMonthly:
+		count = r.Monthly
+	}
+
+	if count == nil || *count <= 0 {
+		return 0
+	}
+	if *count > MaxKeptBackupsCount {
+		return MaxKeptBackupsCount
+	}
+	return *count
+}
+
+// EtcdBackupVerificationSettings configures the verification of backups.
+type EtcdBackupVerificationSettings struct {
+	// Timeout is the time after which a verification job is aborted and the backup is considered
+	// to be broken. Large etcd databases might need more time to be restored. Defaults to 10 minutes.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -96,6 +216,9 @@ type EtcdBackupConfigStatus struct {
 	Conditions map[EtcdBackupConfigConditionType]EtcdBackupConfigCondition `json:"conditions,omitempty"`
 	// If the controller was configured with a cleanupContainer, CleanupRunning keeps track of the corresponding job
 	CleanupRunning bool `json:"cleanupRunning,omitempty"`
+	// WALArchiveEpoch is incremented after every restore of the cluster's etcd. A restore changes the
+	// revision numbering of etcd, so the WAL of every epoch is archived below its own prefix.
+	WALArchiveEpoch int64 `json:"walArchiveEpoch,omitempty"`
 }
 
 type BackupStatusPhase string
@@ -119,6 +242,24 @@ type BackupStatus struct {
 	DeleteFinishedTime metav1.Time       `json:"deleteFinishedTime,omitempty"`
 	DeletePhase        BackupStatusPhase `json:"deletePhase,omitempty"`
 	DeleteMessage      string            `json:"deleteMessage,omitempty"`
+	// VerificationJobName is the name of the job verifying the backup, if verification is enabled.
+	VerificationJobName string `json:"verificationJobName,omitempty"`
+	// +optional
+	VerificationFinishedTime metav1.Time       `json:"verificationFinishedTime,omitempty"`
+	VerificationPhase        BackupStatusPhase `json:"verificationPhase,omitempty"`
+	VerificationMessage      string            `json:"verificationMessage,omitempty"`
+	// VerifiedRevision is the etcd revision of the verified snapshot.
+	VerifiedRevision int64 `json:"verifiedRevision,omitempty"`
+	// VerifiedKeyCount is the number of keys found after restoring the verified snapshot.
+	VerifiedKeyCount int64 `json:"verifiedKeyCount,omitempty"`
+	// RetainedBy lists the retention tiers that currently keep this backup. It is only
+	// set for completed backups of configs with a tiered retention policy.
+	RetainedBy []EtcdBackupRetentionTier `json:"retainedBy,omitempty"`
+	// PruneReason explains why the backup is being deleted.
+	PruneReason string `json:"pruneReason,omitempty"`
+	// WALArchiveEpoch is the epoch of the WAL archive at the time the backup was scheduled. Point-in-time
+	// restores from this backup replay the WAL archived in this epoch.
+	WALArchiveEpoch int64 `json:"walArchiveEpoch,omitempty"`
 }
 
 type EtcdBackupConfigCondition struct {
@@ -137,7 +278,7 @@ type EtcdBackupConfigCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=SchedulingActive
+// +kubebuilder:validation:Enum=SchedulingActive;WALArchivingActive;BackupVerified
 
 // EtcdBackupConfigConditionType is used to indicate the type of a EtcdBackupConfig condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
@@ -148,9 +289,27 @@ const (
 	// EtcdBackupConfigConditionSchedulingActive indicates that the EtcdBackupConfig is active, i.e.
 	// new backups are being scheduled according to the config's schedule.
 	EtcdBackupConfigConditionSchedulingActive EtcdBackupConfigConditionType = "SchedulingActive"
+
+	// EtcdBackupConfigConditionWALArchivingActive indicates that the WAL archiver for the EtcdBackupConfig
+	// is running and continuously shipping changes to the backup destination.
+	EtcdBackupConfigConditionWALArchivingActive EtcdBackupConfigConditionType = "WALArchivingActive"
+
+	// EtcdBackupConfigConditionBackupVerified indicates that the most recently verified backup of the
+	// EtcdBackupConfig passed its verification.
+	EtcdBackupConfigConditionBackupVerified EtcdBackupConfigConditionType = "BackupVerified"
 )
 
+// GetKeptBackupsCount returns the maximum number of completed backups that are kept. For tiered
+// retention policies, this is the sum of all tiers, as every tier might select different backups.
 func (bc *EtcdBackupConfig) GetKeptBackupsCount() int {
+	if r := bc.Spec.Retention; r != nil {
+		count := 0
+		for _, tier := range AllEtcdBackupRetentionTiers {
+			count += r.GetTierCount(tier)
+		}
+		// always keep at least the newest backup, like it is done for Keep
+		return max(count, 1)
+	}
 	if bc.Spec.Keep == nil {
 		return DefaultKeptBackupsCount
 	}
@@ -162,3 +321,35 @@ func (bc *EtcdBackupConfig) GetKeptBackupsCount() int {
 	}
 	return *bc.Spec.Keep
 }
+
+// IsWALArchiveEnabled returns true if the etcd WAL should be continuously archived for this config.
+func (bc *EtcdBackupConfig) IsWALArchiveEnabled() bool {
+	return bc.Spec.WALArchive != nil && bc.Spec.Schedule != ""
+}
+
+// GetBackupFormat returns the format in which backups of this config are stored.
+func (bc *EtcdBackupConfig) GetBackupFormat() EtcdBackupFormat {
+	if bc.Spec.Format == "" {
+		return EtcdBackupFormatSnapshot
+	}
+	return bc.Spec.Format
+}
+
+// IsVerificationEnabled returns true if backups of this config should be verified after they were uploaded.
+func (bc *EtcdBackupConfig) IsVerificationEnabled() bool {
+	return bc.Spec.Verification != nil
+}
+
+func (bc *EtcdBackupConfig) GetVerificationTimeout() time.Duration {
+	if bc.Spec.Verification == nil || bc.Spec.Verification.Timeout == nil || bc.Spec.Verification.Timeout.Duration <= 0 {
+		return DefaultBackupVerificationTimeout
+	}
+	return bc.Spec.Verification.Timeout.Duration
+}
+
+func (bc *EtcdBackupConfig) GetWALSegmentInterval() time.Duration {
+	if bc.Spec.WALArchive == nil || bc.Spec.WALArchive.SegmentInterval == nil || bc.Spec.WALArchive.SegmentInterval.Duration <= 0 {
+		return DefaultWALSegmentInterval
+	}
+	return bc.Spec.WALArchive.SegmentInterval.Duration
+}
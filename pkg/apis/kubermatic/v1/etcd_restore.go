@@ -34,6 +34,10 @@ const (
 	// EtcdRestorePhaseStsRebuilding value indicating that the old Etcd statefulset has been deleted and is now rebuilding.
 	EtcdRestorePhaseStsRebuilding EtcdRestorePhase = "StsRebuilding"
 
+	// EtcdRestorePhaseWALReplaying value indicating that the snapshot has been restored and the archived WAL
+	// is being replayed on top of it.
+	EtcdRestorePhaseWALReplaying EtcdRestorePhase = "WALReplaying"
+
 	// EtcdRestorePhaseCompleted value indicating that the old Etcd statefulset has completed successfully.
 	EtcdRestorePhaseCompleted EtcdRestorePhase = "Completed"
 
@@ -41,7 +45,7 @@ const (
 	EtcdRestorePhaseEtcdLauncherNotEnabled EtcdRestorePhase = "EtcdLauncherNotEnabled"
 )
 
-// +kubebuilder:validation:Enum=Started;StsRebuilding;Completed;EtcdLauncherNotEnabled
+// +kubebuilder:validation:Enum=Started;StsRebuilding;WALReplaying;Completed;EtcdLauncherNotEnabled
 
 // EtcdRestorePhase represents the lifecycle phase of an EtcdRestore.
 type EtcdRestorePhase string
@@ -70,14 +74,33 @@ type EtcdRestoreSpec struct {
 	Name string `json:"name"`
 	// Cluster is the reference to the cluster whose etcd will be backed up
 	Cluster corev1.ObjectReference `json:"cluster"`
-	// BackupName is the name of the backup to restore from
-	BackupName string `json:"backupName"`
+	// BackupName is the name of the backup to restore from. Can be left empty for point-in-time restores,
+	// in which case the nearest backup before the target time is selected.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
 	// BackupDownloadCredentialsSecret is the name of a secret in the cluster-xxx namespace containing
 	// credentials needed to download the backup
 	BackupDownloadCredentialsSecret string `json:"backupDownloadCredentialsSecret,omitempty"`
 	// Destination indicates where the backup was stored. The destination name should correspond to a destination in
 	// the cluster's Seed.Spec.EtcdBackupRestore. If empty, it will use the legacy destination configured in Seed.Spec.BackupRestore
 	Destination string `json:"destination,omitempty"`
+	// PointInTime optionally restores the cluster to a state between two backups. The backup is restored
+	// as usual, after which the WAL archived by the referenced EtcdBackupConfig is replayed on top of it,
+	// up to the given time or revision.
+	PointInTime *EtcdRestorePointInTime `json:"pointInTime,omitempty"`
+}
+
+// EtcdRestorePointInTime specifies the target of a point-in-time restore. At least one of Time and
+// Revision must be set; if both are set, replaying stops at whichever is reached first.
+type EtcdRestorePointInTime struct {
+	// BackupConfig is the name of the EtcdBackupConfig (in the same namespace as the EtcdRestore)
+	// whose backups and archived WAL are used. The EtcdBackupConfig must have WAL archiving enabled.
+	BackupConfig string `json:"backupConfig"`
+	// Time is the point in time to restore to. All changes up to and including this time are restored.
+	Time *metav1.Time `json:"time,omitempty"`
+	// Revision is the etcd revision to restore to. All changes up to and including this revision
+	// are restored. If only Revision is given, BackupName must be set as well.
+	Revision *int64 `json:"revision,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -96,4 +119,17 @@ type EtcdRestoreStatus struct {
 	Phase EtcdRestorePhase `json:"phase"`
 	// +optional
 	RestoreTime metav1.Time `json:"restoreTime,omitempty"`
+	// SnapshotRevision is the etcd revision of the restored backup. Only set for point-in-time restores.
+	SnapshotRevision int64 `json:"snapshotRevision,omitempty"`
+	// ReplayedRevision is the revision of the last archived change that has been replayed on top
+	// of the restored backup. Only set for point-in-time restores.
+	ReplayedRevision int64 `json:"replayedRevision,omitempty"`
+	// WALReplayed is set once the archived WAL has been replayed up to the requested point in time.
+	WALReplayed bool `json:"walReplayed,omitempty"`
+	// WALArchiveEpoch is the epoch of the archived WAL that is replayed, i.e. the epoch in which the
+	// restored backup was taken. Only set for point-in-time restores.
+	WALArchiveEpoch int64 `json:"walArchiveEpoch,omitempty"`
+	// WALArchiveEpochAdvanced is set once the WAL archives of the cluster have been moved to a new
+	// epoch after the restore.
+	WALArchiveEpochAdvanced bool `json:"walArchiveEpochAdvanced,omitempty"`
 }
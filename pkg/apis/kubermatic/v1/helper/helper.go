@@ -347,6 +347,76 @@ func SetSeedCondition(seed *kubermaticv1.Seed, conditionType kubermaticv1.SeedCo
 	seed.Status.Conditions[conditionType] = newCondition
 }
 
+// SetResourceQuotaCondition sets a condition on the given resource quota using the provided type, status,
+// reason and message.
+func SetResourceQuotaCondition(resourceQuota *kubermaticv1.ResourceQuota, conditionType kubermaticv1.ResourceQuotaConditionType, status corev1.ConditionStatus, reason string, message string) {
+	newCondition := kubermaticv1.ResourceQuotaCondition{
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	oldCondition, hadCondition := resourceQuota.Status.Conditions[conditionType]
+	if hadCondition {
+		conditionCopy := oldCondition.DeepCopy()
+
+		// Reset the times before comparing
+		conditionCopy.LastHeartbeatTime.Reset()
+		conditionCopy.LastTransitionTime.Reset()
+
+		if apiequality.Semantic.DeepEqual(*conditionCopy, newCondition) {
+			return
+		}
+	}
+
+	now := metav1.Now()
+	newCondition.LastHeartbeatTime = now
+	newCondition.LastTransitionTime = oldCondition.LastTransitionTime
+	if hadCondition && oldCondition.Status != status {
+		newCondition.LastTransitionTime = now
+	}
+
+	if resourceQuota.Status.Conditions == nil {
+		resourceQuota.Status.Conditions = map[kubermaticv1.ResourceQuotaConditionType]kubermaticv1.ResourceQuotaCondition{}
+	}
+	resourceQuota.Status.Conditions[conditionType] = newCondition
+}
+
+// SetClusterMigrationCondition sets a condition on the given ClusterMigration using the provided
+// type, status, reason and message.
+func SetClusterMigrationCondition(migration *kubermaticv1.ClusterMigration, conditionType kubermaticv1.ClusterMigrationConditionType, status corev1.ConditionStatus, reason string, message string) {
+	newCondition := kubermaticv1.ClusterMigrationCondition{
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	oldCondition, hadCondition := migration.Status.Conditions[conditionType]
+	if hadCondition {
+		conditionCopy := oldCondition.DeepCopy()
+
+		// Reset the times before comparing
+		conditionCopy.LastHeartbeatTime.Reset()
+		conditionCopy.LastTransitionTime.Reset()
+
+		if apiequality.Semantic.DeepEqual(*conditionCopy, newCondition) {
+			return
+		}
+	}
+
+	now := metav1.Now()
+	newCondition.LastHeartbeatTime = now
+	newCondition.LastTransitionTime = oldCondition.LastTransitionTime
+	if hadCondition && oldCondition.Status != status {
+		newCondition.LastTransitionTime = now
+	}
+
+	if migration.Status.Conditions == nil {
+		migration.Status.Conditions = map[kubermaticv1.ClusterMigrationConditionType]kubermaticv1.ClusterMigrationCondition{}
+	}
+	migration.Status.Conditions[conditionType] = newCondition
+}
+
 type ResourceQuotaPatchFunc func(resourceQuota *kubermaticv1.ResourceQuota)
 
 // UpdateResourceQuotaStatus will attempt to patch the resource quota status
@@ -54,6 +54,12 @@ type IPAMAllocationSpec struct {
 	// Addresses are the IP address ranges that are being used for the allocation.
 	// Set when "type=range".
 	Addresses []string `json:"addresses,omitempty"`
+	// IPv6CIDR is the IPv6 CIDR that is being used for the allocation.
+	// Set when "type=prefix" and the pool is dual-stack.
+	IPv6CIDR SubnetCIDR `json:"ipv6Cidr,omitempty"`
+	// IPv6Addresses are the IPv6 address ranges that are being used for the allocation.
+	// Set when "type=range" and the pool is dual-stack.
+	IPv6Addresses []string `json:"ipv6Addresses,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
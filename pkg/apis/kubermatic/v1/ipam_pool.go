@@ -78,6 +78,38 @@ type IPAMPoolDatacenterSettings struct {
 	// Examples: "192.168.1.100-192.168.1.110", "192.168.1.255".
 	// Can be used when "type=range".
 	ExcludeRanges []string `json:"excludeRanges,omitempty"`
+
+	// Optional: IPv6 configures an additional IPv6 pool for dual-stack allocations.
+	// When set, PoolCIDR must be an IPv4 CIDR and every allocation from this datacenter
+	// gets both an IPv4 and an IPv6 part, using the same allocation type.
+	IPv6 *IPAMPoolIPv6Settings `json:"ipv6,omitempty"`
+}
+
+// IPAMPoolIPv6Settings contains the IPv6 part of a dual-stack IPAM Pool configuration for a datacenter.
+type IPAMPoolIPv6Settings struct {
+	// PoolCIDR is the IPv6 pool CIDR to be used for the allocation.
+	PoolCIDR SubnetCIDR `json:"poolCidr"`
+
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=128
+	// AllocationPrefix is the prefix for the IPv6 allocation.
+	// Used when "type=prefix".
+	AllocationPrefix int `json:"allocationPrefix,omitempty"`
+
+	// Optional: ExcludePrefixes is used to exclude particular IPv6 subnets for the allocation.
+	// NOTE: must be the same length as allocationPrefix.
+	// Can be used when "type=prefix".
+	ExcludePrefixes []SubnetCIDR `json:"excludePrefixes,omitempty"`
+
+	// +kubebuilder:validation:Minimum:=1
+	// AllocationRange is the range for the IPv6 allocation.
+	// Used when "type=range".
+	AllocationRange int `json:"allocationRange,omitempty"`
+
+	// Optional: ExcludeRanges is used to exclude particular IPv6 addresses or address ranges for the allocation.
+	// Examples: "fd00::100-fd00::110", "fd00::1".
+	// Can be used when "type=range".
+	ExcludeRanges []string `json:"excludeRanges,omitempty"`
 }
 
 // +kubebuilder:validation:Pattern="((^((([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5]))/([0-9]|[1-2][0-9]|3[0-2])$)|(^(([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:))/([0-9]|[0-9][0-9]|1[0-1][0-9]|12[0-8])$))"
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PriceModelResourceName represents "Resource" defined in Kubernetes.
+	PriceModelResourceName = "pricemodels"
+
+	// PriceModelKindName represents "Kind" defined in Kubernetes.
+	PriceModelKindName = "PriceModel"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:JSONPath=".spec.currency",name="Currency",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// PriceModel assigns costs to the resources consumed by user clusters. Metering reports that
+// reference a PriceModel contain the resulting costs per project and cluster.
+type PriceModel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the prices.
+	Spec PriceModelSpec `json:"spec,omitempty"`
+}
+
+// PriceModelSpec describes the prices of a price model.
+type PriceModelSpec struct {
+	// +kubebuilder:validation:Pattern:=`^[A-Z]{3}$`
+
+	// Currency is the ISO 4217 code of the currency all prices are given in, e.g. "EUR".
+	Currency string `json:"currency"`
+
+	// Default are the prices for all clusters that are not matched by any of the Overrides.
+	Default Prices `json:"default"`
+
+	// Overrides are prices for clusters of a certain datacenter and/or cloud provider. If a
+	// cluster is matched by multiple overrides, the most specific one is used: an override
+	// matching datacenter and provider takes precedence over one matching only the datacenter,
+	// which in turn takes precedence over one matching only the provider. Prices that are not
+	// set in an override are taken from Default.
+	// +optional
+	Overrides []PriceOverride `json:"overrides,omitempty"`
+}
+
+// Prices are the prices of the resources of a cluster. Unset prices are treated as zero.
+type Prices struct {
+	// VCPUHour is the price of one vCPU of a node per hour.
+	VCPUHour *resource.Quantity `json:"vcpuHour,omitempty"`
+	// MemoryGBHour is the price of one GiB of node memory per hour.
+	MemoryGBHour *resource.Quantity `json:"memoryGBHour,omitempty"`
+	// StorageGBHour is the price of one GiB of persistent volume capacity per hour.
+	StorageGBHour *resource.Quantity `json:"storageGBHour,omitempty"`
+	// LoadBalancerHour is the price of one Service of type LoadBalancer per hour.
+	LoadBalancerHour *resource.Quantity `json:"loadBalancerHour,omitempty"`
+}
+
+// PriceOverride are prices for the clusters of a datacenter and/or cloud provider.
+type PriceOverride struct {
+	// Datacenter is the name of the datacenter the prices apply to. If empty, the prices
+	// apply to all datacenters of the Provider.
+	// +optional
+	Datacenter string `json:"datacenter,omitempty"`
+	// Provider is the name of the cloud provider the prices apply to, e.g. "aws". If empty,
+	// the prices apply to all providers of the Datacenter.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	Prices `json:",inline"`
+}
+
+// PricesFor returns the prices for a cluster in the given datacenter and cloud provider.
+func (s *PriceModelSpec) PricesFor(datacenter, provider string) Prices {
+	var (
+		match     *PriceOverride
+		bestScore int
+	)
+
+	for i, o := range s.Overrides {
+		if o.Datacenter == "" && o.Provider == "" {
+			continue
+		}
+		if (o.Datacenter != "" && o.Datacenter != datacenter) || (o.Provider != "" && o.Provider != provider) {
+			continue
+		}
+
+		score := 0
+		if o.Datacenter != "" {
+			score += 2
+		}
+		if o.Provider != "" {
+			score++
+		}
+
+		if score > bestScore {
+			match = &s.Overrides[i]
+			bestScore = score
+		}
+	}
+
+	prices := s.Default
+	if match == nil {
+		return prices
+	}
+
+	if match.VCPUHour != nil {
+		prices.VCPUHour = match.VCPUHour
+	}
+	if match.MemoryGBHour != nil {
+		prices.MemoryGBHour = match.MemoryGBHour
+	}
+	if match.StorageGBHour != nil {
+		prices.StorageGBHour = match.StorageGBHour
+	}
+	if match.LoadBalancerHour != nil {
+		prices.LoadBalancerHour = match.LoadBalancerHour
+	}
+
+	return prices
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// PriceModelList is a collection of price models.
+type PriceModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of the price models.
+	Items []PriceModel `json:"items"`
+}
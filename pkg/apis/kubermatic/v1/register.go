@@ -87,6 +87,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AlertmanagerList{},
 		&ClusterTemplate{},
 		&ClusterTemplateList{},
+		&ClusterMigration{},
+		&ClusterMigrationList{},
 		&ClusterTemplateInstance{},
 		&ClusterTemplateInstanceList{},
 		&RuleGroup{},
@@ -103,6 +105,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&IPAMAllocationList{},
 		&ResourceQuota{},
 		&ResourceQuotaList{},
+		&PriceModel{},
+		&PriceModelList{},
 		&GroupProjectBinding{},
 		&GroupProjectBindingList{},
 		&ClusterBackupStorageLocation{},
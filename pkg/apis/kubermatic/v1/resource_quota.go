@@ -17,6 +17,9 @@ limitations under the License.
 package v1
 
 import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -56,6 +59,17 @@ type ResourceQuotaSpec struct {
 	Subject Subject `json:"subject"`
 	// Quota specifies the current maximum allowed usage of resources.
 	Quota ResourceDetails `json:"quota"`
+	// ScopedQuotas limit the usage of resources in specific datacenters or of specific cloud
+	// providers. They apply in addition to Quota, which always covers all clusters of the subject.
+	// +optional
+	ScopedQuotas []ScopedResourceDetails `json:"scopedQuotas,omitempty"`
+
+	// WarningThresholds are percentages (1-100) of the quota, e.g. 80 and 95. When the global usage of a resource
+	// reaches one of them, either for Quota or for one of the ScopedQuotas, the ThresholdsNotReached condition is
+	// set to false, an event is recorded on the project and notifications are sent, if configured in the
+	// KubermaticSettings. Unlike the quota itself, thresholds never cause requests to be rejected.
+	// +optional
+	WarningThresholds []int `json:"warningThresholds,omitempty"`
 }
 
 // ResourceQuotaStatus describes the current state of a resource quota.
@@ -64,6 +78,44 @@ type ResourceQuotaStatus struct {
 	GlobalUsage ResourceDetails `json:"globalUsage,omitempty"`
 	// LocalUsage is holds the current usage of resources for the local seed.
 	LocalUsage ResourceDetails `json:"localUsage,omitempty"`
+	// GlobalScopedUsage holds the current usage of resources for all seeds, for each of the ScopedQuotas.
+	GlobalScopedUsage []ScopedResourceDetails `json:"globalScopedUsage,omitempty"`
+	// LocalScopedUsage holds the current usage of resources for the local seed, for each of the ScopedQuotas.
+	LocalScopedUsage []ScopedResourceDetails `json:"localScopedUsage,omitempty"`
+	// ExceededThresholds contains the highest warning threshold that is currently reached, keyed by
+	// resource (e.g. "cpu" or "loadBalancers"). For scoped quotas, the key also contains the scope
+	// (e.g. "cpu (datacenter europe-west3-c)"). Resources below all thresholds are omitted.
+	ExceededThresholds map[string]int `json:"exceededThresholds,omitempty"`
+	// Conditions contains conditions of the resource quota.
+	Conditions map[ResourceQuotaConditionType]ResourceQuotaCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ThresholdsNotReached
+
+// ResourceQuotaConditionType is used to indicate the type of a ResourceQuota condition. For all condition
+// types, the `true` value must indicate success.
+type ResourceQuotaConditionType string
+
+const (
+	// ResourceQuotaConditionThresholdsNotReached indicates that the global usage of all resources is
+	// below the warning thresholds of the quota.
+	ResourceQuotaConditionThresholdsNotReached ResourceQuotaConditionType = "ThresholdsNotReached"
+)
+
+type ResourceQuotaCondition struct {
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time we got an update on a given condition.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // Subject describes the entity to which the quota applies to.
@@ -78,7 +130,44 @@ type Subject struct {
 	Kind string `json:"kind"`
 }
 
-// ResourceDetails holds the CPU, Memory and Storage quantities.
+// ResourceQuotaScope restricts a quota to the clusters in a datacenter and/or of a cloud provider.
+// At least one of the fields must be set.
+type ResourceQuotaScope struct {
+	// Datacenter is the name of the datacenter the quota applies to.
+	// +optional
+	Datacenter string `json:"datacenter,omitempty"`
+	// Provider is the name of the cloud provider (e.g. "aws" or "vsphere") the quota applies to.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+}
+
+// Matches returns true if clusters in the given datacenter and of the given provider fall into the scope.
+func (s ResourceQuotaScope) Matches(datacenter, provider string) bool {
+	return (s.Datacenter == "" || s.Datacenter == datacenter) && (s.Provider == "" || s.Provider == provider)
+}
+
+func (s ResourceQuotaScope) String() string {
+	parts := []string{}
+	if s.Datacenter != "" {
+		parts = append(parts, "datacenter "+s.Datacenter)
+	}
+	if s.Provider != "" {
+		parts = append(parts, "provider "+s.Provider)
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// ScopedResourceDetails holds resource quantities for a scope.
+type ScopedResourceDetails struct {
+	ResourceQuotaScope `json:",inline"`
+
+	// Resources holds the resource quantities of the scope.
+	Resources ResourceDetails `json:"resources"`
+}
+
+// ResourceDetails holds the CPU, Memory and Storage quantities as well as the
+// number of LoadBalancers, public IPs and nodes.
 type ResourceDetails struct {
 	// CPU holds the quantity of CPU. For the format, please check k8s.io/apimachinery/pkg/api/resource.Quantity.
 	CPU *resource.Quantity `json:"cpu,omitempty"`
@@ -86,10 +175,42 @@ type ResourceDetails struct {
 	Memory *resource.Quantity `json:"memory,omitempty"`
 	// Storage represents the disk size. For the format, please check k8s.io/apimachinery/pkg/api/resource.Quantity.
 	Storage *resource.Quantity `json:"storage,omitempty"`
+	// LoadBalancers represents the number of Services of type LoadBalancer.
+	LoadBalancers *resource.Quantity `json:"loadBalancers,omitempty"`
+	// PublicIPs represents the number of public IP addresses, assigned either to nodes (e.g. floating IPs)
+	// or to Services of type LoadBalancer.
+	PublicIPs *resource.Quantity `json:"publicIPs,omitempty"`
+	// Nodes represents the number of nodes (i.e. Machines).
+	Nodes *resource.Quantity `json:"nodes,omitempty"`
 }
 
 func (r ResourceDetails) IsEmpty() bool {
-	return (r.CPU == nil || r.CPU.IsZero()) && (r.Memory == nil || r.Memory.IsZero()) && (r.Storage == nil || r.Storage.IsZero())
+	return isZero(r.CPU) && isZero(r.Memory) && isZero(r.Storage) && isZero(r.LoadBalancers) && isZero(r.PublicIPs) && isZero(r.Nodes)
+}
+
+// Add adds the quantities of other to r. Quantities that are not set in r
+// are initialized if they are set in other.
+func (r *ResourceDetails) Add(other ResourceDetails) {
+	addQuantity(&r.CPU, other.CPU)
+	addQuantity(&r.Memory, other.Memory)
+	addQuantity(&r.Storage, other.Storage)
+	addQuantity(&r.LoadBalancers, other.LoadBalancers)
+	addQuantity(&r.PublicIPs, other.PublicIPs)
+	addQuantity(&r.Nodes, other.Nodes)
+}
+
+func isZero(q *resource.Quantity) bool {
+	return q == nil || q.IsZero()
+}
+
+func addQuantity(dst **resource.Quantity, src *resource.Quantity) {
+	if src == nil {
+		return
+	}
+	if *dst == nil {
+		*dst = &resource.Quantity{}
+	}
+	(*dst).Add(*src)
 }
 
 // +kubebuilder:object:generate=true
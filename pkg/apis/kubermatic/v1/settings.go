@@ -120,6 +120,11 @@ type SettingSpec struct {
 	// +optional
 	DefaultProjectResourceQuota *DefaultProjectResourceQuota `json:"defaultQuota,omitempty"`
 
+	// ResourceQuotaNotifications configures how project owners are notified when the usage of a
+	// resource quota reaches one of its warning thresholds. EE-version only.
+	// +optional
+	ResourceQuotaNotifications *ResourceQuotaNotificationSettings `json:"resourceQuotaNotifications,omitempty"`
+
 	// +optional
 	MachineDeploymentOptions MachineDeploymentOptions `json:"machineDeploymentOptions,omitempty"`
 
@@ -220,6 +225,29 @@ type DefaultProjectResourceQuota struct {
 	Quota ResourceDetails `json:"quota,omitempty"`
 }
 
+// ResourceQuotaNotificationSettings configures the notifications which are sent when the usage of
+// a resource quota reaches one of its warning thresholds.
+type ResourceQuotaNotificationSettings struct {
+	// WebhookURL is an HTTP(S) endpoint to which a JSON document describing the reached threshold is POSTed.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// Email configures sending notifications via email to the owners of the affected project.
+	// +optional
+	Email *ResourceQuotaEmailNotificationSettings `json:"email,omitempty"`
+}
+
+// ResourceQuotaEmailNotificationSettings configures the SMTP server used to send notifications.
+type ResourceQuotaEmailNotificationSettings struct {
+	// SMTPServer is the address (host:port) of the SMTP server.
+	SMTPServer string `json:"smtpServer"`
+	// From is the sender address of the notifications.
+	From string `json:"from"`
+	// CredentialsSecret is the name of a Secret in the KKP namespace, containing the `username` and
+	// `password` used to authenticate against the SMTP server. If not set, no authentication is used.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
 // +kubebuilder:object:generate=true
 // +kubebuilder:object:root=true
 
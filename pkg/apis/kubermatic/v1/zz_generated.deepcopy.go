@@ -675,6 +675,184 @@ func (in *ApplicationSettings) DeepCopy() *ApplicationSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogAggregatorSettings) DeepCopyInto(out *AuditLogAggregatorSettings) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RedactionRules != nil {
+		in, out := &in.RedactionRules, &out.RedactionRules
+		*out = make([]AuditLogRedactionRule, len(*in))
+		copy(*out, *in)
+	}
+	in.DefaultSink.DeepCopyInto(&out.DefaultSink)
+	if in.ProjectSinks != nil {
+		in, out := &in.ProjectSinks, &out.ProjectSinks
+		*out = make(map[string]AuditLogSink, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogAggregatorSettings.
+func (in *AuditLogAggregatorSettings) DeepCopy() *AuditLogAggregatorSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogAggregatorSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogHTTPSink) DeepCopyInto(out *AuditLogHTTPSink) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogHTTPSink.
+func (in *AuditLogHTTPSink) DeepCopy() *AuditLogHTTPSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogHTTPSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogLokiSink) DeepCopyInto(out *AuditLogLokiSink) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogLokiSink.
+func (in *AuditLogLokiSink) DeepCopy() *AuditLogLokiSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogLokiSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogRedactionRule) DeepCopyInto(out *AuditLogRedactionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogRedactionRule.
+func (in *AuditLogRedactionRule) DeepCopy() *AuditLogRedactionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogRedactionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogS3Sink) DeepCopyInto(out *AuditLogS3Sink) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogS3Sink.
+func (in *AuditLogS3Sink) DeepCopy() *AuditLogS3Sink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogS3Sink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogSink) DeepCopyInto(out *AuditLogSink) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(AuditLogS3Sink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(AuditLogLokiSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(AuditLogSyslogSink)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(AuditLogHTTPSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogSink.
+func (in *AuditLogSink) DeepCopy() *AuditLogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogSyslogSink) DeepCopyInto(out *AuditLogSyslogSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogSyslogSink.
+func (in *AuditLogSyslogSink) DeepCopy() *AuditLogSyslogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogSyslogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLoggingSettings) DeepCopyInto(out *AuditLoggingSettings) {
 	*out = *in
@@ -768,6 +946,28 @@ func (in *AuditSidecarSettings) DeepCopy() *AuditSidecarSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationConfiguration) DeepCopyInto(out *AuthenticationConfiguration) {
+	*out = *in
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = make([]JWTAuthenticator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationConfiguration.
+func (in *AuthenticationConfiguration) DeepCopy() *AuthenticationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
@@ -842,6 +1042,11 @@ func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
 		*out = new(corev1.SecretReference)
 		**out = **in
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
@@ -854,6 +1059,26 @@ func (in *BackupDestination) DeepCopy() *BackupDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
+	*out = *in
+	if in.KeyEncryptionKeys != nil {
+		in, out := &in.KeyEncryptionKeys, &out.KeyEncryptionKeys
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
@@ -862,6 +1087,12 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	in.BackupFinishedTime.DeepCopyInto(&out.BackupFinishedTime)
 	in.DeleteStartTime.DeepCopyInto(&out.DeleteStartTime)
 	in.DeleteFinishedTime.DeepCopyInto(&out.DeleteFinishedTime)
+	in.VerificationFinishedTime.DeepCopyInto(&out.VerificationFinishedTime)
+	if in.RetainedBy != nil {
+		in, out := &in.RetainedBy, &out.RetainedBy
+		*out = make([]EtcdBackupRetentionTier, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -1083,6 +1314,11 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAddress) DeepCopyInto(out *ClusterAddress) {
 	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAddress.
@@ -1154,6 +1390,23 @@ func (in *ClusterBackupStorageLocationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCARotationStatus) DeepCopyInto(out *ClusterCARotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCARotationStatus.
+func (in *ClusterCARotationStatus) DeepCopy() *ClusterCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
 	*out = *in
@@ -1223,6 +1476,119 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigration) DeepCopyInto(out *ClusterMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigration.
+func (in *ClusterMigration) DeepCopy() *ClusterMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationCondition) DeepCopyInto(out *ClusterMigrationCondition) {
+	*out = *in
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationCondition.
+func (in *ClusterMigrationCondition) DeepCopy() *ClusterMigrationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationList) DeepCopyInto(out *ClusterMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationList.
+func (in *ClusterMigrationList) DeepCopy() *ClusterMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationSpec) DeepCopyInto(out *ClusterMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationSpec.
+func (in *ClusterMigrationSpec) DeepCopy() *ClusterMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationStatus) DeepCopyInto(out *ClusterMigrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(map[ClusterMigrationConditionType]ClusterMigrationCondition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationStatus.
+func (in *ClusterMigrationStatus) DeepCopy() *ClusterMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 	*out = *in
@@ -1300,6 +1666,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	}
 	in.ComponentsOverride.DeepCopyInto(&out.ComponentsOverride)
 	out.OIDC = in.OIDC
+	if in.AuthenticationConfiguration != nil {
+		in, out := &in.AuthenticationConfiguration, &out.AuthenticationConfiguration
+		*out = new(AuthenticationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Features != nil {
 		in, out := &in.Features, &out.Features
 		*out = make(map[string]bool, len(*in))
@@ -1399,7 +1770,7 @@ func (in *ClusterSpec) DeepCopy() *ClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	*out = *in
-	out.Address = in.Address
+	in.Address.DeepCopyInto(&out.Address)
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 	in.ExtendedHealth.DeepCopyInto(&out.ExtendedHealth)
 	in.LastProviderReconciliation.DeepCopyInto(&out.LastProviderReconciliation)
@@ -1438,6 +1809,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ResourceDetails)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(ClusterCARotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -2136,6 +2512,13 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = new(KubeLBDatacenterSettings)
 		**out = **in
 	}
+	if in.KMSPlugins != nil {
+		in, out := &in.KMSPlugins, &out.KMSPlugins
+		*out = make([]KMSPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpec.
@@ -2651,6 +3034,11 @@ func (in *EncryptionConfiguration) DeepCopyInto(out *EncryptionConfiguration) {
 		*out = new(SecretboxEncryptionConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfiguration.
@@ -2775,6 +3163,21 @@ func (in *EtcdBackupConfigSpec) DeepCopyInto(out *EtcdBackupConfigSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(EtcdBackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WALArchive != nil {
+		in, out := &in.WALArchive, &out.WALArchive
+		*out = new(EtcdWALArchiveSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(EtcdBackupVerificationSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupConfigSpec.
@@ -2811,38 +3214,98 @@ func (in *EtcdBackupConfigStatus) DeepCopy() *EtcdBackupConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EtcdBackupConfigStatus)
+	out := new(EtcdBackupConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupRestore) DeepCopyInto(out *EtcdBackupRestore) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make(map[string]*BackupDestination, len(*in))
+		for key, val := range *in {
+			var outVal *BackupDestination
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(BackupDestination)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.DefaultRetention != nil {
+		in, out := &in.DefaultRetention, &out.DefaultRetention
+		*out = new(EtcdBackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupRestore.
+func (in *EtcdBackupRestore) DeepCopy() *EtcdBackupRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupRetention) DeepCopyInto(out *EtcdBackupRetention) {
+	*out = *in
+	if in.Hourly != nil {
+		in, out := &in.Hourly, &out.Hourly
+		*out = new(int)
+		**out = **in
+	}
+	if in.Daily != nil {
+		in, out := &in.Daily, &out.Daily
+		*out = new(int)
+		**out = **in
+	}
+	if in.Weekly != nil {
+		in, out := &in.Weekly, &out.Weekly
+		*out = new(int)
+		**out = **in
+	}
+	if in.Monthly != nil {
+		in, out := &in.Monthly, &out.Monthly
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupRetention.
+func (in *EtcdBackupRetention) DeepCopy() *EtcdBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EtcdBackupRestore) DeepCopyInto(out *EtcdBackupRestore) {
+func (in *EtcdBackupVerificationSettings) DeepCopyInto(out *EtcdBackupVerificationSettings) {
 	*out = *in
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make(map[string]*BackupDestination, len(*in))
-		for key, val := range *in {
-			var outVal *BackupDestination
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = new(BackupDestination)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
-		}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupRestore.
-func (in *EtcdBackupRestore) DeepCopy() *EtcdBackupRestore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupVerificationSettings.
+func (in *EtcdBackupVerificationSettings) DeepCopy() *EtcdBackupVerificationSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(EtcdBackupRestore)
+	out := new(EtcdBackupVerificationSettings)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2852,7 +3315,7 @@ func (in *EtcdRestore) DeepCopyInto(out *EtcdRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2906,10 +3369,39 @@ func (in *EtcdRestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestorePointInTime) DeepCopyInto(out *EtcdRestorePointInTime) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestorePointInTime.
+func (in *EtcdRestorePointInTime) DeepCopy() *EtcdRestorePointInTime {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestorePointInTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdRestoreSpec) DeepCopyInto(out *EtcdRestoreSpec) {
 	*out = *in
 	out.Cluster = in.Cluster
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = new(EtcdRestorePointInTime)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreSpec.
@@ -2982,6 +3474,26 @@ func (in *EtcdStatefulSetSettings) DeepCopy() *EtcdStatefulSetSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdWALArchiveSettings) DeepCopyInto(out *EtcdWALArchiveSettings) {
+	*out = *in
+	if in.SegmentInterval != nil {
+		in, out := &in.SegmentInterval, &out.SegmentInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdWALArchiveSettings.
+func (in *EtcdWALArchiveSettings) DeepCopy() *EtcdWALArchiveSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdWALArchiveSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventRateLimitConfig) DeepCopyInto(out *EventRateLimitConfig) {
 	*out = *in
@@ -3032,6 +3544,28 @@ func (in *EventRateLimitConfigItem) DeepCopy() *EventRateLimitConfigItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeGatewayConfig) DeepCopyInto(out *ExposeGatewayConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeGatewayConfig.
+func (in *ExposeGatewayConfig) DeepCopy() *ExposeGatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeGatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ExposeStrategiesSet) DeepCopyInto(out *ExposeStrategiesSet) {
 	{
@@ -3730,6 +4264,11 @@ func (in *IPAMAllocationSpec) DeepCopyInto(out *IPAMAllocationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPv6Addresses != nil {
+		in, out := &in.IPv6Addresses, &out.IPv6Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMAllocationSpec.
@@ -3781,6 +4320,11 @@ func (in *IPAMPoolDatacenterSettings) DeepCopyInto(out *IPAMPoolDatacenterSettin
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(IPAMPoolIPv6Settings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMPoolDatacenterSettings.
@@ -3793,6 +4337,31 @@ func (in *IPAMPoolDatacenterSettings) DeepCopy() *IPAMPoolDatacenterSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMPoolIPv6Settings) DeepCopyInto(out *IPAMPoolIPv6Settings) {
+	*out = *in
+	if in.ExcludePrefixes != nil {
+		in, out := &in.ExcludePrefixes, &out.ExcludePrefixes
+		*out = make([]SubnetCIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeRanges != nil {
+		in, out := &in.ExcludeRanges, &out.ExcludeRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMPoolIPv6Settings.
+func (in *IPAMPoolIPv6Settings) DeepCopy() *IPAMPoolIPv6Settings {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMPoolIPv6Settings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMPoolList) DeepCopyInto(out *IPAMPoolList) {
 	*out = *in
@@ -3908,29 +4477,241 @@ func (in ImageListWithVersions) DeepCopyInto(out *ImageListWithVersions) {
 			(*out)[key] = outVal
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageListWithVersions.
-func (in ImageListWithVersions) DeepCopy() ImageListWithVersions {
-	if in == nil {
-		return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageListWithVersions.
+func (in ImageListWithVersions) DeepCopy() ImageListWithVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageListWithVersions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Incompatibility) DeepCopyInto(out *Incompatibility) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Incompatibility.
+func (in *Incompatibility) DeepCopy() *Incompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(Incompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthenticator) DeepCopyInto(out *JWTAuthenticator) {
+	*out = *in
+	in.Issuer.DeepCopyInto(&out.Issuer)
+	if in.ClaimValidationRules != nil {
+		in, out := &in.ClaimValidationRules, &out.ClaimValidationRules
+		*out = make([]JWTClaimValidationRule, len(*in))
+		copy(*out, *in)
+	}
+	in.ClaimMappings.DeepCopyInto(&out.ClaimMappings)
+	if in.UserValidationRules != nil {
+		in, out := &in.UserValidationRules, &out.UserValidationRules
+		*out = make([]JWTUserValidationRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthenticator.
+func (in *JWTAuthenticator) DeepCopy() *JWTAuthenticator {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthenticator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMappings) DeepCopyInto(out *JWTClaimMappings) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Groups.DeepCopyInto(&out.Groups)
+	out.UID = in.UID
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make([]JWTExtraMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMappings.
+func (in *JWTClaimMappings) DeepCopy() *JWTClaimMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimOrExpression) DeepCopyInto(out *JWTClaimOrExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimOrExpression.
+func (in *JWTClaimOrExpression) DeepCopy() *JWTClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimValidationRule) DeepCopyInto(out *JWTClaimValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimValidationRule.
+func (in *JWTClaimValidationRule) DeepCopy() *JWTClaimValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTExtraMapping) DeepCopyInto(out *JWTExtraMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTExtraMapping.
+func (in *JWTExtraMapping) DeepCopy() *JWTExtraMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTExtraMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTIssuer) DeepCopyInto(out *JWTIssuer) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTIssuer.
+func (in *JWTIssuer) DeepCopy() *JWTIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTPrefixedClaimOrExpression) DeepCopyInto(out *JWTPrefixedClaimOrExpression) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTPrefixedClaimOrExpression.
+func (in *JWTPrefixedClaimOrExpression) DeepCopy() *JWTPrefixedClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTPrefixedClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTUserValidationRule) DeepCopyInto(out *JWTUserValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTUserValidationRule.
+func (in *JWTUserValidationRule) DeepCopy() *JWTUserValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTUserValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSEncryptionConfiguration) DeepCopyInto(out *KMSEncryptionConfiguration) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSEncryptionConfiguration.
+func (in *KMSEncryptionConfiguration) DeepCopy() *KMSEncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSEncryptionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSPlugin) DeepCopyInto(out *KMSPlugin) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigSecretRef != nil {
+		in, out := &in.ConfigSecretRef, &out.ConfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(ImageListWithVersions)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Incompatibility) DeepCopyInto(out *Incompatibility) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Incompatibility.
-func (in *Incompatibility) DeepCopy() *Incompatibility {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSPlugin.
+func (in *KMSPlugin) DeepCopy() *KMSPlugin {
 	if in == nil {
 		return nil
 	}
-	out := new(Incompatibility)
+	out := new(KMSPlugin)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4366,6 +5147,11 @@ func (in *KubermaticSeedControllerConfiguration) DeepCopyInto(out *KubermaticSee
 		*out = make([]MetricsCollector, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertificateRenewalWindow != nil {
+		in, out := &in.CertificateRenewalWindow, &out.CertificateRenewalWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubermaticSeedControllerConfiguration.
@@ -4954,6 +5740,11 @@ func (in *MeteringConfiguration) DeepCopyInto(out *MeteringConfiguration) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.QueryService != nil {
+		in, out := &in.QueryService, &out.QueryService
+		*out = new(MeteringQueryServiceConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringConfiguration.
@@ -4966,6 +5757,21 @@ func (in *MeteringConfiguration) DeepCopy() *MeteringConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringQueryServiceConfiguration) DeepCopyInto(out *MeteringQueryServiceConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringQueryServiceConfiguration.
+func (in *MeteringQueryServiceConfiguration) DeepCopy() *MeteringQueryServiceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringQueryServiceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MeteringReportConfiguration) DeepCopyInto(out *MeteringReportConfiguration) {
 	*out = *in
@@ -5128,6 +5934,11 @@ func (in *NodeportProxyConfig) DeepCopyInto(out *NodeportProxyConfig) {
 		*out = make([]corev1.IPFamily, len(*in))
 		copy(*out, *in)
 	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(NodeportProxyLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeportProxyConfig.
@@ -5140,6 +5951,36 @@ func (in *NodeportProxyConfig) DeepCopy() *NodeportProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeportProxyLimits) DeepCopyInto(out *NodeportProxyLimits) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionRate != nil {
+		in, out := &in.ConnectionRate, &out.ConnectionRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionBurst != nil {
+		in, out := &in.ConnectionBurst, &out.ConnectionBurst
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeportProxyLimits.
+func (in *NodeportProxyLimits) DeepCopy() *NodeportProxyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeportProxyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotificationsOptions) DeepCopyInto(out *NotificationsOptions) {
 	*out = *in
@@ -5725,6 +6566,138 @@ func (in *PresetSpec) DeepCopy() *PresetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriceModel) DeepCopyInto(out *PriceModel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriceModel.
+func (in *PriceModel) DeepCopy() *PriceModel {
+	if in == nil {
+		return nil
+	}
+	out := new(PriceModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PriceModel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriceModelList) DeepCopyInto(out *PriceModelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PriceModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriceModelList.
+func (in *PriceModelList) DeepCopy() *PriceModelList {
+	if in == nil {
+		return nil
+	}
+	out := new(PriceModelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PriceModelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriceModelSpec) DeepCopyInto(out *PriceModelSpec) {
+	*out = *in
+	in.Default.DeepCopyInto(&out.Default)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]PriceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriceModelSpec.
+func (in *PriceModelSpec) DeepCopy() *PriceModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PriceModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriceOverride) DeepCopyInto(out *PriceOverride) {
+	*out = *in
+	in.Prices.DeepCopyInto(&out.Prices)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriceOverride.
+func (in *PriceOverride) DeepCopy() *PriceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PriceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prices) DeepCopyInto(out *Prices) {
+	*out = *in
+	if in.VCPUHour != nil {
+		in, out := &in.VCPUHour, &out.VCPUHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryGBHour != nil {
+		in, out := &in.MemoryGBHour, &out.MemoryGBHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageGBHour != nil {
+		in, out := &in.StorageGBHour, &out.StorageGBHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LoadBalancerHour != nil {
+		in, out := &in.LoadBalancerHour, &out.LoadBalancerHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prices.
+func (in *Prices) DeepCopy() *Prices {
+	if in == nil {
+		return nil
+	}
+	out := new(Prices)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
@@ -5916,6 +6889,21 @@ func (in *ResourceDetails) DeepCopyInto(out *ResourceDetails) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PublicIPs != nil {
+		in, out := &in.PublicIPs, &out.PublicIPs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDetails.
@@ -5955,6 +6943,38 @@ func (in *ResourceQuota) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaCondition) DeepCopyInto(out *ResourceQuotaCondition) {
+	*out = *in
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaCondition.
+func (in *ResourceQuotaCondition) DeepCopy() *ResourceQuotaCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaEmailNotificationSettings) DeepCopyInto(out *ResourceQuotaEmailNotificationSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaEmailNotificationSettings.
+func (in *ResourceQuotaEmailNotificationSettings) DeepCopy() *ResourceQuotaEmailNotificationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaEmailNotificationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuotaList) DeepCopyInto(out *ResourceQuotaList) {
 	*out = *in
@@ -5987,11 +7007,58 @@ func (in *ResourceQuotaList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaNotificationSettings) DeepCopyInto(out *ResourceQuotaNotificationSettings) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(ResourceQuotaEmailNotificationSettings)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaNotificationSettings.
+func (in *ResourceQuotaNotificationSettings) DeepCopy() *ResourceQuotaNotificationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaNotificationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaScope) DeepCopyInto(out *ResourceQuotaScope) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaScope.
+func (in *ResourceQuotaScope) DeepCopy() *ResourceQuotaScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuotaSpec) DeepCopyInto(out *ResourceQuotaSpec) {
 	*out = *in
 	out.Subject = in.Subject
 	in.Quota.DeepCopyInto(&out.Quota)
+	if in.ScopedQuotas != nil {
+		in, out := &in.ScopedQuotas, &out.ScopedQuotas
+		*out = make([]ScopedResourceDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WarningThresholds != nil {
+		in, out := &in.WarningThresholds, &out.WarningThresholds
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaSpec.
@@ -6009,6 +7076,34 @@ func (in *ResourceQuotaStatus) DeepCopyInto(out *ResourceQuotaStatus) {
 	*out = *in
 	in.GlobalUsage.DeepCopyInto(&out.GlobalUsage)
 	in.LocalUsage.DeepCopyInto(&out.LocalUsage)
+	if in.GlobalScopedUsage != nil {
+		in, out := &in.GlobalScopedUsage, &out.GlobalScopedUsage
+		*out = make([]ScopedResourceDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LocalScopedUsage != nil {
+		in, out := &in.LocalScopedUsage, &out.LocalScopedUsage
+		*out = make([]ScopedResourceDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExceededThresholds != nil {
+		in, out := &in.ExceededThresholds, &out.ExceededThresholds
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(map[ResourceQuotaConditionType]ResourceQuotaCondition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaStatus.
@@ -6120,6 +7215,23 @@ func (in *SSHKeySpec) DeepCopy() *SSHKeySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopedResourceDetails) DeepCopyInto(out *ScopedResourceDetails) {
+	*out = *in
+	out.ResourceQuotaScope = in.ResourceQuotaScope
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopedResourceDetails.
+func (in *ScopedResourceDetails) DeepCopy() *ScopedResourceDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopedResourceDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretboxEncryptionConfiguration) DeepCopyInto(out *SecretboxEncryptionConfiguration) {
 	*out = *in
@@ -6265,6 +7377,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		}
 	}
 	in.NodeportProxy.DeepCopyInto(&out.NodeportProxy)
+	if in.ExposeGateway != nil {
+		in, out := &in.ExposeGateway, &out.ExposeGateway
+		*out = new(ExposeGatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProxySettings != nil {
 		in, out := &in.ProxySettings, &out.ProxySettings
 		*out = new(ProxySettings)
@@ -6301,6 +7418,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = make([]MetricsCollector, len(*in))
 		copy(*out, *in)
 	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(AuditLogAggregatorSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedSpec.
@@ -6416,6 +7538,11 @@ func (in *SettingSpec) DeepCopyInto(out *SettingSpec) {
 		*out = new(DefaultProjectResourceQuota)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceQuotaNotifications != nil {
+		in, out := &in.ResourceQuotaNotifications, &out.ResourceQuotaNotifications
+		*out = new(ResourceQuotaNotificationSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	out.MachineDeploymentOptions = in.MachineDeploymentOptions
 }
 
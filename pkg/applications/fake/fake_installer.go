@@ -68,6 +68,11 @@ func (a *ApplicationInstallerRecorder) Rollback(ctx context.Context, log *zap.Su
 	return nil
 }
 
+func (a *ApplicationInstallerRecorder) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	// NOOP
+	return nil, nil
+}
+
 // ApplicationInstallerLogger is a fake ApplicationInstaller that just logs actions. it's used for the development of the controller.
 type ApplicationInstallerLogger struct {
 }
@@ -100,6 +105,11 @@ func (a ApplicationInstallerLogger) Rollback(ctx context.Context, log *zap.Sugar
 	return nil
 }
 
+func (a ApplicationInstallerLogger) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	log.Debugf("Detect drift of application %s. applicationVersion=%v", applicationInstallation.Name, applicationInstallation.Status.ApplicationVersion)
+	return nil, nil
+}
+
 // CustomApplicationInstaller is an applicationInstaller in which every function can be independently mocked.
 // If a function is not mocked, then default values are returned.
 type CustomApplicationInstaller struct {
@@ -107,6 +117,7 @@ type CustomApplicationInstaller struct {
 	DownloadSourceFunc func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, downloadDest string) (string, error)
 	ApplyFunc          func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, appDefinition *appskubermaticv1.ApplicationDefinition, applicationInstallation *appskubermaticv1.ApplicationInstallation, appSourcePath string) (util.StatusUpdater, error)
 	DeleteFunc         func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error)
+	DetectDriftFunc    func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error)
 }
 
 func (c CustomApplicationInstaller) GetAppCache() string {
@@ -146,3 +157,10 @@ func (c CustomApplicationInstaller) Rollback(ctx context.Context, log *zap.Sugar
 	// NOOP
 	return nil
 }
+
+func (c CustomApplicationInstaller) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	if c.DetectDriftFunc != nil {
+		return c.DetectDriftFunc(ctx, log, seedClient, userClient, applicationInstallation)
+	}
+	return nil, nil
+}
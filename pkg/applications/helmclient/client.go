@@ -256,6 +256,16 @@ func (h HelmClient) DownloadChart(url string, chartName string, version string,
 // Otherwise it upgrades the chart.
 // charLoc is the path to the chart archive (e.g. /tmp/foo/apache-1.0.0.tgz) or folder containing the chart (e.g. /tmp/mychart/apache).
 func (h HelmClient) InstallOrUpgrade(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings) (*release.Release, error) {
+	return h.installOrUpgrade(chartLoc, releaseName, values, deployOpts, auth, false)
+}
+
+// InstallOrForceUpgrade installs the chart like InstallOrUpgrade, but always upgrades an existing release,
+// even if neither the chart nor the values have changed (e.g. to revert changes made in the cluster).
+func (h HelmClient) InstallOrForceUpgrade(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings) (*release.Release, error) {
+	return h.installOrUpgrade(chartLoc, releaseName, values, deployOpts, auth, true)
+}
+
+func (h HelmClient) installOrUpgrade(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings, force bool) (*release.Release, error) {
 	currentRelease, err := h.actionConfig.Releases.Last(releaseName)
 	if err != nil {
 		if errors.Is(err, driver.ErrReleaseNotFound) {
@@ -265,6 +275,11 @@ func (h HelmClient) InstallOrUpgrade(chartLoc string, releaseName string, values
 		return nil, err
 	}
 
+	if force {
+		h.logger.Debugw("Forcing helm upgrade", "release", releaseName)
+		return h.Upgrade(chartLoc, releaseName, values, deployOpts, auth)
+	}
+
 	upgradeNeeded, err := h.shouldUpgrade(chartLoc, currentRelease, values)
 	if err != nil {
 		return nil, err
@@ -426,6 +441,16 @@ func (h HelmClient) GetMetadata(releaseName string) (*action.Metadata, error) {
 	return res, nil
 }
 
+// GetManifest returns the rendered manifest of the last revision of the release.
+func (h HelmClient) GetManifest(releaseName string) (string, error) {
+	client := action.NewGet(h.actionConfig)
+	res, err := client.Run(releaseName)
+	if err != nil {
+		return "", fmt.Errorf("Could not retrieve release %q: %w", releaseName, err)
+	}
+	return res.Manifest, nil
+}
+
 // Rollback wraps helms Rollback command to be used with our ActionConfig.
 func (h HelmClient) Rollback(releaseName string) error {
 	client := action.NewRollback(h.actionConfig)
@@ -299,6 +299,30 @@ func TestHelmClient(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "installOrForceUpgrade from archive should install chart when it not already installed",
+			testFunc: func(t *testing.T) {
+				ns := test.CreateNamespaceWithCleanup(t, ctx, client)
+				installOrForceUpgradeTest(t, ctx, client, ns, chartArchiveV1Path, map[string]interface{}{}, test.DefaultData, test.DefaultVersionLabel, 1)
+				// check only desired release are stored on cluster
+				checkExpectedReleases(t, ctx, client, ns, []test.ReleaseStorageInfo{
+					{Name: "sh.helm.release.v1." + releaseName + ".v1", Version: "1"},
+				})
+			},
+		},
+		{
+			name: "installOrForceUpgrade from archive should upgrade v1 chart when it was already installed and its values weren't changed",
+			testFunc: func(t *testing.T) {
+				ns := test.CreateNamespaceWithCleanup(t, ctx, client)
+				installTest(t, ctx, client, ns, chartArchiveV1Path, map[string]interface{}{}, test.DefaultData, test.DefaultVersionLabel, false)
+				installOrForceUpgradeTest(t, ctx, client, ns, chartArchiveV1Path, map[string]interface{}{}, test.DefaultData, test.DefaultVersionLabel, 2)
+				// check only desired release are stored on cluster
+				checkExpectedReleases(t, ctx, client, ns, []test.ReleaseStorageInfo{
+					{Name: "sh.helm.release.v1." + releaseName + ".v1", Version: "1"},
+					{Name: "sh.helm.release.v1." + releaseName + ".v2", Version: "2"},
+				})
+			},
+		},
 		{
 			name: "uninstall should be successful when chart is already installed",
 			testFunc: func(t *testing.T) {
@@ -574,6 +598,22 @@ func installOrUpgradeTest(t *testing.T, ctx context.Context, client ctrlruntimec
 	test.CheckConfigMap(t, ctx, client, ns, expectedData, expectedVersionLabel, false)
 }
 
+func installOrForceUpgradeTest(t *testing.T, ctx context.Context, client ctrlruntimeclient.Client, ns *corev1.Namespace, chartPath string, values map[string]interface{}, expectedData map[string]string, expectedVersionLabel string, expectedRelVersion int) {
+	helmClient, chartFullPath := buildHelmClient(t, ctx, ns, chartPath)
+
+	releaseInfo, err := helmClient.InstallOrForceUpgrade(chartFullPath, releaseName, values, *defaultDeployOpts(t), AuthSettings{})
+
+	if err != nil {
+		t.Fatalf("helm InstallOrForceUpgrade failed :%s", err)
+	}
+
+	if releaseInfo.Version != expectedRelVersion {
+		t.Fatalf("invalid helm release version. expected %v, got %v", expectedRelVersion, releaseInfo.Version)
+	}
+
+	test.CheckConfigMap(t, ctx, client, ns, expectedData, expectedVersionLabel, false)
+}
+
 func uninstallTest(t *testing.T, ctx context.Context, client ctrlruntimeclient.Client, ns *corev1.Namespace) {
 	tempDir := t.TempDir()
 	settings := NewSettings(tempDir)
@@ -50,6 +50,9 @@ type ApplicationInstaller interface {
 
 	// Rollback rolls an Application back to the previous release
 	Rollback(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) error
+
+	// DetectDrift returns the resources of the application that have been modified or deleted in the user cluster since it has been installed.
+	DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error)
 }
 
 // ApplicationManager handles the installation / uninstallation of an Application on the user-cluster.
@@ -164,3 +167,13 @@ func (a *ApplicationManager) Rollback(ctx context.Context, log *zap.SugaredLogge
 
 	return templateProvider.Rollback(applicationInstallation)
 }
+
+// DetectDrift returns the resources of the application that have been modified or deleted in the user cluster since it has been installed.
+func (a *ApplicationManager) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	templateProvider, err := providers.NewTemplateProvider(ctx, seedClient, a.Kubeconfig, a.ApplicationCache, log, applicationInstallation, a.SecretNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize template provider: %w", err)
+	}
+
+	return templateProvider.DetectDrift(applicationInstallation)
+}
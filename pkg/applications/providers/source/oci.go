@@ -0,0 +1,485 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// helmChartLayerMediaType is the media type of the layer containing the chart archive in Helm OCI artifacts.
+	helmChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+	// cosignSignatureAnnotation is the annotation of a cosign signature layer that holds the
+	// base64 encoded signature of the layer's payload.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// ociTitleAnnotation is the file name of a layer, as set by e.g. oras.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+	// orasUnpackAnnotation marks a layer pushed by oras as a tarball of a directory.
+	orasUnpackAnnotation = "io.deis.oras.content.unpack"
+
+	helmChartArchiveName = "chart.tgz"
+)
+
+// OCISource downloads the application's source from an OCI registry.
+type OCISource struct {
+	Ctx context.Context
+
+	// SeedClient to seed cluster.
+	SeedClient ctrlruntimeclient.Client
+
+	Source *appskubermaticv1.OCISource
+
+	// Namespace where credential secrets are stored.
+	SecretNamespace string
+}
+
+// cosignPayload is the part of the simple signing payload signed by cosign that we care about.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// DownloadSource pulls the artifact into destination and returns the full path to the application's sources.
+// For Helm charts, the path to the chart archive is returned. Otherwise all layers of the artifact are
+// unpacked (tarballs) or written as files (layers with a title annotation) into destination.
+func (o OCISource) DownloadSource(destination string) (string, error) {
+	repo, err := o.repository()
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := o.remoteOptions(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var ref name.Reference = repo.Digest(o.Source.Digest)
+	if o.Source.Tag != "" {
+		ref = repo.Tag(o.Source.Tag)
+	}
+
+	desc, err := remote.Get(ref, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get artifact %s: %w", ref, err)
+	}
+
+	if o.Source.Digest != "" && desc.Digest.String() != o.Source.Digest {
+		return "", fmt.Errorf("artifact %s has digest %s, but %s is expected", ref, desc.Digest, o.Source.Digest)
+	}
+
+	if o.Source.Verification != nil {
+		publicKey, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, o.Source.Verification.PublicKey.Name, o.Source.Verification.PublicKey.Key)
+		if err != nil {
+			return "", err
+		}
+
+		if err := verifySignature(repo, desc.Digest, []byte(publicKey), opts); err != nil {
+			return "", fmt.Errorf("failed to verify signature of artifact %s: %w", ref, err)
+		}
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return "", fmt.Errorf("failed to read artifact %s: %w", ref, err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest of artifact %s: %w", ref, err)
+	}
+
+	for _, layerDesc := range manifest.Layers {
+		if layerDesc.MediaType == helmChartLayerMediaType {
+			return o.downloadHelmChart(img, layerDesc, destination)
+		}
+	}
+
+	for _, layerDesc := range manifest.Layers {
+		if err := unpackLayer(img, layerDesc, destination); err != nil {
+			return "", fmt.Errorf("failed to unpack layer %s: %w", layerDesc.Digest, err)
+		}
+	}
+
+	return path.Join(destination, o.Source.Path), nil
+}
+
+func (o OCISource) repository() (name.Repository, error) {
+	var nameOpts []name.Option
+	if o.Source.PlainHTTP {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+
+	repo, err := name.NewRepository(o.Source.Repository, nameOpts...)
+	if err != nil {
+		return name.Repository{}, fmt.Errorf("invalid repository %q: %w", o.Source.Repository, err)
+	}
+
+	return repo, nil
+}
+
+// remoteOptions returns the options to access the registry with the credentials defined in the OCISource.
+func (o OCISource) remoteOptions(repo name.Repository) ([]remote.Option, error) {
+	opts := []remote.Option{remote.WithContext(o.Ctx)}
+
+	credentials := o.Source.Credentials
+	if credentials == nil {
+		return opts, nil
+	}
+
+	authConfig := authn.AuthConfig{}
+
+	if credentials.Username != nil {
+		username, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.Username.Name, credentials.Username.Key)
+		if err != nil {
+			return nil, err
+		}
+		authConfig.Username = username
+	}
+
+	if credentials.Password != nil {
+		password, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.Password.Name, credentials.Password.Key)
+		if err != nil {
+			return nil, err
+		}
+		authConfig.Password = password
+	}
+
+	if credentials.RegistryConfigFile != nil {
+		registryConfigFile, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.RegistryConfigFile.Name, credentials.RegistryConfigFile.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		authConfig, err = authFromRegistryConfig([]byte(registryConfigFile), repo.RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(opts, remote.WithAuth(authn.FromConfig(authConfig))), nil
+}
+
+// authFromRegistryConfig returns the credentials for the registry from a dockercfg file.
+func authFromRegistryConfig(registryConfigFile []byte, registry string) (authn.AuthConfig, error) {
+	config := struct {
+		Auths map[string]authn.AuthConfig `json:"auths"`
+	}{}
+
+	if err := json.Unmarshal(registryConfigFile, &config); err != nil {
+		return authn.AuthConfig{}, fmt.Errorf("failed to parse registryConfigFile: %w", err)
+	}
+
+	for _, key := range []string{registry, "https://" + registry, "http://" + registry} {
+		if auth, ok := config.Auths[key]; ok {
+			return auth, nil
+		}
+	}
+
+	if registry == name.DefaultRegistry {
+		if auth, ok := config.Auths[authn.DefaultAuthKey]; ok {
+			return auth, nil
+		}
+	}
+
+	return authn.AuthConfig{}, fmt.Errorf("registryConfigFile contains no credentials for registry %q", registry)
+}
+
+// verifySignature verifies that the artifact with the given digest has been signed with the private key
+// belonging to publicKey using cosign. Cosign stores the signatures as layers of an artifact tagged
+// "<algorithm>-<hex>.sig" in the same repository.
+func verifySignature(repo name.Repository, digest v1.Hash, publicKey []byte, opts []remote.Option) error {
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	sigRef := repo.Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+
+	sigImg, err := remote.Image(sigRef, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to get signatures %s: %w", sigRef, err)
+	}
+
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to read manifest of signatures %s: %w", sigRef, err)
+	}
+
+	var errs []error
+	for _, layerDesc := range manifest.Layers {
+		signature, ok := layerDesc.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		err := verifySignatureLayer(sigImg, layerDesc, signature, digest, key)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return errors.New("no cosign signatures found")
+	}
+
+	return fmt.Errorf("no valid signature found: %w", errors.Join(errs...))
+}
+
+func verifySignatureLayer(img v1.Image, layerDesc v1.Descriptor, signature string, digest v1.Hash, key crypto.PublicKey) error {
+	rawSignature, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	payload, err := readLayer(img, layerDesc)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyPayload(key, payload, rawSignature); err != nil {
+		return err
+	}
+
+	signed := cosignPayload{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("failed to parse signature payload: %w", err)
+	}
+
+	if signed.Critical.Image.DockerManifestDigest != digest.String() {
+		return fmt.Errorf("signature is for digest %s", signed.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
+
+func parsePublicKey(publicKey []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	return key, nil
+}
+
+func verifyPayload(key crypto.PublicKey, payload, signature []byte) error {
+	hash := sha256.Sum256(payload)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, hash[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, signature) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	return nil
+}
+
+func (o OCISource) downloadHelmChart(img v1.Image, layerDesc v1.Descriptor, destination string) (string, error) {
+	chart, err := readLayer(img, layerDesc)
+	if err != nil {
+		return "", err
+	}
+
+	chartPath := path.Join(destination, helmChartArchiveName)
+	if err := os.WriteFile(chartPath, chart, 0600); err != nil {
+		return "", fmt.Errorf("failed to write chart archive: %w", err)
+	}
+
+	return chartPath, nil
+}
+
+func readLayer(img v1.Image, layerDesc v1.Descriptor) ([]byte, error) {
+	layer, err := img.LayerByDigest(layerDesc.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer %s: %w", layerDesc.Digest, err)
+	}
+
+	// the layer is read as stored in the registry; Compressed() verifies its digest
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer %s: %w", layerDesc.Digest, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer %s: %w", layerDesc.Digest, err)
+	}
+
+	return content, nil
+}
+
+// unpackLayer extracts tarball layers into destination and writes all other layers
+// as files named after their title annotation.
+func unpackLayer(img v1.Image, layerDesc v1.Descriptor, destination string) error {
+	title := layerDesc.Annotations[ociTitleAnnotation]
+	mediaType := string(layerDesc.MediaType)
+
+	isGzip := strings.HasSuffix(mediaType, "tar+gzip") || strings.HasSuffix(mediaType, "tar.gzip")
+	isTar := isGzip || strings.HasSuffix(mediaType, ".tar") || strings.HasSuffix(mediaType, "+tar")
+
+	// oras only unpacks tarballs of directories; tarballs pushed as plain files keep their name
+	if title != "" && (!isTar || layerDesc.Annotations[orasUnpackAnnotation] != "true") {
+		target, err := securePath(destination, title)
+		if err != nil {
+			return err
+		}
+
+		content, err := readLayer(img, layerDesc)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		return os.WriteFile(target, content, 0644)
+	}
+
+	if !isTar {
+		// e.g. config blobs of other tools, which are not part of the source
+		return nil
+	}
+
+	content, err := readLayer(img, layerDesc)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = bytes.NewReader(content)
+	if isGzip {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to decompress layer: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	// oras puts the directory itself into the tarball, so it is extracted into the destination
+	return untar(r, destination)
+}
+
+func untar(r io.Reader, destination string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+
+		target, err := securePath(destination, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+
+			if err := f.Close(); err != nil {
+				return err
+			}
+		default:
+			// links and special files are not needed for application sources and could
+			// point outside of the destination
+			continue
+		}
+	}
+}
+
+// securePath joins destination and name and ensures that the result does not escape destination.
+func securePath(destination, name string) (string, error) {
+	target := filepath.Join(destination, name)
+	if target != filepath.Clean(destination) && !strings.HasPrefix(target, filepath.Clean(destination)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("path %q escapes the destination directory", name)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testSecretNamespace = "kubermatic"
+	testSecretName      = "oci-credentials"
+)
+
+func TestDownloadOCISource(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse registry URL: %v", err)
+	}
+	host := u.Host
+
+	chartDigest := pushArtifact(t, host+"/charts/apache:1.0.0", mutate.Addendum{
+		Layer: static.NewLayer([]byte("chart-archive"), helmChartLayerMediaType),
+	})
+
+	bundle := tarGz(t, map[string]string{
+		"manifests/deployment.yaml": "kind: Deployment",
+		"manifests/service.yaml":    "kind: Service",
+	})
+	pushArtifact(t, host+"/manifests/app:1.0.0", mutate.Addendum{
+		Layer: static.NewLayer(bundle, types.MediaType("application/vnd.oci.image.layer.v1.tar+gzip")),
+	})
+
+	pushArtifact(t, host+"/files/app:1.0.0", mutate.Addendum{
+		Layer:       static.NewLayer([]byte("kind: ConfigMap"), types.MediaType("application/yaml")),
+		Annotations: map[string]string{ociTitleAnnotation: "configmap.yaml"},
+	})
+
+	pushArtifact(t, host+"/files/evil:1.0.0", mutate.Addendum{
+		Layer:       static.NewLayer([]byte("evil"), types.MediaType("application/yaml")),
+		Annotations: map[string]string{ociTitleAnnotation: "../evil.yaml"},
+	})
+
+	signingKey, publicKey := generateECDSAKey(t)
+	_, otherPublicKey := generateECDSAKey(t)
+
+	signedDigest := pushArtifact(t, host+"/charts/signed:1.0.0", mutate.Addendum{
+		Layer: static.NewLayer([]byte("signed-chart-archive"), helmChartLayerMediaType),
+	})
+	pushSignature(t, host+"/charts/signed", signedDigest, signingKey)
+
+	// signature of another artifact copied to the unsigned one
+	foreignDigest := pushArtifact(t, host+"/charts/foreign:1.0.0", mutate.Addendum{
+		Layer: static.NewLayer([]byte("foreign-chart-archive"), helmChartLayerMediaType),
+	})
+	pushSignature(t, host+"/charts/foreign", foreignDigest, signingKey, signedDigest)
+
+	pushArtifact(t, host+"/charts/unsigned:1.0.0", mutate.Addendum{
+		Layer: static.NewLayer([]byte("unsigned-chart-archive"), helmChartLayerMediaType),
+	})
+
+	testCases := []struct {
+		name          string
+		source        *appskubermaticv1.OCISource
+		secretData    map[string][]byte
+		expectedPath  string
+		expectedFiles map[string]string
+		expectedError string
+	}{
+		{
+			name:          "helm chart by tag",
+			source:        &appskubermaticv1.OCISource{Repository: host + "/charts/apache", Tag: "1.0.0"},
+			expectedPath:  helmChartArchiveName,
+			expectedFiles: map[string]string{helmChartArchiveName: "chart-archive"},
+		},
+		{
+			name:          "helm chart by digest",
+			source:        &appskubermaticv1.OCISource{Repository: host + "/charts/apache", Digest: chartDigest.String()},
+			expectedPath:  helmChartArchiveName,
+			expectedFiles: map[string]string{helmChartArchiveName: "chart-archive"},
+		},
+		{
+			name:          "tag does not match pinned digest",
+			source:        &appskubermaticv1.OCISource{Repository: host + "/charts/apache", Tag: "1.0.0", Digest: signedDigest.String()},
+			expectedError: "is expected",
+		},
+		{
+			name:         "manifest bundle with path",
+			source:       &appskubermaticv1.OCISource{Repository: host + "/manifests/app", Tag: "1.0.0", Path: "manifests"},
+			expectedPath: "manifests",
+			expectedFiles: map[string]string{
+				"manifests/deployment.yaml": "kind: Deployment",
+				"manifests/service.yaml":    "kind: Service",
+			},
+		},
+		{
+			name:          "file with title annotation",
+			source:        &appskubermaticv1.OCISource{Repository: host + "/files/app", Tag: "1.0.0"},
+			expectedFiles: map[string]string{"configmap.yaml": "kind: ConfigMap"},
+		},
+		{
+			name:          "title escaping the destination",
+			source:        &appskubermaticv1.OCISource{Repository: host + "/files/evil", Tag: "1.0.0"},
+			expectedError: "escapes the destination directory",
+		},
+		{
+			name: "basic auth credentials from secret",
+			source: &appskubermaticv1.OCISource{
+				Repository: host + "/charts/apache",
+				Tag:        "1.0.0",
+				Credentials: &appskubermaticv1.OCICredentials{
+					Username: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName}, Key: "username"},
+					Password: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName}, Key: "password"},
+				},
+			},
+			secretData:    map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+			expectedPath:  helmChartArchiveName,
+			expectedFiles: map[string]string{helmChartArchiveName: "chart-archive"},
+		},
+		{
+			name: "registry config file without credentials for registry",
+			source: &appskubermaticv1.OCISource{
+				Repository: host + "/charts/apache",
+				Tag:        "1.0.0",
+				Credentials: &appskubermaticv1.OCICredentials{
+					RegistryConfigFile: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName}, Key: ".dockerconfigjson"},
+				},
+			},
+			secretData:    map[string][]byte{".dockerconfigjson": []byte(`{"auths":{"quay.io":{"username":"user","password":"pass"}}}`)},
+			expectedError: "contains no credentials for registry",
+		},
+		{
+			name:          "valid signature",
+			source:        signedSource(host+"/charts/signed", "publicKey"),
+			secretData:    map[string][]byte{"publicKey": publicKey},
+			expectedPath:  helmChartArchiveName,
+			expectedFiles: map[string]string{helmChartArchiveName: "signed-chart-archive"},
+		},
+		{
+			name:          "signature of another key",
+			source:        signedSource(host+"/charts/signed", "publicKey"),
+			secretData:    map[string][]byte{"publicKey": otherPublicKey},
+			expectedError: "invalid signature",
+		},
+		{
+			name:          "signature of another artifact",
+			source:        signedSource(host+"/charts/foreign", "publicKey"),
+			secretData:    map[string][]byte{"publicKey": publicKey},
+			expectedError: "signature is for digest",
+		},
+		{
+			name:          "missing signature",
+			source:        signedSource(host+"/charts/unsigned", "publicKey"),
+			secretData:    map[string][]byte{"publicKey": publicKey},
+			expectedError: "failed to get signatures",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.source.PlainHTTP = true
+
+			clientBuilder := fake.NewClientBuilder()
+			if tc.secretData != nil {
+				clientBuilder.WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: testSecretName, Namespace: testSecretNamespace},
+					Data:       tc.secretData,
+				})
+			}
+
+			source := OCISource{
+				Ctx:             context.Background(),
+				SeedClient:      clientBuilder.Build(),
+				Source:          tc.source,
+				SecretNamespace: testSecretNamespace,
+			}
+
+			destination := t.TempDir()
+
+			downloadedPath, err := source.DownloadSource(destination)
+			if tc.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, but got none", tc.expectedError)
+				}
+				if !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, but got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to download source: %v", err)
+			}
+
+			if expected := path.Join(destination, tc.expectedPath); downloadedPath != expected {
+				t.Errorf("expected path %q, but got %q", expected, downloadedPath)
+			}
+
+			for file, expectedContent := range tc.expectedFiles {
+				content, err := os.ReadFile(path.Join(destination, file))
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", file, err)
+				}
+				if string(content) != expectedContent {
+					t.Errorf("expected %s to contain %q, but got %q", file, expectedContent, string(content))
+				}
+			}
+		})
+	}
+}
+
+func signedSource(repository string, publicKeyKey string) *appskubermaticv1.OCISource {
+	return &appskubermaticv1.OCISource{
+		Repository: repository,
+		Tag:        "1.0.0",
+		Verification: &appskubermaticv1.OCISignatureVerification{
+			PublicKey: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName}, Key: publicKeyKey},
+		},
+	}
+}
+
+// pushArtifact pushes an artifact with the given layer to ref and returns its digest.
+func pushArtifact(t *testing.T, ref string, layer mutate.Addendum) v1.Hash {
+	t.Helper()
+
+	img, err := mutate.Append(empty.Image, layer)
+	if err != nil {
+		t.Fatalf("failed to build artifact: %v", err)
+	}
+
+	tag, err := name.NewTag(ref, name.Insecure)
+	if err != nil {
+		t.Fatalf("failed to parse reference: %v", err)
+	}
+
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("failed to push artifact: %v", err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("failed to get artifact digest: %v", err)
+	}
+
+	return digest
+}
+
+// pushSignature signs the artifact with the given digest like cosign does. If signedDigest is given,
+// the payload refers to that digest instead, which makes the signature invalid for the artifact.
+func pushSignature(t *testing.T, repository string, digest v1.Hash, key *ecdsa.PrivateKey, signedDigest ...v1.Hash) {
+	t.Helper()
+
+	payloadDigest := digest
+	if len(signedDigest) > 0 {
+		payloadDigest = signedDigest[0]
+	}
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, repository, payloadDigest))
+	hash := sha256.Sum256(payload)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign payload: %v", err)
+	}
+
+	pushArtifact(t, fmt.Sprintf("%s:%s-%s.sig", repository, digest.Algorithm, digest.Hex), mutate.Addendum{
+		Layer:       static.NewLayer(payload, types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json")),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	})
+}
+
+func generateECDSAKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+
+	for file, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tarball: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip stream: %v", err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/apis/equality"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// driftDetectionFieldManager is the field manager used for the server-side apply dry runs of the drift detection.
+// As nothing is persisted, it never shows up in the managed fields of a resource.
+const driftDetectionFieldManager = "kkp-application-drift-detection"
+
+func newUserClient(kubeconfig string) (ctrlruntimeclient.Client, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	client, err := ctrlruntimeclient.New(cfg, ctrlruntimeclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user cluster client: %w", err)
+	}
+
+	return client, nil
+}
+
+// fieldsHash returns the hash of the fields of obj owned by fieldManager through server-side apply,
+// or an empty string if fieldManager does not own any field.
+func fieldsHash(obj *unstructured.Unstructured, fieldManager string) string {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+
+		hash := sha256.Sum256(entry.FieldsV1.Raw)
+		return hex.EncodeToString(hash[:])
+	}
+
+	return ""
+}
+
+func getLiveObject(ctx context.Context, client ctrlruntimeclient.Client, r appskubermaticv1.AppliedResource) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind})
+
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: r.Namespace, Name: r.Name}, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// detectDriftByFieldsHash detects drift of resources applied with server-side apply. Whenever another field manager
+// changes a field owned by the application, the ownership of the field moves to that manager, which changes the
+// fields hash of the application's field manager.
+func detectDriftByFieldsHash(ctx context.Context, client ctrlruntimeclient.Client, fieldManager string, resources []appskubermaticv1.AppliedResource) ([]util.DriftedResource, error) {
+	var drifted []util.DriftedResource
+
+	for _, r := range resources {
+		live, err := getLiveObject(ctx, client, r)
+		if apierrors.IsNotFound(err) {
+			drifted = append(drifted, util.DriftedResource{AppliedResource: r, Deleted: true})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err)
+		}
+
+		// resources applied before the fields hash has been introduced cannot be checked for modifications
+		if r.FieldsHash != "" && fieldsHash(live, fieldManager) != r.FieldsHash {
+			drifted = append(drifted, util.DriftedResource{AppliedResource: r})
+		}
+	}
+
+	return drifted, nil
+}
+
+// detectDriftByDryRun detects drift by applying the desired objects using a server-side apply dry run and comparing
+// the result with the live objects. Any difference means that a field set in the manifests has been changed. This
+// works regardless of how the resources have been created (e.g. by Helm).
+func detectDriftByDryRun(ctx context.Context, client ctrlruntimeclient.Client, namespace string, objs []*unstructured.Unstructured) ([]util.DriftedResource, error) {
+	var drifted []util.DriftedResource
+
+	for _, obj := range objs {
+		namespaced, err := client.IsObjectNamespaced(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine scope of %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		r := appliedResource(obj)
+
+		live, err := getLiveObject(ctx, client, r)
+		if apierrors.IsNotFound(err) {
+			drifted = append(drifted, util.DriftedResource{AppliedResource: r, Deleted: true})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err)
+		}
+
+		desired := obj.DeepCopy()
+		if err := client.Patch(ctx, desired, ctrlruntimeclient.Apply, ctrlruntimeclient.DryRunAll, ctrlruntimeclient.ForceOwnership, ctrlruntimeclient.FieldOwner(driftDetectionFieldManager)); err != nil {
+			return nil, fmt.Errorf("failed to dry-run apply %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err)
+		}
+
+		if !equality.Semantic.DeepEqual(comparableContent(live), comparableContent(desired)) {
+			drifted = append(drifted, util.DriftedResource{AppliedResource: r})
+		}
+	}
+
+	return drifted, nil
+}
+
+// comparableContent returns the content of obj without the fields that are changed by the API server
+// on every write and the status, which is not part of the manifests.
+func comparableContent(obj *unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().Object
+
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(content, "metadata", "generation")
+	unstructured.RemoveNestedField(content, "status")
+
+	return content
+}
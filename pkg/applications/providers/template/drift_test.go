@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testFieldManager = "kkp-application-default-app"
+
+func managedFields(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		APIVersion: "v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("failed to convert object: %v", err)
+	}
+
+	return &unstructured.Unstructured{Object: content}
+}
+
+func TestFieldsHash(t *testing.T) {
+	applied := managedFields(testFieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:key":{}}}`)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{
+		managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:other":{}}}`),
+		applied,
+	}}}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+
+	hash := fieldsHash(toUnstructured(t, cm), testFieldManager)
+	if hash == "" {
+		t.Fatal("expected hash of applied fields, but got none")
+	}
+
+	if other := fieldsHash(toUnstructured(t, cm), "another-manager"); other != "" {
+		t.Errorf("expected no hash for another manager, but got %q", other)
+	}
+
+	// the field has been taken over by kubectl-edit
+	cm.ManagedFields = []metav1.ManagedFieldsEntry{
+		managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:key":{},"f:other":{}}}`),
+		managedFields(testFieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{}}`),
+	}
+	if changed := fieldsHash(toUnstructured(t, cm), testFieldManager); changed == hash {
+		t.Error("expected hash to change after fields have been taken over")
+	}
+}
+
+func TestDetectDriftByFieldsHash(t *testing.T) {
+	applied := managedFields(testFieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:key":{}}}`)
+	unchangedHash := fieldsHash(toUnstructured(t, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{applied}}}), testFieldManager)
+
+	client := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "unchanged", ManagedFields: []metav1.ManagedFieldsEntry{applied}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "modified", ManagedFields: []metav1.ManagedFieldsEntry{
+			managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:key":{}}}`),
+		}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "legacy"}},
+	).Build()
+
+	resources := []appskubermaticv1.AppliedResource{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "unchanged", FieldsHash: unchangedHash},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "modified", FieldsHash: unchangedHash},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "deleted", FieldsHash: unchangedHash},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "legacy"},
+	}
+
+	drifted, err := detectDriftByFieldsHash(context.Background(), client, testFieldManager, resources)
+	if err != nil {
+		t.Fatalf("failed to detect drift: %v", err)
+	}
+
+	expected := []util.DriftedResource{
+		{AppliedResource: resources[1]},
+		{AppliedResource: resources[2], Deleted: true},
+	}
+	if len(drifted) != len(expected) {
+		t.Fatalf("expected drifted resources %v, but got %v", expected, drifted)
+	}
+	for i := range expected {
+		if drifted[i] != expected[i] {
+			t.Errorf("expected drifted resource %v, but got %v", expected[i], drifted[i])
+		}
+	}
+}
+
+func TestComparableContent(t *testing.T) {
+	live := toUnstructured(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", ResourceVersion: "1", ManagedFields: []metav1.ManagedFieldsEntry{managedFields("helm", metav1.ManagedFieldsOperationUpdate, `{}`)}},
+		Data:       map[string]string{"key": "value"},
+	})
+	desired := toUnstructured(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", ResourceVersion: "1", ManagedFields: []metav1.ManagedFieldsEntry{managedFields(driftDetectionFieldManager, metav1.ManagedFieldsOperationApply, `{}`)}},
+		Data:       map[string]string{"key": "value"},
+	})
+
+	if !equalContent(live, desired) {
+		t.Error("expected objects differing only in managed fields to be equal")
+	}
+
+	if err := unstructured.SetNestedField(desired.Object, "changed", "data", "key"); err != nil {
+		t.Fatalf("failed to change data: %v", err)
+	}
+	if equalContent(live, desired) {
+		t.Error("expected objects with different data to differ")
+	}
+}
+
+func equalContent(a, b *unstructured.Unstructured) bool {
+	return reflect.DeepEqual(comparableContent(a), comparableContent(b))
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -29,6 +30,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/applications/helmclient"
 	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
 
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -95,7 +98,15 @@ func (h HelmTemplate) InstallOrUpgrade(chartLoc string, appDefinition *appskuber
 		return util.NoStatusUpdate, fmt.Errorf("failed to unmarshal values: %w", err)
 	}
 
-	helmRelease, err := helmClient.InstallOrUpgrade(chartLoc, getReleaseName(applicationInstallation), values, *deployOpts, auth)
+	var helmRelease *release.Release
+	if isDrifted(applicationInstallation) {
+		// the chart and values have not necessarily changed, so an upgrade has to be forced to revert the drift;
+		// the release is installed again if it has been deleted
+		h.Log.Infow("Application has drifted, upgrading helm release", "release", getReleaseName(applicationInstallation))
+		helmRelease, err = helmClient.InstallOrForceUpgrade(chartLoc, getReleaseName(applicationInstallation), values, *deployOpts, auth)
+	} else {
+		helmRelease, err = helmClient.InstallOrUpgrade(chartLoc, getReleaseName(applicationInstallation), values, *deployOpts, auth)
+	}
 	statusUpdater := util.NoStatusUpdate
 
 	// In some case, even if an error occurred, the helmRelease is updated.
@@ -257,6 +268,51 @@ func (h HelmTemplate) IsStuck(applicationInstallation *appskubermaticv1.Applicat
 	return true, nil
 }
 
+// DetectDrift compares the resources of the helm release in the user cluster with the manifest of the release.
+func (h HelmTemplate) DetectDrift(applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	helmCacheDir, err := util.CreateHelmTempDir(h.CacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create helmCacheDir: %w", err)
+	}
+
+	defer util.CleanUpHelmTempDir(helmCacheDir, h.Log)
+	restClientGetter := &genericclioptions.ConfigFlags{
+		KubeConfig: &h.Kubeconfig,
+		Namespace:  &applicationInstallation.Spec.Namespace.Name,
+	}
+	helmClient, err := helmclient.NewClient(
+		h.Ctx,
+		restClientGetter,
+		helmclient.NewSettings(helmCacheDir),
+		applicationInstallation.Spec.Namespace.Name,
+		h.Log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create helmClient: %w", err)
+	}
+
+	manifest, err := helmClient.GetManifest(getReleaseName(applicationInstallation))
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := decodeManifests(strings.NewReader(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode release manifest: %w", err)
+	}
+
+	client, err := newUserClient(h.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectDriftByDryRun(h.Ctx, client, applicationInstallation.Spec.Namespace.Name, objs)
+}
+
+// isDrifted returns true if drift has been detected for the application.
+func isDrifted(applicationInstallation *appskubermaticv1.ApplicationInstallation) bool {
+	return applicationInstallation.Status.Conditions[appskubermaticv1.Drifted].Status == corev1.ConditionTrue
+}
+
 // Rollback rolls an Application back to the previous release.
 func (h HelmTemplate) Rollback(applicationInstallation *appskubermaticv1.ApplicationInstallation) error {
 	helmCacheDir, err := util.CreateHelmTempDir(h.CacheDir)
@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// ManifestsTemplate applies the plain manifests or the kustomization found in the application's source into
+// the user cluster using server-side apply. The applied resources are tracked in the ApplicationInstallation's
+// status, so that resources removed from the source can be pruned on upgrade and all resources can be deleted
+// on uninstall. Values of the ApplicationInstallation are not used by this template method.
+type ManifestsTemplate struct {
+	Ctx context.Context
+
+	// Kubeconfig of the user-cluster.
+	Kubeconfig string
+
+	Log *zap.SugaredLogger
+
+	// Kustomize builds the kustomization located in the source instead of reading the manifests as they are.
+	Kustomize bool
+
+	// UserClient to the user cluster. If nil, a client is created from Kubeconfig.
+	UserClient ctrlruntimeclient.Client
+}
+
+// InstallOrUpgrade applies the resources from source into the user cluster and deletes the resources that
+// were applied by a previous version of the application but are no longer part of it.
+func (m ManifestsTemplate) InstallOrUpgrade(source string, appDefinition *appskubermaticv1.ApplicationDefinition, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	var (
+		objs []*unstructured.Unstructured
+		err  error
+	)
+
+	if m.Kustomize {
+		objs, err = renderKustomization(source)
+	} else {
+		objs, err = loadManifests(source)
+	}
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	client, err := m.userClient()
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	previous := applicationInstallation.Status.AppliedResources
+
+	applied, err := applyResources(m.Ctx, client, fieldManager(applicationInstallation), applicationInstallation.Spec.Namespace.Name, objs)
+	if err != nil {
+		// keep tracking the resources of the previous version, as nothing has been pruned yet
+		return setAppliedResources(mergeResources(previous, applied)), err
+	}
+
+	remaining, err := deleteResources(m.Ctx, m.Log, client, staleResources(previous, applied))
+
+	return setAppliedResources(mergeResources(applied, remaining)), err
+}
+
+// Uninstall deletes all resources applied by the application from the user cluster.
+func (m ManifestsTemplate) Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	client, err := m.userClient()
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	remaining, err := deleteResources(m.Ctx, m.Log, client, applicationInstallation.Status.AppliedResources)
+
+	return setAppliedResources(remaining), err
+}
+
+// IsStuck always returns false, as server-side apply does not leave any pending operations behind.
+func (m ManifestsTemplate) IsStuck(applicationInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	return false, nil
+}
+
+// DetectDrift returns the applied resources that have been deleted or whose fields set by the application have
+// been changed by others.
+func (m ManifestsTemplate) DetectDrift(applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+	client, err := m.userClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return detectDriftByFieldsHash(m.Ctx, client, fieldManager(applicationInstallation), applicationInstallation.Status.AppliedResources)
+}
+
+// Rollback is not supported, because no history of the applied resources is kept. As IsStuck never reports
+// a stuck installation, it is never called.
+func (m ManifestsTemplate) Rollback(applicationInstallation *appskubermaticv1.ApplicationInstallation) error {
+	return errors.New("rollback is not supported for kustomize and manifests template methods")
+}
+
+func (m ManifestsTemplate) userClient() (ctrlruntimeclient.Client, error) {
+	if m.UserClient != nil {
+		return m.UserClient, nil
+	}
+
+	return newUserClient(m.Kubeconfig)
+}
+
+// fieldManager returns the server-side apply field manager of the application. Each application uses its
+// own field manager, so that fields dropped from a resource by a new version are removed as well.
+func fieldManager(applicationInstallation *appskubermaticv1.ApplicationInstallation) string {
+	return "kkp-application-" + getReleaseName(applicationInstallation)
+}
+
+func setAppliedResources(resources []appskubermaticv1.AppliedResource) util.StatusUpdater {
+	return func(status *appskubermaticv1.ApplicationInstallationStatus) {
+		status.AppliedResources = resources
+	}
+}
+
+// renderKustomization builds the kustomization located in the source directory.
+func renderKustomization(source string) ([]*unstructured.Unstructured, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization: %w", err)
+	}
+
+	return decodeManifests(bytes.NewReader(rendered))
+}
+
+// loadManifests reads all YAML and JSON manifests from source, which is either a single file or a directory.
+// Directories are read recursively in lexical order; hidden files and directories (e.g. .git) are skipped.
+func loadManifests(source string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != source && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fileObjs, err := decodeManifests(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		objs = append(objs, fileObjs...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifests: %w", err)
+	}
+
+	return objs, nil
+}
+
+// decodeManifests decodes all documents of a multi-document YAML or JSON stream. Lists are flattened into their items.
+func decodeManifests(r io.Reader) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, err
+		}
+
+		// empty documents
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("object %q has no kind or apiVersion", obj.GetName())
+		}
+
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				objs = append(objs, &list.Items[i])
+			}
+			continue
+		}
+
+		objs = append(objs, obj)
+	}
+}
+
+// applyPriority orders resources so that namespaces and CRDs are applied before the resources depending on them.
+func applyPriority(obj *unstructured.Unstructured) int {
+	gk := obj.GroupVersionKind().GroupKind()
+
+	switch gk {
+	case schema.GroupKind{Kind: "Namespace"}:
+		return 0
+	case schema.GroupKind{Group: apiextensionsv1.GroupName, Kind: "CustomResourceDefinition"}:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// applyResources applies objs using server-side apply and returns the applied resources. Namespaced resources
+// without a namespace are applied into namespace. On error, the resources applied so far are returned.
+func applyResources(ctx context.Context, client ctrlruntimeclient.Client, fieldManager string, namespace string, objs []*unstructured.Unstructured) ([]appskubermaticv1.AppliedResource, error) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return applyPriority(objs[i]) < applyPriority(objs[j])
+	})
+
+	var applied []appskubermaticv1.AppliedResource
+
+	for _, obj := range objs {
+		namespaced, err := client.IsObjectNamespaced(obj)
+		if err != nil {
+			return applied, fmt.Errorf("failed to determine scope of %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		if err := client.Patch(ctx, obj, ctrlruntimeclient.Apply, ctrlruntimeclient.ForceOwnership, ctrlruntimeclient.FieldOwner(fieldManager)); err != nil {
+			return applied, fmt.Errorf("failed to apply %s %q: %w", obj.GetKind(), ctrlruntimeclient.ObjectKeyFromObject(obj), err)
+		}
+
+		// obj has been updated with the applied resource, including its managed fields
+		resource := appliedResource(obj)
+		resource.FieldsHash = fieldsHash(obj, fieldManager)
+
+		applied = append(applied, resource)
+	}
+
+	return applied, nil
+}
+
+func appliedResource(obj *unstructured.Unstructured) appskubermaticv1.AppliedResource {
+	gvk := obj.GroupVersionKind()
+
+	return appskubermaticv1.AppliedResource{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// resourceKey identifies a resource independent of its API version, which may change between application versions.
+func resourceKey(r appskubermaticv1.AppliedResource) string {
+	return fmt.Sprintf("%s/%s/%s/%s", r.Group, r.Kind, r.Namespace, r.Name)
+}
+
+// staleResources returns the resources from previous that are not in current.
+func staleResources(previous, current []appskubermaticv1.AppliedResource) []appskubermaticv1.AppliedResource {
+	keys := make(map[string]struct{}, len(current))
+	for _, r := range current {
+		keys[resourceKey(r)] = struct{}{}
+	}
+
+	var stale []appskubermaticv1.AppliedResource
+	for _, r := range previous {
+		if _, ok := keys[resourceKey(r)]; !ok {
+			stale = append(stale, r)
+		}
+	}
+
+	return stale
+}
+
+// mergeResources returns the resources of a followed by the resources of b that are not in a.
+func mergeResources(a, b []appskubermaticv1.AppliedResource) []appskubermaticv1.AppliedResource {
+	return append(append([]appskubermaticv1.AppliedResource{}, a...), staleResources(b, a)...)
+}
+
+// deleteResources deletes the resources in reverse order of their application and returns the resources that
+// could not be deleted.
+func deleteResources(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, resources []appskubermaticv1.AppliedResource) ([]appskubermaticv1.AppliedResource, error) {
+	var (
+		remaining []appskubermaticv1.AppliedResource
+		errs      []error
+	)
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		r := resources[i]
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind})
+		obj.SetNamespace(r.Namespace)
+		obj.SetName(r.Name)
+
+		log.Debugw("deleting resource", "kind", r.Kind, "namespace", r.Namespace, "name", r.Name)
+
+		err := client.Delete(ctx, obj, ctrlruntimeclient.PropagationPolicy("Background"))
+		if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			// keep the order of the remaining resources
+			remaining = append([]appskubermaticv1.AppliedResource{r}, remaining...)
+			errs = append(errs, fmt.Errorf("failed to delete %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err))
+		}
+	}
+
+	return remaining, errors.Join(errs...)
+}
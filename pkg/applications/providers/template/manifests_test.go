@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func resourcesOf(objs []*unstructured.Unstructured) []appskubermaticv1.AppliedResource {
+	var resources []appskubermaticv1.AppliedResource
+	for _, obj := range objs {
+		resources = append(resources, appliedResource(obj))
+	}
+	return resources
+}
+
+func TestLoadManifests(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a-deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+---
+# empty document
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`,
+		"b/configmaps.json": `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "one"}},
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "two"}}
+]}`,
+		"c/namespace.yml":   "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: app\n",
+		"README.md":         "not a manifest",
+		".git/config.yaml":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: hidden\n",
+		".hidden-file.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: hidden\n",
+	})
+
+	objs, err := loadManifests(dir)
+	if err != nil {
+		t.Fatalf("failed to load manifests: %v", err)
+	}
+
+	expected := []appskubermaticv1.AppliedResource{
+		{Group: "apps", Version: "v1", Kind: "Deployment", Name: "app"},
+		{Version: "v1", Kind: "Service", Name: "app"},
+		{Version: "v1", Kind: "ConfigMap", Name: "one"},
+		{Version: "v1", Kind: "ConfigMap", Name: "two"},
+		{Version: "v1", Kind: "Namespace", Name: "app"},
+	}
+	if got := resourcesOf(objs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected resources %v, but got %v", expected, got)
+	}
+
+	single, err := loadManifests(filepath.Join(dir, "c/namespace.yml"))
+	if err != nil {
+		t.Fatalf("failed to load single manifest: %v", err)
+	}
+	if len(single) != 1 {
+		t.Errorf("expected 1 resource from single file, but got %d", len(single))
+	}
+
+	writeFiles(t, dir, map[string]string{"invalid.yaml": "metadata:\n  name: no-kind\n"})
+	if _, err := loadManifests(dir); err == nil {
+		t.Error("expected error for manifest without kind, but got none")
+	}
+}
+
+func TestRenderKustomization(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndata:\n  key: base\n",
+		"overlay/kustomization.yaml": `namePrefix: prod-
+namespace: production
+resources:
+- ../base
+patches:
+- patch: |-
+    apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: config
+    data:
+      key: prod
+`,
+	})
+
+	objs, err := renderKustomization(filepath.Join(dir, "overlay"))
+	if err != nil {
+		t.Fatalf("failed to render kustomization: %v", err)
+	}
+
+	if len(objs) != 1 {
+		t.Fatalf("expected 1 resource, but got %d", len(objs))
+	}
+
+	expected := appskubermaticv1.AppliedResource{Version: "v1", Kind: "ConfigMap", Namespace: "production", Name: "prod-config"}
+	if got := appliedResource(objs[0]); got != expected {
+		t.Errorf("expected resource %v, but got %v", expected, got)
+	}
+
+	value, _, _ := unstructured.NestedString(objs[0].Object, "data", "key")
+	if value != "prod" {
+		t.Errorf("expected patched value %q, but got %q", "prod", value)
+	}
+
+	if _, err := renderKustomization(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing kustomization, but got none")
+	}
+}
+
+func TestStaleResources(t *testing.T) {
+	previous := []appskubermaticv1.AppliedResource{
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app", Name: "app"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget", Namespace: "app", Name: "app"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "removed"},
+	}
+	current := []appskubermaticv1.AppliedResource{
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app", Name: "app"},
+		// a changed API version does not make the resource stale
+		{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget", Namespace: "app", Name: "app"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "added"},
+	}
+
+	expected := []appskubermaticv1.AppliedResource{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "removed"},
+	}
+	if got := staleResources(previous, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected stale resources %v, but got %v", expected, got)
+	}
+
+	merged := mergeResources(current, previous)
+	if len(merged) != 4 || merged[3] != expected[0] {
+		t.Errorf("expected current resources followed by the removed one, but got %v", merged)
+	}
+}
+
+func TestDeleteResources(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "existing"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "existing"}},
+	).Build()
+
+	resources := []appskubermaticv1.AppliedResource{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "existing"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "already-deleted"},
+		{Version: "v1", Kind: "Secret", Namespace: "app", Name: "existing"},
+		{Group: "example.com", Version: "v1", Kind: "Unknown", Namespace: "app", Name: "crd-removed"},
+	}
+
+	remaining, err := deleteResources(context.Background(), zap.NewNop().Sugar(), client, resources)
+	if err != nil {
+		t.Fatalf("failed to delete resources: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected no remaining resources, but got %v", remaining)
+	}
+
+	key := types.NamespacedName{Namespace: "app", Name: "existing"}
+	if err := client.Get(context.Background(), key, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ConfigMap to be deleted, but got %v", err)
+	}
+	if err := client.Get(context.Background(), key, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Secret to be deleted, but got %v", err)
+	}
+}
@@ -46,6 +46,8 @@ func NewSourceProvider(ctx context.Context, log *zap.SugaredLogger, client ctrlr
 		return source.HelmSource{Ctx: ctx, SeedClient: client, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, Source: appSource.Helm, SecretNamespace: secretNamespace}, nil
 	case appSource.Git != nil:
 		return source.GitSource{Ctx: ctx, SeedClient: client, Source: appSource.Git, SecretNamespace: secretNamespace}, nil
+	case appSource.OCI != nil:
+		return source.OCISource{Ctx: ctx, SeedClient: client, Source: appSource.OCI, SecretNamespace: secretNamespace}, nil
 	default: // This should not happen. The admission webhook prevents that.
 		return nil, errors.New("no source found")
 	}
@@ -65,6 +67,9 @@ type TemplateProvider interface {
 
 	// Rollback the Application to the previous release
 	Rollback(applicationInstallation *appskubermaticv1.ApplicationInstallation) error
+
+	// DetectDrift returns the resources of the application that have been modified or deleted in the user cluster.
+	DetectDrift(applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error)
 }
 
 // NewTemplateProvider return the concrete implementation of TemplateProvider according to the templateMethod.
@@ -72,6 +77,10 @@ func NewTemplateProvider(ctx context.Context, seedClient ctrlruntimeclient.Clien
 	switch appInstallation.Status.Method {
 	case appskubermaticv1.HelmTemplateMethod:
 		return template.HelmTemplate{Ctx: ctx, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, SecretNamespace: secretNamespace, SeedClient: seedClient}, nil
+	case appskubermaticv1.KustomizeTemplateMethod:
+		return template.ManifestsTemplate{Ctx: ctx, Kubeconfig: kubeconfig, Log: log, Kustomize: true}, nil
+	case appskubermaticv1.ManifestsTemplateMethod:
+		return template.ManifestsTemplate{Ctx: ctx, Kubeconfig: kubeconfig, Log: log}, nil
 	default:
 		return nil, fmt.Errorf("template method '%v' not implemented", appInstallation.Status.Method)
 	}
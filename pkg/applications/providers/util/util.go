@@ -52,6 +52,34 @@ func CleanUpHelmTempDir(cacheDir string, logger *zap.SugaredLogger) {
 // It used to set status's filed of a specific template Provider (eg status.HelmRelease).
 type StatusUpdater func(status *appskubermaticv1.ApplicationInstallationStatus)
 
+// DriftedResource is a resource of an application that has been modified or deleted in the user cluster
+// since the application has been installed.
+type DriftedResource struct {
+	appskubermaticv1.AppliedResource
+
+	// Deleted is true if the resource does not exist anymore. Otherwise, it has been modified.
+	Deleted bool
+}
+
+func (d DriftedResource) String() string {
+	kind := d.Kind
+	if d.Group != "" {
+		kind += "." + d.Group
+	}
+
+	name := d.Name
+	if d.Namespace != "" {
+		name = d.Namespace + "/" + d.Name
+	}
+
+	state := "modified"
+	if d.Deleted {
+		state = "deleted"
+	}
+
+	return fmt.Sprintf("%s %s (%s)", kind, name, state)
+}
+
 // NoStatusUpdate is a StatusUpdater that does not update the status.
 // It useful in case an error happens and we don't have information to update the status.
 var NoStatusUpdate StatusUpdater = func(status *appskubermaticv1.ApplicationInstallationStatus) {
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificateExpiryMetric(t *testing.T) {
+	ca, err := triple.NewCA("root-ca")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	kp, err := triple.NewClientKeyPair(ca, "apiserver-etcd-client", nil)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	kubermaticFakeClient := fake.
+		NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: "cluster-cluster1",
+				},
+			},
+			// clusters without a namespace are skipped
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster2",
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.CASecretName,
+					Namespace: "cluster-cluster1",
+				},
+				Data: map[string][]byte{
+					resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+					resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.ApiserverEtcdClientCertificateSecretName,
+					Namespace: "cluster-cluster1",
+				},
+				Data: map[string][]byte{
+					resources.ApiserverEtcdClientCertificateCertSecretKey: triple.EncodeCertPEM(kp.Cert),
+					resources.ApiserverEtcdClientCertificateKeySecretKey:  triple.EncodePrivateKeyPEM(kp.Key),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "no-certificates",
+					Namespace: "cluster-cluster1",
+				},
+				Data: map[string][]byte{
+					"token": []byte("secret"),
+				},
+			},
+		).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(newCertificateCollector(kubermaticFakeClient)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf(`
+# HELP kubermatic_cluster_certificate_expiry_time_seconds Unix timestamp at which a control plane certificate expires
+# TYPE kubermatic_cluster_certificate_expiry_time_seconds gauge
+kubermatic_cluster_certificate_expiry_time_seconds{cluster="cluster1",common_name="apiserver-etcd-client",key="%s",secret="%s"} %d
+kubermatic_cluster_certificate_expiry_time_seconds{cluster="cluster1",common_name="root-ca",key="%s",secret="%s"} %d
+`,
+		resources.ApiserverEtcdClientCertificateCertSecretKey, resources.ApiserverEtcdClientCertificateSecretName, kp.Cert.NotAfter.Unix(),
+		resources.CACertSecretKey, resources.CASecretName, ca.Cert.NotAfter.Unix(),
+	)
+
+	if err := testutil.CollectAndCompare(registry, strings.NewReader(expected), "kubermatic_cluster_certificate_expiry_time_seconds"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
 
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,6 +35,8 @@ type s3Collector struct {
 	ObjectCount            *prometheus.Desc
 	ObjectLastModifiedDate *prometheus.Desc
 	EmptyObjectCount       *prometheus.Desc
+	ChunkCount             *prometheus.Desc
+	ChunkSize              *prometheus.Desc
 	QuerySuccess           *prometheus.Desc
 	client                 ctrlruntimeclient.Reader
 	bucket                 string
@@ -61,6 +64,14 @@ func MustRegisterS3Collector(minioClient *minio.Client, client ctrlruntimeclient
 		"kubermatic_s3_empty_object_count",
 		"The amount of empty objects (size=0) partitioned by cluster",
 		[]string{"cluster"}, nil)
+	collector.ChunkCount = prometheus.NewDesc(
+		"kubermatic_s3_chunk_count",
+		"The amount of chunks of chunked backups partitioned by cluster",
+		[]string{"cluster"}, nil)
+	collector.ChunkSize = prometheus.NewDesc(
+		"kubermatic_s3_chunk_size_bytes",
+		"The total size of all chunks of chunked backups partitioned by cluster",
+		[]string{"cluster"}, nil)
 	collector.QuerySuccess = prometheus.NewDesc(
 		"kubermatic_s3_query_success",
 		"Whether querying the S3 was successful",
@@ -73,6 +84,8 @@ func (e *s3Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- e.ObjectCount
 	ch <- e.ObjectLastModifiedDate
 	ch <- e.EmptyObjectCount
+	ch <- e.ChunkCount
+	ch <- e.ChunkSize
 	ch <- e.QuerySuccess
 }
 
@@ -111,9 +124,18 @@ func (e *s3Collector) Collect(ch chan<- prometheus.Metric) {
 }
 
 func (e *s3Collector) setMetricsForCluster(ch chan<- prometheus.Metric, allObjects []minio.ObjectInfo, clusterName string) {
-	var clusterObjects []minio.ObjectInfo
+	// chunks of chunked backups are shared by many backups and are not backups by themselves,
+	// so they are accounted for separately; the manifests are counted like regular backups
+	var (
+		clusterObjects []minio.ObjectInfo
+		chunkCount     int
+		chunkSize      int64
+	)
 	for _, object := range allObjects {
-		if strings.HasPrefix(object.Key, fmt.Sprintf("%s-", clusterName)) {
+		if chunkstore.IsChunk(clusterName, object.Key) {
+			chunkCount++
+			chunkSize += object.Size
+		} else if strings.HasPrefix(object.Key, fmt.Sprintf("%s-", clusterName)) {
 			clusterObjects = append(clusterObjects, object)
 		}
 	}
@@ -133,4 +155,14 @@ func (e *s3Collector) setMetricsForCluster(ch chan<- prometheus.Metric, allObjec
 		prometheus.GaugeValue,
 		float64(getEmptyObjectCount(clusterObjects)),
 		clusterName)
+	ch <- prometheus.MustNewConstMetric(
+		e.ChunkCount,
+		prometheus.GaugeValue,
+		float64(chunkCount),
+		clusterName)
+	ch <- prometheus.MustNewConstMetric(
+		e.ChunkSize,
+		prometheus.GaugeValue,
+		float64(chunkSize),
+		clusterName)
 }
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// This controller moves user clusters between seeds.
+	ControllerName = "kkp-cluster-migration-controller"
+
+	// requeueInterval is how often the controller checks on steps that are waiting
+	// for other controllers on the seeds, like the etcd backup or restore.
+	requeueInterval = 30 * time.Second
+)
+
+type lookupFunction func(host string) ([]net.IP, error)
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	seedsGetter  provider.SeedsGetter
+	masterClient ctrlruntimeclient.Client
+	seedClients  kuberneteshelper.SeedClientMap
+	recorder     record.EventRecorder
+	// used to ease unit tests
+	lookupIP lookupFunction
+}
+
+func Add(
+	masterMgr manager.Manager,
+	seedsGetter provider.SeedsGetter,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+) error {
+	log = log.Named(ControllerName)
+	r := &reconciler{
+		log:          log,
+		seedsGetter:  seedsGetter,
+		masterClient: masterMgr.GetClient(),
+		seedClients:  kuberneteshelper.SeedClientMap{},
+		recorder:     masterMgr.GetEventRecorderFor(ControllerName),
+		lookupIP:     net.LookupIP,
+	}
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+	}
+
+	_, err := builder.ControllerManagedBy(masterMgr).
+		Named(ControllerName).
+		For(&kubermaticv1.ClusterMigration{}).
+		Build(r)
+
+	return err
+}
+
+// failure is returned by migration steps if the migration cannot continue.
+type failure struct {
+	message string
+}
+
+func (f *failure) Error() string {
+	return f.message
+}
+
+func failed(format string, args ...interface{}) error {
+	return &failure{message: fmt.Sprintf(format, args...)}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("migration", request.Name)
+	log.Debug("Processing")
+
+	migration := &kubermaticv1.ClusterMigration{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, migration); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !migration.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	switch migration.Status.Phase {
+	case kubermaticv1.ClusterMigrationPhaseCompleted, kubermaticv1.ClusterMigrationPhaseFailed:
+		return reconcile.Result{}, nil
+	}
+
+	log = log.With("cluster", migration.Spec.ClusterName)
+
+	result, err := r.reconcile(ctx, log, migration)
+
+	var f *failure
+	if errors.As(err, &f) {
+		log.Errorw("Migration failed", zap.Error(err))
+		r.recorder.Event(migration, corev1.EventTypeWarning, "MigrationFailed", f.message)
+
+		err = r.updateStatus(ctx, migration, func(m *kubermaticv1.ClusterMigration) {
+			m.Status.Phase = kubermaticv1.ClusterMigrationPhaseFailed
+			m.Status.Message = f.message
+		})
+
+		return reconcile.Result{}, err
+	}
+
+	if err != nil {
+		r.recorder.Event(migration, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+
+	return *result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	seeds, err := r.seedsGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seeds: %w", err)
+	}
+
+	if migration.Status.SourceSeed == "" {
+		if err := r.start(ctx, log, migration, seeds); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := r.newMigrationContext(migration, seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range m.steps() {
+		if migration.IsConditionTrue(step.condition) {
+			continue
+		}
+
+		stepLog := log.With("step", step.condition)
+
+		// steps record their results in the migration's status
+		original := migration.DeepCopy()
+
+		waitingFor, err := step.run(ctx, stepLog)
+		if err != nil {
+			return nil, err
+		}
+
+		status := corev1.ConditionTrue
+		reason := "Completed"
+		if waitingFor != "" {
+			status = corev1.ConditionFalse
+			reason = "InProgress"
+		}
+
+		kubermaticv1helper.SetClusterMigrationCondition(migration, step.condition, status, reason, waitingFor)
+
+		if err := r.masterClient.Status().Patch(ctx, migration, ctrlruntimeclient.MergeFrom(original)); err != nil {
+			return nil, fmt.Errorf("failed to update status: %w", err)
+		}
+
+		if waitingFor != "" {
+			stepLog.Debugw("Waiting", "reason", waitingFor)
+			return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+		}
+
+		stepLog.Info("Step completed")
+		r.recorder.Eventf(migration, corev1.EventTypeNormal, string(step.condition), "Step %s completed", step.condition)
+	}
+
+	log.Info("Cluster migration completed")
+	r.recorder.Eventf(migration, corev1.EventTypeNormal, "MigrationCompleted", "Cluster has been moved from seed %s to seed %s", migration.Status.SourceSeed, migration.Spec.TargetSeed)
+
+	return nil, r.updateStatus(ctx, migration, func(m *kubermaticv1.ClusterMigration) {
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseCompleted
+	})
+}
+
+// start validates the migration before any changes are made and determines the source seed.
+func (r *reconciler) start(ctx context.Context, log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration, seeds map[string]*kubermaticv1.Seed) error {
+	targetSeed, ok := seeds[migration.Spec.TargetSeed]
+	if !ok {
+		return failed("target seed %q does not exist", migration.Spec.TargetSeed)
+	}
+
+	targetClient, ok := r.seedClients[targetSeed.Name]
+	if !ok {
+		return fmt.Errorf("no client for seed %q available", targetSeed.Name)
+	}
+
+	var (
+		cluster    *kubermaticv1.Cluster
+		sourceSeed *kubermaticv1.Seed
+	)
+
+	err := r.seedClients.Each(ctx, log, func(seedName string, seedClient ctrlruntimeclient.Client, _ *zap.SugaredLogger) error {
+		if seedName == targetSeed.Name {
+			return nil
+		}
+
+		c := &kubermaticv1.Cluster{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Name: migration.Spec.ClusterName}, c); err != nil {
+			return ctrlruntimeclient.IgnoreNotFound(err)
+		}
+
+		if seed, ok := seeds[seedName]; ok {
+			cluster = c
+			sourceSeed = seed
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return failed("cluster %q does not exist on any seed other than %q", migration.Spec.ClusterName, targetSeed.Name)
+	}
+
+	if err := validate(migration, cluster, sourceSeed, targetSeed); err != nil {
+		return err
+	}
+
+	if err := targetClient.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err == nil {
+		return failed("a cluster named %q already exists on seed %q", cluster.Name, targetSeed.Name)
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to check for existing cluster on target seed: %w", err)
+	}
+
+	log.Infow("Starting cluster migration", "source", sourceSeed.Name, "target", targetSeed.Name)
+
+	return r.updateStatus(ctx, migration, func(m *kubermaticv1.ClusterMigration) {
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseRunning
+		m.Status.SourceSeed = sourceSeed.Name
+		m.Status.SourceURL = cluster.Status.Address.URL
+	})
+}
+
+func validate(migration *kubermaticv1.ClusterMigration, cluster *kubermaticv1.Cluster, sourceSeed, targetSeed *kubermaticv1.Seed) error {
+	if other := cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation]; other != "" && other != migration.Name {
+		return failed("cluster is already being migrated by ClusterMigration %q", other)
+	}
+
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
+		return failed("cluster does not use the etcd-launcher, which is required to restore its etcd on the target seed")
+	}
+
+	datacenter, ok := targetSeed.Spec.Datacenters[migration.Spec.TargetDatacenter]
+	if !ok {
+		return failed("datacenter %q does not exist on seed %q", migration.Spec.TargetDatacenter, targetSeed.Name)
+	}
+
+	clusterProvider, err := kubermaticv1helper.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		return failed("failed to determine cloud provider of the cluster: %v", err)
+	}
+
+	datacenterProvider, err := kubermaticv1helper.DatacenterCloudProviderName(&datacenter.Spec)
+	if err != nil {
+		return failed("failed to determine cloud provider of datacenter %q: %v", migration.Spec.TargetDatacenter, err)
+	}
+
+	if clusterProvider != datacenterProvider {
+		return failed("datacenter %q uses provider %q, but the cluster uses provider %q", migration.Spec.TargetDatacenter, datacenterProvider, clusterProvider)
+	}
+
+	// The address of the API server must stay the same, so that the worker nodes and existing
+	// kubeconfigs can reach it on the target seed once its DNS record has been updated.
+	if strategy := migration.Spec.TargetExposeStrategy; strategy != "" && strategy != cluster.Spec.ExposeStrategy {
+		return failed("changing the expose strategy from %q to %q would change the address of the API server", cluster.Spec.ExposeStrategy, strategy)
+	}
+
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
+		return failed("clusters using the %q expose strategy cannot be migrated, because their API server address is assigned by the load balancer on the seed", kubermaticv1.ExposeStrategyLoadBalancer)
+	}
+
+	if sourceDomain, targetDomain := seedSubdomain(sourceSeed), seedSubdomain(targetSeed); sourceDomain != targetDomain {
+		return failed("seeds %q and %q use different DNS subdomains (%q and %q), which would change the address of the API server", sourceSeed.Name, targetSeed.Name, sourceDomain, targetDomain)
+	}
+
+	for _, seed := range []*kubermaticv1.Seed{sourceSeed, targetSeed} {
+		if !seed.IsEtcdAutomaticBackupEnabled() {
+			return failed("etcd backups are not enabled on seed %q", seed.Name)
+		}
+
+		if _, ok := seed.Spec.EtcdBackupRestore.Destinations[migration.Spec.Destination]; !ok {
+			return failed("backup destination %q does not exist on seed %q", migration.Spec.Destination, seed.Name)
+		}
+	}
+
+	return nil
+}
+
+// seedSubdomain returns the subdomain of the seed that is part of the external name of its clusters.
+func seedSubdomain(seed *kubermaticv1.Seed) string {
+	if seed.Spec.SeedDNSOverwrite != "" {
+		return seed.Spec.SeedDNSOverwrite
+	}
+
+	return seed.Name
+}
+
+func (r *reconciler) updateStatus(ctx context.Context, migration *kubermaticv1.ClusterMigration, patch func(m *kubermaticv1.ClusterMigration)) error {
+	original := migration.DeepCopy()
+	patch(migration)
+
+	return r.masterClient.Status().Patch(ctx, migration, ctrlruntimeclient.MergeFrom(original))
+}
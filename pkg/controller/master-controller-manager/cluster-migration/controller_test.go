@@ -0,0 +1,626 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/nodeportproxy"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/test/generator"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	migrationName    = "move-it"
+	sourceSeedName   = "source"
+	targetSeedName   = "target"
+	destinationName  = "s3"
+	targetDatacenter = "regular-do1"
+	seedDNSOverwrite = "europe"
+	sourceExposeIP   = "192.0.2.1"
+	targetExposeIP   = "192.0.2.2"
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		migration     *kubermaticv1.ClusterMigration
+		sourceObjs    []ctrlruntimeclient.Object
+		targetObjs    []ctrlruntimeclient.Object
+		validate      func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, targetClient ctrlruntimeclient.Client)
+		expectedPhase kubermaticv1.ClusterMigrationPhase
+	}{
+		{
+			name: "unknown target datacenter fails the migration",
+			migration: genMigration(func(m *kubermaticv1.ClusterMigration) {
+				m.Spec.TargetDatacenter = "does-not-exist"
+			}),
+			sourceObjs:    []ctrlruntimeclient.Object{genCluster()},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseFailed,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				cluster := getCluster(t, sourceClient)
+				if cluster.Spec.Pause {
+					t.Error("Expected source cluster not to be paused.")
+				}
+			},
+		},
+		{
+			name:      "existing cluster on the target seed fails the migration",
+			migration: genMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				genCluster(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseFailed,
+		},
+		{
+			name:      "starting a migration pauses the source cluster and takes a backup",
+			migration: genMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(),
+				genApiserver(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseRunning,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				if migration.Status.SourceSeed != sourceSeedName {
+					t.Errorf("Expected source seed %q, got %q.", sourceSeedName, migration.Status.SourceSeed)
+				}
+
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionSourcePaused:    corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionBackupCompleted: corev1.ConditionFalse,
+				})
+
+				cluster := getCluster(t, sourceClient)
+				if !cluster.Spec.Pause {
+					t.Error("Expected source cluster to be paused.")
+				}
+				if cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] != migrationName {
+					t.Errorf("Expected source cluster to be annotated with the migration, got annotations %v.", cluster.Annotations)
+				}
+
+				apiserver := &appsv1.Deployment{}
+				if err := sourceClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+					t.Fatalf("Failed to get API server: %v", err)
+				}
+				if *apiserver.Spec.Replicas != 0 {
+					t.Errorf("Expected API server to be scaled down, but it has %d replicas.", *apiserver.Spec.Replicas)
+				}
+
+				backupConfig := &kubermaticv1.EtcdBackupConfig{}
+				if err := sourceClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: "migration-" + migrationName}, backupConfig); err != nil {
+					t.Fatalf("Failed to get EtcdBackupConfig: %v", err)
+				}
+				if backupConfig.Spec.Schedule != "" {
+					t.Errorf("Expected a one-shot backup, but got schedule %q.", backupConfig.Spec.Schedule)
+				}
+				if backupConfig.Spec.Destination != destinationName {
+					t.Errorf("Expected destination %q, got %q.", destinationName, backupConfig.Spec.Destination)
+				}
+			},
+		},
+		{
+			name: "completed backup is restored on the target seed",
+			migration: genMigration(func(m *kubermaticv1.ClusterMigration) {
+				m.Status.Phase = kubermaticv1.ClusterMigrationPhaseRunning
+				m.Status.SourceSeed = sourceSeedName
+				setConditions(m, kubermaticv1.ClusterMigrationConditionSourcePaused)
+			}),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+					c.Annotations = map[string]string{kubermaticv1.ClusterMigrationAnnotation: migrationName}
+				}),
+				genBackupConfig(kubermaticv1.BackupStatusPhaseCompleted),
+				genSecret(resources.CASecretName),
+				genSecret(resources.ServiceAccountKeySecretName),
+				genCredentialsSecret(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseRunning,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, _, targetClient ctrlruntimeclient.Client) {
+				if migration.Status.BackupName != "migration-"+migrationName+".db.gz" {
+					t.Errorf("Expected backup name to be recorded, got %q.", migration.Status.BackupName)
+				}
+
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionSourcePaused:      corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionBackupCompleted:   corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionTargetProvisioned: corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionEtcdRestored:      corev1.ConditionFalse,
+				})
+
+				cluster := getCluster(t, targetClient)
+				if !cluster.Spec.Pause {
+					t.Error("Expected target cluster to be created paused.")
+				}
+				if cluster.Spec.Cloud.DatacenterName != targetDatacenter {
+					t.Errorf("Expected target cluster in datacenter %q, got %q.", targetDatacenter, cluster.Spec.Cloud.DatacenterName)
+				}
+				if _, ok := cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation]; ok {
+					t.Error("Expected target cluster not to have the migration annotation.")
+				}
+				if cluster.Status.Address.AdminToken == "" {
+					t.Error("Expected admin token to be copied to the target cluster.")
+				}
+
+				for _, name := range []string{resources.CASecretName, resources.ServiceAccountKeySecretName} {
+					secret := &corev1.Secret{}
+					if err := targetClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+						t.Fatalf("Failed to get Secret %s: %v", name, err)
+					}
+					if string(secret.Data["key"]) != name {
+						t.Errorf("Expected Secret %s to be copied, got data %v.", name, secret.Data)
+					}
+				}
+
+				credentials := genCredentialsSecret()
+				if err := targetClient.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(credentials), &corev1.Secret{}); err != nil {
+					t.Errorf("Expected credentials Secret to be copied: %v", err)
+				}
+
+				restore := &kubermaticv1.EtcdRestore{}
+				if err := targetClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: "migration-" + migrationName}, restore); err != nil {
+					t.Fatalf("Failed to get EtcdRestore: %v", err)
+				}
+				if restore.Spec.BackupName != migration.Status.BackupName {
+					t.Errorf("Expected restore of backup %q, got %q.", migration.Status.BackupName, restore.Spec.BackupName)
+				}
+			},
+		},
+		{
+			name: "changing the expose strategy fails the migration",
+			migration: genMigration(func(m *kubermaticv1.ClusterMigration) {
+				m.Spec.TargetExposeStrategy = kubermaticv1.ExposeStrategyTunneling
+			}),
+			sourceObjs:    []ctrlruntimeclient.Object{genCluster()},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseFailed,
+		},
+		{
+			name:      "migration waits for the external name to point to the target seed",
+			migration: genSwitchingMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+				}),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				genTargetCluster(func(c *kubermaticv1.Cluster) {
+					c.Status.Address.IPs = []string{sourceExposeIP}
+				}),
+				genNodePortProxyService(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseRunning,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionEndpointsSwitched: corev1.ConditionFalse,
+				})
+
+				if migration.Status.TargetURL != "" {
+					t.Errorf("Expected no target URL to be recorded yet, got %q.", migration.Status.TargetURL)
+				}
+
+				getCluster(t, sourceClient)
+			},
+		},
+		{
+			name:      "migration with the Gateway expose strategy waits for the external name to resolve to the target Gateway",
+			migration: genSwitchingMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+					c.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyGateway
+				}),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				// the address of the cluster always points to the Gateway, but the DNS does not yet
+				genTargetCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyGateway
+				}),
+				genExposeGateway(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseRunning,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, _, _ ctrlruntimeclient.Client) {
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionEndpointsSwitched: corev1.ConditionFalse,
+				})
+
+				if migration.Status.TargetURL != "" {
+					t.Errorf("Expected no target URL to be recorded yet, got %q.", migration.Status.TargetURL)
+				}
+			},
+		},
+		{
+			name:      "changed API server address fails the migration",
+			migration: genSwitchingMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+				}),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				genTargetCluster(func(c *kubermaticv1.Cluster) {
+					c.Status.Address.URL = "https://new.example.com:6443"
+				}),
+				genNodePortProxyService(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseFailed,
+			validate: func(t *testing.T, _ *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				getCluster(t, sourceClient)
+			},
+		},
+		{
+			name:      "final backup is deleted before the source is cleaned up",
+			migration: genSwitchingMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+				}),
+				genBackupConfig(kubermaticv1.BackupStatusPhaseCompleted, func(c *kubermaticv1.EtcdBackupConfig) {
+					c.Finalizers = []string{"kubermatic.k8c.io/delete-all-backups"}
+				}),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				genTargetCluster(),
+				genNodePortProxyService(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseRunning,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionEndpointsSwitched: corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionSourceCleanedUp:   corev1.ConditionFalse,
+				})
+
+				backupConfig := &kubermaticv1.EtcdBackupConfig{}
+				if err := sourceClient.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(genBackupConfig("")), backupConfig); err != nil {
+					t.Fatalf("Failed to get EtcdBackupConfig: %v", err)
+				}
+				if backupConfig.DeletionTimestamp == nil {
+					t.Error("Expected the EtcdBackupConfig of the migration to be deleted.")
+				}
+				if len(backupConfig.Finalizers) == 0 {
+					t.Error("Expected the EtcdBackupConfig of the migration to keep its finalizers, so that the backup is deleted.")
+				}
+
+				getCluster(t, sourceClient)
+			},
+		},
+		{
+			name:      "source is cleaned up once the cluster is running on the target seed",
+			migration: genSwitchingMigration(),
+			sourceObjs: []ctrlruntimeclient.Object{
+				genCluster(func(c *kubermaticv1.Cluster) {
+					c.Spec.Pause = true
+					c.Finalizers = []string{kubermaticv1.NodeDeletionFinalizer, kubermaticv1.InClusterLBCleanupFinalizer}
+				}),
+				genBackupConfig(kubermaticv1.BackupStatusPhaseCompleted, func(c *kubermaticv1.EtcdBackupConfig) {
+					c.Name = "daily"
+					c.Finalizers = []string{"kubermatic.k8c.io/delete-all-backups"}
+				}),
+				genCredentialsSecret(),
+			},
+			targetObjs: []ctrlruntimeclient.Object{
+				genTargetCluster(),
+				genNodePortProxyService(),
+			},
+			expectedPhase: kubermaticv1.ClusterMigrationPhaseCompleted,
+			validate: func(t *testing.T, migration *kubermaticv1.ClusterMigration, sourceClient, _ ctrlruntimeclient.Client) {
+				if migration.Status.TargetURL != genCluster().Status.Address.URL {
+					t.Errorf("Expected target URL to be recorded, got %q.", migration.Status.TargetURL)
+				}
+
+				expectConditions(t, migration, map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus{
+					kubermaticv1.ClusterMigrationConditionEndpointsSwitched: corev1.ConditionTrue,
+					kubermaticv1.ClusterMigrationConditionSourceCleanedUp:   corev1.ConditionTrue,
+				})
+
+				err := sourceClient.Get(context.Background(), types.NamespacedName{Name: generator.DefaultClusterID}, &kubermaticv1.Cluster{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Expected source cluster to be deleted, got %v.", err)
+				}
+
+				err = sourceClient.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(genCredentialsSecret()), &corev1.Secret{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Expected credentials Secret on the source seed to be deleted, got %v.", err)
+				}
+
+				backupConfig := &kubermaticv1.EtcdBackupConfig{}
+				key := types.NamespacedName{Namespace: genCluster().Status.NamespaceName, Name: "daily"}
+				if err := sourceClient.Get(context.Background(), key, backupConfig); err != nil {
+					t.Fatalf("Failed to get EtcdBackupConfig: %v", err)
+				}
+				if len(backupConfig.Finalizers) > 0 {
+					t.Errorf("Expected finalizers of the EtcdBackupConfig to be removed to keep the backups, got %v.", backupConfig.Finalizers)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			masterClient := fake.NewClientBuilder().WithObjects(tc.migration).Build()
+			sourceClient := fake.NewClientBuilder().WithObjects(tc.sourceObjs...).Build()
+			targetClient := fake.NewClientBuilder().WithObjects(tc.targetObjs...).Build()
+
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				recorder:     &record.FakeRecorder{},
+				masterClient: masterClient,
+				seedsGetter: func() (map[string]*kubermaticv1.Seed, error) {
+					return map[string]*kubermaticv1.Seed{
+						sourceSeedName: genSeed(sourceSeedName),
+						targetSeedName: genSeed(targetSeedName),
+					}, nil
+				},
+				seedClients: map[string]ctrlruntimeclient.Client{
+					sourceSeedName: sourceClient,
+					targetSeedName: targetClient,
+				},
+				// the external names still resolve to the source seed
+				lookupIP: func(string) ([]net.IP, error) {
+					return []net.IP{net.ParseIP(sourceExposeIP)}, nil
+				},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: migrationName}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			migration := &kubermaticv1.ClusterMigration{}
+			if err := masterClient.Get(ctx, request.NamespacedName, migration); err != nil {
+				t.Fatalf("Failed to get ClusterMigration: %v", err)
+			}
+
+			if migration.Status.Phase != tc.expectedPhase {
+				t.Fatalf("Expected phase %q, got %q (message: %q).", tc.expectedPhase, migration.Status.Phase, migration.Status.Message)
+			}
+
+			if tc.validate != nil {
+				tc.validate(t, migration, sourceClient, targetClient)
+			}
+		})
+	}
+}
+
+func expectConditions(t *testing.T, migration *kubermaticv1.ClusterMigration, expected map[kubermaticv1.ClusterMigrationConditionType]corev1.ConditionStatus) {
+	t.Helper()
+
+	for conditionType, status := range expected {
+		if actual := migration.Status.Conditions[conditionType].Status; actual != status {
+			t.Errorf("Expected condition %s to be %q, got %q.", conditionType, status, actual)
+		}
+	}
+}
+
+func getCluster(t *testing.T, client ctrlruntimeclient.Client) *kubermaticv1.Cluster {
+	t.Helper()
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: generator.DefaultClusterID}, cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	return cluster
+}
+
+func setConditions(migration *kubermaticv1.ClusterMigration, conditionTypes ...kubermaticv1.ClusterMigrationConditionType) {
+	for _, conditionType := range conditionTypes {
+		kubermaticv1helper.SetClusterMigrationCondition(migration, conditionType, corev1.ConditionTrue, "Completed", "")
+	}
+}
+
+func genMigration(modifiers ...func(*kubermaticv1.ClusterMigration)) *kubermaticv1.ClusterMigration {
+	migration := &kubermaticv1.ClusterMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: migrationName,
+		},
+		Spec: kubermaticv1.ClusterMigrationSpec{
+			ClusterName:      generator.DefaultClusterID,
+			TargetSeed:       targetSeedName,
+			TargetDatacenter: targetDatacenter,
+			Destination:      destinationName,
+		},
+	}
+
+	for _, modifier := range modifiers {
+		modifier(migration)
+	}
+
+	return migration
+}
+
+// genSwitchingMigration returns a migration that has restored etcd on the target seed.
+func genSwitchingMigration() *kubermaticv1.ClusterMigration {
+	return genMigration(func(m *kubermaticv1.ClusterMigration) {
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseRunning
+		m.Status.SourceSeed = sourceSeedName
+		m.Status.SourceURL = genCluster().Status.Address.URL
+		setConditions(m,
+			kubermaticv1.ClusterMigrationConditionSourcePaused,
+			kubermaticv1.ClusterMigrationConditionBackupCompleted,
+			kubermaticv1.ClusterMigrationConditionTargetProvisioned,
+			kubermaticv1.ClusterMigrationConditionEtcdRestored,
+		)
+	})
+}
+
+func genSeed(name string) *kubermaticv1.Seed {
+	return generator.GenTestSeed(func(seed *kubermaticv1.Seed) {
+		seed.Name = name
+		seed.Spec.SeedDNSOverwrite = seedDNSOverwrite
+		seed.Spec.EtcdBackupRestore = &kubermaticv1.EtcdBackupRestore{
+			Destinations: map[string]*kubermaticv1.BackupDestination{
+				destinationName: {
+					Endpoint:   "s3.example.com",
+					BucketName: "backups",
+				},
+			},
+		}
+	})
+}
+
+func genCluster(modifiers ...func(*kubermaticv1.Cluster)) *kubermaticv1.Cluster {
+	cluster := generator.GenDefaultCluster()
+	cluster.Spec.Features = map[string]bool{
+		kubermaticv1.ClusterFeatureEtcdLauncher: true,
+	}
+	cluster.Spec.Cloud = kubermaticv1.CloudSpec{
+		DatacenterName: "private-do1",
+		ProviderName:   string(kubermaticv1.DigitaloceanCloudProvider),
+		Digitalocean:   &kubermaticv1.DigitaloceanCloudSpec{},
+	}
+	cluster.Spec.Cloud.Digitalocean.CredentialsReference = &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{
+			Namespace: resources.KubermaticNamespace,
+			Name:      cluster.GetSecretName(),
+		},
+	}
+
+	for _, modifier := range modifiers {
+		modifier(cluster)
+	}
+
+	return cluster
+}
+
+// genTargetCluster returns the cluster on the target seed, whose external name already resolves
+// to the target seed.
+func genTargetCluster(modifiers ...func(*kubermaticv1.Cluster)) *kubermaticv1.Cluster {
+	return genCluster(append([]func(*kubermaticv1.Cluster){func(c *kubermaticv1.Cluster) {
+		c.Spec.Cloud.DatacenterName = targetDatacenter
+		c.Status.Address.IPs = []string{targetExposeIP}
+	}}, modifiers...)...)
+}
+
+func genNodePortProxyService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeportproxy.ServiceName,
+			Namespace: genSeed(targetSeedName).Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: targetExposeIP}},
+			},
+		},
+	}
+}
+
+func genExposeGateway() *gatewayapiv1.Gateway {
+	return &gatewayapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exposegateway.GatewayName,
+			Namespace: genSeed(targetSeedName).Namespace,
+		},
+		Status: gatewayapiv1.GatewayStatus{
+			Addresses: []gatewayapiv1.GatewayStatusAddress{{
+				Type:  ptr.To(gatewayapiv1.IPAddressType),
+				Value: targetExposeIP,
+			}},
+		},
+	}
+}
+
+func genApiserver() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.ApiserverDeploymentName,
+			Namespace: genCluster().Status.NamespaceName,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+		},
+	}
+}
+
+func genSecret(name string) *corev1.Secret {
+	return genSecretIn(genCluster().Status.NamespaceName, name)
+}
+
+func genCredentialsSecret() *corev1.Secret {
+	return genSecretIn(resources.KubermaticNamespace, genCluster().GetSecretName())
+}
+
+func genSecretIn(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"key": []byte(name),
+		},
+	}
+}
+
+func genBackupConfig(phase kubermaticv1.BackupStatusPhase, modifiers ...func(*kubermaticv1.EtcdBackupConfig)) *kubermaticv1.EtcdBackupConfig {
+	backupName := "migration-" + migrationName
+
+	config := &kubermaticv1.EtcdBackupConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupName,
+			Namespace: genCluster().Status.NamespaceName,
+		},
+		Spec: kubermaticv1.EtcdBackupConfigSpec{
+			Name:        backupName,
+			Destination: destinationName,
+		},
+		Status: kubermaticv1.EtcdBackupConfigStatus{
+			CurrentBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:      backupName + ".db.gz",
+					BackupPhase:     phase,
+					BackupStartTime: metav1.NewTime(time.Now()),
+				},
+			},
+		},
+	}
+
+	for _, modifier := range modifiers {
+		modifier(config)
+	}
+
+	return config
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package clustermigration contains a controller that moves user clusters between seeds, as
+requested by ClusterMigration objects on the master cluster.
+
+The cluster on the source seed is paused and its API server is scaled down, so etcd cannot
+change anymore. A final etcd backup is taken and the Cluster object, its namespace and the
+secrets holding its identity (CAs, service account key, tokens, etc.) are recreated on the
+target seed, where the backup is restored into the new etcd.
+
+Worker nodes are not touched, so the address of the API server must not change: clusters using
+the LoadBalancer expose strategy cannot be migrated, the expose strategy cannot be changed and both
+seeds must use the same DNS subdomain (see seedDNSOverwrite). Once the API server on the target
+seed is healthy, the migration waits until the external name of the cluster resolves to the
+expose address of the target seed, which usually means adding a DNS record for it. Only then the
+final backup is deleted and the cluster is removed from the source seed without deleting its
+cloud resources or regular backups.
+
+A failed migration leaves the source cluster paused; it has to be unpaused manually.
+*/
+package clustermigration
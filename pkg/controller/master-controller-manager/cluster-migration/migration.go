@@ -0,0 +1,691 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/nodeportproxy"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// identitySecrets are the secrets in the cluster namespace that cannot be regenerated on the
+// target seed without breaking the user cluster: the worker nodes trust the CAs, existing service
+// account tokens are signed with the service account key and the data in etcd might be encrypted.
+var identitySecrets = []string{
+	resources.CASecretName,
+	resources.FrontProxyCASecretName,
+	resources.ServiceAccountKeySecretName,
+	resources.EncryptionConfigurationSecretName,
+	resources.OpenVPNCASecretName,
+	resources.TokensSecretName,
+	resources.ViewerTokenSecretName,
+}
+
+type step struct {
+	condition kubermaticv1.ClusterMigrationConditionType
+	// run performs the step. If the step is not done yet, it returns a message describing
+	// what it is waiting for.
+	run func(ctx context.Context, log *zap.SugaredLogger) (string, error)
+}
+
+type migrationContext struct {
+	migration    *kubermaticv1.ClusterMigration
+	targetSeed   *kubermaticv1.Seed
+	sourceClient ctrlruntimeclient.Client
+	targetClient ctrlruntimeclient.Client
+	lookupIP     lookupFunction
+}
+
+func (r *reconciler) newMigrationContext(migration *kubermaticv1.ClusterMigration, seeds map[string]*kubermaticv1.Seed) (*migrationContext, error) {
+	m := &migrationContext{
+		migration: migration,
+		lookupIP:  r.lookupIP,
+	}
+
+	for _, seedName := range []string{migration.Status.SourceSeed, migration.Spec.TargetSeed} {
+		if _, ok := seeds[seedName]; !ok {
+			return nil, failed("seed %q does not exist anymore", seedName)
+		}
+
+		if _, ok := r.seedClients[seedName]; !ok {
+			return nil, fmt.Errorf("no client for seed %q available", seedName)
+		}
+	}
+
+	m.targetSeed = seeds[migration.Spec.TargetSeed]
+	m.sourceClient = r.seedClients[migration.Status.SourceSeed]
+	m.targetClient = r.seedClients[migration.Spec.TargetSeed]
+
+	return m, nil
+}
+
+func (m *migrationContext) steps() []step {
+	return []step{
+		{condition: kubermaticv1.ClusterMigrationConditionSourcePaused, run: m.pauseSource},
+		{condition: kubermaticv1.ClusterMigrationConditionBackupCompleted, run: m.backupSource},
+		{condition: kubermaticv1.ClusterMigrationConditionTargetProvisioned, run: m.provisionTarget},
+		{condition: kubermaticv1.ClusterMigrationConditionEtcdRestored, run: m.restoreTarget},
+		{condition: kubermaticv1.ClusterMigrationConditionEndpointsSwitched, run: m.switchEndpoints},
+		{condition: kubermaticv1.ClusterMigrationConditionSourceCleanedUp, run: m.cleanupSource},
+	}
+}
+
+// migrationResourceName is the name of the EtcdBackupConfig and EtcdRestore used to move etcd.
+func (m *migrationContext) migrationResourceName() string {
+	return fmt.Sprintf("migration-%s", m.migration.Name)
+}
+
+func (m *migrationContext) getSourceCluster(ctx context.Context) (*kubermaticv1.Cluster, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := m.sourceClient.Get(ctx, types.NamespacedName{Name: m.migration.Spec.ClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, failed("cluster %q does not exist on seed %q anymore", m.migration.Spec.ClusterName, m.migration.Status.SourceSeed)
+		}
+		return nil, fmt.Errorf("failed to get source cluster: %w", err)
+	}
+
+	return cluster, nil
+}
+
+func (m *migrationContext) getTargetCluster(ctx context.Context) (*kubermaticv1.Cluster, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := m.targetClient.Get(ctx, types.NamespacedName{Name: m.migration.Spec.ClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, failed("cluster %q does not exist on seed %q anymore", m.migration.Spec.ClusterName, m.migration.Spec.TargetSeed)
+		}
+		return nil, fmt.Errorf("failed to get target cluster: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// pauseSource pauses the cluster on the source seed, so that its controllers do not interfere
+// anymore, and scales down its API server, so that etcd cannot change after the final backup.
+func (m *migrationContext) pauseSource(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	cluster, err := m.getSourceCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !cluster.Spec.Pause || cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] != m.migration.Name {
+		log.Info("Pausing source cluster")
+
+		oldCluster := cluster.DeepCopy()
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] = m.migration.Name
+		cluster.Spec.Pause = true
+
+		if err := m.sourceClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return "", fmt.Errorf("failed to pause source cluster: %w", err)
+		}
+	}
+
+	apiserver := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := m.sourceClient.Get(ctx, key, apiserver); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get API server deployment: %w", err)
+	}
+
+	if apiserver.Spec.Replicas == nil || *apiserver.Spec.Replicas != 0 {
+		log.Info("Scaling down source API server")
+
+		oldApiserver := apiserver.DeepCopy()
+		apiserver.Spec.Replicas = ptr.To[int32](0)
+
+		if err := m.sourceClient.Patch(ctx, apiserver, ctrlruntimeclient.MergeFrom(oldApiserver)); err != nil {
+			return "", fmt.Errorf("failed to scale down API server: %w", err)
+		}
+	}
+
+	if apiserver.Status.Replicas > 0 {
+		return "waiting for the API server to shut down", nil
+	}
+
+	return "", nil
+}
+
+// backupSource takes the final etcd backup on the source seed using a one-shot EtcdBackupConfig.
+func (m *migrationContext) backupSource(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	cluster, err := m.getSourceCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: m.migrationResourceName()}
+
+	if err := m.sourceClient.Get(ctx, key, backupConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get EtcdBackupConfig: %w", err)
+		}
+
+		log.Info("Creating final etcd backup")
+
+		backupConfig = &kubermaticv1.EtcdBackupConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					kubermaticv1.ProjectIDLabelKey: cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+				},
+			},
+			Spec: kubermaticv1.EtcdBackupConfigSpec{
+				Name:        key.Name,
+				Cluster:     clusterReference(cluster),
+				Destination: m.migration.Spec.Destination,
+			},
+		}
+
+		if err := m.sourceClient.Create(ctx, backupConfig); err != nil {
+			return "", fmt.Errorf("failed to create EtcdBackupConfig: %w", err)
+		}
+	}
+
+	if len(backupConfig.Status.CurrentBackups) == 0 {
+		return "waiting for the backup to be scheduled", nil
+	}
+
+	backup := backupConfig.Status.CurrentBackups[0]
+
+	switch backup.BackupPhase {
+	case kubermaticv1.BackupStatusPhaseCompleted:
+		m.migration.Status.BackupName = backup.BackupName
+		return "", nil
+	case kubermaticv1.BackupStatusPhaseFailed:
+		return "", failed("backup %s failed: %s", backup.BackupName, backup.BackupMessage)
+	default:
+		return fmt.Sprintf("waiting for backup %s to complete", backup.BackupName), nil
+	}
+}
+
+// provisionTarget recreates the Cluster object on the target seed, along with its namespace and all
+// secrets that make up the cluster's identity. The cluster is created paused; the EtcdRestore will
+// unpause it once the etcd StatefulSet can be created.
+func (m *migrationContext) provisionTarget(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	source, err := m.getSourceCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// the credentials must exist before the cluster can be created
+	credentials, err := resources.GetCredentialsReference(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cloud credentials: %w", err)
+	}
+
+	if credentials != nil && credentials.Name != "" {
+		if err := m.copySecret(ctx, types.NamespacedName{Namespace: credentials.Namespace, Name: credentials.Name}, nil); err != nil {
+			return "", err
+		}
+	}
+
+	target := &kubermaticv1.Cluster{}
+	if err := m.targetClient.Get(ctx, types.NamespacedName{Name: source.Name}, target); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get target cluster: %w", err)
+		}
+
+		log.Info("Creating cluster on target seed")
+
+		target = m.targetCluster(source)
+		if err := m.targetClient.Create(ctx, target); err != nil {
+			return "", fmt.Errorf("failed to create target cluster: %w", err)
+		}
+	}
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, m.targetClient, target, func(c *kubermaticv1.Cluster) {
+		c.Status.NamespaceName = source.Status.NamespaceName
+		c.Status.UserEmail = source.Status.UserEmail
+		c.Status.Address.AdminToken = source.Status.Address.AdminToken
+	}); err != nil {
+		return "", fmt.Errorf("failed to update target cluster status: %w", err)
+	}
+
+	ownerRef := *metav1.NewControllerRef(target, kubermaticv1.SchemeGroupVersion.WithKind(kubermaticv1.ClusterKindName))
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            source.Status.NamespaceName,
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+	}
+	if err := m.targetClient.Create(ctx, namespace); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+		return "", fmt.Errorf("failed to create cluster namespace: %w", err)
+	}
+
+	for _, name := range identitySecrets {
+		key := types.NamespacedName{Namespace: source.Status.NamespaceName, Name: name}
+		if err := m.copySecret(ctx, key, &ownerRef); err != nil {
+			return "", err
+		}
+	}
+
+	// the data key is needed to decrypt the backup if the destination uses encryption
+	dataKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backupcrypto.DataKeySecretName(source)}
+	if err := m.copySecret(ctx, dataKey, &ownerRef); err != nil {
+		return "", err
+	}
+
+	if err := m.copyApiserverService(ctx, source.Status.NamespaceName, &ownerRef); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// copyApiserverService creates the API server's Service on the target seed with the same
+// ports as on the source seed. The port is part of the API server's address, which must not
+// change, and the Service reconciler on the target seed keeps the node port once it is set.
+func (m *migrationContext) copyApiserverService(ctx context.Context, namespace string, ownerRef *metav1.OwnerReference) error {
+	service := &corev1.Service{}
+	key := types.NamespacedName{Namespace: namespace, Name: resources.ApiserverServiceName}
+	if err := m.sourceClient.Get(ctx, key, service); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	copied := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            service.Name,
+			Namespace:       service.Namespace,
+			Labels:          service.Labels,
+			Annotations:     service.Annotations,
+			OwnerReferences: []metav1.OwnerReference{*ownerRef},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     service.Spec.Type,
+			Selector: service.Spec.Selector,
+			Ports:    service.Spec.Ports,
+		},
+	}
+
+	err := m.targetClient.Create(ctx, copied)
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		return nil
+	case apierrors.IsInvalid(err):
+		// most likely the node port is already allocated on the target seed
+		return failed("failed to recreate the API server Service with the same ports on seed %q: %v", m.targetSeed.Name, err)
+	case err != nil:
+		return fmt.Errorf("failed to copy Service %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// targetCluster returns the Cluster object to create on the target seed.
+func (m *migrationContext) targetCluster(source *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	target := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	for k, v := range source.Labels {
+		// the worker name is specific to the controllers of the source seed
+		if k != kubermaticv1.WorkerNameLabelKey {
+			target.Labels[k] = v
+		}
+	}
+
+	for k, v := range source.Annotations {
+		if k != kubermaticv1.ClusterMigrationAnnotation {
+			target.Annotations[k] = v
+		}
+	}
+
+	target.Spec.Pause = true
+	target.Spec.Cloud.DatacenterName = m.migration.Spec.TargetDatacenter
+
+	if m.migration.Spec.TargetExposeStrategy != "" {
+		target.Spec.ExposeStrategy = m.migration.Spec.TargetExposeStrategy
+	}
+
+	return target
+}
+
+// copySecret copies a secret from the source to the target seed, if it exists.
+func (m *migrationContext) copySecret(ctx context.Context, key types.NamespacedName, ownerRef *metav1.OwnerReference) error {
+	secret := &corev1.Secret{}
+	if err := m.sourceClient.Get(ctx, key, secret); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+
+	if ownerRef != nil {
+		copied.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+	}
+
+	if err := m.targetClient.Create(ctx, copied); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("failed to copy Secret %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// restoreTarget restores the final backup into the etcd of the cluster on the target seed.
+func (m *migrationContext) restoreTarget(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	cluster, err := m.getTargetCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	restore := &kubermaticv1.EtcdRestore{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: m.migrationResourceName()}
+
+	if err := m.targetClient.Get(ctx, key, restore); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get EtcdRestore: %w", err)
+		}
+
+		log.Infow("Restoring etcd on target seed", "backup", m.migration.Status.BackupName)
+
+		restore = &kubermaticv1.EtcdRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: kubermaticv1.EtcdRestoreSpec{
+				Name:        key.Name,
+				Cluster:     clusterReference(cluster),
+				BackupName:  m.migration.Status.BackupName,
+				Destination: m.migration.Spec.Destination,
+			},
+		}
+
+		if err := m.targetClient.Create(ctx, restore); err != nil {
+			return "", fmt.Errorf("failed to create EtcdRestore: %w", err)
+		}
+	}
+
+	switch restore.Status.Phase {
+	case kubermaticv1.EtcdRestorePhaseCompleted:
+		return "", nil
+	case kubermaticv1.EtcdRestorePhaseEtcdLauncherNotEnabled:
+		return "", failed("etcd cannot be restored because the etcd-launcher is not enabled")
+	default:
+		return fmt.Sprintf("waiting for the etcd restore to complete (phase %q)", restore.Status.Phase), nil
+	}
+}
+
+// switchEndpoints waits until the API server on the target seed is healthy and its address
+// points to the target seed. The address is never changed by the migration, as worker nodes and
+// kubeconfigs would not be able to reach the API server anymore. Instead, the external name of the
+// cluster has to be resolved to the expose address of the target seed (e.g. by adding a DNS record
+// for it) before the source is cleaned up.
+func (m *migrationContext) switchEndpoints(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	cluster, err := m.getTargetCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if cluster.Status.Address.URL == "" {
+		return "waiting for the API server to be exposed", nil
+	}
+
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return "waiting for the API server to become healthy", nil
+	}
+
+	if cluster.Status.Address.URL != m.migration.Status.SourceURL {
+		return "", failed("API server address on seed %q is %q instead of %q; the cluster on seed %q has been kept", m.targetSeed.Name, cluster.Status.Address.URL, m.migration.Status.SourceURL, m.migration.Status.SourceSeed)
+	}
+
+	exposeIPs, err := m.targetExposeIPs(ctx, cluster)
+	if err != nil {
+		return "", err
+	}
+
+	if len(exposeIPs) == 0 {
+		return fmt.Sprintf("waiting for seed %q to be assigned an expose address", m.targetSeed.Name), nil
+	}
+
+	resolvedIPs, err := m.resolvedExternalIPs(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.ContainsFunc(resolvedIPs, func(ip string) bool { return slices.Contains(exposeIPs, ip) }) {
+		return fmt.Sprintf("waiting for %s to resolve to the expose address of seed %q (%v), currently it resolves to %v", cluster.Status.Address.ExternalName, m.targetSeed.Name, exposeIPs, resolvedIPs), nil
+	}
+
+	log.Infow("API server is reachable on target seed", "url", cluster.Status.Address.URL)
+
+	m.migration.Status.TargetURL = cluster.Status.Address.URL
+
+	return "", nil
+}
+
+// resolvedExternalIPs returns the IPs the external name of the cluster currently resolves to.
+// For the Gateway expose strategy, the address of the cluster is taken from the Gateway instead
+// of the DNS, so the external name has to be resolved here.
+func (m *migrationContext) resolvedExternalIPs(cluster *kubermaticv1.Cluster) ([]string, error) {
+	if cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway {
+		return cluster.Status.Address.IPs, nil
+	}
+
+	resolved, err := m.lookupIP(cluster.Status.Address.ExternalName)
+	if err != nil {
+		// The DNS record might not exist yet.
+		return nil, nil
+	}
+
+	var ips []string
+	for _, ip := range resolved {
+		ips = append(ips, ip.String())
+	}
+
+	return ips, nil
+}
+
+// targetExposeIPs returns the IPs under which the target seed exposes the API servers of its
+// clusters, i.e. the addresses of the expose Gateway or the nodeport-proxy's LoadBalancer.
+func (m *migrationContext) targetExposeIPs(ctx context.Context, cluster *kubermaticv1.Cluster) ([]string, error) {
+	var addresses []string
+
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		gateway := &gatewayapiv1.Gateway{}
+		key := types.NamespacedName{Namespace: m.targetSeed.Namespace, Name: exposegateway.GatewayName}
+		if err := m.targetClient.Get(ctx, key, gateway); err != nil {
+			return nil, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+
+		for _, address := range gateway.Status.Addresses {
+			addresses = append(addresses, address.Value)
+		}
+	} else {
+		service := &corev1.Service{}
+		key := types.NamespacedName{Namespace: m.targetSeed.Namespace, Name: nodeportproxy.ServiceName}
+		if err := m.targetClient.Get(ctx, key, service); err != nil {
+			return nil, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			} else if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+	}
+
+	var ips []string
+	for _, address := range addresses {
+		if net.ParseIP(address) != nil {
+			ips = append(ips, address)
+			continue
+		}
+
+		resolved, err := m.lookupIP(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve expose address %q: %w", address, err)
+		}
+
+		for _, ip := range resolved {
+			ips = append(ips, ip.String())
+		}
+	}
+
+	return ips, nil
+}
+
+// cleanupSource removes the cluster from the source seed. The EtcdBackupConfig of the migration
+// is deleted first, including the final backup. The finalizers of everything else are removed
+// beforehand, so that neither the cloud resources nor the nodes of the cluster nor its regular
+// backups are deleted.
+func (m *migrationContext) cleanupSource(ctx context.Context, log *zap.SugaredLogger) (string, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := m.sourceClient.Get(ctx, types.NamespacedName{Name: m.migration.Spec.ClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get source cluster: %w", err)
+	}
+
+	namespace := cluster.Status.NamespaceName
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	key := types.NamespacedName{Namespace: namespace, Name: m.migrationResourceName()}
+
+	if err := m.sourceClient.Get(ctx, key, backupConfig); err == nil {
+		if backupConfig.DeletionTimestamp == nil {
+			log.Info("Deleting final etcd backup")
+
+			if err := m.sourceClient.Delete(ctx, backupConfig); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return "", fmt.Errorf("failed to delete EtcdBackupConfig: %w", err)
+			}
+		}
+
+		return "waiting for the final etcd backup to be deleted", nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get EtcdBackupConfig: %w", err)
+	}
+
+	// these objects would otherwise block the deletion of the namespace, or their finalizers
+	// would delete the backups
+	lists := []ctrlruntimeclient.ObjectList{
+		&kubermaticv1.EtcdBackupConfigList{},
+		&kubermaticv1.EtcdRestoreList{},
+		&kubermaticv1.AddonList{},
+	}
+
+	for _, list := range lists {
+		if err := m.sourceClient.List(ctx, list, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+			return "", fmt.Errorf("failed to list %T: %w", list, err)
+		}
+
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			return removeFinalizers(ctx, m.sourceClient, obj.(ctrlruntimeclient.Object))
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	log.Info("Deleting cluster from source seed")
+
+	if err := removeFinalizers(ctx, m.sourceClient, cluster); err != nil {
+		return "", err
+	}
+
+	if err := m.sourceClient.Delete(ctx, cluster); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return "", fmt.Errorf("failed to delete source cluster: %w", err)
+	}
+
+	if namespace != "" {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if err := m.sourceClient.Delete(ctx, ns); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to delete cluster namespace: %w", err)
+		}
+	}
+
+	// the credentials secret is only deleted if it was created for this cluster
+	credentials, err := resources.GetCredentialsReference(cluster)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cloud credentials: %w", err)
+	}
+
+	if credentials != nil && credentials.Name == cluster.GetSecretName() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: credentials.Namespace, Name: credentials.Name}}
+		if err := m.sourceClient.Delete(ctx, secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to delete credentials secret: %w", err)
+		}
+	}
+
+	return "", nil
+}
+
+func removeFinalizers(ctx context.Context, client ctrlruntimeclient.Client, obj ctrlruntimeclient.Object) error {
+	if len(obj.GetFinalizers()) == 0 {
+		return nil
+	}
+
+	oldObj := obj.DeepCopyObject().(ctrlruntimeclient.Object)
+	obj.SetFinalizers(nil)
+
+	if err := client.Patch(ctx, obj, ctrlruntimeclient.MergeFrom(oldObj)); err != nil {
+		return fmt.Errorf("failed to remove finalizers from %T %s: %w", obj, ctrlruntimeclient.ObjectKeyFromObject(obj), err)
+	}
+
+	return nil
+}
+
+func clusterReference(cluster *kubermaticv1.Cluster) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:       kubermaticv1.ClusterKindName,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+		APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+	}
+}
@@ -52,6 +52,9 @@ const (
 	defaultAlertmanagerConfigSecretName = "alertmanager"
 
 	secretV1Kind = "Secret"
+
+	// etcdRestoreStatusKindName is used to name the etcd-launcher's Role for the EtcdRestore status subresource.
+	etcdRestoreStatusKindName = "EtcdRestoreStatus"
 )
 
 // AllGroupsPrefixes holds a list of groups with prefixes that we will generate RBAC Roles/Binding for.
@@ -568,7 +568,7 @@ func (c *resourcesController) ensureRBACRoleBindingForClusterAddons(ctx context.
 	return nil
 }
 
-func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context, cluster *kubermaticv1.Cluster, resourceName string, groupName string, kindName string) error {
+func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context, cluster *kubermaticv1.Cluster, verbs []string, resourceName string, groupName string, kindName string) error {
 	var roleList rbacv1.RoleList
 	opts := &ctrlruntimeclient.ListOptions{Namespace: cluster.Status.NamespaceName}
 	if err := c.client.List(ctx, &roleList, opts); err != nil {
@@ -577,7 +577,7 @@ func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context,
 
 	generatedRole, err := generateRBACRoleForClusterNamespaceResourceAndServiceAccount(
 		cluster,
-		[]string{"get", "list"},
+		verbs,
 		EtcdLauncherServiceAccountName,
 		resourceName,
 		groupName,
@@ -1198,25 +1198,39 @@ func (c *resourcesController) ensureRBACForEtcdLauncher(ctx context.Context, cli
 	if err := c.ensureClusterRBACRoleBindingForEtcdLauncher(ctx, fmt.Sprintf("cluster-%s-ca-bundle", cluster.Name), "Configmap", cluster.Status.NamespaceName, projectName, cluster); err != nil {
 		return fmt.Errorf("failed to sync RBAC ClusterRoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
-	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, kubermaticv1.EtcdRestoreResourceName, kubermaticv1.GroupName, kubermaticv1.EtcdRestoreKindName); err != nil {
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get", "list"}, kubermaticv1.EtcdRestoreResourceName, kubermaticv1.GroupName, kubermaticv1.EtcdRestoreKindName); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, kubermaticv1.EtcdRestoreKindName); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC ClusterRoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
-	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, "secrets", "", "Secret"); err != nil {
+	// the etcd-launcher reports the progress of point-in-time restores in the EtcdRestore status
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get", "patch", "update"}, kubermaticv1.EtcdRestoreResourceName+"/status", kubermaticv1.GroupName, etcdRestoreStatusKindName); err != nil {
+		return fmt.Errorf("failed to sync etcd restore status RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
+	}
+	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, etcdRestoreStatusKindName); err != nil {
+		return fmt.Errorf("failed to sync etcd restore status RBAC RoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
+	}
+	// the WAL archiver prunes segments that are no longer needed by the EtcdBackupConfig's backups
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get"}, kubermaticv1.EtcdBackupConfigResourceName, kubermaticv1.GroupName, kubermaticv1.EtcdBackupConfigKindName); err != nil {
+		return fmt.Errorf("failed to sync etcd backup config RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
+	}
+	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, kubermaticv1.EtcdBackupConfigKindName); err != nil {
+		return fmt.Errorf("failed to sync etcd backup config RBAC RoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
+	}
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get", "list"}, "secrets", "", "Secret"); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, "Secret"); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC RoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
-	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, "pods", "", "Pod"); err != nil {
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get", "list"}, "pods", "", "Pod"); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, "Pod"); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC RoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
-	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, "statefulsets", "apps", "StatefulSet"); err != nil {
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, []string{"get", "list"}, "statefulsets", "apps", "StatefulSet"); err != nil {
 		return fmt.Errorf("failed to sync etcd launcher RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, "StatefulSet"); err != nil {
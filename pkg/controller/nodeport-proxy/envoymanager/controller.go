@@ -19,9 +19,8 @@ package envoymanager
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"sync"
 
-	semverlib "github.com/Masterminds/semver/v3"
 	"go.uber.org/zap"
 
 	envoycachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
@@ -63,6 +62,23 @@ type Options struct {
 	// When the value is less or equal than 0 the HTTP/2 CONNECT Listener is
 	// disabled and won't be configured in Envoy.
 	EnvoyTunnelingListenerPort int
+
+	// MaxConnections is the maximum number of concurrent connections to each
+	// exposed Service port. When the value is less or equal than 0 Envoy's
+	// default circuit breaking thresholds apply.
+	MaxConnections int
+	// ConnectionRate is the number of new connections per second accepted for
+	// all Services of a namespace (i.e. a user cluster).
+	// When the value is less or equal than 0 rate limiting is disabled.
+	ConnectionRate int
+	// ConnectionBurst is the number of new connections that can be accepted at
+	// once. When the value is less or equal than 0 ConnectionRate is used.
+	ConnectionBurst int
+
+	// IPFamilies are the IP families the listeners are bound for. Endpoints
+	// of other IP families are not used, as Envoy would not be able to reach
+	// them. Defaults to IPv4.
+	IPFamilies []corev1.IPFamily
 }
 
 func (o Options) IsSNIEnabled() bool {
@@ -73,8 +89,10 @@ func (o Options) IsTunnelingEnabled() bool {
 	return o.EnvoyTunnelingListenerPort > 0
 }
 
-// NewReconciler returns a new Reconciler or an error if something goes wrong
-// during the initial snapshot setup.
+// NewReconciler returns a new Reconciler and the snapshot cache it populates.
+// No initial snapshot is set: until the first snapshot has been built from
+// the watched Services, Envoy keeps its current configuration, so that
+// restarting the envoy-manager does not reset Envoy.
 func NewReconciler(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, opts Options) (*Reconciler, envoycachev3.SnapshotCache, error) {
 	cache := envoycachev3.NewSnapshotCache(true, envoycachev3.IDHash{}, log)
 	r := Reconciler{
@@ -83,14 +101,6 @@ func NewReconciler(ctx context.Context, log *zap.SugaredLogger, client ctrlrunti
 		Options: opts,
 		cache:   cache,
 	}
-	s, err := newSnapshotBuilder(log, portHostMappingFromAnnotation, opts).build("0.0.0")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to build snapshot: %w", err)
-	}
-
-	if err := r.cache.SetSnapshot(ctx, r.EnvoyNodeName, s); err != nil {
-		return nil, nil, fmt.Errorf("failed to set initial Envoy cache snapshot: %w", err)
-	}
 	return &r, cache, nil
 }
 
@@ -101,6 +111,9 @@ type Reconciler struct {
 	Options
 
 	cache envoycachev3.SnapshotCache
+	// lock ensures that the initial sync and the reconciliations do not
+	// build snapshots concurrently.
+	lock sync.Mutex
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrlruntime.Request) (ctrlruntime.Result, error) {
@@ -111,6 +124,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrlruntime.Request) (ct
 }
 
 func (r *Reconciler) sync(ctx context.Context) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	services := corev1.ServiceList{}
 	if err := r.List(ctx, &services,
 		ctrlruntimeclient.InNamespace(r.Namespace),
@@ -146,42 +162,20 @@ func (r *Reconciler) sync(ctx context.Context) error {
 		sb.addService(&service, &eps, ets)
 	}
 
-	// Get current snapshot
-	currSnapshot, err := r.cache.GetSnapshot(r.EnvoyNodeName)
-	if err != nil {
-		r.log.Debugf("setting first snapshot: %v", err)
-		s, err := sb.build("0.0.0")
-		if err != nil {
-			return fmt.Errorf("failed to build the first snapshot: %w", err)
-		}
-		if err := r.cache.SetSnapshot(ctx, r.EnvoyNodeName, s); err != nil {
-			return fmt.Errorf("failed to set a new Envoy cache snapshot: %w", err)
-		}
-		return nil
-	}
-
-	lastUsedVersion, err := semverlib.NewVersion(currSnapshot.GetVersion(envoyresourcev3.ClusterType))
-	if err != nil {
-		return fmt.Errorf("failed to parse version from last snapshot: %w", err)
-	}
-
-	s, err := sb.build(lastUsedVersion.String())
+	newSnapshot, err := sb.build()
 	if err != nil {
 		return fmt.Errorf("failed to build snapshot: %w", err)
 	}
 
-	// Generate a new snapshot using the old version to be able to do a DeepEqual comparison
-	if reflect.DeepEqual(currSnapshot, s) {
+	// The snapshot version is derived from the snapshot content, so comparing
+	// the versions is enough to detect changes.
+	newVersion := newSnapshot.GetVersion(envoyresourcev3.ClusterType)
+	if currSnapshot, err := r.cache.GetSnapshot(r.EnvoyNodeName); err == nil && currSnapshot.GetVersion(envoyresourcev3.ClusterType) == newVersion {
 		r.log.Debug("no changes detected")
 		return nil
 	}
 
-	newVersion := lastUsedVersion.IncMajor()
-	r.log.Infow("detected a change. Updating the Envoy config cache...", "version", newVersion.String())
-	newSnapshot, err := sb.build(newVersion.String())
-	if err != nil {
-		return fmt.Errorf("failed to build snapshot: %w", err)
-	}
+	r.log.Infow("detected a change. Updating the Envoy config cache...", "version", newVersion)
 
 	if err := newSnapshot.Consistent(); err != nil {
 		return fmt.Errorf("new Envoy config snapshot is not consistent: %w", err)
@@ -204,14 +198,35 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrlruntime.Manag
 	}); err != nil {
 		return fmt.Errorf("error occurred while adding service index: %w", err)
 	}
+
+	// Build the initial snapshot as soon as the caches are synced, so that
+	// Envoy gets configured even if no Service is exposed.
+	if err := mgr.Add(&initialSync{reconciler: r}); err != nil {
+		return fmt.Errorf("failed to add initial sync: %w", err)
+	}
+
 	return ctrlruntime.NewControllerManagedBy(mgr).
-		// Ensures that only one new Snapshot is generated at a time
-		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		WithOptions(controller.Options{
+			// Ensures that only one new Snapshot is generated at a time
+			MaxConcurrentReconciles: 1,
+		}).
 		For(&corev1.Service{}, builder.WithPredicates(exposeAnnotationPredicate{annotation: r.ExposeAnnotationKey, log: r.log})).
 		Watches(&corev1.Endpoints{}, handler.EnqueueRequestsFromMapFunc(r.newEndpointHandler())).
 		Complete(r)
 }
 
+// initialSync builds the first snapshot once the caches are synced.
+type initialSync struct {
+	reconciler *Reconciler
+}
+
+func (i *initialSync) Start(ctx context.Context) error {
+	if err := i.reconciler.sync(ctx); err != nil {
+		return fmt.Errorf("failed to build initial snapshot: %w", err)
+	}
+	return nil
+}
+
 func (r *Reconciler) newEndpointHandler() handler.MapFunc {
 	return func(ctx context.Context, obj ctrlruntimeclient.Object) []ctrlruntime.Request {
 		svcName := types.NamespacedName{
@@ -18,6 +18,7 @@ package envoymanager
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -415,7 +416,7 @@ func makeNodePortListener(t *testing.T, name string, portValue uint32) *envoylis
 						Name: envoywellknown.TCPProxy,
 						ConfigType: &envoylistenerv3.Filter_TypedConfig{
 							TypedConfig: marshalMessage(t, &envoytcpfilterv3.TcpProxy{
-								StatPrefix: "ingress_tcp",
+								StatPrefix: namespaceFromKey(name),
 								ClusterSpecifier: &envoytcpfilterv3.TcpProxy_Cluster{
 									Cluster: name,
 								},
@@ -428,6 +429,12 @@ func makeNodePortListener(t *testing.T, name string, portValue uint32) *envoylis
 	}
 }
 
+// namespaceFromKey returns the namespace from the given service (port) key,
+// which is used as stats prefix.
+func namespaceFromKey(key string) string {
+	return strings.SplitN(key, "/", 2)[0]
+}
+
 type hostClusterName struct {
 	Hostname string
 	Cluster  string
@@ -437,7 +444,7 @@ func makeSNIListener(t *testing.T, portValue uint32, hostClusterNames ...hostClu
 	fcs := []*envoylistenerv3.FilterChain{}
 	for _, hc := range hostClusterNames {
 		tcpProxyConfig := &envoytcpfilterv3.TcpProxy{
-			StatPrefix: "ingress_tcp",
+			StatPrefix: namespaceFromKey(hc.Cluster),
 			ClusterSpecifier: &envoytcpfilterv3.TcpProxy_Cluster{
 				Cluster: hc.Cluster,
 			},
@@ -545,6 +552,70 @@ func makeCluster(t *testing.T, name string, portValue uint32, addresses ...strin
 	}
 }
 
+func TestSnapshotVersion(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	ctx := context.Background()
+
+	svc := test.NewServiceBuilder(test.NamespacedName{Name: "my-nodeport", Namespace: "test"}).
+		WithServiceType(corev1.ServiceTypeNodePort).
+		WithAnnotation(nodeportproxy.DefaultExposeAnnotationKey, "true").
+		WithServicePort("http", 80, 32001, intstr.FromString("http"), corev1.ProtocolTCP).
+		Build()
+	eps := test.NewEndpointsBuilder(test.NamespacedName{Name: "my-nodeport", Namespace: "test"}).
+		WithEndpointsSubset().
+		WithEndpointPort("http", 8080, corev1.ProtocolTCP).
+		WithReadyAddressIP("172.16.0.1").
+		DoneWithEndpointSubset().Build()
+
+	newReconciler := func(objects ...ctrlruntimeclient.Object) *Reconciler {
+		client := fake.
+			NewClientBuilder().
+			WithObjects(objects...).
+			WithIndex(&corev1.Service{}, nodeportproxy.DefaultExposeAnnotationKey, func(raw ctrlruntimeclient.Object) []string {
+				if isExposed(raw, nodeportproxy.DefaultExposeAnnotationKey) {
+					return []string{"true"}
+				}
+				return nil
+			}).
+			Build()
+		r, _, _ := NewReconciler(ctx, log, client, Options{
+			EnvoyNodeName:       "node-name",
+			ExposeAnnotationKey: nodeportproxy.DefaultExposeAnnotationKey,
+		})
+		return r
+	}
+
+	syncedVersion := func(r *Reconciler) string {
+		if err := r.sync(ctx); err != nil {
+			t.Fatalf("failed to execute controller sync func: %v", err)
+		}
+		s, err := r.cache.GetSnapshot(r.EnvoyNodeName)
+		if err != nil {
+			t.Fatalf("failed to get snapshot: %v", err)
+		}
+		return s.GetVersion(envoyresourcev3.ClusterType)
+	}
+
+	r := newReconciler(svc, eps)
+	if _, err := r.cache.GetSnapshot(r.EnvoyNodeName); err == nil {
+		t.Fatal("Expected no snapshot to be set before the first sync")
+	}
+
+	version := syncedVersion(r)
+	if v := syncedVersion(r); v != version {
+		t.Errorf("Expected version to remain %q without changes, but got %q", version, v)
+	}
+
+	// another replica (or a restarted envoy-manager) must use the same version
+	if v := syncedVersion(newReconciler(svc.DeepCopy(), eps.DeepCopy())); v != version {
+		t.Errorf("Expected version %q for the same Services, but got %q", version, v)
+	}
+
+	if v := syncedVersion(newReconciler()); v == version {
+		t.Errorf("Expected version to change if the Services change, but got %q", v)
+	}
+}
+
 func TestNewEndpointHandler(t *testing.T) {
 	tests := []struct {
 		name          string
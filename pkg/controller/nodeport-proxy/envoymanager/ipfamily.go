@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoymanager
+
+import (
+	"fmt"
+	"strings"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/utils/net"
+)
+
+// ParseIPFamilies parses a comma separated list of IP families, like
+// "IPv4,IPv6".
+func ParseIPFamilies(s string) ([]corev1.IPFamily, error) {
+	var families []corev1.IPFamily
+	for _, f := range strings.Split(s, ",") {
+		switch family := corev1.IPFamily(strings.TrimSpace(f)); family {
+		case corev1.IPv4Protocol, corev1.IPv6Protocol:
+			for _, existing := range families {
+				if existing == family {
+					return nil, fmt.Errorf("duplicate IP family %q", family)
+				}
+			}
+			families = append(families, family)
+		default:
+			return nil, fmt.Errorf("invalid IP family %q, must be one of %q or %q", f, corev1.IPv4Protocol, corev1.IPv6Protocol)
+		}
+	}
+	return families, nil
+}
+
+// ipFamilies returns the IP families the listeners are bound for, the first
+// one being the primary family. Defaults to IPv4.
+func (o Options) ipFamilies() []corev1.IPFamily {
+	if len(o.IPFamilies) == 0 {
+		return []corev1.IPFamily{corev1.IPv4Protocol}
+	}
+	return o.IPFamilies
+}
+
+// hasIPFamilyOf returns true if the IP family of the given IP address is
+// enabled.
+func (o Options) hasIPFamilyOf(ip string) bool {
+	family := corev1.IPv4Protocol
+	if utilnet.IsIPv6String(ip) {
+		family = corev1.IPv6Protocol
+	}
+	for _, f := range o.ipFamilies() {
+		if f == family {
+			return true
+		}
+	}
+	return false
+}
+
+// listenerAddress returns the address a listener on the given port is bound
+// to for the primary IP family.
+func (o Options) listenerAddress(port uint32) *envoycorev3.Address {
+	return makeAnyAddress(o.ipFamilies()[0], port)
+}
+
+// additionalListenerAddresses returns the addresses a listener on the given
+// port is bound to for the secondary IP family, if any.
+func (o Options) additionalListenerAddresses(port uint32) []*envoylistenerv3.AdditionalAddress {
+	var addresses []*envoylistenerv3.AdditionalAddress
+	for _, family := range o.ipFamilies()[1:] {
+		addresses = append(addresses, &envoylistenerv3.AdditionalAddress{
+			Address: makeAnyAddress(family, port),
+		})
+	}
+	return addresses
+}
+
+// makeAnyAddress returns the address listening on all interfaces of the given
+// IP family. IPv6 sockets are bound with IPV6_V6ONLY, so that both families
+// can listen on the same port.
+func makeAnyAddress(family corev1.IPFamily, port uint32) *envoycorev3.Address {
+	address := "0.0.0.0"
+	if family == corev1.IPv6Protocol {
+		address = "::"
+	}
+
+	return &envoycorev3.Address{
+		Address: &envoycorev3.Address_SocketAddress{
+			SocketAddress: &envoycorev3.SocketAddress{
+				Protocol: envoycorev3.SocketAddress_TCP,
+				Address:  address,
+				PortSpecifier: &envoycorev3.SocketAddress_PortValue{
+					PortValue: port,
+				},
+			},
+		},
+	}
+}
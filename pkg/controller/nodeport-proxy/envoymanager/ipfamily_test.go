@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoymanager
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
+	"k8c.io/kubermatic/v2/pkg/test"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestParseIPFamilies(t *testing.T) {
+	var testcases = []struct {
+		name         string
+		value        string
+		wantFamilies []corev1.IPFamily
+		wantErr      bool
+	}{
+		{
+			name:         "IPv4",
+			value:        "IPv4",
+			wantFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+		},
+		{
+			name:         "Dual-stack with IPv6 as primary family",
+			value:        "IPv6, IPv4",
+			wantFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		},
+		{
+			name:    "Duplicate family",
+			value:   "IPv4,IPv4",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid family",
+			value:   "IPv5",
+			wantErr: true,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			families, err := ParseIPFamilies(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error = %v, but got: %v", tt.wantErr, err)
+			}
+			if d := diff.ObjectDiff(tt.wantFamilies, families); d != "" {
+				t.Errorf("Got unexpected IP families:\n%v", d)
+			}
+		})
+	}
+}
+
+func TestIPFamiliesInSnapshot(t *testing.T) {
+	svc := test.NewServiceBuilder(test.NamespacedName{Name: "my-nodeport", Namespace: "test"}).
+		WithServiceType(corev1.ServiceTypeNodePort).
+		WithAnnotation(nodeportproxy.DefaultExposeAnnotationKey, "true").
+		WithServicePort("http", 80, 32001, intstr.FromString("http"), corev1.ProtocolTCP).
+		Build()
+	eps := test.NewEndpointsBuilder(test.NamespacedName{Name: "my-nodeport", Namespace: "test"}).
+		WithEndpointsSubset().
+		WithEndpointPort("http", 8080, corev1.ProtocolTCP).
+		WithReadyAddressIP("172.16.0.1").
+		WithReadyAddressIP("fd00::1").
+		DoneWithEndpointSubset().Build()
+
+	var testcases = []struct {
+		name            string
+		ipFamilies      []corev1.IPFamily
+		wantAddresses   []string
+		wantEndpointIPs []string
+	}{
+		{
+			name:            "Default",
+			wantAddresses:   []string{"0.0.0.0"},
+			wantEndpointIPs: []string{"172.16.0.1"},
+		},
+		{
+			name:            "IPv6-only",
+			ipFamilies:      []corev1.IPFamily{corev1.IPv6Protocol},
+			wantAddresses:   []string{"::"},
+			wantEndpointIPs: []string{"fd00::1"},
+		},
+		{
+			name:            "Dual-stack",
+			ipFamilies:      []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			wantAddresses:   []string{"0.0.0.0", "::"},
+			wantEndpointIPs: []string{"172.16.0.1", "fd00::1"},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := newSnapshotBuilder(zaptest.NewLogger(t).Sugar(), portHostMappingFromAnnotation, Options{
+				IPFamilies: tt.ipFamilies,
+			})
+			sb.addService(svc, eps, extractExposeTypes(svc, nodeportproxy.DefaultExposeAnnotationKey))
+
+			if len(sb.listeners) != 1 {
+				t.Fatalf("Expected 1 listener, but got %d", len(sb.listeners))
+			}
+			listener := sb.listeners[0].(*envoylistenerv3.Listener)
+			addresses := []string{listener.GetAddress().GetSocketAddress().GetAddress()}
+			for _, a := range listener.GetAdditionalAddresses() {
+				addresses = append(addresses, a.GetAddress().GetSocketAddress().GetAddress())
+			}
+			if d := diff.ObjectDiff(tt.wantAddresses, addresses); d != "" {
+				t.Errorf("Got unexpected listener addresses:\n%v", d)
+			}
+
+			if len(sb.clusters) != 1 {
+				t.Fatalf("Expected 1 cluster, but got %d", len(sb.clusters))
+			}
+			var endpointIPs []string
+			for _, lbEndpoint := range sb.clusters[0].(*envoyclusterv3.Cluster).GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints() {
+				endpoint := lbEndpoint.GetHostIdentifier().(*envoyendpointv3.LbEndpoint_Endpoint).Endpoint
+				endpointIPs = append(endpointIPs, endpoint.GetAddress().GetSocketAddress().GetAddress())
+			}
+			if d := diff.ObjectDiff(tt.wantEndpointIPs, endpointIPs); d != "" {
+				t.Errorf("Got unexpected endpoints:\n%v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoymanager
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyhttplocalratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	envoylocalratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LocalRateLimitNetworkFilter is the name of the Envoy network filter used
+	// to rate limit new connections.
+	LocalRateLimitNetworkFilter = "envoy.filters.network.local_ratelimit"
+	// LocalRateLimitHTTPFilter is the name of the Envoy HTTP filter used to
+	// rate limit new tunneled connections.
+	LocalRateLimitHTTPFilter = "envoy.filters.http.local_ratelimit"
+)
+
+// connectionLimits contains the limits applied to the Envoy resources
+// generated for a single Service.
+type connectionLimits struct {
+	maxConnections  int
+	connectionRate  int
+	connectionBurst int
+}
+
+// connectionLimitsForService returns the connection limits for the given
+// Service. The defaults from the Options can be overridden using annotations
+// on the Service. If an annotation is invalid, the defaults are returned along
+// with the error.
+func (o Options) connectionLimitsForService(svc *corev1.Service) (connectionLimits, error) {
+	defaults := connectionLimits{
+		maxConnections:  o.MaxConnections,
+		connectionRate:  o.ConnectionRate,
+		connectionBurst: o.ConnectionBurst,
+	}
+	l := defaults
+
+	overrides := []struct {
+		annotation string
+		value      *int
+	}{
+		{annotation: nodeportproxy.MaxConnectionsAnnotationKey, value: &l.maxConnections},
+		{annotation: nodeportproxy.ConnectionRateAnnotationKey, value: &l.connectionRate},
+		{annotation: nodeportproxy.ConnectionBurstAnnotationKey, value: &l.connectionBurst},
+	}
+
+	for _, o := range overrides {
+		val, ok := svc.Annotations[o.annotation]
+		if !ok {
+			continue
+		}
+		i, err := strconv.Atoi(val)
+		if err != nil || i < 0 {
+			return defaults, fmt.Errorf("invalid value %q for annotation %s: must be a non-negative integer", val, o.annotation)
+		}
+		*o.value = i
+	}
+
+	return l, nil
+}
+
+// tokenBucket returns the token bucket used to rate limit new connections, or
+// nil if rate limiting is disabled.
+func (l connectionLimits) tokenBucket() *envoytypev3.TokenBucket {
+	if l.connectionRate <= 0 {
+		return nil
+	}
+
+	burst := l.connectionBurst
+	if burst <= 0 {
+		burst = l.connectionRate
+	}
+
+	return &envoytypev3.TokenBucket{
+		MaxTokens:     uint32(burst),
+		TokensPerFill: wrapperspb.UInt32(uint32(l.connectionRate)),
+		FillInterval:  durationpb.New(time.Second),
+	}
+}
+
+// circuitBreakers returns the circuit breakers limiting the number of
+// connections to an Envoy cluster, or nil if no limit is configured.
+func (l connectionLimits) circuitBreakers() *envoyclusterv3.CircuitBreakers {
+	if l.maxConnections <= 0 {
+		return nil
+	}
+
+	return &envoyclusterv3.CircuitBreakers{
+		Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{
+			{
+				MaxConnections: wrapperspb.UInt32(uint32(l.maxConnections)),
+				// expose the remaining connections as gauge, to make the
+				// usage visible
+				TrackRemaining: true,
+			},
+		},
+	}
+}
+
+// rateLimitNetworkFilter returns the network filter used to rate limit new
+// connections to the given Service, or nil if rate limiting is disabled. The
+// filters of all listeners and filter chains of a Service share the same token
+// bucket, so that every Service gets its own budget.
+func (l connectionLimits) rateLimitNetworkFilter(svc *corev1.Service) *envoylistenerv3.Filter {
+	tokenBucket := l.tokenBucket()
+	if tokenBucket == nil {
+		return nil
+	}
+
+	rateLimitConfig, err := anypb.New(&envoylocalratelimitv3.LocalRateLimit{
+		StatPrefix:  statPrefix(svc),
+		TokenBucket: tokenBucket,
+		ShareKey:    ServiceKey(svc),
+	})
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal LocalRateLimit: %w", err))
+	}
+
+	return &envoylistenerv3.Filter{
+		Name: LocalRateLimitNetworkFilter,
+		ConfigType: &envoylistenerv3.Filter_TypedConfig{
+			TypedConfig: rateLimitConfig,
+		},
+	}
+}
+
+// rateLimitPerFilterConfig returns the per virtual host configuration of the
+// HTTP rate limit filter, or nil if rate limiting is disabled.
+func (l connectionLimits) rateLimitPerFilterConfig(statPrefix string) map[string]*anypb.Any {
+	tokenBucket := l.tokenBucket()
+	if tokenBucket == nil {
+		return nil
+	}
+
+	enabled := &envoycorev3.RuntimeFractionalPercent{
+		DefaultValue: &envoytypev3.FractionalPercent{
+			Numerator:   100,
+			Denominator: envoytypev3.FractionalPercent_HUNDRED,
+		},
+	}
+
+	rateLimitConfig, err := anypb.New(&envoyhttplocalratelimitv3.LocalRateLimit{
+		StatPrefix:     statPrefix,
+		TokenBucket:    tokenBucket,
+		FilterEnabled:  enabled,
+		FilterEnforced: enabled,
+	})
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal LocalRateLimit: %w", err))
+	}
+
+	return map[string]*anypb.Any{
+		LocalRateLimitHTTPFilter: rateLimitConfig,
+	}
+}
+
+// statPrefix returns the prefix used for the stats of the given Service. All
+// Services of a user cluster reside in the same namespace, so that using the
+// namespace makes the usage of each user cluster visible.
+func statPrefix(svc *corev1.Service) string {
+	return svc.Namespace
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoymanager
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoylocalratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
+	"k8c.io/kubermatic/v2/pkg/test"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConnectionLimitsForService(t *testing.T) {
+	opts := Options{
+		MaxConnections: 100,
+		ConnectionRate: 10,
+	}
+
+	var testcases = []struct {
+		name        string
+		annotations map[string]string
+		wantLimits  connectionLimits
+		wantErr     bool
+	}{
+		{
+			name:       "Defaults",
+			wantLimits: connectionLimits{maxConnections: 100, connectionRate: 10},
+		},
+		{
+			name: "Overrides",
+			annotations: map[string]string{
+				nodeportproxy.MaxConnectionsAnnotationKey:  "50",
+				nodeportproxy.ConnectionRateAnnotationKey:  "0",
+				nodeportproxy.ConnectionBurstAnnotationKey: "20",
+			},
+			wantLimits: connectionLimits{maxConnections: 50, connectionRate: 0, connectionBurst: 20},
+		},
+		{
+			name: "Invalid override",
+			annotations: map[string]string{
+				nodeportproxy.MaxConnectionsAnnotationKey: "50",
+				nodeportproxy.ConnectionRateAnnotationKey: "-1",
+			},
+			wantLimits: connectionLimits{maxConnections: 100, connectionRate: 10},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{}
+			svc.Annotations = tt.annotations
+
+			limits, err := opts.connectionLimitsForService(svc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error = %v, but got: %v", tt.wantErr, err)
+			}
+			if limits != tt.wantLimits {
+				t.Errorf("Expected limits %+v, but got %+v", tt.wantLimits, limits)
+			}
+		})
+	}
+}
+
+func TestConnectionLimitsInSnapshot(t *testing.T) {
+	svc := test.NewServiceBuilder(test.NamespacedName{Name: "my-service", Namespace: "cluster-test"}).
+		WithServiceType(corev1.ServiceTypeNodePort).
+		WithAnnotation(nodeportproxy.DefaultExposeAnnotationKey, "NodePort,Tunneling").
+		WithAnnotation(nodeportproxy.ConnectionBurstAnnotationKey, "30").
+		WithServicePort("https", 443, 32000, intstr.FromString("https"), corev1.ProtocolTCP).
+		Build()
+	eps := test.NewEndpointsBuilder(test.NamespacedName{Name: "my-service", Namespace: "cluster-test"}).
+		WithEndpointsSubset().
+		WithEndpointPort("https", 8443, corev1.ProtocolTCP).
+		WithReadyAddressIP("172.16.0.1").
+		DoneWithEndpointSubset().Build()
+
+	sb := newSnapshotBuilder(zaptest.NewLogger(t).Sugar(), portHostMappingFromAnnotation, Options{
+		EnvoyTunnelingListenerPort: 8088,
+		MaxConnections:             100,
+		ConnectionRate:             10,
+	})
+	sb.addService(svc, eps, extractExposeTypes(svc, nodeportproxy.DefaultExposeAnnotationKey))
+
+	if len(sb.clusters) != 1 {
+		t.Fatalf("Expected 1 cluster, but got %d", len(sb.clusters))
+	}
+	thresholds := sb.clusters[0].(*envoyclusterv3.Cluster).GetCircuitBreakers().GetThresholds()
+	if len(thresholds) != 1 || thresholds[0].GetMaxConnections().GetValue() != 100 {
+		t.Errorf("Expected cluster to be limited to 100 connections, but got thresholds %v", thresholds)
+	}
+
+	if len(sb.listeners) != 1 {
+		t.Fatalf("Expected 1 NodePort listener, but got %d", len(sb.listeners))
+	}
+	filters := sb.listeners[0].(*envoylistenerv3.Listener).GetFilterChains()[0].GetFilters()
+	if len(filters) != 2 || filters[0].GetName() != LocalRateLimitNetworkFilter || filters[1].GetName() != envoywellknown.TCPProxy {
+		t.Fatalf("Expected rate limit filter followed by TCP proxy filter, but got %v", filters)
+	}
+	rateLimit := &envoylocalratelimitv3.LocalRateLimit{}
+	if err := filters[0].GetTypedConfig().UnmarshalTo(rateLimit); err != nil {
+		t.Fatalf("Failed to unmarshal rate limit filter: %v", err)
+	}
+	if rateLimit.GetStatPrefix() != "cluster-test" || rateLimit.GetShareKey() != ServiceKey(svc) {
+		t.Errorf("Expected rate limit to be shared per Service, but got stat prefix %q and share key %q", rateLimit.GetStatPrefix(), rateLimit.GetShareKey())
+	}
+	if bucket := rateLimit.GetTokenBucket(); bucket.GetMaxTokens() != 30 || bucket.GetTokensPerFill().GetValue() != 10 {
+		t.Errorf("Expected token bucket with 30 tokens and 10 tokens per fill, but got %v", bucket)
+	}
+
+	if len(sb.vhs) != 1 {
+		t.Fatalf("Expected 1 virtual host, but got %d", len(sb.vhs))
+	}
+	if _, ok := sb.vhs[0].GetTypedPerFilterConfig()[LocalRateLimitHTTPFilter]; !ok {
+		t.Errorf("Expected virtual host to be rate limited, but got %v", sb.vhs[0].GetTypedPerFilterConfig())
+	}
+}
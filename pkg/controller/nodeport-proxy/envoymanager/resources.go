@@ -17,6 +17,8 @@ limitations under the License.
 package envoymanager
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"reflect"
@@ -37,6 +39,7 @@ import (
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoylistenerlogv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	envoyhealthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
+	envoyhttplocalratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	envoyrouterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	envoytlsinspectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	envoyhttpconnectionmanagerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
@@ -105,6 +108,11 @@ func (sb *snapshotBuilder) addService(svc *corev1.Service, eps *corev1.Endpoints
 		svcLog.Debug("skipping service: no expose types provided")
 	}
 
+	limits, err := sb.connectionLimitsForService(svc)
+	if err != nil {
+		svcLog.Warnw("ignoring connection limit overrides", "error", err)
+	}
+
 	// Exclude all ports by default, to avoid creating unused clusters.
 	var includePorts sets.Set[string]
 	// Create listeners for NodePortType
@@ -114,33 +122,33 @@ func (sb *snapshotBuilder) addService(svc *corev1.Service, eps *corev1.Endpoints
 			svcLog.Warn("skipping service: it is not of type NodePort", "service")
 		} else {
 			// Add listeners for nodeport services
-			ls, ports := sb.makeListenersForNodePortService(svc)
+			ls, ports := sb.makeListenersForNodePortService(svc, limits)
 			includePorts = ports.Union(includePorts)
 			sb.listeners = append(sb.listeners, ls...)
 		}
 	}
 	// Create filter chains for SNIType
 	if expTypes.Has(nodeportproxy.SNIType) && sb.IsSNIEnabled() {
-		fcs, ports := sb.makeSNIFilterChains(svcLog, svc)
+		fcs, ports := sb.makeSNIFilterChains(svcLog, svc, limits)
 		includePorts = ports.Union(includePorts)
 		sb.fcs = append(sb.fcs, fcs...)
 	}
 	// Create virtual hosts for TunnelingType
 	if expTypes.Has(nodeportproxy.TunnelingType) && sb.IsTunnelingEnabled() {
-		vhs, ports := sb.makeTunnelingVirtualHosts(svc)
+		vhs, ports := sb.makeTunnelingVirtualHosts(svc, limits)
 		includePorts = ports.Union(includePorts)
 		sb.vhs = append(sb.vhs, vhs...)
 	}
 
 	// Create clusters
 	sb.log.Debugw("creating clusters", "includePorts", includePorts)
-	sb.clusters = append(sb.clusters, sb.makeClusters(svc, eps, includePorts)...)
+	sb.clusters = append(sb.clusters, sb.makeClusters(svc, eps, includePorts, limits)...)
 }
 
 // makeSNIFilterChains returns the FilterChains for the given service and the
 // set of ports that are exposed. Note that the set can be nil, don't try to
 // write to it before doing a nil check.
-func (sb *snapshotBuilder) makeSNIFilterChains(svcLog *zap.SugaredLogger, svc *corev1.Service) ([]*envoylistenerv3.FilterChain, sets.Set[string]) {
+func (sb *snapshotBuilder) makeSNIFilterChains(svcLog *zap.SugaredLogger, svc *corev1.Service, limits connectionLimits) ([]*envoylistenerv3.FilterChain, sets.Set[string]) {
 	m, err := sb.portHostMappingGetter(svc)
 	if err != nil {
 		svcLog.Warnw("port host mapping is required with SNI expose type", "error", err)
@@ -165,12 +173,12 @@ func (sb *snapshotBuilder) makeSNIFilterChains(svcLog *zap.SugaredLogger, svc *c
 
 	svcLog.Debugw("creating sni filter chains", "portHostMapping", m)
 	// Besides the filter chains returns the ports that are exposed.
-	return makeSNIFilterChains(svc, m), ports
+	return makeSNIFilterChains(svc, m, limits), ports
 }
 
 // build returns a new Snapshot from the resources derived by the Services
-// provided so far.
-func (sb *snapshotBuilder) build(version string) (*envoycachev3.Snapshot, error) {
+// provided so far. The version of the Snapshot is derived from its content.
+func (sb *snapshotBuilder) build() (*envoycachev3.Snapshot, error) {
 	l, c := sb.makeInitialResources()
 
 	l = append(l, sb.listeners...)
@@ -183,9 +191,34 @@ func (sb *snapshotBuilder) build(version string) (*envoycachev3.Snapshot, error)
 		l = append(l, sb.makeTunnelingListener(sb.vhs...))
 	}
 	c = append(c, sb.clusters...)
+
+	version, err := snapshotVersion(c, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute snapshot version: %w", err)
+	}
 	return newSnapshot(version, c, l)
 }
 
+// snapshotVersion returns a version derived from the content of the given
+// resources. Unlike a counter, it is stable across restarts and replicas of
+// the envoy-manager, so Envoy is only sent updates if the configuration
+// actually changed. The versions of the individual resources, which are used
+// for incremental (delta) xDS, are derived the same way by the snapshot cache.
+func snapshotVersion(resourceLists ...[]envoycachetype.Resource) (string, error) {
+	hasher := sha256.New()
+	for _, resources := range resourceLists {
+		for _, res := range resources {
+			marshalled, err := envoycachev3.MarshalResource(res)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal resource %q: %w", envoycachev3.GetResourceName(res), err)
+			}
+			hasher.Write([]byte(envoycachev3.GetResourceName(res)))
+			hasher.Write(marshalled)
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
 func makeAccessLog() []*envoyaccesslogv3.AccessLog {
 	f := &envoylistenerlogv3.FileAccessLog{
 		Path: "/dev/stdout",
@@ -207,7 +240,29 @@ func makeAccessLog() []*envoyaccesslogv3.AccessLog {
 	return accessLog
 }
 
-func makeSNIFilterChains(service *corev1.Service, p portHostMapping) []*envoylistenerv3.FilterChain {
+// makeTCPProxyFilters returns the network filters proxying connections to the
+// given Service using the given TcpProxy configuration, preceded by the rate
+// limit filter if rate limiting is enabled.
+func makeTCPProxyFilters(service *corev1.Service, tcpProxyConfig *envoytcpfilterv3.TcpProxy, limits connectionLimits) []*envoylistenerv3.Filter {
+	tcpProxyConfigMarshalled, err := anypb.New(tcpProxyConfig)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal tcpProxyConfig: %w", err))
+	}
+
+	var filters []*envoylistenerv3.Filter
+	if rateLimitFilter := limits.rateLimitNetworkFilter(service); rateLimitFilter != nil {
+		filters = append(filters, rateLimitFilter)
+	}
+
+	return append(filters, &envoylistenerv3.Filter{
+		Name: envoywellknown.TCPProxy,
+		ConfigType: &envoylistenerv3.Filter_TypedConfig{
+			TypedConfig: tcpProxyConfigMarshalled,
+		},
+	})
+}
+
+func makeSNIFilterChains(service *corev1.Service, p portHostMapping, limits connectionLimits) []*envoylistenerv3.FilterChain {
 	var sniFilterChains []*envoylistenerv3.FilterChain
 
 	serviceKey := ServiceKey(service)
@@ -216,27 +271,15 @@ func makeSNIFilterChains(service *corev1.Service, p portHostMapping) []*envoylis
 			servicePortKey := ServicePortKey(serviceKey, &servicePort)
 
 			tcpProxyConfig := &envoytcpfilterv3.TcpProxy{
-				StatPrefix: "ingress_tcp",
+				StatPrefix: statPrefix(service),
 				ClusterSpecifier: &envoytcpfilterv3.TcpProxy_Cluster{
 					Cluster: servicePortKey,
 				},
 				AccessLog: makeAccessLog(),
 			}
 
-			tcpProxyConfigMarshalled, err := anypb.New(tcpProxyConfig)
-			if err != nil {
-				panic(fmt.Errorf("failed to marshal tcpProxyConfig: %w", err))
-			}
-
 			sniFilterChains = append(sniFilterChains, &envoylistenerv3.FilterChain{
-				Filters: []*envoylistenerv3.Filter{
-					{
-						Name: envoywellknown.TCPProxy,
-						ConfigType: &envoylistenerv3.Filter_TypedConfig{
-							TypedConfig: tcpProxyConfigMarshalled,
-						},
-					},
-				},
+				Filters: makeTCPProxyFilters(service, tcpProxyConfig, limits),
 				FilterChainMatch: &envoylistenerv3.FilterChainMatch{
 					ServerNames:       []string{name},
 					TransportProtocol: "tls",
@@ -257,18 +300,9 @@ func (sb *snapshotBuilder) makeSNIListener(fcs ...*envoylistenerv3.FilterChain)
 	}
 
 	sniListener := &envoylistenerv3.Listener{
-		Name: "sni_listener",
-		Address: &envoycorev3.Address{
-			Address: &envoycorev3.Address_SocketAddress{
-				SocketAddress: &envoycorev3.SocketAddress{
-					Protocol: envoycorev3.SocketAddress_TCP,
-					Address:  "0.0.0.0",
-					PortSpecifier: &envoycorev3.SocketAddress_PortValue{
-						PortValue: uint32(sb.EnvoySNIListenerPort),
-					},
-				},
-			},
-		},
+		Name:                "sni_listener",
+		Address:             sb.listenerAddress(uint32(sb.EnvoySNIListenerPort)),
+		AdditionalAddresses: sb.additionalListenerAddresses(uint32(sb.EnvoySNIListenerPort)),
 		// TLS inspector need to be activated explicitly starting from Envoy
 		// 1.17.
 		ListenerFilters: []*envoylistenerv3.ListenerFilter{
@@ -284,7 +318,7 @@ func (sb *snapshotBuilder) makeSNIListener(fcs ...*envoylistenerv3.FilterChain)
 	return sniListener
 }
 
-func (sb *snapshotBuilder) makeTunnelingVirtualHosts(service *corev1.Service) (vhs []*envoyroutev3.VirtualHost, ports sets.Set[string]) {
+func (sb *snapshotBuilder) makeTunnelingVirtualHosts(service *corev1.Service, limits connectionLimits) (vhs []*envoyroutev3.VirtualHost, ports sets.Set[string]) {
 	serviceKey := ServiceKey(service)
 	ports = sets.New[string]()
 
@@ -301,6 +335,7 @@ func (sb *snapshotBuilder) makeTunnelingVirtualHosts(service *corev1.Service) (v
 			Domains: []string{
 				fmt.Sprintf("%s.%s.svc.cluster.local:%d", service.Name, service.Namespace, servicePort.Port),
 			},
+			TypedPerFilterConfig: limits.rateLimitPerFilterConfig(statPrefix(service)),
 			Routes: []*envoyroutev3.Route{
 				{
 					Match: &envoyroutev3.RouteMatch{
@@ -335,6 +370,34 @@ func (sb *snapshotBuilder) makeTunnelingListener(vhs ...*envoyroutev3.VirtualHos
 		panic(fmt.Errorf("failed to marshal router: %w", err))
 	}
 
+	var httpFilters []*envoyhttpconnectionmanagerv3.HttpFilter
+	// The rate limit filter is configured per virtual host, it only needs to
+	// be added if rate limiting is enabled for any of them.
+	for _, vh := range vhs {
+		if _, ok := vh.TypedPerFilterConfig[LocalRateLimitHTTPFilter]; ok {
+			rateLimitpb, err := anypb.New(&envoyhttplocalratelimitv3.LocalRateLimit{
+				StatPrefix: "tunneling_rate_limiter",
+			})
+			if err != nil {
+				// panic as this either never occurs or cannot recover
+				panic(fmt.Errorf("failed to marshal rate limit: %w", err))
+			}
+			httpFilters = append(httpFilters, &envoyhttpconnectionmanagerv3.HttpFilter{
+				Name: LocalRateLimitHTTPFilter,
+				ConfigType: &envoyhttpconnectionmanagerv3.HttpFilter_TypedConfig{
+					TypedConfig: rateLimitpb,
+				},
+			})
+			break
+		}
+	}
+	httpFilters = append(httpFilters, &envoyhttpconnectionmanagerv3.HttpFilter{
+		Name: envoywellknown.Router,
+		ConfigType: &envoyhttpconnectionmanagerv3.HttpFilter_TypedConfig{
+			TypedConfig: routerpb,
+		},
+	})
+
 	hcm := &envoyhttpconnectionmanagerv3.HttpConnectionManager{
 		CodecType:  envoyhttpconnectionmanagerv3.HttpConnectionManager_AUTO,
 		StatPrefix: "ingress_http",
@@ -344,15 +407,8 @@ func (sb *snapshotBuilder) makeTunnelingListener(vhs ...*envoyroutev3.VirtualHos
 				VirtualHosts: vhs,
 			},
 		},
-		AccessLog: makeAccessLog(),
-		HttpFilters: []*envoyhttpconnectionmanagerv3.HttpFilter{
-			{
-				Name: envoywellknown.Router,
-				ConfigType: &envoyhttpconnectionmanagerv3.HttpFilter_TypedConfig{
-					TypedConfig: routerpb,
-				},
-			},
-		},
+		AccessLog:   makeAccessLog(),
+		HttpFilters: httpFilters,
 		Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{
 			AllowConnect: true,
 		},
@@ -370,18 +426,9 @@ func (sb *snapshotBuilder) makeTunnelingListener(vhs ...*envoyroutev3.VirtualHos
 	sb.log.Debugf("using a listener on port %d", sb.EnvoyTunnelingListenerPort)
 
 	tunnelingListener := &envoylistenerv3.Listener{
-		Name: "tunneling_listener",
-		Address: &envoycorev3.Address{
-			Address: &envoycorev3.Address_SocketAddress{
-				SocketAddress: &envoycorev3.SocketAddress{
-					Protocol: envoycorev3.SocketAddress_TCP,
-					Address:  "0.0.0.0",
-					PortSpecifier: &envoycorev3.SocketAddress_PortValue{
-						PortValue: uint32(sb.EnvoyTunnelingListenerPort),
-					},
-				},
-			},
-		},
+		Name:                "tunneling_listener",
+		Address:             sb.listenerAddress(uint32(sb.EnvoyTunnelingListenerPort)),
+		AdditionalAddresses: sb.additionalListenerAddresses(uint32(sb.EnvoyTunnelingListenerPort)),
 		FilterChains: []*envoylistenerv3.FilterChain{
 			{
 				Filters: []*envoylistenerv3.Filter{
@@ -398,7 +445,7 @@ func (sb *snapshotBuilder) makeTunnelingListener(vhs ...*envoyroutev3.VirtualHos
 	return tunnelingListener
 }
 
-func (sb *snapshotBuilder) makeClusters(service *corev1.Service, endpoints *corev1.Endpoints, includePorts sets.Set[string]) (clusters []envoycachetype.Resource) {
+func (sb *snapshotBuilder) makeClusters(service *corev1.Service, endpoints *corev1.Endpoints, includePorts sets.Set[string], limits connectionLimits) (clusters []envoycachetype.Resource) {
 	serviceKey := ServiceKey(service)
 	for _, servicePort := range service.Spec.Ports {
 		if !includePorts.Has(servicePort.Name) {
@@ -430,13 +477,14 @@ func (sb *snapshotBuilder) makeClusters(service *corev1.Service, endpoints *core
 					},
 				},
 			},
+			CircuitBreakers: limits.circuitBreakers(),
 		}
 		clusters = append(clusters, cluster)
 	}
 	return
 }
 
-func (sb *snapshotBuilder) makeListenersForNodePortService(service *corev1.Service) (listeners []envoycachetype.Resource, exposedPorts sets.Set[string]) {
+func (sb *snapshotBuilder) makeListenersForNodePortService(service *corev1.Service, limits connectionLimits) (listeners []envoycachetype.Resource, exposedPorts sets.Set[string]) {
 	serviceKey := ServiceKey(service)
 	exposedPorts = sets.New[string]()
 	for _, servicePort := range service.Spec.Ports {
@@ -448,42 +496,21 @@ func (sb *snapshotBuilder) makeListenersForNodePortService(service *corev1.Servi
 		servicePortKey := ServicePortKey(serviceKey, &servicePort)
 
 		tcpProxyConfig := &envoytcpfilterv3.TcpProxy{
-			StatPrefix: "ingress_tcp",
+			StatPrefix: statPrefix(service),
 			ClusterSpecifier: &envoytcpfilterv3.TcpProxy_Cluster{
 				Cluster: servicePortKey,
 			},
 		}
 
-		tcpProxyConfigMarshalled, err := anypb.New(tcpProxyConfig)
-		if err != nil {
-			panic(fmt.Errorf("failed to marshal tcpProxyConfig: %w", err))
-		}
-
 		sb.log.Debugw("creating NodePort listener", "service", serviceKey, "nodePort", servicePort.NodePort)
 
 		listener := &envoylistenerv3.Listener{
-			Name: servicePortKey,
-			Address: &envoycorev3.Address{
-				Address: &envoycorev3.Address_SocketAddress{
-					SocketAddress: &envoycorev3.SocketAddress{
-						Protocol: envoycorev3.SocketAddress_TCP,
-						Address:  "0.0.0.0",
-						PortSpecifier: &envoycorev3.SocketAddress_PortValue{
-							PortValue: uint32(servicePort.NodePort),
-						},
-					},
-				},
-			},
+			Name:                servicePortKey,
+			Address:             sb.listenerAddress(uint32(servicePort.NodePort)),
+			AdditionalAddresses: sb.additionalListenerAddresses(uint32(servicePort.NodePort)),
 			FilterChains: []*envoylistenerv3.FilterChain{
 				{
-					Filters: []*envoylistenerv3.Filter{
-						{
-							Name: envoywellknown.TCPProxy,
-							ConfigType: &envoylistenerv3.Filter_TypedConfig{
-								TypedConfig: tcpProxyConfigMarshalled,
-							},
-						},
-					},
+					Filters: makeTCPProxyFilters(service, tcpProxyConfig, limits),
 				},
 			},
 		}
@@ -608,18 +635,9 @@ func (sb *snapshotBuilder) makeInitialResources() (listeners []envoycachetype.Re
 	}
 
 	listener := &envoylistenerv3.Listener{
-		Name: "service_stats",
-		Address: &envoycorev3.Address{
-			Address: &envoycorev3.Address_SocketAddress{
-				SocketAddress: &envoycorev3.SocketAddress{
-					Protocol: envoycorev3.SocketAddress_TCP,
-					Address:  "0.0.0.0",
-					PortSpecifier: &envoycorev3.SocketAddress_PortValue{
-						PortValue: uint32(sb.EnvoyStatsPort),
-					},
-				},
-			},
-		},
+		Name:                "service_stats",
+		Address:             sb.listenerAddress(uint32(sb.EnvoyStatsPort)),
+		AdditionalAddresses: sb.additionalListenerAddresses(uint32(sb.EnvoyStatsPort)),
 		FilterChains: []*envoylistenerv3.FilterChain{
 			{
 				Filters: []*envoylistenerv3.Filter{
@@ -676,6 +694,10 @@ func (sb *snapshotBuilder) getEndpoints(s *corev1.Service, port *corev1.ServiceP
 			}
 
 			for _, epAddress := range ss.Addresses {
+				if !sb.hasIPFamilyOf(epAddress.IP) {
+					serviceLog.Debugw("skipping endpoint: IP family is not enabled", "address", epAddress.IP)
+					continue
+				}
 				ep := net.JoinHostPort(epAddress.IP, strconv.Itoa(int(targetPort)))
 				if _, exists := processedUpstreamServers[ep]; exists {
 					continue
@@ -118,6 +118,9 @@ func Add(
 
 	bldr.Watches(&kubermaticv1.KubermaticConfiguration{}, configEventHandler, builder.WithPredicates(namespacePredicate, workerNamePredicate, predicate.ResourceVersionChangedPredicate{}))
 
+	// PriceModels are cluster-scoped and copied into every seed whose metering reports reference them
+	bldr.Watches(&kubermaticv1.PriceModel{}, configEventHandler, builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}))
+
 	// watch for changes to the global CA bundle ConfigMap and replicate it into each Seed
 	configMapEventHandler := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a ctrlruntimeclient.Object) []reconcile.Request {
 		// find the owning KubermaticConfiguration
@@ -27,6 +27,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common/vpa"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/auditlogs"
 	kubermaticseed "k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/kubermatic"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/metering"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/networkpolicy"
@@ -343,7 +344,11 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 	// Once the webhooks are reconciled above, we can now clean up unneeded services.
 	common.CleanupWebhookServices(ctx, client, log, cfg.Namespace)
 
-	if err := metering.ReconcileMeteringResources(ctx, client, r.scheme, cfg, seed); err != nil {
+	if err := metering.ReconcileMeteringResources(ctx, client, r.masterClient, r.scheme, r.seedRecorders[seed.Name], cfg, seed, r.versions); err != nil {
+		return err
+	}
+
+	if err := auditlogs.ReconcileAuditLogAggregator(ctx, client, r.scheme, cfg, seed); err != nil {
 		return err
 	}
 
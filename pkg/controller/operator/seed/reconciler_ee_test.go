@@ -30,7 +30,9 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -81,6 +83,61 @@ func TestMeteringReconciling(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "when referencing a price model",
+			seedToReconcile: "seed-with-metering-config",
+			configuration:   &k8cConfig,
+			seedsOnMaster:   []string{"seed-with-metering-config"},
+			syncedSeeds:     sets.New("seed-with-metering-config"),
+			assertion: func(t *testing.T, test *testcase, reconciler *Reconciler) error {
+				ctx := context.Background()
+				seedClient := reconciler.seedClients[test.seedToReconcile]
+
+				must(t, reconciler.masterClient.Create(ctx, &kubermaticv1.PriceModel{
+					ObjectMeta: metav1.ObjectMeta{Name: "standard"},
+					Spec: kubermaticv1.PriceModelSpec{
+						Currency: "EUR",
+						Default: kubermaticv1.Prices{
+							VCPUHour: resource.NewMilliQuantity(20, resource.DecimalSI),
+						},
+					},
+				}))
+
+				seed := &kubermaticv1.Seed{}
+				must(t, seedClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: test.seedToReconcile}, seed))
+
+				report := seed.Spec.Metering.ReportConfigurations["weekly-test"]
+				report.PriceModel = "standard"
+				seed.Spec.Metering.ReportConfigurations["weekly-test"] = report
+				must(t, seedClient.Update(ctx, seed))
+
+				if err := reconciler.reconcile(ctx, reconciler.log, test.seedToReconcile); err != nil && !meteringCredsNotFound(err) {
+					return fmt.Errorf("reconciliation failed: %w", err)
+				}
+
+				priceModel := corev1.ConfigMap{}
+				if err := seedClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: "metering-price-model-standard"}, &priceModel); err != nil {
+					return fmt.Errorf("failed to find price model ConfigMap: %w", err)
+				}
+				if !strings.Contains(priceModel.Data["price-model.yaml"], "currency: EUR") {
+					return fmt.Errorf("price model ConfigMap does not contain the price model: %v", priceModel.Data)
+				}
+
+				cronJob := batchv1.CronJob{}
+				must(t, seedClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: "metering-weekly-test"}, &cronJob))
+
+				podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+				if len(podSpec.Containers) != 2 || podSpec.Containers[1].Name != "cost-report" {
+					return fmt.Errorf("reporting cronjob does not contain a cost-report container")
+				}
+				if podSpec.ServiceAccountName != "metering-query" {
+					return fmt.Errorf("expected reporting cronjob to use the metering-query ServiceAccount, but got %q", podSpec.ServiceAccountName)
+				}
+
+				return nil
+			},
+		},
+
 		{
 			name:            "when removing metering configuration report",
 			seedToReconcile: "seed-with-metering-config",
@@ -0,0 +1,528 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// ConfigSecretName is the name of the Secret containing the aggregator's fluent-bit configuration.
+	ConfigSecretName = "audit-log-aggregator-config"
+
+	configFileName   = "fluent-bit.conf"
+	parsersFileName  = "parsers.conf"
+	scriptFileName   = "audit.lua"
+	configMountPath  = "/etc/fluent-bit"
+	bufferMountPath  = "/var/fluent-bit"
+	auditParserName  = "audit-json"
+	auditTagPrefix   = "audit."
+	metricsTagSuffix = "_metrics"
+
+	defaultSinkAlias = "default"
+	maskedValue      = "[REDACTED]"
+
+	// sharedKeyEnvVar is the environment variable the key shared with the audit-logs sidecars is injected as.
+	sharedKeyEnvVar = "FORWARD_SHARED_KEY"
+)
+
+const parsersConfig = `[PARSER]
+    Name      ` + auditParserName + `
+    Format    json
+`
+
+// section is a single section of a fluent-bit configuration file. Parameters are
+// kept as a list, as some fluent-bit plugins allow to set a parameter multiple times.
+type section struct {
+	name   string
+	params [][2]string
+}
+
+func (s *section) set(key, value string) {
+	s.params = append(s.params, [2]string{key, value})
+}
+
+func (s *section) render(b *strings.Builder) {
+	fmt.Fprintf(b, "[%s]\n", s.name)
+	for _, param := range s.params {
+		fmt.Fprintf(b, "    %-28s %s\n", param[0], param[1])
+	}
+	b.WriteString("\n")
+}
+
+// route is a sink together with the audit events that are written to it.
+type route struct {
+	alias      string
+	sink       kubermaticv1.AuditLogSink
+	matchKey   string
+	matchValue string
+}
+
+// routes returns the project routes, sorted by project ID, followed by the default route.
+func routes(settings *kubermaticv1.AuditLogAggregatorSettings) []route {
+	projects := make([]string, 0, len(settings.ProjectSinks))
+	for project := range settings.ProjectSinks {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	result := []route{}
+	for _, project := range projects {
+		result = append(result, route{
+			alias:      "project-" + project,
+			sink:       settings.ProjectSinks[project],
+			matchKey:   "Match",
+			matchValue: auditTagPrefix + project + ".*",
+		})
+	}
+
+	defaultRoute := route{
+		alias:      defaultSinkAlias,
+		sink:       settings.DefaultSink,
+		matchKey:   "Match",
+		matchValue: auditTagPrefix + "*",
+	}
+
+	// events of projects with a dedicated sink must not end up in the default sink as well
+	if len(projects) > 0 {
+		quoted := make([]string, 0, len(projects))
+		for _, project := range projects {
+			quoted = append(quoted, regexp.QuoteMeta(project))
+		}
+
+		defaultRoute.matchKey = "Match_Regex"
+		defaultRoute.matchValue = fmt.Sprintf(`^%s(?!(%s)\.)`, regexp.QuoteMeta(auditTagPrefix), strings.Join(quoted, "|"))
+	}
+
+	return append(result, defaultRoute)
+}
+
+// credentialsEnvVar returns the name of the environment variable a credential of the given sink is injected as.
+func credentialsEnvVar(alias, key string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(fmt.Sprintf("sink_%s_%s", alias, key)))
+}
+
+func config(settings *kubermaticv1.AuditLogAggregatorSettings) (string, error) {
+	sections := []*section{}
+
+	service := &section{name: "SERVICE"}
+	service.set("Flush", "1")
+	service.set("Log_Level", "info")
+	service.set("Parsers_File", configMountPath+"/"+parsersFileName)
+	service.set("HTTP_Server", "On")
+	service.set("HTTP_Listen", "0.0.0.0")
+	service.set("HTTP_Port", strconv.Itoa(HealthPort))
+	service.set("Health_Check", "On")
+	service.set("storage.path", bufferMountPath+"/buffer/")
+	service.set("storage.sync", "normal")
+	service.set("storage.backlog.mem_limit", "16M")
+	service.set("storage.metrics", "On")
+	sections = append(sections, service)
+
+	forward := &section{name: "INPUT"}
+	forward.set("Name", "forward")
+	forward.set("Listen", "0.0.0.0")
+	forward.set("Port", strconv.Itoa(resources.AuditLogAggregatorForwardPort))
+	// only the audit-logs sidecars know the shared key, so no one else can inject audit events
+	forward.set("Shared_Key", fmt.Sprintf("${%s}", sharedKeyEnvVar))
+	forward.set("storage.type", "filesystem")
+	sections = append(sections, forward)
+
+	internalMetrics := &section{name: "INPUT"}
+	internalMetrics.set("Name", "fluentbit_metrics")
+	internalMetrics.set("Tag", "internal"+metricsTagSuffix)
+	sections = append(sections, internalMetrics)
+
+	// the sidecars ship the raw audit log lines in the "log" field
+	parser := &section{name: "FILTER"}
+	parser.set("Name", "parser")
+	parser.set("Match", auditTagPrefix+"*")
+	parser.set("Key_Name", "log")
+	parser.set("Parser", auditParserName)
+	parser.set("Reserve_Data", "On")
+	sections = append(sections, parser)
+
+	if len(settings.RedactionRules) > 0 {
+		redaction := &section{name: "FILTER"}
+		redaction.set("Name", "lua")
+		redaction.set("Match", auditTagPrefix+"*")
+		redaction.set("script", configMountPath+"/"+scriptFileName)
+		redaction.set("call", "redact")
+		sections = append(sections, redaction)
+	}
+
+	recordMetrics := &section{name: "FILTER"}
+	recordMetrics.set("Name", "log_to_metrics")
+	recordMetrics.set("Match", auditTagPrefix+"*")
+	recordMetrics.set("Tag", "audit"+metricsTagSuffix)
+	recordMetrics.set("Metric_mode", "counter")
+	recordMetrics.set("Metric_namespace", "kubermatic")
+	recordMetrics.set("Metric_subsystem", "audit_log_aggregator")
+	recordMetrics.set("Metric_name", "records_total")
+	recordMetrics.set("Metric_description", "Number of audit events received by the aggregator.")
+	recordMetrics.set("Label_field", "cluster")
+	recordMetrics.set("Label_field", "project")
+	sections = append(sections, recordMetrics)
+
+	outputs := []*section{}
+	for _, r := range routes(settings) {
+		// syslog messages are plain strings, so the whole audit event is encoded into a single field
+		if r.sink.Syslog != nil {
+			encode := &section{name: "FILTER"}
+			encode.set("Name", "lua")
+			encode.set(r.matchKey, r.matchValue)
+			encode.set("script", configMountPath+"/"+scriptFileName)
+			encode.set("call", "encode")
+			sections = append(sections, encode)
+		}
+
+		output, err := sinkOutput(r, settings.BufferSize)
+		if err != nil {
+			return "", fmt.Errorf("invalid sink %q: %w", r.alias, err)
+		}
+		outputs = append(outputs, output)
+	}
+	sections = append(sections, outputs...)
+
+	exporter := &section{name: "OUTPUT"}
+	exporter.set("Name", "prometheus_exporter")
+	exporter.set("Match", "*"+metricsTagSuffix)
+	exporter.set("Host", "0.0.0.0")
+	exporter.set("Port", strconv.Itoa(MetricsPort))
+	sections = append(sections, exporter)
+
+	b := &strings.Builder{}
+	for _, s := range sections {
+		s.render(b)
+	}
+
+	return b.String(), nil
+}
+
+func sinkOutput(r route, bufferSize *resource.Quantity) (*section, error) {
+	output := &section{name: "OUTPUT"}
+
+	switch {
+	case r.sink.S3 != nil:
+		s3 := r.sink.S3
+
+		output.set("Name", "s3")
+		output.set("bucket", s3.Bucket)
+		output.set("region", s3.Region)
+		if s3.Endpoint != "" {
+			output.set("endpoint", s3.Endpoint)
+		}
+		if s3.RoleARN != "" {
+			output.set("role_arn", s3.RoleARN)
+		}
+		// the tag of forwarded audit events is audit.<project>.<cluster>
+		output.set("s3_key_format", strings.TrimSuffix("/"+strings.Trim(s3.KeyPrefix, "/"), "/")+"/$TAG[1]/$TAG[2]/%Y/%m/%d/%H%M%S-$UUID.jsonl")
+		output.set("s3_key_format_tag_delimiters", ".")
+		output.set("store_dir", fmt.Sprintf("%s/s3/%s", bufferMountPath, r.alias))
+		output.set("total_file_size", "50M")
+		output.set("upload_timeout", "5m")
+
+	case r.sink.Loki != nil:
+		loki := r.sink.Loki
+
+		u, err := url.Parse(loki.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Loki URL: %w", err)
+		}
+
+		output.set("Name", "loki")
+		setEndpoint(output, u)
+		output.set("uri", strings.TrimSuffix(u.Path, "/")+"/loki/api/v1/push")
+		if loki.TenantID != "" {
+			output.set("tenant_id", loki.TenantID)
+		}
+
+		labels := []string{"job=audit-logs", "cluster=$cluster", "project=$project"}
+		for _, key := range sortedKeys(loki.Labels) {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, loki.Labels[key]))
+		}
+		output.set("labels", strings.Join(labels, ", "))
+		output.set("line_format", "json")
+
+		if loki.CredentialsSecretRef != nil {
+			output.set("http_user", fmt.Sprintf("${%s}", credentialsEnvVar(r.alias, "username")))
+			output.set("http_passwd", fmt.Sprintf("${%s}", credentialsEnvVar(r.alias, "password")))
+		}
+
+	case r.sink.Syslog != nil:
+		syslog := r.sink.Syslog
+
+		mode := syslog.Mode
+		if mode == "" {
+			mode = kubermaticv1.AuditLogSyslogModeTCP
+		}
+
+		output.set("Name", "syslog")
+		output.set("host", syslog.Host)
+		output.set("port", strconv.Itoa(int(syslog.Port)))
+		output.set("mode", string(mode))
+		if mode == kubermaticv1.AuditLogSyslogModeTLS {
+			output.set("tls", "On")
+		}
+		output.set("syslog_format", "rfc5424")
+		output.set("syslog_hostname_key", "cluster")
+		output.set("syslog_appname_preset", "kube-apiserver-audit")
+		output.set("syslog_message_key", "message")
+
+	case r.sink.HTTP != nil:
+		http := r.sink.HTTP
+
+		u, err := url.Parse(http.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP URL: %w", err)
+		}
+
+		output.set("Name", "http")
+		setEndpoint(output, u)
+		output.set("uri", u.RequestURI())
+		output.set("format", "json_lines")
+		output.set("json_date_key", "timestamp")
+		output.set("json_date_format", "iso8601")
+		for _, key := range sortedKeys(http.Headers) {
+			output.set("header", fmt.Sprintf("%s %s", key, http.Headers[key]))
+		}
+
+		if http.CredentialsSecretRef != nil {
+			output.set("http_user", fmt.Sprintf("${%s}", credentialsEnvVar(r.alias, "username")))
+			output.set("http_passwd", fmt.Sprintf("${%s}", credentialsEnvVar(r.alias, "password")))
+		}
+
+	default:
+		return nil, fmt.Errorf("no sink configured")
+	}
+
+	// the alias shows up in the name label of the fluent-bit output metrics, which
+	// makes dropped and retried audit events observable per project
+	output.set("Alias", r.alias)
+	output.set(r.matchKey, r.matchValue)
+	output.set("Retry_Limit", "False")
+	if bufferSize != nil {
+		output.set("storage.total_limit_size", strconv.FormatInt(bufferSize.Value(), 10))
+	}
+
+	return output, nil
+}
+
+// setEndpoint configures the host, port and TLS parameters of an output from the given URL.
+func setEndpoint(output *section, u *url.URL) {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	output.set("host", u.Hostname())
+	output.set("port", port)
+
+	if u.Scheme == "https" {
+		output.set("tls", "On")
+		output.set("tls.verify", "On")
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// script returns the Lua script used to redact audit events and to encode them for syslog sinks.
+func script(rules []kubermaticv1.AuditLogRedactionRule) string {
+	b := &strings.Builder{}
+
+	b.WriteString("local rules = {\n")
+	for _, rule := range rules {
+		action := rule.Action
+		if action == "" {
+			action = kubermaticv1.AuditLogRedactionMask
+		}
+
+		path := []string{}
+		for _, segment := range strings.Split(rule.Path, ".") {
+			path = append(path, strconv.Quote(segment))
+		}
+
+		fmt.Fprintf(b, "  {path = {%s}, action = %q},\n", strings.Join(path, ", "), action)
+	}
+	b.WriteString("}\n")
+
+	b.WriteString(luaFunctions)
+
+	return b.String()
+}
+
+const luaFunctions = `
+local function redact_path(record, path, action)
+  local parent = record
+  for i = 1, #path - 1 do
+    parent = parent[path[i]]
+    if type(parent) ~= "table" then
+      return false
+    end
+  end
+
+  local key = path[#path]
+  if parent[key] == nil then
+    return false
+  end
+
+  if action == "` + string(kubermaticv1.AuditLogRedactionRemove) + `" then
+    parent[key] = nil
+  else
+    parent[key] = "` + maskedValue + `"
+  end
+
+  return true
+end
+
+function redact(tag, timestamp, record)
+  local modified = false
+  for _, rule in ipairs(rules) do
+    if redact_path(record, rule.path, rule.action) then
+      modified = true
+    end
+  end
+
+  if modified then
+    return 2, timestamp, record
+  end
+
+  return 0, timestamp, record
+end
+
+local function encode_string(value)
+  local escaped = value:gsub('[%c"\\]', function(c)
+    if c == '"' then
+      return '\\"'
+    elseif c == "\\" then
+      return "\\\\"
+    end
+    return string.format("\\u%04x", c:byte())
+  end)
+
+  return '"' .. escaped .. '"'
+end
+
+local function encode_value(value)
+  local kind = type(value)
+
+  if kind == "string" then
+    return encode_string(value)
+  elseif kind == "number" or kind == "boolean" then
+    return tostring(value)
+  elseif kind ~= "table" then
+    return "null"
+  end
+
+  local items = {}
+  if #value > 0 then
+    for _, item in ipairs(value) do
+      items[#items + 1] = encode_value(item)
+    end
+
+    return "[" .. table.concat(items, ",") .. "]"
+  end
+
+  local keys = {}
+  for key in pairs(value) do
+    keys[#keys + 1] = key
+  end
+  table.sort(keys, function(a, b) return tostring(a) < tostring(b) end)
+
+  for _, key in ipairs(keys) do
+    items[#items + 1] = encode_string(tostring(key)) .. ":" .. encode_value(value[key])
+  end
+
+  return "{" .. table.concat(items, ",") .. "}"
+end
+
+function encode(tag, timestamp, record)
+  record["message"] = encode_value(record)
+
+  return 2, timestamp, record
+end
+`
+
+// SharedKeySecretReconciler returns the Secret containing the key shared between the aggregator and the
+// audit-logs sidecars. The key is generated once and kept afterwards.
+func SharedKeySecretReconciler() reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.AuditLogAggregatorSharedKeySecretName, func(s *corev1.Secret) (*corev1.Secret, error) {
+			if len(s.Data[resources.AuditLogAggregatorSharedKeySecretKey]) > 0 {
+				return s, nil
+			}
+
+			key := make([]byte, 32)
+			if _, err := rand.Read(key); err != nil {
+				return nil, fmt.Errorf("failed to generate shared key: %w", err)
+			}
+
+			s.Data = map[string][]byte{
+				resources.AuditLogAggregatorSharedKeySecretKey: []byte(hex.EncodeToString(key)),
+			}
+
+			return s, nil
+		}
+	}
+}
+
+// SecretReconciler returns the Secret containing the aggregator's fluent-bit configuration.
+func SecretReconciler(seed *kubermaticv1.Seed) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return ConfigSecretName, func(s *corev1.Secret) (*corev1.Secret, error) {
+			settings := seed.Spec.AuditLogging
+
+			fluentBitConfig, err := config(settings)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Data = map[string][]byte{
+				configFileName:  []byte(fluentBitConfig),
+				parsersFileName: []byte(parsersConfig),
+				scriptFileName:  []byte(script(settings.RedactionRules)),
+			}
+
+			return s, nil
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRoutes(t *testing.T) {
+	settings := &kubermaticv1.AuditLogAggregatorSettings{
+		DefaultSink: kubermaticv1.AuditLogSink{
+			Loki: &kubermaticv1.AuditLogLokiSink{URL: "https://loki.example.com"},
+		},
+		ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+			"xyz987": {HTTP: &kubermaticv1.AuditLogHTTPSink{URL: "https://collector.example.com"}},
+			"abc123": {HTTP: &kubermaticv1.AuditLogHTTPSink{URL: "https://collector.example.com"}},
+		},
+	}
+
+	result := routes(settings)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 routes, got %d", len(result))
+	}
+
+	if result[0].alias != "project-abc123" || result[1].alias != "project-xyz987" || result[2].alias != defaultSinkAlias {
+		t.Fatalf("Expected project routes sorted by project ID followed by the default route, got %q, %q, %q", result[0].alias, result[1].alias, result[2].alias)
+	}
+
+	if result[0].matchValue != "audit.abc123.*" {
+		t.Errorf("Expected project route to match %q, got %q", "audit.abc123.*", result[0].matchValue)
+	}
+
+	// Go does not support negative lookaheads, so the default route's regex
+	// is checked by looking at its structure instead of evaluating it
+	expected := `^audit\.(?!(abc123|xyz987)\.)`
+	if result[2].matchKey != "Match_Regex" || result[2].matchValue != expected {
+		t.Errorf("Expected default route to use Match_Regex %q, got %s %q", expected, result[2].matchKey, result[2].matchValue)
+	}
+
+	result = routes(&kubermaticv1.AuditLogAggregatorSettings{DefaultSink: settings.DefaultSink})
+	if len(result) != 1 || result[0].matchKey != "Match" || result[0].matchValue != "audit.*" {
+		t.Errorf("Expected a single default route matching all audit events, got %+v", result)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	bufferSize := resource.MustParse("1Mi")
+
+	settings := &kubermaticv1.AuditLogAggregatorSettings{
+		Enabled:    true,
+		BufferSize: &bufferSize,
+		RedactionRules: []kubermaticv1.AuditLogRedactionRule{
+			{Path: "requestObject.data"},
+		},
+		DefaultSink: kubermaticv1.AuditLogSink{
+			S3: &kubermaticv1.AuditLogS3Sink{
+				Bucket:    "audit",
+				Region:    "eu-central-1",
+				KeyPrefix: "/seed-1/",
+			},
+		},
+		ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+			"abc123": {
+				Loki: &kubermaticv1.AuditLogLokiSink{
+					URL:                  "https://loki.example.com/tenant",
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: "loki-credentials"},
+				},
+			},
+			"def456": {
+				Syslog: &kubermaticv1.AuditLogSyslogSink{Host: "syslog.example.com", Port: 514},
+			},
+		},
+	}
+
+	cfg, err := config(settings)
+	if err != nil {
+		t.Fatalf("Failed to render config: %v", err)
+	}
+
+	for _, expected := range []string{
+		`call                         redact`,
+		`s3_key_format                /seed-1/$TAG[1]/$TAG[2]/%Y/%m/%d/%H%M%S-$UUID.jsonl`,
+		`uri                          /tenant/loki/api/v1/push`,
+		`http_passwd                  ${SINK_PROJECT_ABC123_PASSWORD}`,
+		`Alias                        project-def456`,
+		`syslog_message_key           message`,
+		`storage.total_limit_size     1048576`,
+		`Label_field                  project`,
+		`Shared_Key                   ${FORWARD_SHARED_KEY}`,
+	} {
+		if !strings.Contains(cfg, expected) {
+			t.Errorf("Expected config to contain %q, but it did not:\n%s", expected, cfg)
+		}
+	}
+
+	// the buffer volume must hold the buffers of all three sinks
+	if limit := bufferSizeLimit(settings); limit == nil || limit.Value() != 3*bufferSize.Value() {
+		t.Errorf("Expected buffer size limit of %d bytes, got %v", 3*bufferSize.Value(), limit)
+	}
+
+	// the syslog encoding filter must only apply to the events routed to the syslog sink
+	encodeFilter := regexp.MustCompile(`(?s)\[FILTER\]\n    Name\s+lua\n    Match\s+audit\.def456\.\*\n    script\s+\S+\n    call\s+encode\n`)
+	if !encodeFilter.MatchString(cfg) {
+		t.Errorf("Expected config to contain the encode filter for the syslog sink:\n%s", cfg)
+	}
+
+	env := credentialsEnv(settings)
+	if len(env) != 2 || env[0].Name != "SINK_PROJECT_ABC123_USERNAME" || env[0].ValueFrom.SecretKeyRef.Name != "loki-credentials" {
+		t.Errorf("Expected the Loki credentials to be injected, got %+v", env)
+	}
+}
+
+func TestScript(t *testing.T) {
+	s := script([]kubermaticv1.AuditLogRedactionRule{
+		{Path: "requestObject.data"},
+		{Path: "user.extra", Action: kubermaticv1.AuditLogRedactionRemove},
+	})
+
+	for _, expected := range []string{
+		`{path = {"requestObject", "data"}, action = "Mask"},`,
+		`{path = {"user", "extra"}, action = "Remove"},`,
+		`function redact(tag, timestamp, record)`,
+		`function encode(tag, timestamp, record)`,
+	} {
+		if !strings.Contains(s, expected) {
+			t.Errorf("Expected script to contain %q, but it did not:\n%s", expected, s)
+		}
+	}
+}
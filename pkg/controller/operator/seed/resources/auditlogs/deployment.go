@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"strconv"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// HealthPort is the port of the aggregator's fluent-bit HTTP server, used for health checks.
+	HealthPort = 2020
+	// MetricsPort is the port the aggregator exposes its Prometheus metrics on.
+	MetricsPort = 2021
+
+	fluentBitImage = "/fluent/fluent-bit:3.1.9"
+)
+
+// DeploymentReconciler returns the aggregator Deployment.
+func DeploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) reconciling.NamedDeploymentReconcilerFactory {
+	return func() (string, reconciling.DeploymentReconciler) {
+		return resources.AuditLogAggregatorName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+			settings := seed.Spec.AuditLogging
+			if settings == nil {
+				settings = &kubermaticv1.AuditLogAggregatorSettings{}
+			}
+
+			d.Spec.Replicas = settings.Replicas
+			d.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.NameLabel: resources.AuditLogAggregatorName,
+				},
+			}
+
+			kubernetes.EnsureLabels(&d.Spec.Template, d.Spec.Selector.MatchLabels)
+			// the apiserver network policies select the aggregator by its app label
+			kubernetes.EnsureLabels(&d.Spec.Template, map[string]string{
+				resources.AppLabelKey: resources.AuditLogAggregatorName,
+			})
+			kubernetes.EnsureAnnotations(&d.Spec.Template, map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   strconv.Itoa(MetricsPort),
+				"prometheus.io/path":   "/metrics",
+				resources.ClusterAutoscalerSafeToEvictVolumesAnnotation: "buffer",
+			})
+
+			getRegistry := registry.GetImageRewriterFunc(cfg.Spec.UserCluster.OverwriteRegistry)
+
+			d.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:    "fluent-bit",
+					Image:   registry.Must(getRegistry(resources.RegistryDocker + fluentBitImage)),
+					Command: []string{"/fluent-bit/bin/fluent-bit"},
+					Args:    []string{"-c", configMountPath + "/" + configFileName},
+					Env: append([]corev1.EnvVar{
+						secretEnvVar(sharedKeyEnvVar, resources.AuditLogAggregatorSharedKeySecretName, resources.AuditLogAggregatorSharedKeySecretKey),
+					}, credentialsEnv(settings)...),
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "forward",
+							ContainerPort: resources.AuditLogAggregatorForwardPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+						{
+							Name:          "http",
+							ContainerPort: HealthPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+						{
+							Name:          "metrics",
+							ContainerPort: MetricsPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "config",
+							MountPath: configMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      "buffer",
+							MountPath: bufferMountPath,
+						},
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/api/v1/health",
+								Port: intstr.FromInt(HealthPort),
+							},
+						},
+						InitialDelaySeconds: 10,
+						PeriodSeconds:       10,
+						FailureThreshold:    3,
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{
+								Port: intstr.FromInt(resources.AuditLogAggregatorForwardPort),
+							},
+						},
+						PeriodSeconds: 5,
+					},
+					Resources: settings.Resources,
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+						ReadOnlyRootFilesystem:   ptr.To(true),
+					},
+				},
+			}
+
+			d.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: ConfigSecretName,
+						},
+					},
+				},
+				{
+					Name: "buffer",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							SizeLimit: bufferSizeLimit(settings),
+						},
+					},
+				},
+			}
+
+			return d, nil
+		}
+	}
+}
+
+// bufferSizeLimit returns the size limit of the buffer volume, which holds the
+// filesystem buffer of every sink.
+func bufferSizeLimit(settings *kubermaticv1.AuditLogAggregatorSettings) *resource.Quantity {
+	if settings.BufferSize == nil {
+		return nil
+	}
+
+	sinks := int64(len(routes(settings)))
+
+	return resource.NewQuantity(settings.BufferSize.Value()*sinks, resource.BinarySI)
+}
+
+// credentialsEnv returns the environment variables that inject the credentials of all sinks.
+func credentialsEnv(settings *kubermaticv1.AuditLogAggregatorSettings) []corev1.EnvVar {
+	var (
+		env            []corev1.EnvVar
+		awsCredentials bool
+	)
+
+	for _, r := range routes(settings) {
+		switch {
+		case r.sink.S3 != nil && r.sink.S3.CredentialsSecretRef != nil:
+			// the S3 output relies on the AWS credential chain, so all S3 sinks share one set of credentials
+			if !awsCredentials {
+				env = append(env,
+					secretEnvVar("AWS_ACCESS_KEY_ID", r.sink.S3.CredentialsSecretRef.Name, "accessKeyId"),
+					secretEnvVar("AWS_SECRET_ACCESS_KEY", r.sink.S3.CredentialsSecretRef.Name, "secretAccessKey"),
+				)
+				awsCredentials = true
+			}
+
+		case r.sink.Loki != nil && r.sink.Loki.CredentialsSecretRef != nil:
+			env = append(env,
+				secretEnvVar(credentialsEnvVar(r.alias, "username"), r.sink.Loki.CredentialsSecretRef.Name, "username"),
+				secretEnvVar(credentialsEnvVar(r.alias, "password"), r.sink.Loki.CredentialsSecretRef.Name, "password"),
+			)
+
+		case r.sink.HTTP != nil && r.sink.HTTP.CredentialsSecretRef != nil:
+			env = append(env,
+				secretEnvVar(credentialsEnvVar(r.alias, "username"), r.sink.HTTP.CredentialsSecretRef.Name, "username"),
+				secretEnvVar(credentialsEnvVar(r.alias, "password"), r.sink.HTTP.CredentialsSecretRef.Name, "password"),
+			)
+		}
+	}
+
+	return env
+}
+
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditlogs contains the seed-level audit log aggregator. It is a
+// fluent-bit Deployment that the audit-logs sidecars of all user cluster
+// apiservers on the seed forward their audit events to. The aggregator
+// applies the seed-wide redaction rules and routes the audit events per
+// project to the configured sinks.
+package auditlogs
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReconcileAuditLogAggregator reconciles the seed-level audit log aggregator, or removes it
+// if it is not enabled for the seed.
+func ReconcileAuditLogAggregator(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) error {
+	if !seed.IsAuditLogAggregatorEnabled() {
+		return undeploy(ctx, client, seed.Namespace)
+	}
+
+	seedOwner := common.OwnershipModifierFactory(seed, scheme)
+
+	if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretReconcilerFactory{
+		SecretReconciler(seed),
+		SharedKeySecretReconciler(),
+	}, seed.Namespace, client, seedOwner); err != nil {
+		return fmt.Errorf("failed to reconcile audit log aggregator Secret: %w", err)
+	}
+
+	modifiers := []reconciling.ObjectModifier{
+		seedOwner,
+		common.VolumeRevisionLabelsModifierFactory(ctx, client),
+	}
+	if cfg.Spec.ImagePullSecret != "" {
+		modifiers = append(modifiers, reconciling.ImagePullSecretsWrapper(common.DockercfgSecretName))
+	}
+
+	if err := reconciling.ReconcileDeployments(ctx, []reconciling.NamedDeploymentReconcilerFactory{
+		DeploymentReconciler(cfg, seed),
+	}, seed.Namespace, client, modifiers...); err != nil {
+		return fmt.Errorf("failed to reconcile audit log aggregator Deployment: %w", err)
+	}
+
+	if err := reconciling.ReconcileServices(ctx, []reconciling.NamedServiceReconcilerFactory{
+		ServiceReconciler(),
+	}, seed.Namespace, client, seedOwner); err != nil {
+		return fmt.Errorf("failed to reconcile audit log aggregator Service: %w", err)
+	}
+
+	return nil
+}
+
+// undeploy removes all audit log aggregator resources.
+func undeploy(ctx context.Context, client ctrlruntimeclient.Client, namespace string) error {
+	objects := []ctrlruntimeclient.Object{
+		&corev1.Service{},
+		&appsv1.Deployment{},
+	}
+
+	for _, obj := range objects {
+		if err := cleanupResource(ctx, client, types.NamespacedName{Name: resources.AuditLogAggregatorName, Namespace: namespace}, obj); err != nil {
+			return fmt.Errorf("failed to cleanup audit log aggregator %T: %w", obj, err)
+		}
+	}
+
+	for _, name := range []string{ConfigSecretName, resources.AuditLogAggregatorSharedKeySecretName} {
+		if err := cleanupResource(ctx, client, types.NamespacedName{Name: name, Namespace: namespace}, &corev1.Secret{}); err != nil {
+			return fmt.Errorf("failed to cleanup audit log aggregator Secret %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func cleanupResource(ctx context.Context, client ctrlruntimeclient.Client, key types.NamespacedName, obj ctrlruntimeclient.Object) error {
+	obj.SetNamespace(key.Namespace)
+	obj.SetName(key.Name)
+
+	return ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, obj))
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServiceReconciler returns the Service the audit-logs sidecars forward audit events to.
+func ServiceReconciler() reconciling.NamedServiceReconcilerFactory {
+	return func() (string, reconciling.ServiceReconciler) {
+		return resources.AuditLogAggregatorName, func(s *corev1.Service) (*corev1.Service, error) {
+			s.Spec.Type = corev1.ServiceTypeClusterIP
+			s.Spec.Selector = map[string]string{
+				common.NameLabel: resources.AuditLogAggregatorName,
+			}
+			s.Spec.Ports = []corev1.ServicePort{
+				{
+					Name:       "forward",
+					Port:       resources.AuditLogAggregatorForwardPort,
+					TargetPort: intstr.FromInt(resources.AuditLogAggregatorForwardPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			}
+
+			return s, nil
+		}
+	}
+}
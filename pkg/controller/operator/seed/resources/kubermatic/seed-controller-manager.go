@@ -97,6 +97,10 @@ func SeedControllerManagerDeploymentReconciler(workerName string, versions kuber
 				args = append(args, "-enable-user-cluster-mla")
 			}
 
+			if window := cfg.Spec.SeedController.CertificateRenewalWindow; window != nil {
+				args = append(args, fmt.Sprintf("-certificate-renewal-window=%s", window.Duration))
+			}
+
 			if cfg.Spec.SeedController.DebugLog {
 				args = append(args, "-v=4", "-log-debug=true")
 			} else {
@@ -23,18 +23,20 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ReconcileMeteringResources reconciles the metering related resources.
-func ReconcileMeteringResources(_ context.Context, _ ctrlruntimeclient.Client, _ *runtime.Scheme, _ *kubermaticv1.KubermaticConfiguration, _ *kubermaticv1.Seed) error {
+func ReconcileMeteringResources(_ context.Context, _ ctrlruntimeclient.Client, _ ctrlruntimeclient.Reader, _ *runtime.Scheme, _ record.EventRecorder, _ *kubermaticv1.KubermaticConfiguration, _ *kubermaticv1.Seed, _ kubermaticversion.Versions) error {
 	return nil
 }
 
 // CronJobReconciler returns the func to create/update the metering report cronjob. Available only for ee.
-func CronJobReconciler(_ string, _ kubermaticv1.MeteringReportConfiguration, _ string, _ registry.ImageRewriter, _ *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
+func CronJobReconciler(_ string, _ kubermaticv1.MeteringReportConfiguration, _ string, _ registry.ImageRewriter, _ string, _ *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
 	return nil
 }
@@ -24,18 +24,20 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/ee/metering"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ReconcileMeteringResources reconciles the metering related resources.
-func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) error {
-	return metering.ReconcileMeteringResources(ctx, client, scheme, cfg, seed)
+func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, masterClient ctrlruntimeclient.Reader, scheme *runtime.Scheme, recorder record.EventRecorder, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions kubermaticversion.Versions) error {
+	return metering.ReconcileMeteringResources(ctx, client, masterClient, scheme, recorder, cfg, seed, versions)
 }
 
 // CronJobReconciler returns the func to create/update the metering report cronjob. Available only for ee.
-func CronJobReconciler(rn string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, r registry.ImageRewriter, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
-	return metering.CronJobReconciler(rn, mrc, caBundleName, r, seed)
+func CronJobReconciler(rn string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, r registry.ImageRewriter, kubermaticImage string, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
+	return metering.CronJobReconciler(rn, mrc, caBundleName, r, kubermaticImage, seed)
 }
@@ -24,6 +24,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	nodeportproxyresources "k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
@@ -98,6 +99,8 @@ func EnvoyDeploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed *
 				fmt.Sprintf("-envoy-sni-port=%d", EnvoySNIPort),
 				fmt.Sprintf("-envoy-tunneling-port=%d", EnvoyTunnelingPort),
 			}
+			args = append(args, nodeportproxyresources.EnvoyManagerArgs(seed.Spec.NodeportProxy)...)
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    "envoy-manager",
@@ -228,6 +231,8 @@ func UpdaterDeploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed
 			args := []string{
 				"-lb-namespace=$(NAMESPACE)",
 				fmt.Sprintf("-lb-name=%s", ServiceName),
+				"-enable-leader-election",
+				"-leader-election-namespace=$(NAMESPACE)",
 				fmt.Sprintf("-envoy-sni-port=%d", EnvoySNIPort),
 				fmt.Sprintf("-envoy-tunneling-port=%d", EnvoyTunnelingPort),
 			}
@@ -41,6 +41,12 @@ func RoleReconciler() reconciling.NamedRoleReconcilerFactory {
 					Verbs:         []string{"update"},
 					ResourceNames: []string{ServiceName},
 				},
+				// for the leader election of the lb-updater
+				{
+					APIGroups: []string{"coordination.k8s.io"},
+					Resources: []string{"leases"},
+					Verbs:     []string{"get", "create", "update"},
+				},
 			}
 
 			return cr, nil
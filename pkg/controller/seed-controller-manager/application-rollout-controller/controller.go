@@ -0,0 +1,542 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationrolloutcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// This controller upgrades ApplicationInstallations in user clusters according to ApplicationRollouts.
+	ControllerName = "kkp-application-rollout-controller"
+
+	// requeueInterval is how often the controller checks on the upgraded installations.
+	requeueInterval = 30 * time.Second
+
+	// maxListedInstallations is the maximum number of installations named in the rollout's status message.
+	maxListedInstallations = 5
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client.
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type reconciler struct {
+	ctrlruntimeclient.Client
+
+	log                           *zap.SugaredLogger
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider UserClusterClientProvider
+}
+
+func Add(mgr manager.Manager, numWorkers int, workerName string, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger) error {
+	r := &reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&appskubermaticv1.ApplicationRollout{}, builder.WithPredicates(workerlabel.Predicate(workerName))).
+		Build(r)
+
+	return err
+}
+
+// failure is returned if the rollout cannot be performed at all.
+type failure struct {
+	message string
+}
+
+func (f *failure) Error() string {
+	return f.message
+}
+
+func failed(format string, args ...interface{}) error {
+	return &failure{message: fmt.Sprintf(format, args...)}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("rollout", request.Name)
+	log.Debug("Processing")
+
+	rollout := &appskubermaticv1.ApplicationRollout{}
+	if err := r.Get(ctx, request.NamespacedName, rollout); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !rollout.DeletionTimestamp.IsZero() || isFinished(rollout) {
+		return reconcile.Result{}, nil
+	}
+
+	err := r.reconcile(ctx, log, rollout)
+
+	var f *failure
+	if errors.As(err, &f) {
+		log.Errorw("Rollout failed", zap.Error(err))
+		r.recorder.Event(rollout, corev1.EventTypeWarning, "RolloutFailed", f.message)
+
+		return reconcile.Result{}, r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+			s.Phase = appskubermaticv1.ApplicationRolloutPhaseFailed
+			s.Message = f.message
+		})
+	}
+
+	if err != nil {
+		r.recorder.Event(rollout, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	if isFinished(rollout) {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func isFinished(rollout *appskubermaticv1.ApplicationRollout) bool {
+	switch rollout.Status.Phase {
+	case appskubermaticv1.ApplicationRolloutPhaseCompleted,
+		appskubermaticv1.ApplicationRolloutPhaseRolledBack,
+		appskubermaticv1.ApplicationRolloutPhaseFailed:
+		return true
+	}
+
+	return false
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	if err := r.validateTargetVersion(ctx, rollout); err != nil {
+		return err
+	}
+
+	if rollout.Spec.Paused {
+		return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+			s.Phase = appskubermaticv1.ApplicationRolloutPhasePaused
+			s.Message = "Rollout has been paused."
+		})
+	}
+
+	if rollout.Status.CurrentWave >= len(rollout.Spec.Waves) {
+		return failed("current wave %d does not exist, the rollout only has %d waves", rollout.Status.CurrentWave, len(rollout.Spec.Waves))
+	}
+
+	waves, paused, err := r.clusterWaves(ctx, rollout)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordSkippedClusters(ctx, rollout, paused); err != nil {
+		return err
+	}
+
+	// Installations of previous waves are checked as well, so that failures
+	// after a wave has been completed still stop the rollout.
+	var pending []string
+	for wave := 0; wave <= rollout.Status.CurrentWave; wave++ {
+		for i := range waves[wave] {
+			cluster := &waves[wave][i]
+
+			clusterPending, failureMessage, err := r.reconcileCluster(ctx, log, rollout, cluster, wave)
+			if err != nil {
+				return fmt.Errorf("failed to reconcile cluster %s: %w", cluster.Name, err)
+			}
+
+			if failureMessage != "" {
+				return r.handleFailure(ctx, log, rollout, failureMessage)
+			}
+
+			pending = append(pending, clusterPending...)
+		}
+	}
+
+	wave := rollout.Spec.Waves[rollout.Status.CurrentWave]
+
+	if len(pending) > 0 {
+		return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+			s.Phase = appskubermaticv1.ApplicationRolloutPhaseProgressing
+			s.WaveReadyTime = nil
+			s.Message = fmt.Sprintf("Wave %q: waiting for %s.", wave.Name, describePending(pending))
+		})
+	}
+
+	now := metav1.Now()
+	readyTime := rollout.Status.WaveReadyTime
+	if readyTime == nil {
+		readyTime = &now
+	}
+
+	if remaining := wave.SoakTime.Duration - now.Sub(readyTime.Time); remaining > 0 {
+		return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+			s.Phase = appskubermaticv1.ApplicationRolloutPhaseProgressing
+			s.WaveReadyTime = readyTime
+			s.Message = fmt.Sprintf("Wave %q is ready, soaking for %v.", wave.Name, remaining.Round(time.Second))
+		})
+	}
+
+	log.Infow("Wave completed", "wave", wave.Name)
+	r.recorder.Eventf(rollout, corev1.EventTypeNormal, "WaveCompleted", "Wave %q has been upgraded to version %s", wave.Name, rollout.Spec.TargetVersion)
+
+	return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+		s.CurrentWave++
+		s.WaveReadyTime = nil
+
+		if s.CurrentWave >= len(rollout.Spec.Waves) {
+			s.CurrentWave = len(rollout.Spec.Waves) - 1
+			s.Phase = appskubermaticv1.ApplicationRolloutPhaseCompleted
+			s.Message = fmt.Sprintf("All waves have been upgraded to version %s.", rollout.Spec.TargetVersion)
+			return
+		}
+
+		s.Phase = appskubermaticv1.ApplicationRolloutPhaseProgressing
+		s.Message = fmt.Sprintf("Starting wave %q.", rollout.Spec.Waves[s.CurrentWave].Name)
+	})
+}
+
+func (r *reconciler) validateTargetVersion(ctx context.Context, rollout *appskubermaticv1.ApplicationRollout) error {
+	appDef := &appskubermaticv1.ApplicationDefinition{}
+	if err := r.Get(ctx, types.NamespacedName{Name: rollout.Spec.ApplicationDefinition}, appDef); err != nil {
+		if apierrors.IsNotFound(err) {
+			return failed("ApplicationDefinition %q does not exist", rollout.Spec.ApplicationDefinition)
+		}
+		return fmt.Errorf("failed to get ApplicationDefinition: %w", err)
+	}
+
+	for _, version := range appDef.Spec.Versions {
+		if version.Version == rollout.Spec.TargetVersion {
+			return nil
+		}
+	}
+
+	return failed("ApplicationDefinition %q has no version %q", appDef.Name, rollout.Spec.TargetVersion)
+}
+
+// clusterWaves returns the clusters of each wave. Clusters are assigned to the
+// first wave whose selector matches them. Clusters whose reconciliation is paused
+// are not part of any wave, they are returned separately with the wave they belong to.
+func (r *reconciler) clusterWaves(ctx context.Context, rollout *appskubermaticv1.ApplicationRollout) ([][]kubermaticv1.Cluster, map[string]int, error) {
+	selectors := make([]labels.Selector, len(rollout.Spec.Waves))
+	for i, wave := range rollout.Spec.Waves {
+		selector, err := metav1.LabelSelectorAsSelector(&wave.ClusterSelector)
+		if err != nil {
+			return nil, nil, failed("invalid cluster selector in wave %q: %v", wave.Name, err)
+		}
+		selectors[i] = selector
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return nil, nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		return clusters.Items[i].Name < clusters.Items[j].Name
+	})
+
+	waves := make([][]kubermaticv1.Cluster, len(rollout.Spec.Waves))
+	paused := map[string]int{}
+	for _, cluster := range clusters.Items {
+		if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+			continue
+		}
+
+		for i, selector := range selectors {
+			if selector.Matches(labels.Set(cluster.Labels)) {
+				if cluster.Spec.Pause {
+					paused[cluster.Name] = i
+				} else {
+					waves[i] = append(waves[i], cluster)
+				}
+				break
+			}
+		}
+	}
+
+	return waves, paused, nil
+}
+
+// recordSkippedClusters records the paused clusters of the started waves in the rollout's status
+// and emits an event for each cluster that is skipped for the first time.
+func (r *reconciler) recordSkippedClusters(ctx context.Context, rollout *appskubermaticv1.ApplicationRollout, paused map[string]int) error {
+	var skipped []string
+	for name, wave := range paused {
+		if wave <= rollout.Status.CurrentWave {
+			skipped = append(skipped, name)
+		}
+	}
+	sort.Strings(skipped)
+
+	for _, name := range skipped {
+		if !slices.Contains(rollout.Status.SkippedClusters, name) {
+			r.recorder.Eventf(rollout, corev1.EventTypeWarning, "ClusterSkipped", "Cluster %s is not upgraded because its reconciliation is paused", name)
+		}
+	}
+
+	return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+		s.SkippedClusters = skipped
+	})
+}
+
+// reconcileCluster upgrades the matching installations in the given cluster. It returns what the
+// wave is still waiting for, or the message of the first failed installation.
+func (r *reconciler) reconcileCluster(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout, cluster *kubermaticv1.Cluster, wave int) ([]string, string, error) {
+	if !cluster.Status.ExtendedHealth.ApplicationControllerHealthy() {
+		return []string{fmt.Sprintf("cluster %s", cluster.Name)}, "", nil
+	}
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	installations := &appskubermaticv1.ApplicationInstallationList{}
+	if err := userClusterClient.List(ctx, installations); err != nil {
+		return nil, "", fmt.Errorf("failed to list ApplicationInstallations: %w", err)
+	}
+
+	var pending []string
+	for i := range installations.Items {
+		installation := &installations.Items[i]
+		if installation.Spec.ApplicationRef.Name != rollout.Spec.ApplicationDefinition || !installation.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s/%s", cluster.Name, installation.Namespace, installation.Name)
+
+		if findInstallation(rollout, cluster.Name, installation) == nil {
+			if !isSourceVersion(rollout, installation.Spec.ApplicationRef.Version) {
+				continue
+			}
+
+			// The previous version has to be recorded before the installation is
+			// touched, otherwise it could not be rolled back.
+			err := r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+				s.Installations = append(s.Installations, appskubermaticv1.ApplicationRolloutInstallation{
+					Cluster:         cluster.Name,
+					Namespace:       installation.Namespace,
+					Name:            installation.Name,
+					Wave:            wave,
+					PreviousVersion: installation.Spec.ApplicationRef.Version,
+				})
+			})
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		if installation.Spec.ApplicationRef.Version != rollout.Spec.TargetVersion {
+			log.Infow("Upgrading ApplicationInstallation", "installation", name, "version", rollout.Spec.TargetVersion)
+
+			if err := setVersion(ctx, userClusterClient, installation, rollout.Spec.TargetVersion); err != nil {
+				return nil, "", err
+			}
+		}
+
+		ready, installErr := installationState(installation, rollout.Spec.TargetVersion)
+		if installErr != nil {
+			return nil, fmt.Sprintf("ApplicationInstallation %s failed: %v", name, installErr), nil
+		}
+
+		if !ready {
+			pending = append(pending, "ApplicationInstallation "+name)
+		}
+	}
+
+	return pending, "", nil
+}
+
+// handleFailure pauses the rollout or, if enabled, rolls back all upgraded installations.
+func (r *reconciler) handleFailure(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout, message string) error {
+	log.Infow("Upgraded installation has failed", "reason", message)
+	r.recorder.Event(rollout, corev1.EventTypeWarning, "InstallationFailed", message)
+
+	if !rollout.Spec.AutoRollback {
+		return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+			s.Phase = appskubermaticv1.ApplicationRolloutPhasePaused
+			s.WaveReadyTime = nil
+			s.Message = fmt.Sprintf("%s. The rollout continues once the installation has recovered.", message)
+		})
+	}
+
+	if err := r.rollback(ctx, log, rollout); err != nil {
+		return fmt.Errorf("failed to roll back: %w", err)
+	}
+
+	r.recorder.Eventf(rollout, corev1.EventTypeNormal, "RolledBack", "Installations have been reverted to their previous versions")
+
+	return r.updateStatus(ctx, rollout, func(s *appskubermaticv1.ApplicationRolloutStatus) {
+		s.Phase = appskubermaticv1.ApplicationRolloutPhaseRolledBack
+		s.WaveReadyTime = nil
+		s.Message = fmt.Sprintf("%s. All upgraded installations have been rolled back.", message)
+	})
+}
+
+func (r *reconciler) rollback(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	for _, recorded := range rollout.Status.Installations {
+		cluster := &kubermaticv1.Cluster{}
+		if err := r.Get(ctx, types.NamespacedName{Name: recorded.Cluster}, cluster); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get cluster %s: %w", recorded.Cluster, err)
+		}
+
+		userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+		if err != nil {
+			return fmt.Errorf("failed to get client for cluster %s: %w", cluster.Name, err)
+		}
+
+		installation := &appskubermaticv1.ApplicationInstallation{}
+		if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: recorded.Namespace, Name: recorded.Name}, installation); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get ApplicationInstallation: %w", err)
+		}
+
+		if installation.Spec.ApplicationRef.Version == recorded.PreviousVersion {
+			continue
+		}
+
+		log.Infow("Rolling back ApplicationInstallation", "installation", fmt.Sprintf("%s/%s/%s", recorded.Cluster, recorded.Namespace, recorded.Name), "version", recorded.PreviousVersion)
+
+		if err := setVersion(ctx, userClusterClient, installation, recorded.PreviousVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setVersion(ctx context.Context, client ctrlruntimeclient.Client, installation *appskubermaticv1.ApplicationInstallation, version string) error {
+	oldInstallation := installation.DeepCopy()
+	installation.Spec.ApplicationRef.Version = version
+
+	if err := client.Patch(ctx, installation, ctrlruntimeclient.MergeFrom(oldInstallation)); err != nil {
+		return fmt.Errorf("failed to update version of ApplicationInstallation %s/%s: %w", installation.Namespace, installation.Name, err)
+	}
+
+	return nil
+}
+
+// installationState returns whether the installation has been upgraded successfully, or an
+// error if the upgrade has failed.
+func installationState(installation *appskubermaticv1.ApplicationInstallation, version string) (bool, error) {
+	if installation.Status.ApplicationVersion == nil || installation.Status.ApplicationVersion.Version != version {
+		return false, nil
+	}
+
+	condition, ok := installation.Status.Conditions[appskubermaticv1.Ready]
+	if !ok || condition.ObservedGeneration < installation.Generation {
+		return false, nil
+	}
+
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		return true, nil
+	case corev1.ConditionFalse:
+		if condition.Message == "" {
+			return false, errors.New(condition.Reason)
+		}
+		return false, errors.New(condition.Message)
+	default:
+		return false, nil
+	}
+}
+
+func findInstallation(rollout *appskubermaticv1.ApplicationRollout, cluster string, installation *appskubermaticv1.ApplicationInstallation) *appskubermaticv1.ApplicationRolloutInstallation {
+	for i, recorded := range rollout.Status.Installations {
+		if recorded.Cluster == cluster && recorded.Namespace == installation.Namespace && recorded.Name == installation.Name {
+			return &rollout.Status.Installations[i]
+		}
+	}
+
+	return nil
+}
+
+func isSourceVersion(rollout *appskubermaticv1.ApplicationRollout, version string) bool {
+	if version == rollout.Spec.TargetVersion {
+		return false
+	}
+
+	if len(rollout.Spec.SourceVersions) == 0 {
+		return true
+	}
+
+	for _, v := range rollout.Spec.SourceVersions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describePending(pending []string) string {
+	if len(pending) > maxListedInstallations {
+		return fmt.Sprintf("%s and %d more", strings.Join(pending[:maxListedInstallations], ", "), len(pending)-maxListedInstallations)
+	}
+
+	return strings.Join(pending, ", ")
+}
+
+func (r *reconciler) updateStatus(ctx context.Context, rollout *appskubermaticv1.ApplicationRollout, modify func(*appskubermaticv1.ApplicationRolloutStatus)) error {
+	oldRollout := rollout.DeepCopy()
+	modify(&rollout.Status)
+
+	if reflect.DeepEqual(oldRollout.Status, rollout.Status) {
+		return nil
+	}
+
+	if err := r.Status().Patch(ctx, rollout, ctrlruntimeclient.MergeFrom(oldRollout)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationrolloutcontroller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/test/generator"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	appName      = "app-a"
+	appNamespace = "default"
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name string
+		// clusters are labelled with their stage, "canary" or "prod"
+		clusters map[string]string
+		// pausedClusters are the clusters whose reconciliation is paused
+		pausedClusters []string
+		installations  map[string]*appskubermaticv1.ApplicationInstallation
+		rollout        *appskubermaticv1.ApplicationRollout
+		expectedPhase  appskubermaticv1.ApplicationRolloutPhase
+		expectedWave   int
+		// expectedVersions maps cluster names to the expected version of their installation
+		expectedVersions map[string]string
+		expectedSkipped  []string
+	}{
+		{
+			name:     "scenario 1: first wave is upgraded, second wave is untouched",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("1.0.0", "", ""),
+				"prod-1":   genInstallation("1.0.0", "", ""),
+			},
+			rollout:          genRollout(nil),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseProgressing,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "prod-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 2: installations not matching the source versions are not upgraded",
+			clusters: map[string]string{"canary-1": "canary", "canary-2": "canary"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("1.0.0", "", ""),
+				"canary-2": genInstallation("0.9.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Spec.SourceVersions = []string{"1.0.0"}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseProgressing,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "canary-2": "0.9.0"},
+		},
+		{
+			name:     "scenario 3: ready wave without soak time moves on to the next wave",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("2.0.0", "2.0.0", corev1.ConditionTrue),
+				"prod-1":   genInstallation("1.0.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Status.Installations = []appskubermaticv1.ApplicationRolloutInstallation{recorded("canary-1", 0, "1.0.0")}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseProgressing,
+			expectedWave:     1,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "prod-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 4: ready wave waits for its soak time",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("2.0.0", "2.0.0", corev1.ConditionTrue),
+				"prod-1":   genInstallation("1.0.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Spec.Waves[0].SoakTime = metav1.Duration{Duration: time.Hour}
+				r.Status.WaveReadyTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+				r.Status.Installations = []appskubermaticv1.ApplicationRolloutInstallation{recorded("canary-1", 0, "1.0.0")}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseProgressing,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "prod-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 5: ready last wave completes the rollout",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("2.0.0", "2.0.0", corev1.ConditionTrue),
+				"prod-1":   genInstallation("2.0.0", "2.0.0", corev1.ConditionTrue),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Status.CurrentWave = 1
+				r.Status.Installations = []appskubermaticv1.ApplicationRolloutInstallation{
+					recorded("canary-1", 0, "1.0.0"),
+					recorded("prod-1", 1, "1.0.0"),
+				}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseCompleted,
+			expectedWave:     1,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "prod-1": "2.0.0"},
+		},
+		{
+			name:     "scenario 6: failed installation pauses the rollout",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("2.0.0", "2.0.0", corev1.ConditionFalse),
+				"prod-1":   genInstallation("1.0.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Status.Installations = []appskubermaticv1.ApplicationRolloutInstallation{recorded("canary-1", 0, "1.0.0")}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhasePaused,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "prod-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 7: failed installation rolls back all upgraded installations",
+			clusters: map[string]string{"canary-1": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("2.0.0", "2.0.0", corev1.ConditionTrue),
+				"prod-1":   genInstallation("2.0.0", "2.0.0", corev1.ConditionFalse),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Spec.AutoRollback = true
+				r.Status.CurrentWave = 1
+				r.Status.Installations = []appskubermaticv1.ApplicationRolloutInstallation{
+					recorded("canary-1", 0, "1.0.0"),
+					recorded("prod-1", 1, "1.0.0"),
+				}
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseRolledBack,
+			expectedWave:     1,
+			expectedVersions: map[string]string{"canary-1": "1.0.0", "prod-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 8: paused rollout does not upgrade installations",
+			clusters: map[string]string{"canary-1": "canary"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("1.0.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Spec.Paused = true
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhasePaused,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 9: unknown target version fails the rollout",
+			clusters: map[string]string{"canary-1": "canary"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("1.0.0", "", ""),
+			},
+			rollout: genRollout(func(r *appskubermaticv1.ApplicationRollout) {
+				r.Spec.TargetVersion = "3.0.0"
+			}),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseFailed,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "1.0.0"},
+		},
+		{
+			name:     "scenario 10: paused clusters of started waves are recorded as skipped",
+			clusters: map[string]string{"canary-1": "canary", "canary-2": "canary", "prod-1": "prod"},
+			installations: map[string]*appskubermaticv1.ApplicationInstallation{
+				"canary-1": genInstallation("1.0.0", "", ""),
+				"canary-2": genInstallation("1.0.0", "", ""),
+				"prod-1":   genInstallation("1.0.0", "", ""),
+			},
+			pausedClusters:   []string{"canary-2", "prod-1"},
+			rollout:          genRollout(nil),
+			expectedPhase:    appskubermaticv1.ApplicationRolloutPhaseProgressing,
+			expectedWave:     0,
+			expectedVersions: map[string]string{"canary-1": "2.0.0", "canary-2": "1.0.0", "prod-1": "1.0.0"},
+			expectedSkipped:  []string{"canary-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			seedObjects := []ctrlruntimeclient.Object{tc.rollout, genApplicationDefinition()}
+			userClusterClients := map[string]ctrlruntimeclient.Client{}
+			for name, stage := range tc.clusters {
+				seedObjects = append(seedObjects, generator.GenCluster(name, name, "project", time.Now(), func(c *kubermaticv1.Cluster) {
+					c.Labels["stage"] = stage
+					c.Spec.Pause = slices.Contains(tc.pausedClusters, name)
+				}))
+
+				builder := kubermaticfake.NewClientBuilder()
+				if installation, ok := tc.installations[name]; ok {
+					builder.WithObjects(installation)
+				}
+				userClusterClients[name] = builder.Build()
+			}
+
+			seedClient := kubermaticfake.NewClientBuilder().WithObjects(seedObjects...).Build()
+
+			r := &reconciler{
+				Client:                        seedClient,
+				log:                           kubermaticlog.Logger,
+				recorder:                      record.NewFakeRecorder(10),
+				userClusterConnectionProvider: &fakeClientProvider{clients: userClusterClients},
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.rollout.Name}}); err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			rollout := &appskubermaticv1.ApplicationRollout{}
+			if err := seedClient.Get(ctx, types.NamespacedName{Name: tc.rollout.Name}, rollout); err != nil {
+				t.Fatalf("Failed to get rollout: %v", err)
+			}
+
+			if rollout.Status.Phase != tc.expectedPhase {
+				t.Errorf("Expected phase %q, got %q (%s)", tc.expectedPhase, rollout.Status.Phase, rollout.Status.Message)
+			}
+
+			if rollout.Status.CurrentWave != tc.expectedWave {
+				t.Errorf("Expected wave %d, got %d", tc.expectedWave, rollout.Status.CurrentWave)
+			}
+
+			if !slices.Equal(rollout.Status.SkippedClusters, tc.expectedSkipped) {
+				t.Errorf("Expected skipped clusters %v, got %v", tc.expectedSkipped, rollout.Status.SkippedClusters)
+			}
+
+			for cluster, expectedVersion := range tc.expectedVersions {
+				installation := &appskubermaticv1.ApplicationInstallation{}
+				if err := userClusterClients[cluster].Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: appName}, installation); err != nil {
+					t.Fatalf("Failed to get installation in cluster %s: %v", cluster, err)
+				}
+
+				if version := installation.Spec.ApplicationRef.Version; version != expectedVersion {
+					t.Errorf("Expected installation in cluster %s to have version %q, got %q", cluster, expectedVersion, version)
+				}
+
+				if expectedVersion == tc.rollout.Spec.TargetVersion && findInstallation(rollout, cluster, installation) == nil {
+					t.Errorf("Expected upgraded installation in cluster %s to be recorded in the status", cluster)
+				}
+			}
+		})
+	}
+}
+
+type fakeClientProvider struct {
+	clients map[string]ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.clients[c.Name], nil
+}
+
+func genApplicationDefinition() *appskubermaticv1.ApplicationDefinition {
+	return &appskubermaticv1.ApplicationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: appName,
+		},
+		Spec: appskubermaticv1.ApplicationDefinitionSpec{
+			Method: appskubermaticv1.HelmTemplateMethod,
+			Versions: []appskubermaticv1.ApplicationVersion{
+				{Version: "1.0.0"},
+				{Version: "2.0.0"},
+			},
+		},
+	}
+}
+
+func genRollout(modify func(*appskubermaticv1.ApplicationRollout)) *appskubermaticv1.ApplicationRollout {
+	rollout := &appskubermaticv1.ApplicationRollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rollout",
+		},
+		Spec: appskubermaticv1.ApplicationRolloutSpec{
+			ApplicationDefinition: appName,
+			TargetVersion:         "2.0.0",
+			Waves: []appskubermaticv1.ApplicationRolloutWave{
+				{
+					Name: "canary",
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"stage": "canary"},
+					},
+				},
+				{
+					Name: "prod",
+				},
+			},
+		},
+	}
+
+	if modify != nil {
+		modify(rollout)
+	}
+
+	return rollout
+}
+
+// genInstallation returns an installation of the given version. If a status version is given,
+// the installation has been reconciled with the given Ready status.
+func genInstallation(version, statusVersion string, ready corev1.ConditionStatus) *appskubermaticv1.ApplicationInstallation {
+	installation := &appskubermaticv1.ApplicationInstallation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      appName,
+			Namespace: appNamespace,
+		},
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			ApplicationRef: appskubermaticv1.ApplicationRef{
+				Name:    appName,
+				Version: version,
+			},
+		},
+	}
+
+	if statusVersion != "" {
+		installation.Status.ApplicationVersion = &appskubermaticv1.ApplicationVersion{Version: statusVersion}
+		if ready == corev1.ConditionFalse {
+			installation.SetCondition(appskubermaticv1.Ready, ready, "InstallationFailed", "helm release failed")
+		} else {
+			installation.SetCondition(appskubermaticv1.Ready, ready, "InstallationSuccessful", "application successfully installed or upgraded")
+		}
+	}
+
+	return installation
+}
+
+func recorded(cluster string, wave int, previousVersion string) appskubermaticv1.ApplicationRolloutInstallation {
+	return appskubermaticv1.ApplicationRolloutInstallation{
+		Cluster:         cluster,
+		Namespace:       appNamespace,
+		Name:            appName,
+		Wave:            wave,
+		PreviousVersion: previousVersion,
+	}
+}
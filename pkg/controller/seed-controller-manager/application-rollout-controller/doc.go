@@ -0,0 +1,23 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package applicationrolloutcontroller contains a controller that performs ApplicationRollouts. It upgrades
+the ApplicationInstallations of an ApplicationDefinition in the user clusters of the seed wave by wave,
+waits for the upgraded installations to become ready and for the wave's soak time to pass before moving
+on to the next wave. If an upgraded installation fails, the rollout is either paused or rolled back.
+*/
+package applicationrolloutcontroller
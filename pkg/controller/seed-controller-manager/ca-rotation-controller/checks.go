@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	secretRevisionLabelSuffix = "-secret-revision"
+
+	// machineDeploymentRotationAnnotation is set on the machine template of all MachineDeployments
+	// to roll out new nodes, which trust the current set of CAs.
+	machineDeploymentRotationAnnotation = "kubermatic.k8c.io/ca-rotation"
+)
+
+// controlPlaneConverged checks that all control plane Pods are ready and use the current revision
+// of all Secrets they mount. If not, a message describing what is not converged yet is returned.
+func controlPlaneConverged(ctx context.Context, client ctrlruntimeclient.Client, namespace string) (string, error) {
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list Pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if pod.DeletionTimestamp != nil {
+			return fmt.Sprintf("waiting for Pod %s to terminate", pod.Name), nil
+		}
+
+		for _, label := range sets.List(sets.KeySet(pod.Labels)) {
+			secretName, ok := strings.CutSuffix(label, secretRevisionLabelSuffix)
+			if !ok {
+				continue
+			}
+
+			secret := &corev1.Secret{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return "", fmt.Errorf("failed to get Secret %s: %w", secretName, err)
+			}
+
+			if secret.ResourceVersion != pod.Labels[label] {
+				return fmt.Sprintf("waiting for Pod %s to use the current revision of Secret %s", pod.Name, secretName), nil
+			}
+		}
+
+		if !isPodReady(&pod) {
+			return fmt.Sprintf("waiting for Pod %s to become ready", pod.Name), nil
+		}
+	}
+
+	return "", nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// findCertificateSignedBy returns a message naming the first Secret in the namespace that still
+// contains a leaf certificate or kubeconfig client certificate signed by one of the given CAs.
+func findCertificateSignedBy(ctx context.Context, client ctrlruntimeclient.Client, namespace string, cas []*x509.Certificate) (string, error) {
+	if len(cas) == 0 {
+		return "", nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	for _, secret := range secrets.Items {
+		for _, cert := range certificates.SecretCertificates(&secret) {
+			if cert.Cert.IsCA {
+				continue
+			}
+
+			for _, ca := range cas {
+				if cert.Cert.CheckSignatureFrom(ca) == nil {
+					return fmt.Sprintf("waiting for certificate %s in Secret %s to be reissued", cert.Key, secret.Name), nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// clusterInfoTrusts checks if the cluster-info ConfigMap in the user cluster, which is used
+// by joining nodes, trusts the given CA.
+func clusterInfoTrusts(ctx context.Context, client ctrlruntimeclient.Client, ca *x509.Certificate) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespacePublic, Name: resources.ClusterInfoConfigMapName}, cm); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	kubeconfig, err := clientcmd.Load([]byte(cm.Data["kubeconfig"]))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cluster-info kubeconfig: %w", err)
+	}
+
+	for _, cluster := range kubeconfig.Clusters {
+		certs, err := triple.ParseCertsPEM(cluster.CertificateAuthorityData)
+		if err != nil {
+			continue
+		}
+
+		for _, cert := range certs {
+			if cert.Equal(ca) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// rollMachineDeployments sets the given revision on the machine template of all MachineDeployments
+// and returns a message naming the first MachineDeployment that has not been rolled out yet.
+func rollMachineDeployments(ctx context.Context, client ctrlruntimeclient.Client, revision string) (string, error) {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	// Kubermatic only creates MachineDeployments in the kube-system namespace, everything else is essentially unsupported
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return "", fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	message := ""
+	for _, md := range machineDeployments.Items {
+		if md.Spec.Template.Annotations[machineDeploymentRotationAnnotation] != revision {
+			oldMD := md.DeepCopy()
+			if md.Spec.Template.Annotations == nil {
+				md.Spec.Template.Annotations = map[string]string{}
+			}
+			md.Spec.Template.Annotations[machineDeploymentRotationAnnotation] = revision
+
+			if err := client.Patch(ctx, &md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return "", fmt.Errorf("failed to update MachineDeployment %s: %w", md.Name, err)
+			}
+
+			if message == "" {
+				message = fmt.Sprintf("waiting for MachineDeployment %s to be rolled out", md.Name)
+			}
+			continue
+		}
+
+		if message == "" && !isMachineDeploymentRolledOut(&md) {
+			message = fmt.Sprintf("waiting for MachineDeployment %s to be rolled out", md.Name)
+		}
+	}
+
+	return message, nil
+}
+
+func isMachineDeploymentRolledOut(md *clusterv1alpha1.MachineDeployment) bool {
+	replicas := int32(1)
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+
+	return md.Status.ObservedGeneration >= md.Generation &&
+		md.Status.UpdatedReplicas == replicas &&
+		md.Status.Replicas == replicas &&
+		md.Status.AvailableReplicas == replicas
+}
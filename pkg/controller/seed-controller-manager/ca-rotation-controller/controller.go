@@ -0,0 +1,414 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-ca-rotation-controller"
+
+	// serviceAccountTokenRefreshPeriod is the minimum time to wait after the service account
+	// signing key has been replaced, so that all projected service account tokens (which
+	// are valid for one hour by default) have been refreshed.
+	serviceAccountTokenRefreshPeriod = time.Hour
+
+	// waitInterval is used to requeue clusters while waiting for the rotation to progress.
+	waitInterval = 30 * time.Second
+)
+
+// caSecretNames are the Secrets containing the CAs that are rotated.
+var caSecretNames = []string{resources.CASecretName, resources.FrontProxyCASecretName}
+
+// userClusterConnectionProvider offers functions to retrieve clients for the given user clusters.
+type userClusterConnectionProvider interface {
+	GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log                     *zap.SugaredLogger
+	userClusterConnProvider userClusterConnectionProvider
+	workerName              string
+	recorder                record.EventRecorder
+	versions                kubermatic.Versions
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+
+	numWorkers int,
+	workerName string,
+
+	userClusterConnProvider userClusterConnectionProvider,
+	versions kubermatic.Versions,
+) error {
+	reconciler := &Reconciler{
+		log:                     log.Named(ControllerName),
+		Client:                  mgr.GetClient(),
+		userClusterConnProvider: userClusterConnProvider,
+		workerName:              workerName,
+		recorder:                mgr.GetEventRecorderFor(ControllerName),
+		versions:                versions,
+	}
+
+	enqueueCluster := controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient())
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.Cluster{}).
+		Watches(&corev1.Secret{}, enqueueCluster, builder.WithPredicates(predicateutil.ByName(append(caSecretNames, resources.ServiceAccountKeySecretName)...))).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Debug("Could not find cluster")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// rotations are not started or continued during cluster deletion
+	if cluster.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// Add a wrapping here so we can emit an event on error
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionCARotationControllerReconcilingSuccess,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Status.NamespaceName == "" {
+		return nil, nil
+	}
+
+	if status := cluster.Status.CARotation; status == nil || status.Phase == kubermaticv1.ClusterCARotationPhaseCompleted {
+		reason, err := r.rotationReason(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only start a rotation on healthy clusters. If the cluster gets healthy,
+		// we'll get notified by the event. No need to requeue.
+		if reason == "" || cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+			return nil, nil
+		}
+
+		log.Infow("Starting rotation of certificate authorities", "reason", reason)
+
+		now := metav1.Now()
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.CARotation = &kubermaticv1.ClusterCARotationStatus{
+				Phase:              kubermaticv1.ClusterCARotationPhaseTrusting,
+				StartTime:          now,
+				LastTransitionTime: now,
+				Message:            reason,
+			}
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update cluster status: %w", err)
+		}
+
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "CARotationStarted", "Started rotation of certificate authorities: %s", reason)
+	}
+
+	switch cluster.Status.CARotation.Phase {
+	case kubermaticv1.ClusterCARotationPhaseTrusting:
+		return r.reconcileTrusting(ctx, cluster)
+	case kubermaticv1.ClusterCARotationPhaseReissuing:
+		return r.reconcileReissuing(ctx, cluster)
+	case kubermaticv1.ClusterCARotationPhaseRetiring:
+		return r.reconcileRetiring(ctx, log, cluster)
+	default:
+		return nil, nil
+	}
+}
+
+// rotationReason returns why a rotation should be started, or an empty string if no rotation is needed.
+func (r *Reconciler) rotationReason(ctx context.Context, cluster *kubermaticv1.Cluster) (string, error) {
+	if _, ok := cluster.Annotations[kubermaticv1.RotateCertificateAuthoritiesAnnotation]; ok {
+		return "rotation was requested", nil
+	}
+
+	for _, name := range caSecretNames {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get Secret %s: %w", name, err)
+		}
+
+		certs, err := parseCACerts(secret)
+		if err != nil {
+			return "", err
+		}
+
+		if resources.CertWillExpireSoon(certs[0]) {
+			return fmt.Sprintf("CA %s expires at %s", name, certs[0].NotAfter.Format(time.RFC3339)), nil
+		}
+	}
+
+	return "", nil
+}
+
+// reconcileTrusting makes all control plane components and nodes trust the new CAs and
+// service account key.
+func (r *Reconciler) reconcileTrusting(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if err := r.transitionSecrets(ctx, cluster, trustNextCA, trustNextServiceAccountKey); err != nil {
+		return nil, err
+	}
+
+	if message, err := controlPlaneConverged(ctx, r, cluster.Status.NamespaceName); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	caSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s: %w", resources.CASecretName, err)
+	}
+
+	certs, err := parseCACerts(caSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	userClusterClient, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	// the next CA is the last one in the trust bundle
+	trusted, err := clusterInfoTrusts(ctx, userClusterClient, certs[len(certs)-1])
+	if err != nil {
+		return nil, err
+	}
+	if !trusted {
+		return r.wait(ctx, cluster, "waiting for the cluster-info ConfigMap to contain the new CA", nil)
+	}
+
+	if message, err := rollMachineDeployments(ctx, userClusterClient, machineDeploymentRevision(cluster)); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	return r.setPhase(ctx, cluster, kubermaticv1.ClusterCARotationPhaseReissuing)
+}
+
+// reconcileReissuing makes the new CAs and service account key the signers and waits until
+// all certificates and tokens have been reissued.
+func (r *Reconciler) reconcileReissuing(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if err := r.transitionSecrets(ctx, cluster, promoteNextCA, promoteNextServiceAccountKey); err != nil {
+		return nil, err
+	}
+
+	var previousCAs []*x509.Certificate
+	for _, name := range caSecretNames {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s: %w", name, err)
+		}
+
+		certs, err := parseCACerts(secret)
+		if err != nil {
+			return nil, err
+		}
+		previousCAs = append(previousCAs, certs[1:]...)
+	}
+
+	if message, err := findCertificateSignedBy(ctx, r, cluster.Status.NamespaceName, previousCAs); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	if message, err := controlPlaneConverged(ctx, r, cluster.Status.NamespaceName); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	userClusterClient, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	if message, err := rollMachineDeployments(ctx, userClusterClient, machineDeploymentRevision(cluster)); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	if remaining := serviceAccountTokenRefreshPeriod - time.Since(cluster.Status.CARotation.LastTransitionTime.Time); remaining > 0 {
+		result, err := r.wait(ctx, cluster, "waiting for service account tokens to be refreshed", nil)
+		if result != nil && remaining < result.RequeueAfter {
+			result.RequeueAfter = remaining
+		}
+		return result, err
+	}
+
+	return r.setPhase(ctx, cluster, kubermaticv1.ClusterCARotationPhaseRetiring)
+}
+
+// reconcileRetiring removes the previous CAs and service account key from all trust bundles.
+func (r *Reconciler) reconcileRetiring(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if err := r.transitionSecrets(ctx, cluster, retireCAs, retireServiceAccountKeys); err != nil {
+		return nil, err
+	}
+
+	if message, err := controlPlaneConverged(ctx, r, cluster.Status.NamespaceName); err != nil || message != "" {
+		return r.wait(ctx, cluster, message, err)
+	}
+
+	// remove the annotation before completing the rotation, so that it does not
+	// trigger another rotation
+	if _, ok := cluster.Annotations[kubermaticv1.RotateCertificateAuthoritiesAnnotation]; ok {
+		oldCluster := cluster.DeepCopy()
+		delete(cluster.Annotations, kubermaticv1.RotateCertificateAuthoritiesAnnotation)
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to remove %s annotation: %w", kubermaticv1.RotateCertificateAuthoritiesAnnotation, err)
+		}
+	}
+
+	log.Info("Rotation of certificate authorities has been completed")
+
+	return r.setPhase(ctx, cluster, kubermaticv1.ClusterCARotationPhaseCompleted)
+}
+
+// transitionSecrets applies the given transitions to all CA Secrets and the service account key Secret.
+func (r *Reconciler) transitionSecrets(ctx context.Context, cluster *kubermaticv1.Cluster, caTransition, serviceAccountKeyTransition secretTransition) error {
+	for _, name := range caSecretNames {
+		if err := r.transitionSecret(ctx, cluster.Status.NamespaceName, name, caTransition); err != nil {
+			return err
+		}
+	}
+
+	return r.transitionSecret(ctx, cluster.Status.NamespaceName, resources.ServiceAccountKeySecretName, serviceAccountKeyTransition)
+}
+
+func (r *Reconciler) transitionSecret(ctx context.Context, namespace, name string, transition secretTransition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+			return fmt.Errorf("failed to get Secret %s: %w", name, err)
+		}
+
+		updated := secret.DeepCopy()
+		if updated.Data == nil {
+			updated.Data = map[string][]byte{}
+		}
+
+		if err := transition(updated); err != nil {
+			return fmt.Errorf("failed to rotate Secret %s: %w", name, err)
+		}
+
+		if equality.Semantic.DeepEqual(secret.Data, updated.Data) {
+			return nil
+		}
+
+		return r.Update(ctx, updated)
+	})
+}
+
+// wait records what the rotation is waiting for and requeues the cluster.
+func (r *Reconciler) wait(ctx context.Context, cluster *kubermaticv1.Cluster, message string, err error) (*reconcile.Result, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.CARotation.Message = message
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	return &reconcile.Result{RequeueAfter: waitInterval}, nil
+}
+
+func (r *Reconciler) setPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterCARotationPhase) (*reconcile.Result, error) {
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.CARotation.Phase = phase
+		c.Status.CARotation.LastTransitionTime = metav1.Now()
+		c.Status.CARotation.Message = ""
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "CARotationProgressing", "Rotation of certificate authorities entered phase %s", phase)
+
+	// requeue to continue with the next phase
+	return &reconcile.Result{Requeue: phase != kubermaticv1.ClusterCARotationPhaseCompleted}, nil
+}
+
+// machineDeploymentRevision returns a value that is unique for each phase of a rotation,
+// so that nodes are replaced once per phase.
+func machineDeploymentRevision(cluster *kubermaticv1.Cluster) string {
+	status := cluster.Status.CARotation
+	return fmt.Sprintf("%d-%s", status.StartTime.Unix(), status.Phase)
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterName = "testcluster"
+	namespace   = "cluster-testcluster"
+)
+
+var testScheme = fake.NewScheme()
+
+func init() {
+	utilruntime.Must(clusterv1alpha1.AddToScheme(testScheme))
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+func genCluster(annotations map[string]string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clusterName,
+			Annotations: annotations,
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: namespace,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+}
+
+type testEnv struct {
+	t                 *testing.T
+	reconciler        *Reconciler
+	seedClient        ctrlruntimeclient.Client
+	userClusterClient ctrlruntimeclient.Client
+}
+
+func newTestEnv(t *testing.T, cluster *kubermaticv1.Cluster) *testEnv {
+	seedObjects := []ctrlruntimeclient.Object{cluster}
+	for _, secret := range []*corev1.Secret{
+		newCASecret(t, resources.CASecretName),
+		newCASecret(t, resources.FrontProxyCASecretName),
+		newServiceAccountKeySecret(t),
+	} {
+		secret.Namespace = namespace
+		seedObjects = append(seedObjects, secret)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workers",
+			Namespace: metav1.NamespaceSystem,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+	}
+
+	seedClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(seedObjects...).Build()
+	userClusterClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(md).Build()
+
+	return &testEnv{
+		t:                 t,
+		seedClient:        seedClient,
+		userClusterClient: userClusterClient,
+		reconciler: &Reconciler{
+			Client:                  seedClient,
+			log:                     zap.NewNop().Sugar(),
+			userClusterConnProvider: &fakeClientProvider{client: userClusterClient},
+			recorder:                &record.FakeRecorder{},
+			versions:                kubermatic.NewFakeVersions(),
+		},
+	}
+}
+
+func (e *testEnv) reconcile() *kubermaticv1.Cluster {
+	e.t.Helper()
+
+	ctx := context.Background()
+	if _, err := e.reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterName}}); err != nil {
+		e.t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	return e.cluster()
+}
+
+func (e *testEnv) cluster() *kubermaticv1.Cluster {
+	e.t.Helper()
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := e.seedClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		e.t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	return cluster
+}
+
+func (e *testEnv) secret(name string) *corev1.Secret {
+	e.t.Helper()
+
+	secret := &corev1.Secret{}
+	if err := e.seedClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		e.t.Fatalf("Failed to get Secret: %v", err)
+	}
+
+	return secret
+}
+
+// publishClusterInfo mimics the user cluster controller manager.
+func (e *testEnv) publishClusterInfo() {
+	e.t.Helper()
+
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"": {
+				Server:                   "https://example.com",
+				CertificateAuthorityData: e.secret(resources.CASecretName).Data[resources.CACertSecretKey],
+			},
+		},
+	})
+	if err != nil {
+		e.t.Fatalf("Failed to encode kubeconfig: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.ClusterInfoConfigMapName,
+			Namespace: metav1.NamespacePublic,
+		},
+		Data: map[string]string{
+			"kubeconfig": string(kubeconfig),
+		},
+	}
+	if err := e.userClusterClient.Create(context.Background(), cm); err != nil {
+		e.t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+}
+
+// rollOutMachineDeployment mimics the machine-controller.
+func (e *testEnv) rollOutMachineDeployment() {
+	e.t.Helper()
+
+	ctx := context.Background()
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := e.userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "workers"}, md); err != nil {
+		e.t.Fatalf("Failed to get MachineDeployment: %v", err)
+	}
+
+	md.Status.ObservedGeneration = md.Generation
+	md.Status.Replicas = 1
+	md.Status.UpdatedReplicas = 1
+	md.Status.AvailableReplicas = 1
+	if err := e.userClusterClient.Update(ctx, md); err != nil {
+		e.t.Fatalf("Failed to update MachineDeployment: %v", err)
+	}
+}
+
+func expectPhase(t *testing.T, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterCARotationPhase, message string) {
+	t.Helper()
+
+	status := cluster.Status.CARotation
+	if status == nil {
+		t.Fatalf("Expected rotation to be in phase %s, but no rotation status was found.", phase)
+	}
+	if status.Phase != phase {
+		t.Fatalf("Expected rotation to be in phase %s, but it is in phase %s.", phase, status.Phase)
+	}
+	if !strings.Contains(status.Message, message) {
+		t.Fatalf("Expected status message to contain %q, but got %q.", message, status.Message)
+	}
+}
+
+func TestReconcileWithoutRequest(t *testing.T) {
+	env := newTestEnv(t, genCluster(nil))
+
+	cluster := env.reconcile()
+	if cluster.Status.CARotation != nil {
+		t.Fatalf("Expected no rotation to be started, but got %+v.", cluster.Status.CARotation)
+	}
+}
+
+// newLeafSecret creates a Secret containing a leaf certificate signed by the given CA.
+func newLeafSecret(t *testing.T, ca *triple.KeyPair) *corev1.Secret {
+	kp, err := triple.NewClientKeyPair(ca, "leaf", nil)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "leaf",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"leaf.crt": triple.EncodeCertPEM(kp.Cert),
+			"leaf.key": triple.EncodePrivateKeyPEM(kp.Key),
+		},
+	}
+}
+
+func TestReconcileRotation(t *testing.T) {
+	env := newTestEnv(t, genCluster(map[string]string{
+		kubermaticv1.RotateCertificateAuthoritiesAnnotation: "",
+	}))
+	ctx := context.Background()
+
+	previousCA, err := resources.GetClusterRootCA(ctx, namespace, env.seedClient)
+	if err != nil {
+		t.Fatalf("Failed to get CA: %v", err)
+	}
+
+	leaf := newLeafSecret(t, previousCA)
+	if err := env.seedClient.Create(ctx, leaf); err != nil {
+		t.Fatalf("Failed to create Secret: %v", err)
+	}
+
+	// the rotation starts and waits for the new CA to be distributed to the user cluster
+	cluster := env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseTrusting, "cluster-info")
+
+	ca, err := resources.GetClusterRootCA(ctx, namespace, env.seedClient)
+	if err != nil {
+		t.Fatalf("Failed to get CA: %v", err)
+	}
+	if !ca.Cert.Equal(previousCA.Cert) || len(ca.Bundle) != 1 {
+		t.Fatal("Expected the previous CA to remain the signer and the new CA to be trusted.")
+	}
+
+	env.publishClusterInfo()
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseTrusting, "MachineDeployment workers")
+
+	env.rollOutMachineDeployment()
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseReissuing, "")
+
+	// the new CA is the signer now, but the leaf certificate still has to be reissued
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseReissuing, "Secret leaf")
+
+	ca, err = resources.GetClusterRootCA(ctx, namespace, env.seedClient)
+	if err != nil {
+		t.Fatalf("Failed to get CA: %v", err)
+	}
+	if ca.Cert.Equal(previousCA.Cert) || len(ca.Bundle) != 1 || !ca.Bundle[0].Equal(previousCA.Cert) {
+		t.Fatal("Expected the new CA to be the signer and the previous CA to be trusted.")
+	}
+
+	reissued := newLeafSecret(t, ca)
+	reissued.ResourceVersion = env.secret(leaf.Name).ResourceVersion
+	if err := env.seedClient.Update(ctx, reissued); err != nil {
+		t.Fatalf("Failed to update Secret: %v", err)
+	}
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseReissuing, "MachineDeployment workers")
+
+	env.rollOutMachineDeployment()
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseReissuing, "service account tokens")
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, env.seedClient, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.CARotation.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * serviceAccountTokenRefreshPeriod))
+	}); err != nil {
+		t.Fatalf("Failed to update cluster status: %v", err)
+	}
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseRetiring, "")
+
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseCompleted, "")
+
+	if _, ok := cluster.Annotations[kubermaticv1.RotateCertificateAuthoritiesAnnotation]; ok {
+		t.Fatal("Expected the annotation to be removed after the rotation has been completed.")
+	}
+
+	rotatedCA, err := resources.GetClusterRootCA(ctx, namespace, env.seedClient)
+	if err != nil {
+		t.Fatalf("Failed to get CA: %v", err)
+	}
+	if !rotatedCA.Cert.Equal(ca.Cert) || len(rotatedCA.Bundle) != 0 {
+		t.Fatal("Expected only the new CA to be trusted.")
+	}
+
+	// a completed rotation must not start a new one
+	cluster = env.reconcile()
+	expectPhase(t, cluster, kubermaticv1.ClusterCARotationPhaseCompleted, "")
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package carotationcontroller contains a controller that rotates the root CA, the
+front-proxy CA and the service account signing key of user clusters.
+
+A rotation is started when the `kubermatic.k8c.io/rotate-certificate-authorities`
+annotation is set on a Cluster, or when one of its CAs is about to expire. It
+progresses through the following phases, which are tracked in the Cluster status:
+
+  - `Trusting`: new CAs and a new service account key are created and trusted next
+    to the current ones. The controller waits until the control plane has been
+    restarted, the user cluster's cluster-info ConfigMap contains the new root CA
+    and all MachineDeployments have been rolled out.
+  - `Reissuing`: the new CAs and key become the signers. The `kubernetes_controller`
+    then reissues all leaf certificates and kubeconfigs. The controller waits until
+    no certificate signed by a previous CA is left, all MachineDeployments have been
+    rolled out again and all projected service account tokens have been refreshed.
+  - `Retiring`: the previous CAs and key are no longer trusted. Afterwards the rotation
+    is `Completed` and the annotation is removed.
+
+Legacy service account token Secrets signed by the previous key become invalid once
+the rotation has been completed.
+*/
+
+package carotationcontroller
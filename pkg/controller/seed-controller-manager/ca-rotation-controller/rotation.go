@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// secretTransition modifies a CA or service account key Secret in place.
+type secretTransition func(secret *corev1.Secret) error
+
+// trustNextCA creates a new CA next to the current one and adds it to the trust bundle,
+// while the current CA remains the signer.
+func trustNextCA(secret *corev1.Secret) error {
+	certs, err := parseCACerts(secret)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := secret.Data[resources.NextCAKeySecretKey]; !exists {
+		// re-use the common name, as it is part of the certificate's subject, which
+		// clients expect in the issuer field of certificates signed by this CA
+		next, err := triple.NewCA(certs[0].Subject.CommonName)
+		if err != nil {
+			return fmt.Errorf("failed to create new CA: %w", err)
+		}
+
+		secret.Data[resources.NextCACertSecretKey] = triple.EncodeCertPEM(next.Cert)
+		secret.Data[resources.NextCAKeySecretKey] = triple.EncodePrivateKeyPEM(next.Key)
+	}
+
+	nextCerts, err := triple.ParseCertsPEM(secret.Data[resources.NextCACertSecretKey])
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", resources.NextCACertSecretKey, err)
+	}
+
+	secret.Data[resources.CACertSecretKey] = encodeCerts(certs[0], nextCerts[0])
+
+	return nil
+}
+
+// promoteNextCA makes the next CA the signer, while the previous CA remains trusted.
+func promoteNextCA(secret *corev1.Secret) error {
+	nextKey, exists := secret.Data[resources.NextCAKeySecretKey]
+	if !exists {
+		// the next CA has already been promoted
+		return nil
+	}
+
+	certs, err := parseCACerts(secret)
+	if err != nil {
+		return err
+	}
+
+	nextCerts, err := triple.ParseCertsPEM(secret.Data[resources.NextCACertSecretKey])
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", resources.NextCACertSecretKey, err)
+	}
+
+	secret.Data[resources.CACertSecretKey] = encodeCerts(nextCerts[0], certs[0])
+	secret.Data[resources.CAKeySecretKey] = nextKey
+	delete(secret.Data, resources.NextCACertSecretKey)
+	delete(secret.Data, resources.NextCAKeySecretKey)
+
+	return nil
+}
+
+// retireCAs removes all CAs except for the signer from the trust bundle.
+func retireCAs(secret *corev1.Secret) error {
+	certs, err := parseCACerts(secret)
+	if err != nil {
+		return err
+	}
+
+	secret.Data[resources.CACertSecretKey] = encodeCerts(certs[0])
+
+	return nil
+}
+
+// trustNextServiceAccountKey creates a new service account signing key and adds its
+// public key to the trusted keys, while the current key remains the signing key.
+func trustNextServiceAccountKey(secret *corev1.Secret) error {
+	if _, exists := secret.Data[resources.NextServiceAccountKeySecretKey]; !exists {
+		privateKey, _, err := apiserver.GenerateServiceAccountKey()
+		if err != nil {
+			return fmt.Errorf("failed to create new service account key: %w", err)
+		}
+
+		secret.Data[resources.NextServiceAccountKeySecretKey] = privateKey
+	}
+
+	publicKeys, err := encodePublicKeys(secret.Data[resources.ServiceAccountKeySecretKey], secret.Data[resources.NextServiceAccountKeySecretKey])
+	if err != nil {
+		return err
+	}
+
+	secret.Data[resources.ServiceAccountKeyPublicKey] = publicKeys
+
+	return nil
+}
+
+// promoteNextServiceAccountKey makes the next service account key the signing key, while
+// tokens signed by the previous key remain valid.
+func promoteNextServiceAccountKey(secret *corev1.Secret) error {
+	nextKey, exists := secret.Data[resources.NextServiceAccountKeySecretKey]
+	if !exists {
+		// the next key has already been promoted
+		return nil
+	}
+
+	publicKeys, err := encodePublicKeys(nextKey, secret.Data[resources.ServiceAccountKeySecretKey])
+	if err != nil {
+		return err
+	}
+
+	secret.Data[resources.ServiceAccountKeySecretKey] = nextKey
+	secret.Data[resources.ServiceAccountKeyPublicKey] = publicKeys
+	delete(secret.Data, resources.NextServiceAccountKeySecretKey)
+
+	return nil
+}
+
+// retireServiceAccountKeys removes all public keys except for the one of the signing key.
+func retireServiceAccountKeys(secret *corev1.Secret) error {
+	publicKeys, err := encodePublicKeys(secret.Data[resources.ServiceAccountKeySecretKey])
+	if err != nil {
+		return err
+	}
+
+	secret.Data[resources.ServiceAccountKeyPublicKey] = publicKeys
+
+	return nil
+}
+
+// parseCACerts returns all certificates of the trust bundle, starting with the signer.
+func parseCACerts(secret *corev1.Secret) ([]*x509.Certificate, error) {
+	certPEM, exists := secret.Data[resources.CACertSecretKey]
+	if !exists {
+		return nil, fmt.Errorf("secret %s does not contain %s", secret.Name, resources.CACertSecretKey)
+	}
+
+	certs, err := triple.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", resources.CACertSecretKey, err)
+	}
+
+	return certs, nil
+}
+
+func encodeCerts(certs ...*x509.Certificate) []byte {
+	var certPEM []byte
+	for _, cert := range certs {
+		certPEM = append(certPEM, triple.EncodeCertPEM(cert)...)
+	}
+
+	return certPEM
+}
+
+func encodePublicKeys(privateKeys ...[]byte) ([]byte, error) {
+	var publicKeys []byte
+	for _, privateKey := range privateKeys {
+		if len(privateKey) == 0 {
+			return nil, errors.New("service account key must not be empty")
+		}
+
+		publicKey, err := apiserver.ServiceAccountPublicKeyPEM(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, publicKey...)
+	}
+
+	return publicKeys, nil
+}
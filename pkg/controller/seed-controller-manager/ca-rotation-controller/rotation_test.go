@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"bytes"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCASecret(t *testing.T, name string) *corev1.Secret {
+	ca, err := triple.NewCA("root-ca.example.com")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+		},
+	}
+}
+
+func newServiceAccountKeySecret(t *testing.T) *corev1.Secret {
+	privateKey, publicKey, err := apiserver.GenerateServiceAccountKey()
+	if err != nil {
+		t.Fatalf("Failed to create service account key: %v", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: resources.ServiceAccountKeySecretName,
+		},
+		Data: map[string][]byte{
+			resources.ServiceAccountKeySecretKey: privateKey,
+			resources.ServiceAccountKeyPublicKey: publicKey,
+		},
+	}
+}
+
+func mustApply(t *testing.T, secret *corev1.Secret, transition secretTransition) {
+	t.Helper()
+
+	if err := transition(secret); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+}
+
+func TestCATransitions(t *testing.T) {
+	secret := newCASecret(t, resources.CASecretName)
+	originalCert := secret.Data[resources.CACertSecretKey]
+	originalKey := secret.Data[resources.CAKeySecretKey]
+
+	mustApply(t, secret, trustNextCA)
+
+	nextCert := secret.Data[resources.NextCACertSecretKey]
+	nextKey := secret.Data[resources.NextCAKeySecretKey]
+	if len(nextCert) == 0 || len(nextKey) == 0 {
+		t.Fatal("Expected the next CA to be stored in the Secret.")
+	}
+	if expected := append(append([]byte{}, originalCert...), nextCert...); !bytes.Equal(secret.Data[resources.CACertSecretKey], expected) {
+		t.Fatal("Expected the trust bundle to contain the current CA followed by the next CA.")
+	}
+	if !bytes.Equal(secret.Data[resources.CAKeySecretKey], originalKey) {
+		t.Fatal("Expected the current CA to remain the signer.")
+	}
+
+	// trusting must be idempotent and not create yet another CA
+	mustApply(t, secret, trustNextCA)
+	if !bytes.Equal(secret.Data[resources.NextCACertSecretKey], nextCert) {
+		t.Fatal("Expected the next CA to be reused.")
+	}
+
+	mustApply(t, secret, promoteNextCA)
+
+	if expected := append(append([]byte{}, nextCert...), originalCert...); !bytes.Equal(secret.Data[resources.CACertSecretKey], expected) {
+		t.Fatal("Expected the trust bundle to contain the next CA followed by the previous CA.")
+	}
+	if !bytes.Equal(secret.Data[resources.CAKeySecretKey], nextKey) {
+		t.Fatal("Expected the next CA to be the signer.")
+	}
+	if _, exists := secret.Data[resources.NextCAKeySecretKey]; exists {
+		t.Fatal("Expected the next CA to be removed from the Secret.")
+	}
+
+	// promoting must be idempotent and not swap the CAs back
+	mustApply(t, secret, promoteNextCA)
+	if !bytes.Equal(secret.Data[resources.CAKeySecretKey], nextKey) {
+		t.Fatal("Expected the next CA to remain the signer.")
+	}
+
+	mustApply(t, secret, retireCAs)
+
+	if !bytes.Equal(secret.Data[resources.CACertSecretKey], nextCert) {
+		t.Fatal("Expected the trust bundle to only contain the new CA.")
+	}
+
+	// the resulting Secret must be usable as a cluster CA
+	if _, err := triple.ParseRSAKeyPair(secret.Data[resources.CACertSecretKey], secret.Data[resources.CAKeySecretKey]); err != nil {
+		t.Fatalf("Failed to parse rotated CA: %v", err)
+	}
+}
+
+func TestServiceAccountKeyTransitions(t *testing.T) {
+	secret := newServiceAccountKeySecret(t)
+	originalKey := secret.Data[resources.ServiceAccountKeySecretKey]
+	originalPublicKey := secret.Data[resources.ServiceAccountKeyPublicKey]
+
+	mustApply(t, secret, trustNextServiceAccountKey)
+
+	nextKey := secret.Data[resources.NextServiceAccountKeySecretKey]
+	if len(nextKey) == 0 {
+		t.Fatal("Expected the next key to be stored in the Secret.")
+	}
+
+	nextPublicKey, err := apiserver.ServiceAccountPublicKeyPEM(nextKey)
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+
+	if expected := append(append([]byte{}, originalPublicKey...), nextPublicKey...); !bytes.Equal(secret.Data[resources.ServiceAccountKeyPublicKey], expected) {
+		t.Fatal("Expected the current public key followed by the next public key to be trusted.")
+	}
+	if !bytes.Equal(secret.Data[resources.ServiceAccountKeySecretKey], originalKey) {
+		t.Fatal("Expected the current key to remain the signing key.")
+	}
+
+	mustApply(t, secret, promoteNextServiceAccountKey)
+
+	if expected := append(append([]byte{}, nextPublicKey...), originalPublicKey...); !bytes.Equal(secret.Data[resources.ServiceAccountKeyPublicKey], expected) {
+		t.Fatal("Expected the next public key followed by the previous public key to be trusted.")
+	}
+	if !bytes.Equal(secret.Data[resources.ServiceAccountKeySecretKey], nextKey) {
+		t.Fatal("Expected the next key to be the signing key.")
+	}
+	if _, exists := secret.Data[resources.NextServiceAccountKeySecretKey]; exists {
+		t.Fatal("Expected the next key to be removed from the Secret.")
+	}
+
+	mustApply(t, secret, retireServiceAccountKeys)
+
+	if !bytes.Equal(secret.Data[resources.ServiceAccountKeyPublicKey], nextPublicKey) {
+		t.Fatal("Expected only the new public key to be trusted.")
+	}
+}
@@ -23,6 +23,15 @@ While updating the configuration and the kube-apiserver Pods happens in the
 `kubernetes_controller`, the `encryption_controller` will update the Cluster
 status according to changes observed in kube-apiserver and launch a re-encryption
 job based on the observed phase of the encryption process.
+
+Two providers are supported: `secretbox` with static keys stored in the seed and
+KMS v2, where a plugin runs as a sidecar next to kube-apiserver and keeps the key
+encryption key in an external key management system. The plugins are defined by
+the administrator in the datacenter and clusters only select one of them by name.
+Any plugin that serves the KMS v2 API can be used, e.g. one backed by the transit
+engine of a Vault instance or, for testing, by a SoftHSM token. When switching
+providers or renaming the KMS provider, the previous provider stays configured
+for reading until the re-encryption job has finished.
 */
 
 package encryptionatrestcontroller
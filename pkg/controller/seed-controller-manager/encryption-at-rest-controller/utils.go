@@ -81,9 +81,8 @@ func isApiserverUpdated(ctx context.Context, client ctrlruntimeclient.Client, cl
 // getActiveConfiguration returns a key "hint" and a list of resources. It does not return secret data.
 func getActiveConfiguration(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (string, []string, error) {
 	var (
-		keyName string
-		secret  corev1.Secret
-		config  apiserverconfigv1.EncryptionConfiguration
+		secret corev1.Secret
+		config apiserverconfigv1.EncryptionConfiguration
 	)
 
 	if err := client.Get(ctx, types.NamespacedName{
@@ -99,23 +98,15 @@ func getActiveConfiguration(ctx context.Context, client ctrlruntimeclient.Client
 		}
 	}
 
-	// we expect two providers, (1) the configured encryption provider as per the ClusterSpec (secretbox or KMS plugins)
-	// and (2) the "identity" provider, which is there for reading (and if at the top of the list, writing) resources as
+	// we expect (1) the configured encryption provider as per the ClusterSpec (secretbox or KMS plugins), optionally
+	// (2) providers that have been replaced but are still needed for reading resources that were not re-encrypted yet
+	// and (3) the "identity" provider, which is there for reading (and if at the top of the list, writing) resources as
 	// unencrypted.
-	if len(config.Resources) != 1 || (len(config.Resources[0].Providers) != 1 && len(config.Resources[0].Providers) != 2) {
-		return "", []string{}, errors.New("unexpected apiserverconfigv1.EncryptionConfiguration: too many items in .resources or .resources[0].providers")
+	if len(config.Resources) != 1 || len(config.Resources[0].Providers) == 0 {
+		return "", []string{}, errors.New("unexpected apiserverconfigv1.EncryptionConfiguration: expected exactly one item in .resources with at least one provider")
 	}
 
-	providerConfig := &config.Resources[0].Providers[0]
-
-	switch {
-	case providerConfig.Secretbox != nil:
-		keyName = fmt.Sprintf("%s/%s", encryptionresources.SecretboxPrefix, providerConfig.Secretbox.Keys[0].Name)
-	case providerConfig.Identity != nil:
-		keyName = encryptionresources.IdentityKey
-	}
-
-	return keyName, config.Resources[0].Resources, nil
+	return encryptionresources.KeyHint(config.Resources[0].Providers[0]), config.Resources[0].Resources, nil
 }
 
 // getConfiguredKey returns a key "hint" for the primary key as configured in a ClusterSpec. This can return a different result
@@ -129,6 +120,8 @@ func getConfiguredKey(cluster *kubermaticv1.Cluster) (string, error) {
 	switch {
 	case cluster.Spec.EncryptionConfiguration.Secretbox != nil:
 		return fmt.Sprintf("%s/%s", encryptionresources.SecretboxPrefix, cluster.Spec.EncryptionConfiguration.Secretbox.Keys[0].Name), nil
+	case cluster.Spec.EncryptionConfiguration.KMS != nil:
+		return fmt.Sprintf("%s/%s", encryptionresources.KMSPrefix, cluster.Spec.EncryptionConfiguration.KMS.Name), nil
 	}
 
 	return "", errors.New("no supported encryption provider found")
@@ -27,6 +27,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdrestore"
 	"k8c.io/kubermatic/v2/pkg/defaulting"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -34,10 +35,13 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
 	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -194,24 +198,33 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	var suppressedError error
 
-	// Add a wrapping here so we can emit an event on error
-	result, err := kubermaticv1helper.ClusterReconcileWrapper(
-		ctx,
-		r.Client,
-		r.workerName,
-		cluster,
-		r.versions,
-		kubermaticv1.ClusterConditionNone,
-		func() (*reconcile.Result, error) {
-			result, err := r.reconcile(ctx, log, backupConfig, cluster, seed, config)
-			if apierrors.IsConflict(err) {
-				// benign update conflict -- remember this so we can
-				// suppress log.Error and event generation below
-				suppressedError = err
-			}
-			return result, err
-		},
-	)
+	reconcileFunc := func() (*reconcile.Result, error) {
+		result, err := r.reconcile(ctx, log, backupConfig, cluster, seed, config)
+		if apierrors.IsConflict(err) {
+			// benign update conflict -- remember this so we can
+			// suppress log.Error and event generation below
+			suppressedError = err
+		}
+		return result, err
+	}
+
+	var result *reconcile.Result
+	if isMigrating(cluster, r.workerName) {
+		// paused clusters are usually not reconciled, but a cluster migration pauses
+		// the cluster before taking its final backup
+		result, err = reconcileFunc()
+	} else {
+		// Add a wrapping here so we can emit an event on error
+		result, err = kubermaticv1helper.ClusterReconcileWrapper(
+			ctx,
+			r.Client,
+			r.workerName,
+			cluster,
+			r.versions,
+			kubermaticv1.ClusterConditionNone,
+			reconcileFunc,
+		)
+	}
 	if err != nil {
 		if suppressedError != nil {
 			// we know that err is a 1-element Aggregate containing just suppressedError
@@ -234,6 +247,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return *result, err
 }
 
+// isMigrating returns true if the cluster has been paused by a ClusterMigration.
+func isMigrating(cluster *kubermaticv1.Cluster, workerName string) bool {
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != workerName {
+		return false
+	}
+
+	_, migrating := cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation]
+
+	return migrating && cluster.Spec.Pause
+}
+
 func (r *Reconciler) reconcile(
 	ctx context.Context,
 	log *zap.SugaredLogger,
@@ -255,6 +279,10 @@ func (r *Reconciler) reconcile(
 		return nil, fmt.Errorf("failed to create backup configmaps: %w", err)
 	}
 
+	if err := r.ensureDataKey(ctx, cluster, backupConfig, data.EtcdBackupDestination()); err != nil {
+		return nil, fmt.Errorf("failed to ensure backup encryption key: %w", err)
+	}
+
 	var nextReconcile, totalReconcile *reconcile.Result
 
 	if nextReconcile, err = r.ensurePendingBackupIsScheduled(ctx, backupConfig, cluster); err != nil {
@@ -269,6 +297,12 @@ func (r *Reconciler) reconcile(
 
 	totalReconcile = minReconcile(totalReconcile, nextReconcile)
 
+	if nextReconcile, err = r.updateBackupVerifications(ctx, data, backupConfig); err != nil {
+		return nil, fmt.Errorf("failed to update backup verifications: %w", err)
+	}
+
+	totalReconcile = minReconcile(totalReconcile, nextReconcile)
+
 	if nextReconcile, err = r.startPendingBackupDeleteJobs(ctx, data, backupConfig); err != nil {
 		return nil, fmt.Errorf("failed to start pending backup delete jobs: %w", err)
 	}
@@ -287,6 +321,12 @@ func (r *Reconciler) reconcile(
 
 	totalReconcile = minReconcile(totalReconcile, nextReconcile)
 
+	if nextReconcile, err = r.ensureWALArchiver(ctx, data, backupConfig, cluster); err != nil {
+		return nil, fmt.Errorf("failed to ensure WAL archiver: %w", err)
+	}
+
+	totalReconcile = minReconcile(totalReconcile, nextReconcile)
+
 	if nextReconcile, err = r.handleFinalization(ctx, backupConfig); err != nil {
 		return nil, fmt.Errorf("failed to clean up EtcdBackupConfig: %w", err)
 	}
@@ -379,7 +419,7 @@ func (r *Reconciler) ensurePendingBackupIsScheduled(ctx context.Context, backupC
 		backupConfig.Status.CurrentBackups = []kubermaticv1.BackupStatus{{}}
 		backupToSchedule = &backupConfig.Status.CurrentBackups[0]
 		backupToSchedule.ScheduledTime = metav1.NewTime(r.clock.Now())
-		backupToSchedule.BackupName = fmt.Sprintf("%s.db.gz", backupConfig.Name)
+		backupToSchedule.BackupName = backupConfig.Name + backupFileSuffix(backupConfig)
 		requeueAfter = 0
 	} else {
 		// compute the pending (i.e. latest past) and the next (i.e. earliest future) backup time,
@@ -412,10 +452,11 @@ func (r *Reconciler) ensurePendingBackupIsScheduled(ctx context.Context, backupC
 		backupConfig.Status.CurrentBackups = append(backupConfig.Status.CurrentBackups, kubermaticv1.BackupStatus{})
 		backupToSchedule = &backupConfig.Status.CurrentBackups[len(backupConfig.Status.CurrentBackups)-1]
 		backupToSchedule.ScheduledTime = metav1.NewTime(pendingBackupTime)
-		backupToSchedule.BackupName = fmt.Sprintf("%s-%s.db.gz", backupConfig.Name, backupToSchedule.ScheduledTime.UTC().Format("2006-01-02t15-04-05"))
+		backupToSchedule.BackupName = fmt.Sprintf("%s-%s%s", backupConfig.Name, backupToSchedule.ScheduledTime.UTC().Format("2006-01-02t15-04-05"), backupFileSuffix(backupConfig))
 		requeueAfter = nextBackupTime.Sub(now)
 	}
 
+	backupToSchedule.WALArchiveEpoch = backupConfig.Status.WALArchiveEpoch
 	backupToSchedule.JobName = r.limitNameLength(fmt.Sprintf("%s-backup-%s-create-%s", cluster.Name, backupConfig.Name, r.randStringGenerator()))
 	backupToSchedule.DeleteJobName = r.limitNameLength(fmt.Sprintf("%s-backup-%s-delete-%s", cluster.Name, backupConfig.Name, r.randStringGenerator()))
 
@@ -542,14 +583,27 @@ func (r *Reconciler) startPendingBackupJobs(ctx context.Context, data *resources
 	return returnReconcile, nil
 }
 
-// create any backup delete jobs that can be created, i.e. for all completed backups older than the last backupConfig.GetKeptBackupsCount() ones.
+// create any backup delete jobs that can be created, i.e. for all completed backups older than the last backupConfig.GetKeptBackupsCount() ones,
+// or, if the config has a tiered retention policy, for all completed backups that are not retained by any of its tiers.
 func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
 	// one-shot backups are not deleted until their backupConfig is deleted
 	if backupConfig.Spec.Schedule == "" && backupConfig.DeletionTimestamp == nil {
 		return nil, nil
 	}
 
-	var backupsToDelete []*kubermaticv1.BackupStatus
+	type backupToDelete struct {
+		backup *kubermaticv1.BackupStatus
+		reason string
+	}
+
+	var retained map[string][]kubermaticv1.EtcdBackupRetentionTier
+	if backupConfig.Spec.Retention != nil && backupConfig.DeletionTimestamp == nil {
+		retained = retainedBackups(backupConfig.Status.CurrentBackups, backupConfig.Spec.Retention)
+	}
+
+	oldBackupConfig := backupConfig.DeepCopy()
+
+	var backupsToDelete []backupToDelete
 	keepCount := backupConfig.GetKeptBackupsCount()
 	if backupConfig.DeletionTimestamp != nil {
 		keepCount = 0
@@ -562,25 +616,45 @@ func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, data *res
 			runningDeleteJobsCount++
 		}
 		if backup.BackupPhase == kubermaticv1.BackupStatusPhaseFailed && backup.DeletePhase == "" {
-			backupsToDelete = append(backupsToDelete, backup)
+			backupsToDelete = append(backupsToDelete, backupToDelete{backup, "backup failed"})
 		} else if backup.BackupPhase == kubermaticv1.BackupStatusPhaseCompleted {
-			kept++
-			if kept > keepCount && backup.DeletePhase == "" {
-				backupsToDelete = append(backupsToDelete, backup)
+			// do not pull a backup from under a running verification, unless everything is deleted anyway
+			verifying := backup.VerificationPhase == kubermaticv1.BackupStatusPhaseRunning && backupConfig.DeletionTimestamp == nil
+
+			switch {
+			case backupConfig.DeletionTimestamp != nil:
+				if backup.DeletePhase == "" {
+					backupsToDelete = append(backupsToDelete, backupToDelete{backup, "backup config is being deleted"})
+				}
+			case retained != nil:
+				tiers, keep := retained[backup.BackupName]
+				if backup.DeletePhase == "" {
+					backup.RetainedBy = tiers
+				}
+				if !keep && backup.DeletePhase == "" && !verifying {
+					backupsToDelete = append(backupsToDelete, backupToDelete{backup, "not retained by any retention tier"})
+				}
+			default:
+				kept++
+				if kept > keepCount && backup.DeletePhase == "" && !verifying {
+					backupsToDelete = append(backupsToDelete, backupToDelete{backup, fmt.Sprintf("only the newest %d backups are kept", keepCount)})
+				}
 			}
 		}
 	}
 
-	oldBackupConfig := backupConfig.DeepCopy()
-
-	modified := false
-	for _, backup := range backupsToDelete {
+	modified := !apiequality.Semantic.DeepEqual(oldBackupConfig.Status, backupConfig.Status)
+	started := false
+	for _, toDelete := range backupsToDelete {
 		if runningDeleteJobsCount < maxSimultaneousDeleteJobsPerConfig {
-			if err := r.createBackupDeleteJob(ctx, data, backupConfig, backup); err != nil {
+			if err := r.createBackupDeleteJob(ctx, data, backupConfig, toDelete.backup); err != nil {
 				return nil, err
 			}
+			toDelete.backup.RetainedBy = nil
+			toDelete.backup.PruneReason = toDelete.reason
 			runningDeleteJobsCount++
 			modified = true
+			started = true
 		}
 	}
 
@@ -588,15 +662,26 @@ func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, data *res
 		if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
 			return nil, fmt.Errorf("failed to update backup status: %w", err)
 		}
+	}
 
+	if started {
 		return &reconcile.Result{RequeueAfter: assumedJobRuntime}, nil
 	}
 
 	return nil, nil
 }
 
+// backupFileSuffix returns the suffix of the names of all backups created for the given config.
+func backupFileSuffix(backupConfig *kubermaticv1.EtcdBackupConfig) string {
+	if backupConfig.GetBackupFormat() == kubermaticv1.EtcdBackupFormatChunked {
+		return chunkstore.ManifestSuffix
+	}
+	return ".db.gz"
+}
+
 func (r *Reconciler) createBackupDeleteJob(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig, backup *kubermaticv1.BackupStatus) error {
-	if data.EtcdBackupDeleteContainer() != nil {
+	// chunked backups are always deleted by the etcd-launcher
+	if data.EtcdBackupDeleteContainer() != nil || backupConfig.GetBackupFormat() == kubermaticv1.EtcdBackupFormatChunked {
 		job := etcdbackup.BackupDeleteJob(data, backupConfig, backup)
 		if err := r.Create(ctx, job); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
 			return fmt.Errorf("error creating delete job for backup %s: %w", backup.BackupName, err)
@@ -677,8 +762,8 @@ func (r *Reconciler) updateRunningBackupDeleteJobs(ctx context.Context, data *re
 	return returnReconcile, nil
 }
 
-// Delete backup and delete jobs that have been finished for a while.
-// For backups where both the backup and delete jobs have been deleted, delete the backup status entry too.
+// Delete backup, delete and verification jobs that have been finished for a while.
+// For backups where all of their jobs have been deleted, delete the backup status entry too.
 func (r *Reconciler) deleteFinishedBackupJobs(ctx context.Context, log *zap.SugaredLogger, backupConfig *kubermaticv1.EtcdBackupConfig, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	var returnReconcile *reconcile.Result
 
@@ -766,7 +851,44 @@ func (r *Reconciler) deleteFinishedBackupJobs(ctx context.Context, log *zap.Suga
 			}
 		}
 
-		if backupJobDeleted && deleteJobDeleted {
+		verificationJobDeleted := backup.VerificationJobName == ""
+		if !verificationJobDeleted && (!backup.VerificationFinishedTime.IsZero() || !backupConfig.DeletionTimestamp.IsZero()) {
+			var retentionTime time.Duration
+			switch {
+			case !backupConfig.DeletionTimestamp.IsZero():
+				retentionTime = 0
+			case backup.VerificationPhase == kubermaticv1.BackupStatusPhaseCompleted:
+				retentionTime = succeededJobRetentionTime
+			default:
+				retentionTime = failedJobRetentionTime
+			}
+
+			age := r.clock.Now().Sub(backup.VerificationFinishedTime.Time)
+
+			if !backup.VerificationFinishedTime.IsZero() && age < retentionTime {
+				// don't delete the job yet, but reconcile when the time has come to delete it
+				returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: retentionTime - age})
+			} else {
+				// delete job
+				job := &batchv1.Job{}
+
+				err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backup.VerificationJobName}, job)
+				switch {
+				case apierrors.IsNotFound(err):
+					verificationJobDeleted = true
+				case err == nil:
+					err := r.Delete(ctx, job, ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground))
+					if err != nil && !apierrors.IsNotFound(err) {
+						return nil, fmt.Errorf("backup %s: failed to delete verification job %s: %w", backup.BackupName, backup.VerificationJobName, err)
+					}
+					verificationJobDeleted = true
+				case !apierrors.IsNotFound(err):
+					return nil, fmt.Errorf("backup %s: failed to get verification job %s: %w", backup.BackupName, backup.VerificationJobName, err)
+				}
+			}
+		}
+
+		if backupJobDeleted && deleteJobDeleted && verificationJobDeleted {
 			// don't add backup to newBackups, which ends up deleting it from backupConfig.Status.CurrentBackups below
 			modified = true
 			continue
@@ -785,6 +907,78 @@ func (r *Reconciler) deleteFinishedBackupJobs(ctx context.Context, log *zap.Suga
 	return returnReconcile, nil
 }
 
+// ensureWALArchiver runs the WAL archiver for backup configs that have WAL archiving enabled and
+// removes it otherwise. While the cluster's etcd is being restored, the archiver is paused, as the restore
+// changes the revision numbering; the restore controller moves the archive to a new epoch afterwards.
+// Whether the archiver is available is reflected in the WALArchivingActive condition.
+func (r *Reconciler) ensureWALArchiver(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	oldBackupConfig := backupConfig.DeepCopy()
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: etcdbackup.WALArchiverDeploymentName(cluster, backupConfig)}
+
+	restoring := cluster.Annotations[etcdrestore.ActiveRestoreAnnotationName] != ""
+
+	if !backupConfig.IsWALArchiveEnabled() || backupConfig.DeletionTimestamp != nil || cluster.DeletionTimestamp != nil || restoring {
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, key, deployment)
+		switch {
+		case err == nil:
+			if err := r.Delete(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to delete WAL archiver: %w", err)
+			}
+		case !apierrors.IsNotFound(err):
+			return nil, fmt.Errorf("failed to get WAL archiver: %w", err)
+		}
+
+		if restoring && backupConfig.IsWALArchiveEnabled() {
+			if r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionWALArchivingActive, corev1.ConditionFalse, "RestoreInProgress", "the WAL archiver is paused while the etcd is being restored") {
+				if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+					return nil, fmt.Errorf("failed to update backup status: %w", err)
+				}
+			}
+
+			return &reconcile.Result{RequeueAfter: assumedJobRuntime}, nil
+		}
+
+		if _, ok := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionWALArchivingActive]; ok {
+			delete(backupConfig.Status.Conditions, kubermaticv1.EtcdBackupConfigConditionWALArchivingActive)
+			if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+				return nil, fmt.Errorf("failed to update backup status: %w", err)
+			}
+		}
+
+		return nil, nil
+	}
+
+	creators := []reconciling.NamedDeploymentReconcilerFactory{
+		etcdbackup.WALArchiverDeploymentReconciler(data, backupConfig),
+	}
+
+	if err := reconciling.ReconcileDeployments(ctx, creators, metav1.NamespaceSystem, r.Client, common.OwnershipModifierFactory(cluster, r.scheme)); err != nil {
+		return nil, fmt.Errorf("failed to reconcile WAL archiver: %w", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return nil, fmt.Errorf("failed to get WAL archiver: %w", err)
+	}
+
+	var result *reconcile.Result
+
+	status, reason, message := corev1.ConditionTrue, "", ""
+	if deployment.Status.AvailableReplicas < 1 {
+		status, reason, message = corev1.ConditionFalse, "ArchiverNotAvailable", "the WAL archiver is not running yet"
+		result = &reconcile.Result{RequeueAfter: assumedJobRuntime}
+	}
+
+	if r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionWALArchivingActive, status, reason, message) {
+		if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+			return nil, fmt.Errorf("failed to update backup status: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
 func (r *Reconciler) handleFinalization(ctx context.Context, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
 	if backupConfig.DeletionTimestamp == nil || len(backupConfig.Status.CurrentBackups) > 0 {
 		return nil, nil
@@ -824,6 +1018,45 @@ func (r *Reconciler) ensureSecrets(ctx context.Context, cluster *kubermaticv1.Cl
 	return reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client, common.OwnershipModifierFactory(cluster, r.scheme))
 }
 
+// ensureDataKey ensures that the cluster has a data key for the backup destination, if the
+// destination uses encryption. Whenever the destination's active key encryption key changes,
+// the data key is re-wrapped with the new key, so existing backups remain readable.
+func (r *Reconciler) ensureDataKey(ctx context.Context, cluster *kubermaticv1.Cluster, backupConfig *kubermaticv1.EtcdBackupConfig, destination *kubermaticv1.BackupDestination) error {
+	if destination == nil || destination.Encryption == nil {
+		return nil
+	}
+
+	keys, err := backupcrypto.GetKeyEncryptionKeys(ctx, r, destination.Encryption)
+	if err != nil {
+		return err
+	}
+
+	creators := []reconciling.NamedSecretReconcilerFactory{
+		dataKeySecretReconciler(cluster, backupConfig.Spec.Destination, keys, destination.Encryption.ActiveKey),
+	}
+
+	return reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client, common.OwnershipModifierFactory(cluster, r.scheme))
+}
+
+func dataKeySecretReconciler(cluster *kubermaticv1.Cluster, destinationName string, keys backupcrypto.KeyEncryptionKeys, activeKey string) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return backupcrypto.DataKeySecretName(cluster), func(s *corev1.Secret) (*corev1.Secret, error) {
+			if s.Data == nil {
+				s.Data = map[string][]byte{}
+			}
+
+			wrapped, err := keys.EnsureWrappedKey(s.Data[destinationName], activeKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to ensure data key for destination %q: %w", destinationName, err)
+			}
+
+			s.Data[destinationName] = wrapped
+
+			return s, nil
+		}
+	}
+}
+
 func caBundleConfigMapName(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("cluster-%s-ca-bundle", cluster.Name)
 }
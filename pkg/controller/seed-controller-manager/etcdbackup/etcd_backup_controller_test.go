@@ -17,6 +17,7 @@ limitations under the License.
 package etcdbackup
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/url"
@@ -28,6 +29,7 @@ import (
 	"github.com/go-test/deep"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdrestore"
 	"k8c.io/kubermatic/v2/pkg/defaulting"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -39,15 +41,19 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/diff"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/test/generator"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -208,6 +214,7 @@ func TestEnsurePendingBackupIsScheduled(t *testing.T) {
 		creationTime      time.Time
 		currentTime       time.Time
 		schedule          string
+		format            kubermaticv1.EtcdBackupFormat
 		backupConfigName  string
 		existingBackups   []kubermaticv1.BackupStatus
 		expectedBackups   []kubermaticv1.BackupStatus
@@ -357,6 +364,26 @@ func TestEnsurePendingBackupIsScheduled(t *testing.T) {
 				RequeueAfter: 3600 * 24 * 3 * time.Second,
 			},
 		},
+		{
+			name:            "chunked backups are named after their manifest",
+			creationTime:    time.Unix(0, 0).UTC(),
+			currentTime:     time.Unix(3600*24*17, 0).UTC(),
+			schedule:        "@every 120h",
+			format:          kubermaticv1.EtcdBackupFormatChunked,
+			existingBackups: nil,
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime: metav1.NewTime(time.Unix(3600*24*15, 0).UTC()),
+					BackupName:    "testbackup-1970-01-16t00-00-00.manifest.json",
+					JobName:       "testcluster-backup-testbackup-create-xxxx",
+					DeleteJobName: "testcluster-backup-testbackup-delete-xxxx",
+				},
+			},
+			expectedReconcile: &reconcile.Result{
+				Requeue:      true,
+				RequeueAfter: 3600 * 24 * 3 * time.Second,
+			},
+		},
 		{
 			name:             "If the backup config name is long, job names are cut short properly",
 			currentTime:      time.Unix(10, 0).UTC(),
@@ -387,6 +414,7 @@ func TestEnsurePendingBackupIsScheduled(t *testing.T) {
 			clock := clocktesting.NewFakeClock(tc.currentTime.UTC())
 			backupConfig.SetCreationTimestamp(metav1.Time{Time: clock.Now()})
 			backupConfig.Spec.Schedule = tc.schedule
+			backupConfig.Spec.Format = tc.format
 			backupConfig.SetCreationTimestamp(metav1.Time{Time: tc.creationTime})
 			backupConfig.Status.CurrentBackups = tc.existingBackups
 			if tc.backupConfigName != "" {
@@ -714,6 +742,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 					BackupMessage:      "job completed",
 					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
 					DeletePhase:        kubermaticv1.BackupStatusPhaseRunning,
+					PruneReason:        "only the newest 1 backups are kept",
 				},
 				{
 					ScheduledTime:      metav1.NewTime(time.Unix(120, 0).UTC()),
@@ -766,6 +795,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 					BackupMessage:      "job completed",
 					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
 					DeletePhase:        kubermaticv1.BackupStatusPhaseRunning,
+					PruneReason:        "backup failed",
 				},
 			},
 			expectedReconcile: &reconcile.Result{RequeueAfter: assumedJobRuntime},
@@ -832,6 +862,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 					BackupMessage:      "job completed",
 					DeleteJobName:      "testcluster-backup-testbackup-delete-bbbb",
 					DeletePhase:        kubermaticv1.BackupStatusPhaseRunning,
+					PruneReason:        "only the newest 1 backups are kept",
 				},
 				{
 					ScheduledTime:      metav1.NewTime(time.Unix(180, 0).UTC()),
@@ -921,6 +952,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 				}
 				if i > 0 && i <= maxSimultaneousDeleteJobsPerConfig {
 					result.DeletePhase = kubermaticv1.BackupStatusPhaseRunning
+					result.PruneReason = "only the newest 1 backups are kept"
 				}
 				return result
 			}),
@@ -1756,6 +1788,515 @@ func TestMultipleBackupDestination(t *testing.T) {
 	}
 }
 
+func TestEnsureWALArchiver(t *testing.T) {
+	testCases := []struct {
+		name               string
+		backupConfig       *kubermaticv1.EtcdBackupConfig
+		restoring          bool
+		existingObjects    []ctrlruntimeclient.Object
+		expectedDeployment bool
+		expectedCondition  *corev1.ConditionStatus
+	}{
+		{
+			name: "no archiver without WAL archiving",
+			backupConfig: func() *kubermaticv1.EtcdBackupConfig {
+				c := genBackupConfig(genTestCluster(), "testbackup")
+				c.Spec.Destination = "s3"
+				c.Spec.Schedule = "*/10 * * * *"
+				return c
+			}(),
+			expectedDeployment: false,
+		},
+		{
+			name: "no archiver for one-shot backups",
+			backupConfig: func() *kubermaticv1.EtcdBackupConfig {
+				c := genBackupConfig(genTestCluster(), "testbackup")
+				c.Spec.Destination = "s3"
+				c.Spec.WALArchive = &kubermaticv1.EtcdWALArchiveSettings{}
+				return c
+			}(),
+			expectedDeployment: false,
+		},
+		{
+			name: "archiver is created for scheduled backups with WAL archiving",
+			backupConfig: func() *kubermaticv1.EtcdBackupConfig {
+				c := genBackupConfig(genTestCluster(), "testbackup")
+				c.Spec.Destination = "s3"
+				c.Spec.Schedule = "*/10 * * * *"
+				c.Spec.WALArchive = &kubermaticv1.EtcdWALArchiveSettings{}
+				return c
+			}(),
+			expectedDeployment: true,
+			expectedCondition:  ptr.To(corev1.ConditionFalse),
+		},
+		{
+			name: "archiver is removed when WAL archiving is disabled",
+			backupConfig: func() *kubermaticv1.EtcdBackupConfig {
+				c := genBackupConfig(genTestCluster(), "testbackup")
+				c.Spec.Destination = "s3"
+				c.Spec.Schedule = "*/10 * * * *"
+				c.Status.Conditions = map[kubermaticv1.EtcdBackupConfigConditionType]kubermaticv1.EtcdBackupConfigCondition{
+					kubermaticv1.EtcdBackupConfigConditionWALArchivingActive: {
+						Status: corev1.ConditionTrue,
+					},
+				}
+				return c
+			}(),
+			existingObjects: []ctrlruntimeclient.Object{
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testcluster-testbackup-wal-archiver",
+						Namespace: metav1.NamespaceSystem,
+					},
+				},
+			},
+			expectedDeployment: false,
+		},
+		{
+			name: "archiver is paused while the etcd is being restored",
+			backupConfig: func() *kubermaticv1.EtcdBackupConfig {
+				c := genBackupConfig(genTestCluster(), "testbackup")
+				c.Spec.Destination = "s3"
+				c.Spec.Schedule = "*/10 * * * *"
+				c.Spec.WALArchive = &kubermaticv1.EtcdWALArchiveSettings{}
+				return c
+			}(),
+			restoring: true,
+			existingObjects: []ctrlruntimeclient.Object{
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testcluster-testbackup-wal-archiver",
+						Namespace: metav1.NamespaceSystem,
+					},
+				},
+			},
+			expectedDeployment: false,
+			expectedCondition:  ptr.To(corev1.ConditionFalse),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := genTestCluster()
+			if tc.restoring {
+				cluster.Annotations = map[string]string{
+					etcdrestore.ActiveRestoreAnnotationName: "cluster-testcluster/restore",
+				}
+			}
+
+			initObjs := []ctrlruntimeclient.Object{
+				cluster,
+				tc.backupConfig,
+			}
+			initObjs = append(initObjs, tc.existingObjects...)
+
+			reconciler := Reconciler{
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:   fake.NewClientBuilder().WithObjects(initObjs...).Build(),
+				scheme:   scheme.Scheme,
+				recorder: record.NewFakeRecorder(10),
+				clock:    clocktesting.NewFakeClock(time.Unix(60, 0).UTC()),
+				caBundle: certificates.NewFakeCABundle(),
+				seedGetter: func() (*kubermaticv1.Seed, error) {
+					return generator.GenTestSeed(addSeedDestinations), nil
+				},
+				randStringGenerator: constRandStringGenerator("bob"),
+				configGetter:        getConfigGetter(t),
+
+				etcdLauncherImage: defaulting.DefaultEtcdLauncherImage,
+			}
+
+			ctx := context.Background()
+			config, err := reconciler.configGetter(ctx)
+			if err != nil {
+				t.Fatalf("failed to get config: %v", err)
+			}
+
+			data, err := reconciler.getClusterTemplateData(ctx, cluster, generator.GenTestSeed(addSeedDestinations), config, tc.backupConfig)
+			if err != nil {
+				t.Fatalf("failed to get template data: %v", err)
+			}
+
+			if _, err := reconciler.ensureWALArchiver(ctx, data, tc.backupConfig, cluster); err != nil {
+				t.Fatalf("failed to ensure WAL archiver: %v", err)
+			}
+
+			deployments := appsv1.DeploymentList{}
+			if err := reconciler.List(ctx, &deployments); err != nil {
+				t.Fatalf("failed to list deployments: %v", err)
+			}
+
+			if tc.expectedDeployment {
+				if len(deployments.Items) != 1 {
+					t.Fatalf("expected 1 deployment, got %d", len(deployments.Items))
+				}
+
+				command := deployments.Items[0].Spec.Template.Spec.Containers[0].Command
+				if !strings.Contains(strings.Join(command, " "), "archive-wal") {
+					t.Errorf("expected archiver to run archive-wal, but command is %v", command)
+				}
+			} else if len(deployments.Items) != 0 {
+				t.Fatalf("expected no deployments, got %d", len(deployments.Items))
+			}
+
+			backupConfig := &kubermaticv1.EtcdBackupConfig{}
+			if err := reconciler.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(tc.backupConfig), backupConfig); err != nil {
+				t.Fatalf("failed to get backup config: %v", err)
+			}
+
+			condition, exists := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionWALArchivingActive]
+			switch {
+			case tc.expectedCondition == nil && exists:
+				t.Errorf("expected no %s condition, but got %v", kubermaticv1.EtcdBackupConfigConditionWALArchivingActive, condition)
+			case tc.expectedCondition != nil && !exists:
+				t.Errorf("expected %s condition, but it does not exist", kubermaticv1.EtcdBackupConfigConditionWALArchivingActive)
+			case tc.expectedCondition != nil && condition.Status != *tc.expectedCondition:
+				t.Errorf("expected %s condition to be %s, but is %s", kubermaticv1.EtcdBackupConfigConditionWALArchivingActive, *tc.expectedCondition, condition.Status)
+			}
+		})
+	}
+}
+
+func TestUpdateBackupVerifications(t *testing.T) {
+	const jobName = "testcluster-backup-testbackup-verify-bob"
+
+	genVerificationConfig := func(verification bool, backups ...kubermaticv1.BackupStatus) *kubermaticv1.EtcdBackupConfig {
+		c := genBackupConfig(genTestCluster(), "testbackup")
+		c.Spec.Destination = "s3"
+		c.Spec.Schedule = "*/10 * * * *"
+		if verification {
+			c.Spec.Verification = &kubermaticv1.EtcdBackupVerificationSettings{}
+		}
+		c.Status.CurrentBackups = backups
+		return c
+	}
+
+	genFinishedJob := func(condType batchv1.JobConditionType, message string) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jobName,
+				Namespace: metav1.NamespaceSystem,
+			},
+		}
+		return jobAddCondition(job, condType, corev1.ConditionTrue, time.Unix(50, 0), message)
+	}
+
+	genVerifierPod := func(result string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jobName + "-abcde",
+				Namespace: metav1.NamespaceSystem,
+				Labels: map[string]string{
+					"job-name": jobName,
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: etcdbackup.VerificationContainerName,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: result,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	runningBackup := kubermaticv1.BackupStatus{
+		BackupName:          "testbackup-2",
+		BackupPhase:         kubermaticv1.BackupStatusPhaseCompleted,
+		VerificationJobName: jobName,
+		VerificationPhase:   kubermaticv1.BackupStatusPhaseRunning,
+	}
+
+	testCases := []struct {
+		name              string
+		backupConfig      *kubermaticv1.EtcdBackupConfig
+		existingObjects   []ctrlruntimeclient.Object
+		expectedJob       bool
+		expectedBackups   []kubermaticv1.BackupStatus
+		expectedCondition *corev1.ConditionStatus
+	}{
+		{
+			name: "no verification without verification settings",
+			backupConfig: genVerificationConfig(false, kubermaticv1.BackupStatus{
+				BackupName:  "testbackup-1",
+				BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+			}),
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+			},
+		},
+		{
+			name: "newest completed backup is verified first",
+			backupConfig: genVerificationConfig(true,
+				kubermaticv1.BackupStatus{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				kubermaticv1.BackupStatus{
+					BackupName:  "testbackup-2",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				kubermaticv1.BackupStatus{
+					BackupName:  "testbackup-3",
+					BackupPhase: kubermaticv1.BackupStatusPhaseRunning,
+				},
+			),
+			expectedJob: true,
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				{
+					BackupName:          "testbackup-2",
+					BackupPhase:         kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationJobName: jobName,
+					VerificationPhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+				{
+					BackupName:  "testbackup-3",
+					BackupPhase: kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+		},
+		{
+			name: "successful verification is recorded",
+			backupConfig: genVerificationConfig(true, kubermaticv1.BackupStatus{
+				BackupName:               "testbackup-1",
+				BackupPhase:              kubermaticv1.BackupStatusPhaseCompleted,
+				VerificationPhase:        kubermaticv1.BackupStatusPhaseFailed,
+				VerificationFinishedTime: metav1.NewTime(time.Unix(10, 0)),
+			}, runningBackup),
+			existingObjects: []ctrlruntimeclient.Object{
+				genFinishedJob(batchv1.JobComplete, ""),
+				genVerifierPod(`{"revision":42,"keyCount":1337,"sourceKeyCount":1337}`),
+			},
+			expectedJob: true,
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:               "testbackup-1",
+					BackupPhase:              kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationPhase:        kubermaticv1.BackupStatusPhaseFailed,
+					VerificationFinishedTime: metav1.NewTime(time.Unix(10, 0)),
+				},
+				{
+					BackupName:               "testbackup-2",
+					BackupPhase:              kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationJobName:      jobName,
+					VerificationPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationFinishedTime: metav1.NewTime(time.Unix(50, 0)),
+					VerifiedRevision:         42,
+					VerifiedKeyCount:         1337,
+				},
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name:         "failed verification is recorded",
+			backupConfig: genVerificationConfig(true, runningBackup),
+			existingObjects: []ctrlruntimeclient.Object{
+				genFinishedJob(batchv1.JobFailed, "Job has reached the specified backoff limit"),
+				genVerifierPod(`{"revision":42,"keyCount":1336,"sourceKeyCount":1337,"error":"key count mismatch"}`),
+			},
+			expectedJob: true,
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:               "testbackup-2",
+					BackupPhase:              kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationJobName:      jobName,
+					VerificationPhase:        kubermaticv1.BackupStatusPhaseFailed,
+					VerificationFinishedTime: metav1.NewTime(time.Unix(50, 0)),
+					VerificationMessage:      "key count mismatch",
+					VerifiedRevision:         42,
+					VerifiedKeyCount:         1336,
+				},
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+		},
+		{
+			name:         "externally deleted verification job fails the verification",
+			backupConfig: genVerificationConfig(true, runningBackup),
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:               "testbackup-2",
+					BackupPhase:              kubermaticv1.BackupStatusPhaseCompleted,
+					VerificationJobName:      jobName,
+					VerificationPhase:        kubermaticv1.BackupStatusPhaseFailed,
+					VerificationFinishedTime: metav1.NewTime(time.Unix(60, 0)),
+					VerificationMessage:      "verification job deleted externally",
+				},
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := genTestCluster()
+
+			initObjs := []ctrlruntimeclient.Object{
+				cluster,
+				tc.backupConfig,
+			}
+			initObjs = append(initObjs, tc.existingObjects...)
+
+			reconciler := Reconciler{
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:   fake.NewClientBuilder().WithObjects(initObjs...).Build(),
+				scheme:   scheme.Scheme,
+				recorder: record.NewFakeRecorder(10),
+				clock:    clocktesting.NewFakeClock(time.Unix(60, 0).UTC()),
+				caBundle: certificates.NewFakeCABundle(),
+				seedGetter: func() (*kubermaticv1.Seed, error) {
+					return generator.GenTestSeed(addSeedDestinations), nil
+				},
+				randStringGenerator: constRandStringGenerator("bob"),
+				configGetter:        getConfigGetter(t),
+
+				etcdLauncherImage: defaulting.DefaultEtcdLauncherImage,
+			}
+
+			ctx := context.Background()
+			config, err := reconciler.configGetter(ctx)
+			if err != nil {
+				t.Fatalf("failed to get config: %v", err)
+			}
+
+			data, err := reconciler.getClusterTemplateData(ctx, cluster, generator.GenTestSeed(addSeedDestinations), config, tc.backupConfig)
+			if err != nil {
+				t.Fatalf("failed to get template data: %v", err)
+			}
+
+			if _, err := reconciler.updateBackupVerifications(ctx, data, tc.backupConfig); err != nil {
+				t.Fatalf("failed to update backup verifications: %v", err)
+			}
+
+			job := &batchv1.Job{}
+			err = reconciler.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: jobName}, job)
+			switch {
+			case tc.expectedJob && err != nil:
+				t.Fatalf("expected verification job to exist: %v", err)
+			case !tc.expectedJob && !apierrors.IsNotFound(err):
+				t.Fatalf("expected no verification job, but got err=%v", err)
+			}
+
+			backupConfig := &kubermaticv1.EtcdBackupConfig{}
+			if err := reconciler.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(tc.backupConfig), backupConfig); err != nil {
+				t.Fatalf("failed to get backup config: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedBackups, backupConfig.Status.CurrentBackups) {
+				t.Errorf("backups differ from the expected ones:\n%v", diff.ObjectDiff(tc.expectedBackups, backupConfig.Status.CurrentBackups))
+			}
+
+			condition, exists := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionBackupVerified]
+			switch {
+			case tc.expectedCondition == nil && exists:
+				t.Errorf("expected no %s condition, but got %v", kubermaticv1.EtcdBackupConfigConditionBackupVerified, condition)
+			case tc.expectedCondition != nil && !exists:
+				t.Errorf("expected %s condition, but it does not exist", kubermaticv1.EtcdBackupConfigConditionBackupVerified)
+			case tc.expectedCondition != nil && condition.Status != *tc.expectedCondition:
+				t.Errorf("expected %s condition to be %s, but is %s", kubermaticv1.EtcdBackupConfigConditionBackupVerified, *tc.expectedCondition, condition.Status)
+			}
+		})
+	}
+}
+
+func TestEnsureDataKey(t *testing.T) {
+	ctx := context.Background()
+	cluster := genTestCluster()
+
+	backupConfig := genBackupConfig(cluster, "testbackup")
+	backupConfig.Spec.Destination = "s3"
+
+	keysSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup-keys",
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string][]byte{
+			"key-1": []byte(strings.Repeat("a", backupcrypto.KeySize)),
+			"key-2": []byte(strings.Repeat("b", backupcrypto.KeySize)),
+		},
+	}
+
+	destination := genDefaultBackupDestination()
+	destination.Encryption = &kubermaticv1.BackupEncryption{
+		KeyEncryptionKeys: &corev1.SecretReference{
+			Name:      keysSecret.Name,
+			Namespace: keysSecret.Namespace,
+		},
+		ActiveKey: "key-1",
+	}
+
+	reconciler := Reconciler{
+		log:    kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client: fake.NewClientBuilder().WithObjects(cluster, keysSecret).Build(),
+		scheme: scheme.Scheme,
+	}
+
+	getWrappedKey := func() *backupcrypto.WrappedKey {
+		t.Helper()
+
+		secret := &corev1.Secret{}
+		if err := reconciler.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backupcrypto.DataKeySecretName(cluster)}, secret); err != nil {
+			t.Fatalf("failed to get data key secret: %v", err)
+		}
+
+		wrapped, err := backupcrypto.DecodeWrappedKey(secret.Data[backupConfig.Spec.Destination])
+		if err != nil {
+			t.Fatalf("failed to decode data key: %v", err)
+		}
+
+		return wrapped
+	}
+
+	if err := reconciler.ensureDataKey(ctx, cluster, backupConfig, destination); err != nil {
+		t.Fatalf("failed to ensure data key: %v", err)
+	}
+
+	wrapped := getWrappedKey()
+	if wrapped.KeyID != "key-1" {
+		t.Fatalf("expected data key to be wrapped with key-1, but got %q", wrapped.KeyID)
+	}
+
+	keys := backupcrypto.KeyEncryptionKeys(keysSecret.Data)
+
+	dataKey, err := keys.Unwrap(wrapped)
+	if err != nil {
+		t.Fatalf("failed to unwrap data key: %v", err)
+	}
+
+	// rotate the key encryption key
+	destination.Encryption.ActiveKey = "key-2"
+
+	if err := reconciler.ensureDataKey(ctx, cluster, backupConfig, destination); err != nil {
+		t.Fatalf("failed to ensure data key: %v", err)
+	}
+
+	rewrapped := getWrappedKey()
+	if rewrapped.KeyID != "key-2" {
+		t.Fatalf("expected data key to be re-wrapped with key-2, but got %q", rewrapped.KeyID)
+	}
+
+	rotatedDataKey, err := keys.Unwrap(rewrapped)
+	if err != nil {
+		t.Fatalf("failed to unwrap data key: %v", err)
+	}
+
+	if !bytes.Equal(dataKey, rotatedDataKey) {
+		t.Fatal("expected the data key to not change during key rotation")
+	}
+}
+
 func addSeedDestinations(seed *kubermaticv1.Seed) {
 	seed.Spec.EtcdBackupRestore = &kubermaticv1.EtcdBackupRestore{
 		DefaultDestination: "s3",
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	backupVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd_backup",
+		Name:      "verifications_total",
+		Help:      "The number of finished backup verifications, partitioned by their result",
+	}, []string{"cluster", "backup_config", "result"})
+
+	lastSuccessfulVerification = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd_backup",
+		Name:      "last_successful_verification_timestamp_seconds",
+		Help:      "The time when a backup of the backup config was last verified successfully",
+	}, []string{"cluster", "backup_config"})
+
+	verifiedKeyCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd_backup",
+		Name:      "verified_key_count",
+		Help:      "The number of keys found in the most recently verified backup of the backup config",
+	}, []string{"cluster", "backup_config"})
+)
+
+func MustRegisterMetrics(c prometheus.Registerer) {
+	c.MustRegister(backupVerifications)
+	c.MustRegister(lastSuccessfulVerification)
+	c.MustRegister(verifiedKeyCount)
+}
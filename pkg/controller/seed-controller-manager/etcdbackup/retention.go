@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// retentionPeriod returns the identifier of the period of the given tier that t falls into.
+func retentionPeriod(tier kubermaticv1.EtcdBackupRetentionTier, t time.Time) string {
+	t = t.UTC()
+
+	switch tier {
+	case kubermaticv1.EtcdBackupRetentionTierHourly:
+		return t.Format("2006-01-02T15")
+	case kubermaticv1.EtcdBackupRetentionTierDaily:
+		return t.Format("2006-01-02")
+	case kubermaticv1.EtcdBackupRetentionTierWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case kubermaticv1.EtcdBackupRetentionTierMonthly:
+		return t.Format("2006-01")
+	default:
+		return ""
+	}
+}
+
+// retainedBackups applies the given retention policy to all completed backups and returns the
+// tiers that keep each of them, keyed by backup name. Backups that are already being deleted are
+// not taken into account, as they cannot be kept anymore. Every tier keeps the newest backup of each
+// of its last N periods. Backups that are not part of the result are to be pruned. If no tier
+// keeps any backup, the newest backup is kept anyway (without any tiers), so that a policy with
+// all tiers set to 0 does not delete every single backup.
+func retainedBackups(backups []kubermaticv1.BackupStatus, retention *kubermaticv1.EtcdBackupRetention) map[string][]kubermaticv1.EtcdBackupRetentionTier {
+	var completed []*kubermaticv1.BackupStatus
+	for i := range backups {
+		if backups[i].BackupPhase == kubermaticv1.BackupStatusPhaseCompleted && backups[i].DeletePhase == "" {
+			completed = append(completed, &backups[i])
+		}
+	}
+
+	// newest first
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[j].ScheduledTime.Before(&completed[i].ScheduledTime)
+	})
+
+	result := map[string][]kubermaticv1.EtcdBackupRetentionTier{}
+
+	for _, tier := range kubermaticv1.AllEtcdBackupRetentionTiers {
+		count := retention.GetTierCount(tier)
+		periods := sets.New[string]()
+
+		for _, backup := range completed {
+			if periods.Len() >= count {
+				break
+			}
+
+			period := retentionPeriod(tier, backup.ScheduledTime.Time)
+			if periods.Has(period) {
+				continue
+			}
+
+			periods.Insert(period)
+			result[backup.BackupName] = append(result[backup.BackupName], tier)
+		}
+	}
+
+	if len(result) == 0 && len(completed) > 0 {
+		result[completed[0].BackupName] = nil
+	}
+
+	return result
+}
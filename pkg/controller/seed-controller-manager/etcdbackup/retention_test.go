@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRetainedBackups(t *testing.T) {
+	// one completed backup every 6 hours for 60 days, starting on Monday, 2024-01-01
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	genBackups := func() []kubermaticv1.BackupStatus {
+		var backups []kubermaticv1.BackupStatus
+		for i := 0; i < 60*4; i++ {
+			scheduled := start.Add(time.Duration(i) * 6 * time.Hour)
+			backups = append(backups, kubermaticv1.BackupStatus{
+				ScheduledTime: metav1.NewTime(scheduled),
+				BackupName:    fmt.Sprintf("backup-%s", scheduled.Format("2006-01-02t15")),
+				BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+			})
+		}
+		return backups
+	}
+
+	testCases := []struct {
+		name      string
+		backups   []kubermaticv1.BackupStatus
+		retention *kubermaticv1.EtcdBackupRetention
+		expected  map[string][]kubermaticv1.EtcdBackupRetentionTier
+	}{
+		{
+			name:    "tiers overlap on the newest backups",
+			backups: genBackups(),
+			retention: &kubermaticv1.EtcdBackupRetention{
+				Hourly:  ptr.To(2),
+				Daily:   ptr.To(2),
+				Weekly:  ptr.To(2),
+				Monthly: ptr.To(3),
+			},
+			expected: map[string][]kubermaticv1.EtcdBackupRetentionTier{
+				// newest backup, Thursday in week 9 and in February
+				"backup-2024-02-29t18": {
+					kubermaticv1.EtcdBackupRetentionTierHourly,
+					kubermaticv1.EtcdBackupRetentionTierDaily,
+					kubermaticv1.EtcdBackupRetentionTierWeekly,
+					kubermaticv1.EtcdBackupRetentionTierMonthly,
+				},
+				"backup-2024-02-29t12": {kubermaticv1.EtcdBackupRetentionTierHourly},
+				"backup-2024-02-28t18": {kubermaticv1.EtcdBackupRetentionTierDaily},
+				// last backup of week 8
+				"backup-2024-02-25t18": {kubermaticv1.EtcdBackupRetentionTierWeekly},
+				// last backup of January, there is no older month
+				"backup-2024-01-31t18": {kubermaticv1.EtcdBackupRetentionTierMonthly},
+			},
+		},
+		{
+			name: "only completed backups are considered",
+			backups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime: metav1.NewTime(start),
+					BackupName:    "completed",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				{
+					ScheduledTime: metav1.NewTime(start.Add(time.Hour)),
+					BackupName:    "failed",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseFailed,
+				},
+				{
+					ScheduledTime: metav1.NewTime(start.Add(2 * time.Hour)),
+					BackupName:    "running",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+			retention: &kubermaticv1.EtcdBackupRetention{
+				Hourly: ptr.To(3),
+			},
+			expected: map[string][]kubermaticv1.EtcdBackupRetentionTier{
+				"completed": {kubermaticv1.EtcdBackupRetentionTierHourly},
+			},
+		},
+		{
+			name: "backups being deleted are not retained",
+			backups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime: metav1.NewTime(start),
+					BackupName:    "older",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				{
+					ScheduledTime: metav1.NewTime(start.Add(time.Hour)),
+					BackupName:    "deleting",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+					DeletePhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+				{
+					ScheduledTime: metav1.NewTime(start.Add(2 * time.Hour)),
+					BackupName:    "newest",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+				},
+			},
+			retention: &kubermaticv1.EtcdBackupRetention{
+				Hourly: ptr.To(2),
+			},
+			expected: map[string][]kubermaticv1.EtcdBackupRetentionTier{
+				"older":  {kubermaticv1.EtcdBackupRetentionTierHourly},
+				"newest": {kubermaticv1.EtcdBackupRetentionTierHourly},
+			},
+		},
+		{
+			name:      "newest backup is kept without any tiers",
+			backups:   genBackups()[:3],
+			retention: &kubermaticv1.EtcdBackupRetention{},
+			expected: map[string][]kubermaticv1.EtcdBackupRetentionTier{
+				"backup-2024-01-01t12": nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			retained := retainedBackups(tc.backups, tc.retention)
+			if !diff.SemanticallyEqual(tc.expected, retained) {
+				t.Fatalf("retained backups differ from the expected ones:\n%v", diff.ObjectDiff(tc.expected, retained))
+			}
+		})
+	}
+}
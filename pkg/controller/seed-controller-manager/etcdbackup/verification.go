@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// updateBackupVerifications starts verification jobs for completed backups and updates the status
+// of backups whose verification jobs have finished. Only one verification runs at a time per
+// backup config, newer backups are verified first.
+func (r *Reconciler) updateBackupVerifications(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
+	var returnReconcile *reconcile.Result
+
+	oldBackupConfig := backupConfig.DeepCopy()
+	cluster := data.Cluster()
+
+	running := false
+	for i := range backupConfig.Status.CurrentBackups {
+		backup := &backupConfig.Status.CurrentBackups[i]
+		if backup.VerificationPhase != kubermaticv1.BackupStatusPhaseRunning {
+			continue
+		}
+
+		job := &batchv1.Job{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backup.VerificationJobName}, job)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting verification job for backup %s: %w", backup.BackupName, err)
+			}
+			// job not found. Apparently deleted externally.
+			backup.VerificationPhase = kubermaticv1.BackupStatusPhaseFailed
+			backup.VerificationMessage = "verification job deleted externally"
+			backup.VerificationFinishedTime = metav1.NewTime(r.clock.Now())
+		} else {
+			var finished *batchv1.JobCondition
+			if cond := getJobConditionIfTrue(job, batchv1.JobComplete); cond != nil {
+				backup.VerificationPhase = kubermaticv1.BackupStatusPhaseCompleted
+				finished = cond
+			} else if cond := getJobConditionIfTrue(job, batchv1.JobFailed); cond != nil {
+				backup.VerificationPhase = kubermaticv1.BackupStatusPhaseFailed
+				finished = cond
+			} else {
+				// job still running
+				running = true
+				returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: assumedJobRuntime})
+				continue
+			}
+
+			backup.VerificationFinishedTime = finished.LastTransitionTime
+			backup.VerificationMessage = finished.Message
+
+			result, err := r.getVerificationResult(ctx, job)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get verification result for backup %s: %w", backup.BackupName, err)
+			}
+
+			if result != nil {
+				backup.VerifiedRevision = result.Revision
+				backup.VerifiedKeyCount = result.KeyCount
+				if result.Error != "" {
+					backup.VerificationMessage = result.Error
+				}
+			}
+		}
+
+		r.recordVerification(cluster, backupConfig, backup)
+	}
+
+	if !running && backupConfig.IsVerificationEnabled() && backupConfig.DeletionTimestamp == nil {
+		for i := len(backupConfig.Status.CurrentBackups) - 1; i >= 0; i-- {
+			backup := &backupConfig.Status.CurrentBackups[i]
+			if backup.BackupPhase != kubermaticv1.BackupStatusPhaseCompleted || backup.DeletePhase != "" || backup.VerificationPhase != "" {
+				continue
+			}
+
+			backup.VerificationJobName = r.limitNameLength(fmt.Sprintf("%s-backup-%s-verify-%s", cluster.Name, backupConfig.Name, r.randStringGenerator()))
+
+			job := etcdbackup.BackupVerificationJob(data, backupConfig, backup)
+			if err := r.Create(ctx, job); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+				return nil, fmt.Errorf("error creating verification job for backup %s: %w", backup.BackupName, err)
+			}
+
+			backup.VerificationPhase = kubermaticv1.BackupStatusPhaseRunning
+			returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: assumedJobRuntime})
+
+			break
+		}
+	}
+
+	r.setBackupVerifiedCondition(backupConfig)
+
+	if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+		return nil, fmt.Errorf("failed to update backup status: %w", err)
+	}
+
+	return returnReconcile, nil
+}
+
+// getVerificationResult returns the result the verifier wrote into its termination message,
+// or nil if the verifier terminated without a (valid) result, e.g. when it was killed because
+// the job exceeded its deadline.
+func (r *Reconciler) getVerificationResult(ctx context.Context, job *batchv1.Job) (*etcdbackup.BackupVerificationResult, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(job.Namespace), ctrlruntimeclient.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != etcdbackup.VerificationContainerName || status.State.Terminated == nil || status.State.Terminated.Message == "" {
+				continue
+			}
+
+			result := &etcdbackup.BackupVerificationResult{}
+			if err := json.Unmarshal([]byte(status.State.Terminated.Message), result); err != nil {
+				r.log.Debugw("ignoring invalid verification result", "pod", pod.Name, "error", err)
+				continue
+			}
+
+			return result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Reconciler) recordVerification(cluster *kubermaticv1.Cluster, backupConfig *kubermaticv1.EtcdBackupConfig, backup *kubermaticv1.BackupStatus) {
+	result := "success"
+	if backup.VerificationPhase != kubermaticv1.BackupStatusPhaseCompleted {
+		result = "failure"
+	}
+
+	backupVerifications.WithLabelValues(cluster.Name, backupConfig.Name, result).Inc()
+
+	if backup.VerificationPhase == kubermaticv1.BackupStatusPhaseCompleted {
+		lastSuccessfulVerification.WithLabelValues(cluster.Name, backupConfig.Name).Set(float64(backup.VerificationFinishedTime.Unix()))
+		verifiedKeyCount.WithLabelValues(cluster.Name, backupConfig.Name).Set(float64(backup.VerifiedKeyCount))
+
+		r.recorder.Eventf(backupConfig, corev1.EventTypeNormal, "BackupVerified", "backup %s verified successfully (revision %d, %d keys)", backup.BackupName, backup.VerifiedRevision, backup.VerifiedKeyCount)
+	} else {
+		r.recorder.Eventf(backupConfig, corev1.EventTypeWarning, "BackupVerificationFailed", "verification of backup %s failed: %s", backup.BackupName, backup.VerificationMessage)
+	}
+}
+
+// setBackupVerifiedCondition reflects the outcome of the most recently finished verification in
+// the BackupVerified condition. The condition is removed if verification is disabled.
+func (r *Reconciler) setBackupVerifiedCondition(backupConfig *kubermaticv1.EtcdBackupConfig) {
+	if !backupConfig.IsVerificationEnabled() {
+		delete(backupConfig.Status.Conditions, kubermaticv1.EtcdBackupConfigConditionBackupVerified)
+		return
+	}
+
+	var latest *kubermaticv1.BackupStatus
+	for i := range backupConfig.Status.CurrentBackups {
+		backup := &backupConfig.Status.CurrentBackups[i]
+		if backup.VerificationFinishedTime.IsZero() {
+			continue
+		}
+
+		if latest == nil || latest.VerificationFinishedTime.Before(&backup.VerificationFinishedTime) {
+			latest = backup
+		}
+	}
+
+	// keep the last known outcome, e.g. when the verified backup has been deleted already
+	if latest == nil {
+		return
+	}
+
+	if latest.VerificationPhase == kubermaticv1.BackupStatusPhaseCompleted {
+		r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionBackupVerified, corev1.ConditionTrue, "VerificationSucceeded",
+			fmt.Sprintf("backup %s was restored successfully (revision %d, %d keys)", latest.BackupName, latest.VerifiedRevision, latest.VerifiedKeyCount))
+	} else {
+		r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionBackupVerified, corev1.ConditionFalse, "VerificationFailed",
+			fmt.Sprintf("verification of backup %s failed: %s", latest.BackupName, latest.VerificationMessage))
+	}
+}
@@ -28,9 +28,13 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+	"k8c.io/kubermatic/v2/pkg/util/chunkstore"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -178,6 +182,12 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 		return nil, nil
 	}
 
+	if restore.Spec.PointInTime != nil {
+		if err := r.resolvePointInTimeBackup(ctx, restore); err != nil {
+			return nil, fmt.Errorf("failed to determine backup for point-in-time restore: %w", err)
+		}
+	}
+
 	log.Infof("performing etcd restore from backup %v", restore.Spec.BackupName)
 
 	if restore.DeletionTimestamp == nil {
@@ -207,8 +217,20 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 		return nil, fmt.Errorf("failed to obtain S3 client: %w", err)
 	}
 
+	// the etcd-launcher needs to read the key encryption keys to unwrap the backup's data key
+	if destination != nil && destination.Encryption != nil {
+		if err := backupcrypto.GrantKeyEncryptionKeysAccess(ctx, r, cluster, destination.Encryption, rbac.EtcdLauncherServiceAccountName); err != nil {
+			return nil, fmt.Errorf("failed to grant access to the key encryption keys: %w", err)
+		}
+	}
+
 	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), restore.Spec.BackupName)
-	if _, err := s3Client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{}); err != nil {
+	if chunkstore.IsManifest(objectName) {
+		// for chunked backups, make sure the manifest can actually be understood
+		if _, err := chunkstore.New(s3Client, bucketName, cluster.GetName()).GetManifest(ctx, objectName); err != nil {
+			return nil, fmt.Errorf("could not read backup manifest %s: %w", objectName, err)
+		}
+	} else if _, err := s3Client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{}); err != nil {
 		return nil, fmt.Errorf("could not access backup object %s: %w", objectName, err)
 	}
 
@@ -233,8 +255,17 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 		}
 	}
 
-	if restore.Status.Phase == kubermaticv1.EtcdRestorePhaseStsRebuilding {
-		return r.rebuildEtcdStatefulset(ctx, log, restore, cluster)
+	// the WAL must not be archived while the etcd is being restored
+	paused, err := r.pauseWALArchivers(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if !paused {
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if restore.Status.Phase == kubermaticv1.EtcdRestorePhaseStsRebuilding || restore.Status.Phase == kubermaticv1.EtcdRestorePhaseWALReplaying {
+		return r.rebuildEtcdStatefulset(ctx, log, restore, cluster, destination)
 	}
 
 	// pause cluster
@@ -293,10 +324,10 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 		return nil, fmt.Errorf("failed to proceed to sts rebuilding phase: %w", err)
 	}
 
-	return r.rebuildEtcdStatefulset(ctx, log, restore, cluster)
+	return r.rebuildEtcdStatefulset(ctx, log, restore, cluster, destination)
 }
 
-func (r *Reconciler) rebuildEtcdStatefulset(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+func (r *Reconciler) rebuildEtcdStatefulset(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster, destination *kubermaticv1.BackupDestination) (*reconcile.Result, error) {
 	log.Info("Rebuilding Statefulset...")
 
 	if cluster.Spec.Pause {
@@ -325,6 +356,27 @@ func (r *Reconciler) rebuildEtcdStatefulset(ctx context.Context, log *zap.Sugare
 		return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// for point-in-time restores, the etcd-launcher replays the archived WAL once etcd is healthy again
+	if restore.Spec.PointInTime != nil && !restore.Status.WALReplayed {
+		if err := r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+			restore.Status.Phase = kubermaticv1.EtcdRestorePhaseWALReplaying
+		}); err != nil {
+			return nil, fmt.Errorf("failed to proceed to WAL replaying phase: %w", err)
+		}
+
+		return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.advanceWALArchiveEpochs(ctx, restore, cluster); err != nil {
+		return nil, err
+	}
+
+	if destination != nil && destination.Encryption != nil {
+		if err := backupcrypto.RevokeKeyEncryptionKeysAccess(ctx, r, cluster, destination.Encryption); err != nil {
+			return nil, fmt.Errorf("failed to revoke access to the key encryption keys: %w", err)
+		}
+	}
+
 	if err := r.updateCluster(ctx, cluster, func(cluster *kubermaticv1.Cluster) {
 		delete(cluster.Annotations, ActiveRestoreAnnotationName)
 	}); err != nil {
@@ -341,6 +393,133 @@ func (r *Reconciler) rebuildEtcdStatefulset(ctx context.Context, log *zap.Sugare
 	return nil, nil
 }
 
+// resolvePointInTimeBackup validates the point-in-time target of a restore and, if no backup was given
+// explicitly, picks the most recent completed backup of the referenced EtcdBackupConfig that finished
+// before the target time.
+func (r *Reconciler) resolvePointInTimeBackup(ctx context.Context, restore *kubermaticv1.EtcdRestore) error {
+	pointInTime := restore.Spec.PointInTime
+
+	if pointInTime.BackupConfig == "" {
+		return errors.New("no EtcdBackupConfig specified")
+	}
+
+	if pointInTime.Time == nil && pointInTime.Revision == nil {
+		return errors.New("either a time or a revision must be specified")
+	}
+
+	// once the restore has moved the archive to a new epoch, the backup and epoch must not change anymore
+	if restore.Status.WALArchiveEpochAdvanced {
+		return nil
+	}
+
+	if restore.Spec.BackupName == "" && pointInTime.Time == nil {
+		return errors.New("a backup name must be specified when restoring to a revision")
+	}
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: pointInTime.BackupConfig}, backupConfig); err != nil {
+		return fmt.Errorf("failed to get EtcdBackupConfig %q: %w", pointInTime.BackupConfig, err)
+	}
+
+	if !backupConfig.IsWALArchiveEnabled() {
+		return fmt.Errorf("EtcdBackupConfig %q does not archive the WAL", backupConfig.Name)
+	}
+
+	var backup *kubermaticv1.BackupStatus
+	if restore.Spec.BackupName == "" {
+		for i, candidate := range backupConfig.Status.CurrentBackups {
+			if candidate.BackupPhase != kubermaticv1.BackupStatusPhaseCompleted || candidate.BackupFinishedTime.After(pointInTime.Time.Time) {
+				continue
+			}
+
+			if backup == nil || candidate.BackupFinishedTime.After(backup.BackupFinishedTime.Time) {
+				backup = &backupConfig.Status.CurrentBackups[i]
+			}
+		}
+
+		if backup == nil {
+			return fmt.Errorf("EtcdBackupConfig %q has no completed backup before %s", backupConfig.Name, pointInTime.Time)
+		}
+	} else {
+		for i, candidate := range backupConfig.Status.CurrentBackups {
+			if candidate.BackupName == restore.Spec.BackupName {
+				backup = &backupConfig.Status.CurrentBackups[i]
+				break
+			}
+		}
+	}
+
+	// the WAL of the epoch in which the backup was taken is replayed; backups that are
+	// not tracked by the EtcdBackupConfig are assumed to belong to the current epoch
+	epoch := backupConfig.Status.WALArchiveEpoch
+	if backup != nil {
+		epoch = backup.WALArchiveEpoch
+	}
+
+	return r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		if backup != nil {
+			restore.Spec.BackupName = backup.BackupName
+		}
+		if restore.Spec.Destination == "" {
+			restore.Spec.Destination = backupConfig.Spec.Destination
+		}
+		restore.Status.WALArchiveEpoch = epoch
+	})
+}
+
+// pauseWALArchivers removes the WAL archivers of the cluster while its etcd is being restored and
+// returns true once none is running anymore. The etcd backup controller does not recreate them as
+// long as the cluster has an active restore.
+func (r *Reconciler) pauseWALArchivers(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	archivers := &appsv1.DeploymentList{}
+	if err := r.List(ctx, archivers, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem), ctrlruntimeclient.MatchingLabels{
+		resources.AppLabelKey:     etcdbackup.WALArchiverLabel,
+		resources.ClusterLabelKey: cluster.Name,
+	}); err != nil {
+		return false, fmt.Errorf("failed to list WAL archivers: %w", err)
+	}
+
+	for i := range archivers.Items {
+		if err := r.Delete(ctx, &archivers.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete WAL archiver %s: %w", archivers.Items[i].Name, err)
+		}
+	}
+
+	return len(archivers.Items) == 0, nil
+}
+
+// advanceWALArchiveEpochs moves the WAL archives of all EtcdBackupConfigs of the cluster to a new
+// epoch. The restored etcd continues with the revision numbering of the snapshot (and creates new
+// revisions while replaying the WAL), so archiving into the previous epoch would mix up revisions.
+func (r *Reconciler) advanceWALArchiveEpochs(ctx context.Context, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) error {
+	if restore.Status.WALArchiveEpochAdvanced {
+		return nil
+	}
+
+	backupConfigs := &kubermaticv1.EtcdBackupConfigList{}
+	if err := r.List(ctx, backupConfigs, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return fmt.Errorf("failed to list EtcdBackupConfigs: %w", err)
+	}
+
+	for i := range backupConfigs.Items {
+		backupConfig := &backupConfigs.Items[i]
+		if backupConfig.Spec.Cluster.Name != cluster.Name || !backupConfig.IsWALArchiveEnabled() {
+			continue
+		}
+
+		oldBackupConfig := backupConfig.DeepCopy()
+		backupConfig.Status.WALArchiveEpoch++
+
+		if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+			return fmt.Errorf("failed to advance WAL archive epoch of EtcdBackupConfig %s: %w", backupConfig.Name, err)
+		}
+	}
+
+	return r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		restore.Status.WALArchiveEpochAdvanced = true
+	})
+}
+
 func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
 	oldCluster := cluster.DeepCopy()
 	modify(cluster)
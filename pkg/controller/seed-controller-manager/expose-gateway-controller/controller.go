@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exposegatewaycontroller
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// This controller manages the Gateway used by the Gateway expose strategy.
+	ControllerName = "kkp-expose-gateway-controller"
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log        *zap.SugaredLogger
+	recorder   record.EventRecorder
+	seedGetter provider.SeedGetter
+}
+
+func Add(mgr manager.Manager, log *zap.SugaredLogger, seedGetter provider.SeedGetter) error {
+	reconciler := &Reconciler{
+		Client:     mgr.GetClient(),
+		log:        log.Named(ControllerName),
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		seedGetter: seedGetter,
+	}
+
+	// All events lead to the one shared Gateway being reconciled. The Gateway itself is
+	// not watched, so that seeds without the Gateway API CRDs can still run this controller.
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		Watches(&kubermaticv1.Cluster{}, controllerutil.EnqueueConst("")).
+		Watches(&kubermaticv1.Seed{}, controllerutil.EnqueueConst("")).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	r.log.Debug("Processing")
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	err = r.reconcile(ctx, seed)
+	if err != nil {
+		r.log.Errorw("Failed to reconcile expose Gateway", zap.Error(err))
+		r.recorder.Event(seed, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, seed *kubermaticv1.Seed) error {
+	clusters, err := r.exposedClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) == 0 {
+		return r.ensureGatewayIsRemoved(ctx, seed)
+	}
+
+	if seed.Spec.ExposeGateway == nil {
+		return fmt.Errorf("%d cluster(s) use the %s expose strategy, but the seed has no exposeGateway configuration", len(clusters), kubermaticv1.ExposeStrategyGateway)
+	}
+
+	gatewayReconcilers := []reconciling.NamedGatewayReconcilerFactory{
+		exposegateway.GatewayReconciler(seed.Spec.ExposeGateway),
+	}
+
+	if err := reconciling.ReconcileGateways(ctx, gatewayReconcilers, seed.Namespace, r); err != nil {
+		return fmt.Errorf("failed to reconcile Gateway: %w", err)
+	}
+
+	return nil
+}
+
+// exposedClusters returns all clusters using the Gateway expose strategy.
+func (r *Reconciler) exposedClusters(ctx context.Context) ([]kubermaticv1.Cluster, error) {
+	clusterList := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusterList); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var clusters []kubermaticv1.Cluster
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway || cluster.Status.NamespaceName == "" || cluster.DeletionTimestamp != nil {
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
+
+func (r *Reconciler) ensureGatewayIsRemoved(ctx context.Context, seed *kubermaticv1.Seed) error {
+	gateway := &gatewayapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: seed.Namespace,
+			Name:      exposegateway.GatewayName,
+		},
+	}
+
+	// The Gateway API CRDs are only required on seeds using the Gateway expose strategy.
+	if err := r.Delete(ctx, gateway); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete Gateway: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exposegatewaycontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const seedNamespace = "kubermatic"
+
+func TestReconcile(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name              string
+		exposeGateway     *kubermaticv1.ExposeGatewayConfig
+		objects           []ctrlruntimeclient.Object
+		existingGateway   bool
+		expectedListeners []string
+		expectedErr       bool
+	}{
+		{
+			name:            "scenario 1: Gateway is removed if no cluster uses the Gateway expose strategy",
+			exposeGateway:   &kubermaticv1.ExposeGatewayConfig{GatewayClassName: "envoy"},
+			existingGateway: true,
+			objects: []ctrlruntimeclient.Object{
+				genCluster("a", kubermaticv1.ExposeStrategyNodePort, now),
+			},
+		},
+		{
+			name:          "scenario 2: all clusters share the TLS listener",
+			exposeGateway: &kubermaticv1.ExposeGatewayConfig{GatewayClassName: "envoy"},
+			objects: []ctrlruntimeclient.Object{
+				genCluster("a", kubermaticv1.ExposeStrategyGateway, now),
+				genCluster("b", kubermaticv1.ExposeStrategyGateway, now.Add(time.Minute)),
+				genCluster("c", kubermaticv1.ExposeStrategyNodePort, now),
+			},
+			expectedListeners: []string{"tls:443"},
+		},
+		{
+			name: "scenario 3: Gateway expose strategy without Gateway configuration fails",
+			objects: []ctrlruntimeclient.Object{
+				genCluster("a", kubermaticv1.ExposeStrategyGateway, now),
+			},
+			expectedErr: true,
+		},
+		{
+			name:          "scenario 4: the number of clusters is not limited by the number of listeners",
+			exposeGateway: &kubermaticv1.ExposeGatewayConfig{GatewayClassName: "envoy"},
+			objects: func() []ctrlruntimeclient.Object {
+				var objects []ctrlruntimeclient.Object
+				for i := 0; i < 100; i++ {
+					objects = append(objects, genCluster(fmt.Sprintf("c%03d", i), kubermaticv1.ExposeStrategyGateway, now.Add(time.Duration(i)*time.Minute)))
+				}
+				return objects
+			}(),
+			expectedListeners: []string{"tls:443"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			seed := &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "seed",
+					Namespace: seedNamespace,
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeGateway: tc.exposeGateway,
+				},
+			}
+
+			objects := append([]ctrlruntimeclient.Object{seed}, tc.objects...)
+			if tc.existingGateway {
+				objects = append(objects, &gatewayapiv1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      exposegateway.GatewayName,
+						Namespace: seedNamespace,
+					},
+				})
+			}
+
+			client := kubermaticfake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := &Reconciler{
+				Client:   client,
+				log:      kubermaticlog.Logger,
+				recorder: record.NewFakeRecorder(100),
+				seedGetter: func() (*kubermaticv1.Seed, error) {
+					return seed, nil
+				},
+			}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			gateway := &gatewayapiv1.Gateway{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: seedNamespace, Name: exposegateway.GatewayName}, gateway)
+
+			if tc.expectedListeners == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("Expected Gateway to be removed, but got: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to get Gateway: %v", err)
+			}
+
+			if gateway.Spec.GatewayClassName != gatewayapiv1.ObjectName(tc.exposeGateway.GatewayClassName) {
+				t.Errorf("Expected GatewayClass %q, got %q", tc.exposeGateway.GatewayClassName, gateway.Spec.GatewayClassName)
+			}
+
+			var listeners []string
+			for _, l := range gateway.Spec.Listeners {
+				listeners = append(listeners, fmt.Sprintf("%s:%d", l.Name, l.Port))
+			}
+
+			if fmt.Sprint(listeners) != fmt.Sprint(tc.expectedListeners) {
+				t.Errorf("Expected listeners %v, got %v", tc.expectedListeners, listeners)
+			}
+		})
+	}
+}
+
+func genCluster(name string, exposeStrategy kubermaticv1.ExposeStrategy, created time.Time) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ExposeStrategy: exposeStrategy,
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+		},
+	}
+}
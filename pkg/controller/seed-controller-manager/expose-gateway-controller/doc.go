@@ -0,0 +1,23 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package exposegatewaycontroller contains a controller that reconciles the Gateway shared by all
+clusters using the Gateway expose strategy. The Gateway has a single TLS listener that routes
+connections by their SNI hostname, so the number of clusters is not limited by the number of
+listeners. The TLSRoutes attaching to this listener are created by the kubernetes controller.
+*/
+package exposegatewaycontroller
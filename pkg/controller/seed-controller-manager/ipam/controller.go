@@ -19,6 +19,7 @@ package ipam
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"go.uber.org/zap"
 
@@ -211,7 +212,11 @@ func (r *Reconciler) compileCurrentAllocationsForPoolInDatacenter(ctx context.Co
 	// Check for exclusions in the configuration to mark them as "not free"
 	switch dcIPAMPoolCfg.Type {
 	case kubermaticv1.IPAMPoolAllocationTypeRange:
-		ipsToExclude, err := getIPsFromAddressRanges(dcIPAMPoolCfg.ExcludeRanges)
+		excludeRanges := dcIPAMPoolCfg.ExcludeRanges
+		if dcIPAMPoolCfg.IPv6 != nil {
+			excludeRanges = append(slices.Clone(excludeRanges), dcIPAMPoolCfg.IPv6.ExcludeRanges...)
+		}
+		ipsToExclude, err := getIPsFromAddressRanges(excludeRanges)
 		if err != nil {
 			return nil, err
 		}
@@ -222,6 +227,11 @@ func (r *Reconciler) compileCurrentAllocationsForPoolInDatacenter(ctx context.Co
 		for _, subnetCIDRToExclude := range dcIPAMPoolCfg.ExcludePrefixes {
 			dcIPAMPoolUsageMap.Insert(string(subnetCIDRToExclude))
 		}
+		if dcIPAMPoolCfg.IPv6 != nil {
+			for _, subnetCIDRToExclude := range dcIPAMPoolCfg.IPv6.ExcludePrefixes {
+				dcIPAMPoolUsageMap.Insert(string(subnetCIDRToExclude))
+			}
+		}
 	}
 
 	// List all IPAM allocations
@@ -232,13 +242,20 @@ func (r *Reconciler) compileCurrentAllocationsForPoolInDatacenter(ctx context.Co
 	}
 
 	// Iterate current IPAM allocations to build a map of used IPs (for range allocation type)
-	// or used subnets (for prefix allocation type) per datacenter pool
+	// or used subnets (for prefix allocation type) per datacenter pool.
+	// IPv4 and IPv6 entries never collide, so both families of a dual-stack pool share the map.
 	for _, ipamAllocation := range ipamAllocationList.Items {
 		if ipamAllocation.Name != ipamPoolName || ipamAllocation.Spec.DC != dc {
 			// This allocation is not relevant for this IPAM Pool, so skip it
 			continue
 		}
 
+		hasIPv6Allocation := ipamAllocation.Spec.IPv6CIDR != "" || len(ipamAllocation.Spec.IPv6Addresses) > 0
+		if hasIPv6Allocation && dcIPAMPoolCfg.IPv6 == nil {
+			// the IPv6 part of a dual-stack pool cannot be removed while there are allocations for it
+			return nil, errIncompatiblePool
+		}
+
 		switch ipamAllocation.Spec.Type {
 		case kubermaticv1.IPAMPoolAllocationTypeRange:
 			currentAllocatedIPs, err := getIPsFromAddressRanges(ipamAllocation.Spec.Addresses)
@@ -253,6 +270,20 @@ func (r *Reconciler) compileCurrentAllocationsForPoolInDatacenter(ctx context.Co
 			for _, ip := range currentAllocatedIPs {
 				dcIPAMPoolUsageMap.Insert(ip)
 			}
+
+			if hasIPv6Allocation {
+				currentAllocatedIPv6IPs, err := getIPsFromAddressRanges(ipamAllocation.Spec.IPv6Addresses)
+				if err != nil {
+					return nil, err
+				}
+				err = checkRangeAllocation(currentAllocatedIPv6IPs, string(dcIPAMPoolCfg.IPv6.PoolCIDR), dcIPAMPoolCfg.IPv6.AllocationRange)
+				if err != nil {
+					return nil, err
+				}
+				for _, ip := range currentAllocatedIPv6IPs {
+					dcIPAMPoolUsageMap.Insert(ip)
+				}
+			}
 		case kubermaticv1.IPAMPoolAllocationTypePrefix:
 			// check if the current allocation is compatible with the IPAMPool being applied
 			err := checkPrefixAllocation(string(ipamAllocation.Spec.CIDR), string(dcIPAMPoolCfg.PoolCIDR), dcIPAMPoolCfg.ExcludePrefixes, dcIPAMPoolCfg.AllocationPrefix)
@@ -260,6 +291,14 @@ func (r *Reconciler) compileCurrentAllocationsForPoolInDatacenter(ctx context.Co
 				return nil, err
 			}
 			dcIPAMPoolUsageMap.Insert(string(ipamAllocation.Spec.CIDR))
+
+			if hasIPv6Allocation {
+				err := checkPrefixAllocation(string(ipamAllocation.Spec.IPv6CIDR), string(dcIPAMPoolCfg.IPv6.PoolCIDR), dcIPAMPoolCfg.IPv6.ExcludePrefixes, dcIPAMPoolCfg.IPv6.AllocationPrefix)
+				if err != nil {
+					return nil, err
+				}
+				dcIPAMPoolUsageMap.Insert(string(ipamAllocation.Spec.IPv6CIDR))
+			}
 		}
 	}
 
@@ -292,24 +331,33 @@ func IPAMAllocationReconciler(ipamAllocation *kubermaticv1.IPAMAllocation, clust
 
 			switch dcIPAMPoolCfg.Type {
 			case kubermaticv1.IPAMPoolAllocationTypeRange:
-				ipsAllocated, err := getIPsFromAddressRanges(ipamAllocation.Spec.Addresses)
+				addresses, err := allocateRanges(ipamPool.Name, dcIPAMPoolCfg.PoolCIDR, dcIPAMPoolCfg.AllocationRange, ipamAllocation.Spec.Addresses, dcIPAMPoolUsageMap)
 				if err != nil {
 					return nil, err
 				}
-
-				newIPRangeToAllocate := dcIPAMPoolCfg.AllocationRange - len(ipsAllocated)
-
-				addresses, err := findFirstFreeRangesOfPool(ipamPool.Name, string(dcIPAMPoolCfg.PoolCIDR), newIPRangeToAllocate, dcIPAMPoolUsageMap)
-				if err != nil {
-					return nil, err
+				ipamAllocation.Spec.Addresses = addresses
+
+				if dcIPAMPoolCfg.IPv6 != nil {
+					ipv6Addresses, err := allocateRanges(ipamPool.Name, dcIPAMPoolCfg.IPv6.PoolCIDR, dcIPAMPoolCfg.IPv6.AllocationRange, ipamAllocation.Spec.IPv6Addresses, dcIPAMPoolUsageMap)
+					if err != nil {
+						return nil, err
+					}
+					ipamAllocation.Spec.IPv6Addresses = ipv6Addresses
 				}
-				ipamAllocation.Spec.Addresses = append(ipamAllocation.Spec.Addresses, addresses...)
 			case kubermaticv1.IPAMPoolAllocationTypePrefix:
 				subnetCIDR, err := findFirstFreeSubnetOfPool(ipamPool.Name, string(dcIPAMPoolCfg.PoolCIDR), string(ipamAllocation.Spec.CIDR), dcIPAMPoolCfg.AllocationPrefix, dcIPAMPoolUsageMap)
 				if err != nil {
 					return nil, err
 				}
 				ipamAllocation.Spec.CIDR = kubermaticv1.SubnetCIDR(subnetCIDR)
+
+				if dcIPAMPoolCfg.IPv6 != nil {
+					ipv6SubnetCIDR, err := findFirstFreeSubnetOfPool(ipamPool.Name, string(dcIPAMPoolCfg.IPv6.PoolCIDR), string(ipamAllocation.Spec.IPv6CIDR), dcIPAMPoolCfg.IPv6.AllocationPrefix, dcIPAMPoolUsageMap)
+					if err != nil {
+						return nil, err
+					}
+					ipamAllocation.Spec.IPv6CIDR = kubermaticv1.SubnetCIDR(ipv6SubnetCIDR)
+				}
 			}
 
 			return ipamAllocation, nil
@@ -1037,6 +1037,157 @@ func TestReconcileCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "range: dual-stack pool",
+			cluster: generateTestCluster("test-cluster-2", "test-dc-1"),
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.IPAMPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pool-1",
+					},
+					Spec: kubermaticv1.IPAMPoolSpec{
+						Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+							"test-dc-1": {
+								Type:            "range",
+								PoolCIDR:        "192.168.1.0/28",
+								AllocationRange: 8,
+								IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+									PoolCIDR:        "fd00::/120",
+									AllocationRange: 4,
+									ExcludeRanges:   []string{"fd00::4"},
+								},
+							},
+						},
+					},
+				},
+				&kubermaticv1.IPAMAllocation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-pool-1",
+						Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-1"),
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubermatic.k8c.io/v1", Kind: "IPAMPool", Name: "test-pool-1"}},
+					},
+					Spec: kubermaticv1.IPAMAllocationSpec{
+						Type:          kubermaticv1.IPAMPoolAllocationTypeRange,
+						DC:            "test-dc-1",
+						Addresses:     []string{"192.168.1.0-192.168.1.7"},
+						IPv6Addresses: []string{"fd00::-fd00::3"},
+					},
+				},
+			},
+			expectedClusterAllocations: &kubermaticv1.IPAMAllocationList{
+				Items: []kubermaticv1.IPAMAllocation{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "test-pool-1",
+							Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-2"),
+							ResourceVersion: "1",
+							OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubermatic.k8c.io/v1", Kind: "IPAMPool", Name: "test-pool-1"}},
+						},
+						Spec: kubermaticv1.IPAMAllocationSpec{
+							Type:          kubermaticv1.IPAMPoolAllocationTypeRange,
+							DC:            "test-dc-1",
+							Addresses:     []string{"192.168.1.8-192.168.1.15"},
+							IPv6Addresses: []string{"fd00::5-fd00::8"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prefix: dual-stack pool",
+			cluster: generateTestCluster("test-cluster-2", "test-dc-1"),
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.IPAMPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pool-1",
+					},
+					Spec: kubermaticv1.IPAMPoolSpec{
+						Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+							"test-dc-1": {
+								Type:             "prefix",
+								PoolCIDR:         "192.168.1.0/28",
+								AllocationPrefix: 29,
+								IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+									PoolCIDR:         "fd00::/48",
+									AllocationPrefix: 64,
+								},
+							},
+						},
+					},
+				},
+				&kubermaticv1.IPAMAllocation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-pool-1",
+						Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-1"),
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubermatic.k8c.io/v1", Kind: "IPAMPool", Name: "test-pool-1"}},
+					},
+					Spec: kubermaticv1.IPAMAllocationSpec{
+						Type:     kubermaticv1.IPAMPoolAllocationTypePrefix,
+						DC:       "test-dc-1",
+						CIDR:     "192.168.1.0/29",
+						IPv6CIDR: "fd00::/64",
+					},
+				},
+			},
+			expectedClusterAllocations: &kubermaticv1.IPAMAllocationList{
+				Items: []kubermaticv1.IPAMAllocation{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "test-pool-1",
+							Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-2"),
+							ResourceVersion: "1",
+							OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubermatic.k8c.io/v1", Kind: "IPAMPool", Name: "test-pool-1"}},
+						},
+						Spec: kubermaticv1.IPAMAllocationSpec{
+							Type:     kubermaticv1.IPAMPoolAllocationTypePrefix,
+							DC:       "test-dc-1",
+							CIDR:     "192.168.1.8/29",
+							IPv6CIDR: "fd00:0:0:1::/64",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prefix: dual-stack pool with IPv6 part removed",
+			cluster: generateTestCluster("test-cluster-2", "test-dc-1"),
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.IPAMPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pool-1",
+					},
+					Spec: kubermaticv1.IPAMPoolSpec{
+						Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+							"test-dc-1": {
+								Type:             "prefix",
+								PoolCIDR:         "192.168.1.0/28",
+								AllocationPrefix: 29,
+							},
+						},
+					},
+				},
+				&kubermaticv1.IPAMAllocation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-pool-1",
+						Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-1"),
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubermatic.k8c.io/v1", Kind: "IPAMPool", Name: "test-pool-1"}},
+					},
+					Spec: kubermaticv1.IPAMAllocationSpec{
+						Type:     kubermaticv1.IPAMPoolAllocationTypePrefix,
+						DC:       "test-dc-1",
+						CIDR:     "192.168.1.0/29",
+						IPv6CIDR: "fd00::/64",
+					},
+				},
+			},
+			expectedClusterAllocations: &kubermaticv1.IPAMAllocationList{
+				Items: []kubermaticv1.IPAMAllocation{},
+			},
+			expectedError: errIncompatiblePool,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -22,6 +22,8 @@ import (
 	"net"
 	"strings"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -86,6 +88,22 @@ func calculateRangeFreeIPsFromDatacenterPool(poolCIDR string, dcIPAMPoolUsageMap
 	return rangeFreeIPs, nil
 }
 
+// allocateRanges tops up the currently allocated address ranges to the allocation range
+// with free addresses of the pool.
+func allocateRanges(poolName string, poolCIDR kubermaticv1.SubnetCIDR, allocationRange int, currentAddresses []string, dcIPAMPoolUsageMap sets.Set[string]) ([]string, error) {
+	ipsAllocated, err := getIPsFromAddressRanges(currentAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := findFirstFreeRangesOfPool(poolName, string(poolCIDR), allocationRange-len(ipsAllocated), dcIPAMPoolUsageMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(currentAddresses, addresses...), nil
+}
+
 func findFirstFreeRangesOfPool(poolName, poolCIDR string, allocationRange int, dcIPAMPoolUsageMap sets.Set[string]) ([]string, error) {
 	addressRanges := []string{}
 
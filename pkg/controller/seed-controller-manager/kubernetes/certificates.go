@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// syncCertificateExpiry reflects the certificate that expires next in the cluster namespace
+// in the CertificatesValid condition. The certificates themselves are renewed by their
+// reconcilers once they enter the renewal window.
+func (r *Reconciler) syncCertificateExpiry(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if cluster.Status.NamespaceName == "" {
+		return nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	secret, nearest := certificates.NearestExpiry(secrets.Items)
+	if nearest == nil {
+		return nil
+	}
+
+	status := corev1.ConditionTrue
+	if resources.CertWillExpireSoon(nearest.Cert) {
+		status = corev1.ConditionFalse
+	}
+
+	message := fmt.Sprintf("certificate %s in Secret %s expires next at %s", nearest.Key, secret.Name, nearest.Cert.NotAfter.UTC().Format(time.RFC3339))
+
+	return kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionCertificatesValid,
+			status,
+			"",
+			message,
+		)
+	})
+}
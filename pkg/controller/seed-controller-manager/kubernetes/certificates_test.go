@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncCertificateExpiry(t *testing.T) {
+	ca, err := triple.NewCA("root-ca")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	kp, err := triple.NewClientKeyPair(ca, "apiserver-etcd-client", nil)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithObjects(
+			cluster,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.CASecretName,
+					Namespace: cluster.Status.NamespaceName,
+				},
+				Data: map[string][]byte{
+					resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+					resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.ApiserverEtcdClientCertificateSecretName,
+					Namespace: cluster.Status.NamespaceName,
+				},
+				Data: map[string][]byte{
+					resources.ApiserverEtcdClientCertificateCertSecretKey: triple.EncodeCertPEM(kp.Cert),
+					resources.ApiserverEtcdClientCertificateKeySecretKey:  triple.EncodePrivateKeyPEM(kp.Key),
+				},
+			},
+		).Build(),
+	}
+
+	if err := r.syncCertificateExpiry(context.Background(), cluster); err != nil {
+		t.Fatalf("Failed to sync certificate expiry: %v", err)
+	}
+
+	condition, exists := cluster.Status.Conditions[kubermaticv1.ClusterConditionCertificatesValid]
+	if !exists {
+		t.Fatal("Expected CertificatesValid condition to be set")
+	}
+
+	if condition.Status != corev1.ConditionTrue {
+		t.Errorf("Expected condition to be %s, but got %s", corev1.ConditionTrue, condition.Status)
+	}
+
+	// the client certificate expires long before the CA
+	if !strings.Contains(condition.Message, resources.ApiserverEtcdClientCertificateSecretName) {
+		t.Errorf("Expected condition message to name Secret %q, but got %q", resources.ApiserverEtcdClientCertificateSecretName, condition.Message)
+	}
+}
@@ -318,6 +318,10 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed to sync health: %w", err)
 	}
 
+	if err := r.syncCertificateExpiry(ctx, cluster); err != nil {
+		return nil, fmt.Errorf("failed to sync certificate expiry: %w", err)
+	}
+
 	res, err := r.reconcileCluster(ctx, cluster, namespace)
 	if err != nil {
 		updateErr := r.updateClusterError(ctx, cluster, kubermaticv1.ReconcileClusterError, err.Error())
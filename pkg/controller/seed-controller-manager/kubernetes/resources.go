@@ -40,6 +40,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/csi"
 	"k8c.io/kubermatic/v2/pkg/resources/dns"
 	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
 	"k8c.io/kubermatic/v2/pkg/resources/gatekeeper"
 	"k8c.io/kubermatic/v2/pkg/resources/konnectivity"
 	kubernetesdashboard "k8c.io/kubermatic/v2/pkg/resources/kubernetes-dashboard"
@@ -60,10 +61,13 @@ import (
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -113,6 +117,14 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return nil, err
 	}
 
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		if err := r.ensureExposeGatewayRoutes(ctx, data); err != nil {
+			return nil, err
+		}
+	} else if err := r.ensureExposeGatewayRoutesAreRemoved(ctx, data, sets.New[string]()); err != nil {
+		return nil, err
+	}
+
 	if err := r.ensureNetworkPolicies(ctx, cluster, data, config); err != nil {
 		return nil, err
 	}
@@ -537,6 +549,10 @@ func (r *Reconciler) GetSecretReconcilers(ctx context.Context, data *resources.T
 
 	if data.Cluster().IsEncryptionEnabled() || data.Cluster().IsEncryptionActive() {
 		creators = append(creators, apiserver.EncryptionConfigurationSecretReconciler(data))
+
+		if data.Cluster().Spec.EncryptionConfiguration != nil && data.Cluster().Spec.EncryptionConfiguration.KMS != nil {
+			creators = append(creators, apiserver.KMSPluginConfigSecretReconciler(data))
+		}
 	}
 
 	if flag := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; flag {
@@ -689,12 +705,32 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 			)
 		}
 
-		issuerURL := c.Spec.OIDC.IssuerURL
-		if issuerURL == "" && r.features.KubernetesOIDCAuthentication {
-			issuerURL = data.OIDCIssuerURL()
+		var issuerURLs []string
+		if authn := c.Spec.AuthenticationConfiguration; authn != nil {
+			for _, jwt := range authn.JWT {
+				// the discovery URL is used to reach the issuer instead of the issuer URL, if set
+				if jwt.Issuer.DiscoveryURL != "" {
+					issuerURLs = append(issuerURLs, jwt.Issuer.DiscoveryURL)
+				} else {
+					issuerURLs = append(issuerURLs, jwt.Issuer.URL)
+				}
+			}
+
+			if r.features.KubernetesOIDCAuthentication {
+				issuerURLs = append(issuerURLs, data.OIDCIssuerURL())
+			}
+		} else if c.Spec.OIDC.IssuerURL != "" {
+			issuerURLs = append(issuerURLs, c.Spec.OIDC.IssuerURL)
+		} else if r.features.KubernetesOIDCAuthentication {
+			issuerURLs = append(issuerURLs, data.OIDCIssuerURL())
 		}
 
-		if issuerURL != "" {
+		var issuerIPs []net.IP
+		for _, issuerURL := range issuerURLs {
+			if issuerURL == "" {
+				continue
+			}
+
 			u, err := url.Parse(issuerURL)
 			if err != nil {
 				return fmt.Errorf("failed to parse OIDC issuer URL %q: %w", issuerURL, err)
@@ -705,7 +741,11 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 			if err != nil {
 				return fmt.Errorf("failed to resolve OIDC issuer URL %q: %w", issuerURL, err)
 			}
-			namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.OIDCIssuerAllowReconciler(ipList, cfg.Spec.Ingress.NamespaceOverride))
+			issuerIPs = append(issuerIPs, ipList...)
+		}
+
+		if len(issuerIPs) > 0 {
+			namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.OIDCIssuerAllowReconciler(issuerIPs, cfg.Spec.Ingress.NamespaceOverride))
 		}
 
 		apiIPs, err := r.fetchKubernetesServiceIPList(ctx, resolverCtx)
@@ -715,6 +755,20 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 
 		namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.SeedApiServerAllowReconciler(apiIPs))
 
+		if data.IsAuditLogAggregatorEnabled() {
+			namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.AuditLogAggregatorAllowReconciler(data.Seed().Namespace))
+		} else {
+			policy := &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.NetworkPolicyAuditLogAggregatorAllow,
+					Namespace: c.Status.NamespaceName,
+				},
+			}
+			if err := r.Client.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete audit log aggregator Network Policy: %w", err)
+			}
+		}
+
 		if err := reconciling.ReconcileNetworkPolicies(ctx, namedNetworkPolicyReconcilerFactories, c.Status.NamespaceName, r.Client); err != nil {
 			return fmt.Errorf("failed to ensure Network Policies: %w", err)
 		}
@@ -724,12 +778,15 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 }
 
 // GetConfigMapReconcilers returns all ConfigMapReconcilers that are currently in use.
-func GetConfigMapReconcilers(data *resources.TemplateData) []reconciling.NamedConfigMapReconcilerFactory {
+func GetConfigMapReconcilers(data *resources.TemplateData, enableAPIserverOIDCAuthentication bool) []reconciling.NamedConfigMapReconcilerFactory {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
 		apiserver.AuditConfigMapReconciler(data),
 		apiserver.AdmissionControlReconciler(data),
 		apiserver.CABundleReconciler(data),
 	}
+	if data.Cluster().Spec.AuthenticationConfiguration != nil {
+		creators = append(creators, apiserver.AuthenticationConfigurationReconciler(data, enableAPIserverOIDCAuthentication))
+	}
 	if !data.Cluster().Spec.DisableCSIDriver {
 		creators = append(creators, csi.ConfigMapsReconcilers(data)...)
 	}
@@ -747,7 +804,7 @@ func GetConfigMapReconcilers(data *resources.TemplateData) []reconciling.NamedCo
 }
 
 func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	creators := GetConfigMapReconcilers(data)
+	creators := GetConfigMapReconcilers(data, r.features.KubernetesOIDCAuthentication)
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure that the ConfigMap exists: %w", err)
@@ -1053,3 +1110,55 @@ func hostnameToIPList(ctx context.Context, hostname string) ([]net.IP, error) {
 
 	return ipList, nil
 }
+
+// ensureExposeGatewayRoutes creates the routes that attach the cluster's
+// control plane components to the seed's shared Gateway.
+func (r *Reconciler) ensureExposeGatewayRoutes(ctx context.Context, data *resources.TemplateData) error {
+	cluster := data.Cluster()
+	gatewayNamespace := data.Seed().Namespace
+
+	// The Gateway routes connections only by their SNI hostname. OpenVPN cannot be
+	// routed like this, but Konnectivity can no longer be disabled anyway.
+	tlsRoutes := []kkpreconciling.NamedTLSRouteReconcilerFactory{
+		exposegateway.APIServerTLSRouteReconciler(cluster, gatewayNamespace),
+		exposegateway.KonnectivityTLSRouteReconciler(cluster, gatewayNamespace),
+	}
+
+	if data.UserClusterMLAEnabled() && cluster.Spec.MLA != nil && (cluster.Spec.MLA.MonitoringEnabled || cluster.Spec.MLA.LoggingEnabled) {
+		tlsRoutes = append(tlsRoutes, exposegateway.MLAGatewayTLSRouteReconciler(cluster, gatewayNamespace))
+	}
+
+	if err := kkpreconciling.ReconcileTLSRoutes(ctx, tlsRoutes, cluster.Status.NamespaceName, r.Client); err != nil {
+		return fmt.Errorf("failed to ensure TLSRoutes: %w", err)
+	}
+
+	keep := sets.New[string]()
+	for _, factory := range tlsRoutes {
+		name, _ := factory()
+		keep.Insert("TLSRoute/" + name)
+	}
+
+	return r.ensureExposeGatewayRoutesAreRemoved(ctx, data, keep)
+}
+
+// ensureExposeGatewayRoutesAreRemoved removes all routes for the Gateway expose
+// strategy, except for the given ones (identified as "Kind/name").
+func (r *Reconciler) ensureExposeGatewayRoutesAreRemoved(ctx context.Context, data *resources.TemplateData, keep sets.Set[string]) error {
+	for _, resource := range exposegateway.ResourcesForDeletion(data.Cluster().Status.NamespaceName) {
+		gvk, err := apiutil.GVKForObject(resource, r.Scheme())
+		if err != nil {
+			return err
+		}
+
+		if keep.Has(gvk.Kind + "/" + resource.GetName()) {
+			continue
+		}
+
+		// The Gateway API CRDs are only required on seeds using the Gateway expose strategy.
+		if err := r.Client.Delete(ctx, resource); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to ensure Gateway routes are removed/not present: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -278,6 +278,11 @@ func GatewayExternalServiceReconciler(c *kubermaticv1.Cluster) reconciling.Named
 				s.Annotations[nodeportproxy.PortHostMappingAnnotationKey] =
 					fmt.Sprintf(`{%q: %q}`, extPortName, resources.MLAGatewaySNIPrefix+c.Status.Address.ExternalName)
 				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				// Exposes MLA GW via SNI using a TLSRoute.
+				s.Spec.Type = corev1.ServiceTypeClusterIP
+				delete(s.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", c.Spec.ExposeStrategy)
 			}
@@ -291,7 +296,7 @@ func GatewayExternalServiceReconciler(c *kubermaticv1.Cluster) reconciling.Named
 			s.Spec.Ports[0].Port = 80
 			s.Spec.Ports[0].TargetPort = intstr.FromString(extPortName)
 
-			if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+			if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 				s.Spec.Ports[0].NodePort = 0 // allows switching from other expose strategies
 			}
 
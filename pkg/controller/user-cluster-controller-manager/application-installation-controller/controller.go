@@ -97,8 +97,11 @@ func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.M
 			handler.TypedEnqueueRequestsFromMapFunc(enqueueAppInstallationForAppDef(r.userClient)),
 		)).
 		Build(r)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return addDriftController(log, seedMgr, userMgr, clusterIsPaused, appInstaller)
 }
 
 // Reconcile ApplicationInstallation (i.e. install / update or uninstall application into the user-cluster).
@@ -279,6 +282,11 @@ func (r *reconciler) handleInstallation(ctx context.Context, log *zap.SugaredLog
 	statusUpdater(&appInstallation.Status)
 	appInstallation.SetReadyCondition(installErr, hasLimitedRetries(appDefinition, appInstallation))
 
+	// the application has been re-applied, which reverted all changes made to its resources
+	if installErr == nil && appInstallation.Status.Conditions[appskubermaticv1.Drifted].Status == corev1.ConditionTrue {
+		appInstallation.SetCondition(appskubermaticv1.Drifted, corev1.ConditionFalse, "DriftReverted", "application has been re-applied")
+	}
+
 	// we set condition in every case and condition update the LastHeartbeatTime. So patch will not be empty.
 	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	driftControllerName = "kkp-app-drift-detection-controller"
+
+	// Event raised when resources of an applicationInstallation have been modified or deleted in the user cluster.
+	applicationDriftedEvent = "ApplicationDrifted"
+
+	// defaultDriftDetectionInterval is the interval at which applications are checked for drift if not configured.
+	defaultDriftDetectionInterval = 10 * time.Minute
+
+	// maxReportedDriftedResources is the maximum number of drifted resources listed in the Drifted condition.
+	maxReportedDriftedResources = 10
+)
+
+// driftReconciler periodically compares the resources of installed applications with their manifests. It is a
+// separate controller, so that the checks do not interfere with the installation of the applications. Self-healing
+// is delegated to the installation controller by changing the ApplicationSelfHealAnnotation.
+type driftReconciler struct {
+	log             *zap.SugaredLogger
+	seedClient      ctrlruntimeclient.Client
+	userClient      ctrlruntimeclient.Client
+	userRecorder    record.EventRecorder
+	clusterIsPaused userclustercontrollermanager.IsPausedChecker
+	appInstaller    applications.ApplicationInstaller
+}
+
+func addDriftController(log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterIsPaused userclustercontrollermanager.IsPausedChecker, appInstaller applications.ApplicationInstaller) error {
+	r := &driftReconciler{
+		log:             log.Named(driftControllerName),
+		seedClient:      seedMgr.GetClient(),
+		userClient:      userMgr.GetClient(),
+		userRecorder:    userMgr.GetEventRecorderFor(driftControllerName),
+		clusterIsPaused: clusterIsPaused,
+		appInstaller:    appInstaller,
+	}
+
+	_, err := builder.ControllerManagedBy(userMgr).
+		Named(driftControllerName).
+		// the checks are triggered by requeuing; spec changes are only watched to apply the drift detection settings
+		For(&appskubermaticv1.ApplicationInstallation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Build(r)
+
+	return err
+}
+
+func (r *driftReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("applicationinstallation", request)
+	log.Debug("Processing")
+
+	paused, err := r.clusterIsPaused(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check cluster pause status: %w", err)
+	}
+	if paused {
+		return reconcile.Result{}, nil
+	}
+
+	appInstallation := &appskubermaticv1.ApplicationInstallation{}
+	if err := r.userClient.Get(ctx, request.NamespacedName, appInstallation); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !appInstallation.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	settings := appInstallation.Spec.DriftDetection
+	if settings != nil && settings.Disabled {
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.reconcile(ctx, log, appInstallation); err != nil {
+		r.userRecorder.Event(appInstallation, corev1.EventTypeWarning, applicationInstallationReconcileFailedEvent, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	log.Debug("Processed")
+	return reconcile.Result{RequeueAfter: driftDetectionInterval(appInstallation)}, nil
+}
+
+func (r *driftReconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
+	// drift can only be detected once the application has been installed; a failed or ongoing installation
+	// is reported by the Ready condition
+	if appInstallation.Status.ApplicationVersion == nil || appInstallation.Status.Conditions[appskubermaticv1.Ready].Status != corev1.ConditionTrue {
+		log.Debug("Application is not ready, skipping drift detection")
+		return nil
+	}
+
+	drifted, err := r.appInstaller.DetectDrift(ctx, log, r.seedClient, r.userClient, appInstallation)
+	if err != nil {
+		return fmt.Errorf("failed to detect drift: %w", err)
+	}
+
+	oldAppInstallation := appInstallation.DeepCopy()
+	if len(drifted) == 0 {
+		appInstallation.SetCondition(appskubermaticv1.Drifted, corev1.ConditionFalse, "NoDrift", "all resources match the application's manifests")
+	} else {
+		message := driftMessage(drifted)
+		appInstallation.SetCondition(appskubermaticv1.Drifted, corev1.ConditionTrue, "DriftDetected", message)
+
+		log.Infow("Application has drifted", "resources", len(drifted))
+		r.userRecorder.Event(appInstallation, corev1.EventTypeWarning, applicationDriftedEvent, message)
+	}
+
+	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if len(drifted) == 0 || appInstallation.Spec.DriftDetection == nil || !appInstallation.Spec.DriftDetection.SelfHeal {
+		return nil
+	}
+
+	// changing the annotation triggers the installation controller, which re-applies the application
+	log.Info("Triggering re-installation of application to revert drift")
+
+	oldAppInstallation = appInstallation.DeepCopy()
+	if appInstallation.Annotations == nil {
+		appInstallation.Annotations = map[string]string{}
+	}
+	appInstallation.Annotations[appskubermaticv1.ApplicationSelfHealAnnotation] = time.Now().UTC().Format(time.RFC3339)
+
+	if err := r.userClient.Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to trigger self-healing: %w", err)
+	}
+
+	return nil
+}
+
+func driftDetectionInterval(appInstallation *appskubermaticv1.ApplicationInstallation) time.Duration {
+	if settings := appInstallation.Spec.DriftDetection; settings != nil && settings.Interval.Duration > 0 {
+		return settings.Interval.Duration
+	}
+
+	return defaultDriftDetectionInterval
+}
+
+// driftMessage lists the drifted resources for the Drifted condition.
+func driftMessage(drifted []util.DriftedResource) string {
+	var resources []string
+	for i, d := range drifted {
+		if i == maxReportedDriftedResources {
+			resources = append(resources, fmt.Sprintf("and %d more", len(drifted)-maxReportedDriftedResources))
+			break
+		}
+		resources = append(resources, d.String())
+	}
+
+	return fmt.Sprintf("%d resource(s) drifted: %s", len(drifted), strings.Join(resources, ", "))
+}
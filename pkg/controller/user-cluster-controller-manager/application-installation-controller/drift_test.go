@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/fake"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDriftDetection(t *testing.T) {
+	driftedResources := []util.DriftedResource{
+		{AppliedResource: appskubermaticv1.AppliedResource{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "web"}},
+		{AppliedResource: appskubermaticv1.AppliedResource{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "config"}, Deleted: true},
+	}
+
+	genInstalledApp := func(ready corev1.ConditionStatus, driftDetection *appskubermaticv1.DriftDetection) *appskubermaticv1.ApplicationInstallation {
+		app := genApplicationInstallation("appInstallation-1", "app-def-1", "1.0.0", 0, 1, 1)
+		app.Spec.DriftDetection = driftDetection
+		app.Status.ApplicationVersion = &genApplicationDefinition("app-def-1").Spec.Versions[0]
+		app.Status.Conditions[appskubermaticv1.Ready] = appskubermaticv1.ApplicationInstallationCondition{Status: ready}
+		return app
+	}
+
+	testCases := []struct {
+		name                  string
+		appInstallation       *appskubermaticv1.ApplicationInstallation
+		drifted               []util.DriftedResource
+		expectDetection       bool
+		expectedCondition     corev1.ConditionStatus
+		expectedReason        string
+		expectSelfHealTrigger bool
+	}{
+		{
+			name:            "application not ready is not checked",
+			appInstallation: genInstalledApp(corev1.ConditionFalse, nil),
+			drifted:         driftedResources,
+			expectDetection: false,
+		},
+		{
+			name:              "no drift",
+			appInstallation:   genInstalledApp(corev1.ConditionTrue, nil),
+			expectDetection:   true,
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    "NoDrift",
+		},
+		{
+			name:              "drift is only reported by default",
+			appInstallation:   genInstalledApp(corev1.ConditionTrue, nil),
+			drifted:           driftedResources,
+			expectDetection:   true,
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    "DriftDetected",
+		},
+		{
+			name:                  "drift triggers re-installation with self-healing",
+			appInstallation:       genInstalledApp(corev1.ConditionTrue, &appskubermaticv1.DriftDetection{SelfHeal: true}),
+			drifted:               driftedResources,
+			expectDetection:       true,
+			expectedCondition:     corev1.ConditionTrue,
+			expectedReason:        "DriftDetected",
+			expectSelfHealTrigger: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			userClient := kubermaticfake.NewClientBuilder().WithObjects(tc.appInstallation).Build()
+
+			detected := false
+			r := driftReconciler{
+				log:          kubermaticlog.New(true, kubermaticlog.FormatJSON).Sugar(),
+				seedClient:   userClient,
+				userClient:   userClient,
+				userRecorder: record.NewFakeRecorder(10),
+				appInstaller: fake.CustomApplicationInstaller{
+					DetectDriftFunc: func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedResource, error) {
+						detected = true
+						return tc.drifted, nil
+					},
+				},
+			}
+
+			appInstall := &appskubermaticv1.ApplicationInstallation{}
+			key := types.NamespacedName{Name: "appInstallation-1", Namespace: applicationNamespace}
+			if err := userClient.Get(ctx, key, appInstall); err != nil {
+				t.Fatalf("failed to get application installation: %v", err)
+			}
+
+			if err := r.reconcile(ctx, r.log, appInstall); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+
+			if detected != tc.expectDetection {
+				t.Fatalf("expected drift detection to be called=%v, but was %v", tc.expectDetection, detected)
+			}
+
+			appInstall = &appskubermaticv1.ApplicationInstallation{}
+			if err := userClient.Get(ctx, key, appInstall); err != nil {
+				t.Fatalf("failed to get application installation: %v", err)
+			}
+
+			condition := appInstall.Status.Conditions[appskubermaticv1.Drifted]
+			if condition.Status != tc.expectedCondition || condition.Reason != tc.expectedReason {
+				t.Errorf("expected Drifted condition %q/%q, but got %q/%q", tc.expectedCondition, tc.expectedReason, condition.Status, condition.Reason)
+			}
+
+			if len(tc.drifted) > 0 && tc.expectDetection {
+				for _, d := range tc.drifted {
+					if !strings.Contains(condition.Message, d.String()) {
+						t.Errorf("expected condition message to list %q, but got %q", d.String(), condition.Message)
+					}
+				}
+			}
+
+			_, triggered := appInstall.Annotations[appskubermaticv1.ApplicationSelfHealAnnotation]
+			if triggered != tc.expectSelfHealTrigger {
+				t.Errorf("expected self-heal annotation to be set=%v, but was %v", tc.expectSelfHealTrigger, triggered)
+			}
+		})
+	}
+}
+
+func TestDriftRevertedByInstallation(t *testing.T) {
+	ctx := context.Background()
+
+	app := genApplicationInstallation("appInstallation-1", "app-def-1", "1.0.0", 0, 1, 1)
+	app.Status.Conditions[appskubermaticv1.Drifted] = appskubermaticv1.ApplicationInstallationCondition{Status: corev1.ConditionTrue, Reason: "DriftDetected"}
+	userClient := kubermaticfake.NewClientBuilder().WithObjects(app).Build()
+
+	r := reconciler{log: kubermaticlog.Logger, seedClient: userClient, userClient: userClient, appInstaller: fake.ApplicationInstallerLogger{}}
+
+	key := types.NamespacedName{Name: "appInstallation-1", Namespace: applicationNamespace}
+	appInstall := &appskubermaticv1.ApplicationInstallation{}
+	if err := userClient.Get(ctx, key, appInstall); err != nil {
+		t.Fatalf("failed to get application installation: %v", err)
+	}
+
+	if err := r.handleInstallation(ctx, kubermaticlog.Logger, genApplicationDefinition("app-def-1"), appInstall); err != nil {
+		t.Fatalf("failed to handle installation: %v", err)
+	}
+
+	appInstall = &appskubermaticv1.ApplicationInstallation{}
+	if err := userClient.Get(ctx, key, appInstall); err != nil {
+		t.Fatalf("failed to get application installation: %v", err)
+	}
+
+	if condition := appInstall.Status.Conditions[appskubermaticv1.Drifted]; condition.Status != corev1.ConditionFalse || condition.Reason != "DriftReverted" {
+		t.Errorf("expected Drifted condition to be reverted, but got %q/%q", condition.Status, condition.Reason)
+	}
+}
+
+func TestDriftMessage(t *testing.T) {
+	var drifted []util.DriftedResource
+	for i := 0; i < maxReportedDriftedResources+2; i++ {
+		drifted = append(drifted, util.DriftedResource{AppliedResource: appskubermaticv1.AppliedResource{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: fmt.Sprintf("cm-%d", i)}})
+	}
+
+	message := driftMessage(drifted)
+
+	if !strings.HasPrefix(message, "12 resource(s) drifted: ConfigMap default/cm-0 (modified)") {
+		t.Errorf("unexpected message %q", message)
+	}
+	if !strings.HasSuffix(message, "and 2 more") {
+		t.Errorf("expected message to be truncated, but got %q", message)
+	}
+}
+
+func TestDriftDetectionInterval(t *testing.T) {
+	app := &appskubermaticv1.ApplicationInstallation{}
+	if interval := driftDetectionInterval(app); interval != defaultDriftDetectionInterval {
+		t.Errorf("expected default interval, but got %v", interval)
+	}
+
+	app.Spec.DriftDetection = &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: time.Minute}}
+	if interval := driftDetectionInterval(app); interval != time.Minute {
+		t.Errorf("expected configured interval, but got %v", interval)
+	}
+}
@@ -208,6 +208,12 @@ func Add(
 		))
 	}
 
+	// Watch resource quotas so that the Service validation webhook is installed or removed
+	// as soon as a quota for the cluster's project is created or deleted.
+	if versions.KubermaticEdition.IsEE() {
+		bldr.WatchesRawSource(source.Kind[ctrlruntimeclient.Object](seedMgr.GetCache(), &kubermaticv1.ResourceQuota{}, mapFn))
+	}
+
 	var clusterObj ctrlruntimeclient.Object = &kubermaticv1.Cluster{}
 
 	// Watch cluster if user cluster MLA is enabled so that controller can get resource requirements for user cluster MLA components.
@@ -387,7 +393,7 @@ func (r *reconciler) setupNetworkingData(cluster *kubermaticv1.Cluster, data *re
 		data.reconcileK8sSvcEndpoints = false
 	}
 
-	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 		data.k8sServiceEndpointAddress = r.tunnelingAgentIP.String()
 		data.k8sServiceEndpointPort = int32(r.kasSecurePort)
 	} else {
@@ -21,6 +21,7 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
@@ -54,6 +55,7 @@ import (
 	operatingsystemmanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/operating-system-manager"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/prometheus"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/scheduler"
+	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/service"
 	systembasicuser "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/system-basic-user"
 	userauth "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/user-auth"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/usersshkeys"
@@ -154,6 +156,15 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	data.clusterVersion = clusterVersion
 	data.kubernetesDashboardEnabled = cluster.Spec.IsKubernetesDashboardEnabled()
 
+	// Resource quotas are an EE feature; the Service webhook is only installed if there is actually
+	// a quota to enforce, so that it cannot block Services in clusters which are not subject to one.
+	if r.versions.KubermaticEdition.IsEE() {
+		data.serviceQuotaEnforced, err = r.hasResourceQuota(ctx, cluster)
+		if err != nil {
+			return fmt.Errorf("failed to check for resource quota: %w", err)
+		}
+	}
+
 	// Must be first because of openshift
 	if err := r.ensureAPIServices(ctx, data); err != nil {
 		return err
@@ -305,7 +316,7 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 }
 
 func (r *reconciler) ensureAPIServices(ctx context.Context, data reconcileData) error {
-	caCert := triple.EncodeCertPEM(data.caCert.Cert)
+	caCert := data.caCert.TrustBundlePEM()
 	creators := []kkpreconciling.NamedAPIServiceReconcilerFactory{
 		metricsserver.APIServiceReconciler(caCert),
 	}
@@ -686,6 +697,10 @@ func (r *reconciler) reconcileValidatingWebhookConfigurations(ctx context.Contex
 		creators = append(creators, machine.ValidatingWebhookConfigurationReconciler(data.caCert.Cert, r.namespace))
 	}
 
+	if data.serviceQuotaEnforced {
+		creators = append(creators, service.ValidatingWebhookConfigurationReconciler(data.caCert.Cert, r.namespace))
+	}
+
 	if r.opaIntegration {
 		creators = append(creators, gatekeeper.ValidatingWebhookConfigurationReconciler(r.opaWebhookTimeout))
 	}
@@ -702,6 +717,16 @@ func (r *reconciler) reconcileValidatingWebhookConfigurations(ctx context.Contex
 	if err := reconciling.ReconcileValidatingWebhookConfigurations(ctx, creators, "", r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile ValidatingWebhookConfigurations: %w", err)
 	}
+
+	if !data.serviceQuotaEnforced {
+		if err := r.Client.Delete(ctx, &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: service.ValidatingWebhookConfigurationName,
+			}}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove Service validation webhook: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -765,7 +790,7 @@ func (r *reconciler) reconcileEndpoints(ctx context.Context, data reconcileData)
 
 func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
-		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.caCert.Cert),
+		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.caCert),
 	}
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, metav1.NamespacePublic, r.Client); err != nil {
@@ -785,6 +810,18 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 				},
 			},
 		}
+		if data.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+			// The Gateway routes the apiserver only by its SNI hostname, so the connections
+			// of in-cluster clients are re-originated with it instead of being tunneled.
+			port, err := strconv.ParseUint(r.clusterURL.Port(), 10, 32)
+			if err != nil {
+				return fmt.Errorf("failed to parse port of cluster URL: %w", err)
+			}
+			envoyConfig.ProxyPort = uint32(port)
+			envoyConfig.TLSOrigination = &envoyagent.TLSOrigination{
+				ServerName: r.clusterURL.Hostname(),
+			}
+		}
 		if !r.isKonnectivityEnabled {
 			// add OpenVPN server port listener if Konnectivity is NOT enabled
 			envoyConfig.Listeners = append(envoyConfig.Listeners,
@@ -887,6 +924,10 @@ func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) e
 		creators = append(creators, usersshkeys.SecretReconciler(data.userSSHKeys))
 	}
 
+	if len(r.tunnelingAgentIP) > 0 && data.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		creators = append(creators, envoyagent.ServingCertSecretReconciler(data.caCert, r.tunnelingAgentIP, *data.k8sServiceApiIP, data.cluster.Spec.ClusterNetwork.DNSDomain))
+	}
+
 	if err := reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile Secrets in kube-system Namespace: %w", err)
 	}
@@ -963,7 +1004,8 @@ func (r *reconciler) reconcileDaemonSet(ctx context.Context, data reconcileData)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve envoy-agent config hash: %w", err)
 		}
-		dsReconcilers = append(dsReconcilers, envoyagent.DaemonSetReconciler(r.tunnelingAgentIP, r.versions, configHash, r.imageRewriter))
+		tlsOrigination := data.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway
+		dsReconcilers = append(dsReconcilers, envoyagent.DaemonSetReconciler(r.tunnelingAgentIP, tlsOrigination, r.versions, configHash, r.imageRewriter))
 	}
 
 	if err := reconciling.ReconcileDaemonSets(ctx, dsReconcilers, metav1.NamespaceSystem, r.Client); err != nil {
@@ -1144,6 +1186,7 @@ type reconcileData struct {
 	k8sServiceEndpointPort      int32
 	reconcileK8sSvcEndpoints    bool
 	kubernetesDashboardEnabled  bool
+	serviceQuotaEnforced        bool
 }
 
 func (r *reconciler) ensureOPAIntegrationIsRemoved(ctx context.Context) error {
@@ -1175,6 +1218,24 @@ func (r *reconciler) ensureOPAExperimentalMutationWebhookIsRemoved(ctx context.C
 	return nil
 }
 
+// hasResourceQuota returns whether a ResourceQuota exists for the project the cluster belongs to.
+func (r *reconciler) hasResourceQuota(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return false, nil
+	}
+
+	quotas := &kubermaticv1.ResourceQuotaList{}
+	if err := r.seedClient.List(ctx, quotas, ctrlruntimeclient.MatchingLabels{
+		kubermaticv1.ResourceQuotaSubjectNameLabelKey: projectID,
+		kubermaticv1.ResourceQuotaSubjectKindLabelKey: kubermaticv1.ProjectSubjectKind,
+	}); err != nil {
+		return false, err
+	}
+
+	return len(quotas.Items) > 0, nil
+}
+
 func (r *reconciler) getCluster(ctx context.Context) (*kubermaticv1.Cluster, error) {
 	cluster, err := kubernetes.ClusterFromNamespace(ctx, r.seedClient, r.namespace)
 	if err != nil {
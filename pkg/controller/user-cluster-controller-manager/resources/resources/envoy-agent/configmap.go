@@ -78,19 +78,50 @@ static_resources:
           "@type": type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy
           stat_prefix: tcp_stats
           cluster: "proxy_cluster"
+{{- if $.TLSOrigination}}
+      transport_socket:
+        name: envoy.transport_sockets.tls
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.DownstreamTlsContext
+          common_tls_context:
+            tls_certificates:
+            - certificate_chain:
+                filename: /etc/envoy/tls/tls.crt
+              private_key:
+                filename: /etc/envoy/tls/tls.key
+{{- else}}
           tunneling_config:
             hostname: {{$l.Authority}}
+{{- end}}
 {{- end}}
   clusters:
     - name: proxy_cluster
       connect_timeout: 5s
       type: LOGICAL_DNS
+{{- if .TLSOrigination}}
+      # The connection is re-encrypted with the SNI hostname by which the upstream routes it.
+      transport_socket:
+        name: envoy.transport_sockets.tls
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+          sni: {{.TLSOrigination.ServerName}}
+          common_tls_context:
+            alpn_protocols: ["http/1.1"]
+            validation_context:
+              trusted_ca:
+                filename: /etc/envoy/tls/ca.crt
+              match_typed_subject_alt_names:
+              - san_type: DNS
+                matcher:
+                  exact: {{.TLSOrigination.ServerName}}
+{{- else}}
       # This ensures HTTP/2 CONNECT is used for establishing the tunnel.
       typed_extension_protocol_options:
         envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
           "@type": type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
           explicit_http_config:
             http2_protocol_options: {}
+{{- end}}
       load_assignment:
         cluster_name: proxy_cluster
         endpoints:
@@ -120,12 +151,25 @@ type Config struct {
 	ProxyHost string
 	ProxyPort uint32
 	Listeners []Listener
+	// TLSOrigination makes the agent terminate the TLS connections on its listeners and
+	// re-originate them to the proxy, instead of tunneling them via HTTP/2 CONNECT.
+	TLSOrigination *TLSOrigination
 }
 
 type Listener struct {
 	BindAddress string
 	BindPort    uint32
-	Authority   string
+	// Authority is the target of the HTTP/2 CONNECT tunnel. It is not used with TLSOrigination.
+	Authority string
+}
+
+// TLSOrigination configures the agent to proxy connections to an upstream that routes them
+// by their SNI hostname, which clients connecting to an IP address do not send. The agent
+// serves the certificate from the EnvoyAgentServingCertSecretName secret and verifies the upstream
+// with the CA contained in it.
+type TLSOrigination struct {
+	// ServerName is used as SNI hostname and must be contained in the upstream's certificate.
+	ServerName string
 }
 
 // ConfigMapReconciler returns a ConfigMap containing the config for the Envoy agent.
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoyagent
+
+import (
+	"fmt"
+	"net"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/servingcerthelper"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ServingCertSecretReconciler returns the function to reconcile the certificate with which
+// the agent serves the apiserver to in-cluster clients when using TLS origination. It is
+// valid for the names and IPs of the kubernetes Service and the agent IP. The secret also
+// contains the cluster CA, which is used to verify the apiserver.
+func ServingCertSecretReconciler(ca *triple.KeyPair, agentIP net.IP, apiserverIP net.IP, dnsDomain string) reconciling.NamedSecretReconcilerFactory {
+	dnsNames := []string{
+		"kubernetes",
+		"kubernetes.default",
+		"kubernetes.default.svc",
+		fmt.Sprintf("kubernetes.default.svc.%s", dnsDomain),
+	}
+	caGetter := func() (*triple.KeyPair, error) {
+		return ca, nil
+	}
+
+	return func() (string, reconciling.SecretReconciler) {
+		name, servingCertReconciler := servingcerthelper.ServingCertSecretReconciler(caGetter, resources.EnvoyAgentServingCertSecretName, "kubernetes", dnsNames, []net.IP{agentIP, apiserverIP})()
+
+		return name, func(s *corev1.Secret) (*corev1.Secret, error) {
+			s, err := servingCertReconciler(s)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(ca.Cert)
+
+			return s, nil
+		}
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package machinecontroller
 
 import (
-	"crypto/x509"
 	"fmt"
 
 	"k8c.io/kubermatic/v2/pkg/resources"
@@ -30,7 +29,9 @@ import (
 )
 
 // ClusterInfoConfigMapReconciler returns the func to create/update the ConfigMap.
-func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconciling.NamedConfigMapReconcilerFactory {
+// The kubeconfig contains all CAs trusted by the cluster, so that nodes joining
+// during a CA rotation trust both the old and the new CA.
+func ClusterInfoConfigMapReconciler(url string, ca *triple.KeyPair) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return resources.ClusterInfoConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
@@ -43,7 +44,7 @@ func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconc
 			kubeconfig.Clusters = map[string]*clientcmdapi.Cluster{
 				"": {
 					Server:                   url,
-					CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+					CertificateAuthorityData: ca.TrustBundlePEM(),
 				},
 			}
 
@@ -245,7 +245,7 @@ func DefaultClusterNetwork(specClusterNetwork kubermaticv1.ClusterNetworkingConf
 		specClusterNetwork.DNSDomain = "cluster.local"
 	}
 
-	if exposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+	if exposeStrategy == kubermaticv1.ExposeStrategyTunneling || exposeStrategy == kubermaticv1.ExposeStrategyGateway {
 		if specClusterNetwork.TunnelingAgentIP == "" {
 			specClusterNetwork.TunnelingAgentIP = resources.DefaultTunnelingAgentIP
 		}
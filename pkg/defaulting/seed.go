@@ -36,6 +36,12 @@ const (
 	// DefaultMeteringRetentionDays is the default number of days for which the metering Prometheus
 	// should keep data.
 	DefaultMeteringRetentionDays = 90
+
+	// DefaultAuditLogAggregatorReplicas is the default number of replicas of the seed-level audit log aggregator.
+	DefaultAuditLogAggregatorReplicas = 2
+	// DefaultAuditLogAggregatorBufferSize is the default amount of audit events the seed-level audit log
+	// aggregator buffers per sink while the sink is unavailable.
+	DefaultAuditLogAggregatorBufferSize = "1Gi"
 )
 
 // DefaultSeed fills in missing values in the Seed's spec by copying them from the global
@@ -62,6 +68,21 @@ func DefaultSeed(seed *kubermaticv1.Seed, config *kubermaticv1.KubermaticConfigu
 		}
 	}
 
+	if auditLogging := seedCopy.Spec.AuditLogging; auditLogging != nil {
+		if auditLogging.Replicas == nil {
+			auditLogging.Replicas = ptr.To[int32](DefaultAuditLogAggregatorReplicas)
+		}
+
+		if auditLogging.BufferSize == nil {
+			bufferSize := resource.MustParse(DefaultAuditLogAggregatorBufferSize)
+			auditLogging.BufferSize = &bufferSize
+		}
+
+		if err := defaultResources(&auditLogging.Resources, DefaultAuditLogAggregatorResources, "auditLogging.resources", logger); err != nil {
+			return seedCopy, err
+		}
+	}
+
 	if err := defaultDockerRepo(&seedCopy.Spec.NodeportProxy.Envoy.DockerRepository, DefaultEnvoyDockerRepository, "nodeportProxy.envoy.dockerRepository", logger); err != nil {
 		return seedCopy, err
 	}
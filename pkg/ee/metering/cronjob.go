@@ -30,6 +30,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	"k8c.io/kubermatic/v2/pkg/ee/metering/prometheus"
+	"k8c.io/kubermatic/v2/pkg/ee/metering/query"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
@@ -45,8 +46,9 @@ func cronJobName(reportName string) string {
 	return "metering-" + reportName
 }
 
-// CronJobReconciler returns the func to create/update the metering report cronjob.
-func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, getRegistry registry.ImageRewriter, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
+// CronJobReconciler returns the func to create/update the metering report cronjob. If the report
+// references a PriceModel, the cronjob additionally generates a cost report using the given KKP image.
+func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, getRegistry registry.ImageRewriter, kubermaticImage string, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
 	return func() (string, reconciling.CronJobReconciler) {
 		return cronJobName(reportName), func(job *batchv1.CronJob) (*batchv1.CronJob, error) {
 			var args []string
@@ -93,52 +95,7 @@ func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfigu
 					Image:           getMeteringImage(getRegistry),
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Args:            args,
-					Env: []corev1.EnvVar{
-						{
-							Name: "S3_ENDPOINT",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Endpoint,
-								},
-							},
-						},
-						{
-							Name: "S3_BUCKET",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Bucket,
-								},
-							},
-						},
-						{
-							Name: "ACCESS_KEY_ID",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: AccessKey,
-								},
-							},
-						},
-						{
-							Name: "SECRET_ACCESS_KEY",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: SecretKey,
-								},
-							},
-						},
-					},
+					Env:             s3EnvVars(),
 					VolumeMounts: []corev1.VolumeMount{
 						{
 							Name:      "ca-bundle",
@@ -168,7 +125,123 @@ func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfigu
 				},
 			}
 
+			if mrc.PriceModel != "" {
+				// the cost report needs to map the cluster namespaces to projects
+				job.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = query.Name
+
+				job.Spec.JobTemplate.Spec.Template.Spec.Containers = append(job.Spec.JobTemplate.Spec.Template.Spec.Containers,
+					costReportContainer(reportName, mrc, kubermaticImage, seed))
+
+				job.Spec.JobTemplate.Spec.Template.Spec.Volumes = append(job.Spec.JobTemplate.Spec.Template.Spec.Volumes, corev1.Volume{
+					Name: "price-model",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: priceModelConfigMapName(mrc.PriceModel),
+							},
+						},
+					},
+				})
+			}
+
 			return job, nil
 		}
 	}
 }
+
+// costReportContainer returns the container generating the cost report for the given report.
+func costReportContainer(reportName string, mrc kubermaticv1.MeteringReportConfiguration, kubermaticImage string, seed *kubermaticv1.Seed) corev1.Container {
+	args := []string{
+		"-ca-bundle=/opt/ca-bundle/ca-bundle.pem",
+		fmt.Sprintf("-prometheus-api=http://%s.%s.svc", prometheus.Name, seed.Namespace),
+		fmt.Sprintf("-price-model=/opt/price-model/%s", priceModelKey),
+		fmt.Sprintf("-output-dir=%s", reportName),
+		fmt.Sprintf("-output-prefix=%s", seed.Name),
+	}
+
+	if mrc.Format != "" {
+		args = append(args, fmt.Sprintf("-output-format=%s", mrc.Format))
+	}
+
+	if mrc.Monthly {
+		args = append(args, "-last-month")
+	} else {
+		args = append(args, fmt.Sprintf("-last-number-of-days=%d", mrc.Interval))
+	}
+
+	return corev1.Container{
+		Name:            "cost-report",
+		Image:           kubermaticImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"metering-cost-reporter"},
+		Args:            args,
+		Env:             s3EnvVars(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "ca-bundle",
+				MountPath: "/opt/ca-bundle/",
+				ReadOnly:  true,
+			},
+			{
+				Name:      "price-model",
+				MountPath: "/opt/price-model/",
+				ReadOnly:  true,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+	}
+}
+
+func s3EnvVars() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: "S3_ENDPOINT",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: SecretName,
+					},
+					Key: Endpoint,
+				},
+			},
+		},
+		{
+			Name: "S3_BUCKET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: SecretName,
+					},
+					Key: Bucket,
+				},
+			},
+		},
+		{
+			Name: "ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: SecretName,
+					},
+					Key: AccessKey,
+				},
+			},
+		},
+		{
+			Name: "SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: SecretName,
+					},
+					Key: SecretKey,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package metering
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	priceModelComponent = "metering-price-model"
+	priceModelKey       = "price-model.yaml"
+)
+
+func priceModelConfigMapName(priceModel string) string {
+	return "metering-price-model-" + priceModel
+}
+
+// reconcilePriceModels copies the PriceModels referenced by the seed's metering reports from
+// the master cluster into ConfigMaps in the seed, so they can be mounted into the report CronJobs.
+// PriceModels that do not exist are reported as an event for each report referencing them and
+// returned, so that these reports can be skipped; their existing ConfigMaps are kept.
+func reconcilePriceModels(ctx context.Context, client ctrlruntimeclient.Client, masterClient ctrlruntimeclient.Reader, recorder record.EventRecorder, seed *kubermaticv1.Seed, modifiers ...reconciling.ObjectModifier) (sets.Set[string], error) {
+	desired := sets.New[string]()
+	missing := sets.New[string]()
+	var reconcilers []reconciling.NamedConfigMapReconcilerFactory
+
+	for _, reportName := range sets.List(sets.KeySet(seed.Spec.Metering.ReportConfigurations)) {
+		reportConf := seed.Spec.Metering.ReportConfigurations[reportName]
+		if reportConf.PriceModel == "" {
+			continue
+		}
+
+		if missing.Has(reportConf.PriceModel) {
+			recorder.Eventf(seed, corev1.EventTypeWarning, "PriceModelNotFound", "PriceModel %q of metering report %q does not exist, the report is not updated", reportConf.PriceModel, reportName)
+			continue
+		}
+
+		if desired.Has(reportConf.PriceModel) {
+			continue
+		}
+
+		priceModel := &kubermaticv1.PriceModel{}
+		if err := masterClient.Get(ctx, types.NamespacedName{Name: reportConf.PriceModel}, priceModel); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get PriceModel %q: %w", reportConf.PriceModel, err)
+			}
+
+			recorder.Eventf(seed, corev1.EventTypeWarning, "PriceModelNotFound", "PriceModel %q of metering report %q does not exist, the report is not updated", reportConf.PriceModel, reportName)
+			missing.Insert(reportConf.PriceModel)
+			continue
+		}
+
+		desired.Insert(priceModel.Name)
+		reconcilers = append(reconcilers, priceModelConfigMapReconciler(priceModel))
+	}
+
+	if err := reconciling.ReconcileConfigMaps(ctx, reconcilers, seed.Namespace, client, modifiers...); err != nil {
+		return nil, fmt.Errorf("failed to reconcile price model ConfigMaps: %w", err)
+	}
+
+	// keep the ConfigMaps of missing PriceModels for the existing report CronJobs
+	if err := cleanupOrphanedPriceModels(ctx, client, seed.Namespace, desired.Union(missing)); err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+func priceModelConfigMapReconciler(priceModel *kubermaticv1.PriceModel) reconciling.NamedConfigMapReconcilerFactory {
+	return func() (string, reconciling.ConfigMapReconciler) {
+		return priceModelConfigMapName(priceModel.Name), func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			kubernetes.EnsureLabels(cm, map[string]string{
+				common.NameLabel:      priceModel.Name,
+				common.ComponentLabel: priceModelComponent,
+			})
+
+			spec, err := yaml.Marshal(priceModel.Spec)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode price model: %w", err)
+			}
+
+			cm.Data = map[string]string{
+				priceModelKey: string(spec),
+			}
+
+			return cm, nil
+		}
+	}
+}
+
+// cleanupOrphanedPriceModels removes the price model ConfigMaps that are not referenced by any report anymore.
+func cleanupOrphanedPriceModels(ctx context.Context, client ctrlruntimeclient.Client, namespace string, desired sets.Set[string]) error {
+	configMaps := &corev1.ConfigMapList{}
+	if err := client.List(ctx, configMaps, ctrlruntimeclient.InNamespace(namespace), ctrlruntimeclient.MatchingLabels{common.ComponentLabel: priceModelComponent}); err != nil {
+		return fmt.Errorf("failed to list price model ConfigMaps: %w", err)
+	}
+
+	for i, cm := range configMaps.Items {
+		if desired.Has(cm.Labels[common.NameLabel]) {
+			continue
+		}
+
+		if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, &configMaps.Items[i])); err != nil {
+			return fmt.Errorf("failed to remove orphaned price model ConfigMap %s: %w", cm.Name, err)
+		}
+	}
+
+	return nil
+}
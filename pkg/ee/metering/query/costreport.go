@@ -0,0 +1,139 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+const (
+	// ClusterCostReport is the cost report listing the costs per cluster.
+	ClusterCostReport = "cluster"
+	// ProjectCostReport is the cost report listing the costs per project.
+	ProjectCostReport = "project"
+)
+
+var costReportHeader = []string{
+	"project",
+	"cluster",
+	"datacenter",
+	"provider",
+	"vcpu-hours",
+	"memory-gb-hours",
+	"storage-gb-hours",
+	"load-balancer-hours",
+	"cost",
+	"currency",
+}
+
+// ReportTimeRange returns the time range of a metering report generated at the given time,
+// either the previous calendar month or the given number of days before today, in UTC.
+func ReportTimeRange(now time.Time, monthly bool, days int) (from, to time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if monthly {
+		to = today.AddDate(0, 0, 1-today.Day())
+		return to.AddDate(0, -1, 0), to
+	}
+
+	return today.AddDate(0, 0, -days), today
+}
+
+// CostReports returns the cluster and project cost reports for the given options, encoded in
+// the given format and keyed by report type. opts.PriceModel must be set.
+func (q *Querier) CostReports(ctx context.Context, opts Options, format kubermaticv1.MeteringReportFormat) (map[string][]byte, error) {
+	if opts.PriceModel == nil {
+		return nil, fmt.Errorf("no price model given")
+	}
+
+	clusters, err := q.ClusterUsage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := q.ProjectUsage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := map[string][]byte{}
+	for name, report := range map[string]*Report{ClusterCostReport: clusters, ProjectCostReport: projects} {
+		encoded, err := encodeCostReport(report, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s cost report: %w", name, err)
+		}
+
+		reports[name] = encoded
+	}
+
+	return reports, nil
+}
+
+func encodeCostReport(report *Report, format kubermaticv1.MeteringReportFormat) ([]byte, error) {
+	if format == kubermaticv1.MeteringReportFormatJSON {
+		return json.Marshal(report)
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 4, 64)
+	}
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(costReportHeader); err != nil {
+		return nil, err
+	}
+
+	for _, u := range report.Usage {
+		if err := w.Write([]string{
+			u.Project,
+			u.Cluster,
+			u.Datacenter,
+			u.Provider,
+			formatFloat(u.VCPUHours),
+			formatFloat(u.MemoryGBHours),
+			formatFloat(u.StorageGBHours),
+			formatFloat(u.LoadBalancerHours),
+			strconv.FormatFloat(u.Cost, 'f', 2, 64),
+			u.Currency,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
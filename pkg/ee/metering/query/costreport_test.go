@@ -0,0 +1,130 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestReportTimeRange(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 37, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		monthly      bool
+		days         int
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{
+			name:         "last 7 days",
+			days:         7,
+			expectedFrom: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "last month",
+			monthly:      true,
+			expectedFrom: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := ReportTimeRange(now, tc.monthly, tc.days)
+			if !from.Equal(tc.expectedFrom) || !to.Equal(tc.expectedTo) {
+				t.Errorf("Expected %v - %v, but got %v - %v", tc.expectedFrom, tc.expectedTo, from, to)
+			}
+		})
+	}
+}
+
+func TestCostReports(t *testing.T) {
+	price := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+
+	priceModel := &kubermaticv1.PriceModelSpec{
+		Currency: "EUR",
+		Default: kubermaticv1.Prices{
+			VCPUHour:         price("0.02"),
+			MemoryGBHour:     price("0.01"),
+			StorageGBHour:    price("0.001"),
+			LoadBalancerHour: price("0.03"),
+		},
+		Overrides: []kubermaticv1.PriceOverride{
+			{
+				Provider: "aws",
+				Prices:   kubermaticv1.Prices{VCPUHour: price("0.03")},
+			},
+			{
+				// more specific than the aws override, so its VCPUHour price is not used for dc-b
+				Datacenter: "dc-b",
+				Provider:   "aws",
+				Prices:     kubermaticv1.Prices{StorageGBHour: price("0.5")},
+			},
+		},
+	}
+
+	to := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	querier, _ := newTestQuerier()
+
+	reports, err := querier.CostReports(context.Background(), Options{From: to.AddDate(0, 0, -7), To: to, PriceModel: priceModel}, kubermaticv1.MeteringReportFormatCSV)
+	if err != nil {
+		t.Fatalf("Failed to generate cost reports: %v", err)
+	}
+
+	expectedReports := map[string]string{
+		ClusterCostReport: `project,cluster,datacenter,provider,vcpu-hours,memory-gb-hours,storage-gb-hours,load-balancer-hours,cost,currency
+,deleted,,,0.0000,0.0000,0.0000,0.0000,0.00,EUR
+project1,c1,dc-a,aws,6.0000,3.0000,0.0000,0.0000,0.21,EUR
+project1,c2,dc-b,aws,0.0000,0.0000,1.0000,0.0000,0.50,EUR
+project2,c3,dc-c,openstack,0.0000,0.0000,0.0000,2.0000,0.06,EUR
+`,
+		ProjectCostReport: `project,cluster,datacenter,provider,vcpu-hours,memory-gb-hours,storage-gb-hours,load-balancer-hours,cost,currency
+project1,,,,6.0000,3.0000,1.0000,0.0000,0.71,EUR
+project2,,,,0.0000,0.0000,0.0000,2.0000,0.06,EUR
+`,
+	}
+
+	for name, expected := range expectedReports {
+		if got := string(reports[name]); got != expected {
+			t.Errorf("Expected %s cost report\n%s\nbut got\n%s", name, expected, got)
+		}
+	}
+
+	if _, err := querier.CostReports(context.Background(), Options{From: to.AddDate(0, 0, -7), To: to}, kubermaticv1.MeteringReportFormatCSV); err == nil || !strings.Contains(err.Error(), "price model") {
+		t.Errorf("Expected an error without price model, but got: %v", err)
+	}
+}
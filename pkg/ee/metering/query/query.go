@@ -0,0 +1,360 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// namespaceLabel is the label the metering Prometheus puts the cluster namespace into,
+	// see the relabel_configs in the metering Prometheus configuration.
+	namespaceLabel = "Namespace"
+
+	// scrapeInterval is the scrape interval of the metering Prometheus. Every
+	// kube_node_info sample therefore represents one node-minute.
+	scrapeInterval = time.Minute
+
+	gibibyte = 1024 * 1024 * 1024
+)
+
+// Usage is the resource usage of a single cluster or project within a time range.
+type Usage struct {
+	// Project is the ID of the project the usage belongs to. It is empty for
+	// clusters that no longer exist, as their project cannot be determined anymore.
+	Project string `json:"project"`
+	// Cluster is the name of the cluster. It is empty when the usage of a whole project is reported.
+	Cluster string `json:"cluster,omitempty"`
+	// Datacenter and Provider of the cluster. They are empty for projects and clusters that no longer exist.
+	Datacenter string `json:"datacenter,omitempty"`
+	Provider   string `json:"provider,omitempty"`
+
+	// CPUCoreSeconds is the CPU time consumed by all nodes of the cluster(s).
+	CPUCoreSeconds float64 `json:"cpuCoreSeconds"`
+	// AverageMemoryBytes is the average working set memory of all nodes of the cluster(s).
+	AverageMemoryBytes float64 `json:"averageMemoryBytes"`
+	// AverageStorageBytes is the average capacity of all persistent volumes of the cluster(s).
+	AverageStorageBytes float64 `json:"averageStorageBytes"`
+	// NodeHours is the sum of the time all nodes of the cluster(s) have been running.
+	NodeHours float64 `json:"nodeHours"`
+
+	// VCPUHours is the number of vCPUs of all nodes multiplied by the time they have been running.
+	VCPUHours float64 `json:"vcpuHours"`
+	// MemoryGBHours is the memory (in GiB) of all nodes multiplied by the time they have been running.
+	MemoryGBHours float64 `json:"memoryGBHours"`
+	// StorageGBHours is the capacity (in GiB) of all persistent volumes multiplied by the time they existed.
+	StorageGBHours float64 `json:"storageGBHours"`
+	// LoadBalancerHours is the sum of the time all Services of type LoadBalancer existed.
+	LoadBalancerHours float64 `json:"loadBalancerHours"`
+
+	// Cost is the cost of the usage according to the price model given in the query options.
+	Cost float64 `json:"cost,omitempty"`
+	// Currency is the currency of the Cost.
+	Currency string `json:"currency,omitempty"`
+}
+
+// add adds the usage (and cost) of other to u.
+func (u *Usage) add(other Usage) {
+	u.CPUCoreSeconds += other.CPUCoreSeconds
+	u.AverageMemoryBytes += other.AverageMemoryBytes
+	u.AverageStorageBytes += other.AverageStorageBytes
+	u.NodeHours += other.NodeHours
+	u.VCPUHours += other.VCPUHours
+	u.MemoryGBHours += other.MemoryGBHours
+	u.StorageGBHours += other.StorageGBHours
+	u.LoadBalancerHours += other.LoadBalancerHours
+	u.Cost += other.Cost
+}
+
+// Report is the result of a usage query.
+type Report struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Usage []Usage   `json:"usage"`
+}
+
+// Options restrict a usage query.
+type Options struct {
+	From time.Time
+	To   time.Time
+
+	// Project restricts the report to the clusters of the given project.
+	Project string
+	// Cluster restricts the report to the given cluster.
+	Cluster string
+
+	// PriceModel is used to calculate the cost of the usage, if set.
+	PriceModel *kubermaticv1.PriceModelSpec
+}
+
+func (o Options) validate() error {
+	if !o.From.Before(o.To) {
+		return fmt.Errorf("start of the time range (%s) must be before its end (%s)", o.From.Format(time.RFC3339), o.To.Format(time.RFC3339))
+	}
+
+	if o.To.Sub(o.From) < scrapeInterval {
+		return fmt.Errorf("time range must be at least %v", scrapeInterval)
+	}
+
+	return nil
+}
+
+// metric describes how a field of Usage is computed from the metering Prometheus.
+type metric struct {
+	// query is a PromQL expression with a single %s placeholder for the range.
+	query string
+	set   func(u *Usage, value float64)
+}
+
+var metrics = []metric{
+	{
+		query: `sum by (Namespace) (increase(node_cpu_usage_seconds_total[%s]))`,
+		set:   func(u *Usage, v float64) { u.CPUCoreSeconds += v },
+	},
+	{
+		query: `sum by (Namespace) (avg_over_time(node_memory_working_set_bytes[%s]))`,
+		set:   func(u *Usage, v float64) { u.AverageMemoryBytes += v },
+	},
+	{
+		query: `sum by (Namespace) (avg_over_time(kubelet_volume_stats_capacity_bytes[%s]))`,
+		set:   func(u *Usage, v float64) { u.AverageStorageBytes += v },
+	},
+	{
+		query: `sum by (Namespace) (count_over_time(kube_node_info[%s]))`,
+		set:   func(u *Usage, v float64) { u.NodeHours += v * scrapeInterval.Hours() },
+	},
+	{
+		query: `sum by (Namespace) (sum_over_time(machine_cpu_cores[%s]))`,
+		set:   func(u *Usage, v float64) { u.VCPUHours += v * scrapeInterval.Hours() },
+	},
+	{
+		query: `sum by (Namespace) (sum_over_time(machine_memory_bytes[%s]))`,
+		set:   func(u *Usage, v float64) { u.MemoryGBHours += v / gibibyte * scrapeInterval.Hours() },
+	},
+	{
+		query: `sum by (Namespace) (sum_over_time(kubelet_volume_stats_capacity_bytes[%s]))`,
+		set:   func(u *Usage, v float64) { u.StorageGBHours += v / gibibyte * scrapeInterval.Hours() },
+	},
+	{
+		query: `sum by (Namespace) (count_over_time(kube_service_spec_type{type="LoadBalancer"}[%s]))`,
+		set:   func(u *Usage, v float64) { u.LoadBalancerHours += v * scrapeInterval.Hours() },
+	},
+}
+
+// Querier computes usage reports from the metering Prometheus.
+type Querier struct {
+	client     ctrlruntimeclient.Reader
+	prometheus promv1.API
+}
+
+// NewQuerier returns a new Querier. The client is used to map the cluster
+// namespaces found in Prometheus to clusters and their projects.
+func NewQuerier(client ctrlruntimeclient.Reader, prometheus promv1.API) *Querier {
+	return &Querier{
+		client:     client,
+		prometheus: prometheus,
+	}
+}
+
+// ClusterUsage returns the usage of every cluster matching the options.
+func (q *Querier) ClusterUsage(ctx context.Context, opts Options) (*Report, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	clusters, err := q.clustersByNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]*Usage{}
+	for _, m := range metrics {
+		vector, err := q.query(ctx, m, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sample := range vector {
+			namespace := string(sample.Metric[namespaceLabel])
+			if namespace == "" {
+				continue
+			}
+
+			u, ok := usages[namespace]
+			if !ok {
+				u = usageForNamespace(namespace, clusters)
+				usages[namespace] = u
+			}
+
+			m.set(u, float64(sample.Value))
+		}
+	}
+
+	report := &Report{
+		From:  opts.From,
+		To:    opts.To,
+		Usage: []Usage{},
+	}
+
+	for _, u := range usages {
+		if opts.Project != "" && u.Project != opts.Project {
+			continue
+		}
+		if opts.Cluster != "" && u.Cluster != opts.Cluster {
+			continue
+		}
+
+		if opts.PriceModel != nil {
+			u.Cost = cost(*u, opts.PriceModel.PricesFor(u.Datacenter, u.Provider))
+			u.Currency = opts.PriceModel.Currency
+		}
+
+		report.Usage = append(report.Usage, *u)
+	}
+
+	sortUsage(report.Usage)
+
+	return report, nil
+}
+
+// ProjectUsage returns the usage of every project matching the options, summed up
+// over all their clusters. Usage of clusters that no longer exist is not included,
+// as they cannot be attributed to a project anymore.
+func (q *Querier) ProjectUsage(ctx context.Context, opts Options) (*Report, error) {
+	report, err := q.ClusterUsage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := map[string]*Usage{}
+	for _, u := range report.Usage {
+		if u.Project == "" {
+			continue
+		}
+
+		project, ok := projects[u.Project]
+		if !ok {
+			project = &Usage{Project: u.Project, Currency: u.Currency}
+			projects[u.Project] = project
+		}
+
+		project.add(u)
+	}
+
+	report.Usage = []Usage{}
+	for _, u := range projects {
+		report.Usage = append(report.Usage, *u)
+	}
+
+	sortUsage(report.Usage)
+
+	return report, nil
+}
+
+func (q *Querier) query(ctx context.Context, m metric, opts Options) (model.Vector, error) {
+	query := fmt.Sprintf(m.query, model.Duration(opts.To.Sub(opts.From)))
+
+	result, _, err := q.prometheus.Query(ctx, query, opts.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus for %q: %w", query, err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s for %q", result.Type(), query)
+	}
+
+	return vector, nil
+}
+
+func (q *Querier) clustersByNamespace(ctx context.Context) (map[string]kubermaticv1.Cluster, error) {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := q.client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	result := map[string]kubermaticv1.Cluster{}
+	for _, cluster := range clusters.Items {
+		namespace := cluster.Status.NamespaceName
+		if namespace == "" {
+			namespace = "cluster-" + cluster.Name
+		}
+
+		result[namespace] = cluster
+	}
+
+	return result, nil
+}
+
+func usageForNamespace(namespace string, clusters map[string]kubermaticv1.Cluster) *Usage {
+	cluster, ok := clusters[namespace]
+	if !ok {
+		return &Usage{Cluster: strings.TrimPrefix(namespace, "cluster-")}
+	}
+
+	return &Usage{
+		Project:    cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+		Cluster:    cluster.Name,
+		Datacenter: cluster.Spec.Cloud.DatacenterName,
+		Provider:   cluster.Spec.Cloud.ProviderName,
+	}
+}
+
+// cost returns the cost of the given usage.
+func cost(u Usage, prices kubermaticv1.Prices) float64 {
+	price := func(q *resource.Quantity) float64 {
+		if q == nil {
+			return 0
+		}
+		return q.AsApproximateFloat64()
+	}
+
+	return u.VCPUHours*price(prices.VCPUHour) +
+		u.MemoryGBHours*price(prices.MemoryGBHour) +
+		u.StorageGBHours*price(prices.StorageGBHour) +
+		u.LoadBalancerHours*price(prices.LoadBalancerHour)
+}
+
+func sortUsage(usage []Usage) {
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Project != usage[j].Project {
+			return usage[i].Project < usage[j].Project
+		}
+
+		return usage[i].Cluster < usage[j].Cluster
+	})
+}
@@ -0,0 +1,291 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakePrometheus returns the configured vector for every query starting with
+// the given metric function, e.g. "increase(node_cpu_usage_seconds_total".
+type fakePrometheus struct {
+	promv1.API
+
+	results map[string]model.Vector
+	queries []string
+}
+
+func (f *fakePrometheus) Query(_ context.Context, query string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	f.queries = append(f.queries, query)
+
+	for metric, vector := range f.results {
+		if strings.Contains(query, metric) {
+			return vector, nil, nil
+		}
+	}
+
+	return model.Vector{}, nil, nil
+}
+
+func sample(namespace string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{namespaceLabel: model.LabelValue(namespace)},
+		Value:  model.SampleValue(value),
+	}
+}
+
+func genCluster(name, projectID, datacenter, provider string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: projectID},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				DatacenterName: datacenter,
+				ProviderName:   provider,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+		},
+	}
+}
+
+func newTestQuerier() (*Querier, *fakePrometheus) {
+	prometheus := &fakePrometheus{
+		results: map[string]model.Vector{
+			"node_cpu_usage_seconds_total": {
+				sample("cluster-c1", 3600),
+				sample("cluster-c2", 1800),
+				sample("cluster-c3", 7200),
+				sample("cluster-deleted", 60),
+			},
+			"node_memory_working_set_bytes": {
+				sample("cluster-c1", 2e9),
+				sample("cluster-c2", 1e9),
+			},
+			"kubelet_volume_stats_capacity_bytes": {
+				// used for both average and sum, which is 1 GiB for 60 minutes
+				sample("cluster-c2", 60*gibibyte),
+			},
+			"kube_node_info": {
+				// two nodes for 90 minutes
+				sample("cluster-c1", 180),
+				sample("cluster-c2", 60),
+			},
+			"machine_cpu_cores": {
+				// two nodes with 2 vCPUs each for 90 minutes
+				sample("cluster-c1", 360),
+			},
+			"machine_memory_bytes": {
+				sample("cluster-c1", 180*gibibyte),
+			},
+			"kube_service_spec_type": {
+				sample("cluster-c3", 120),
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithObjects(
+		genCluster("c1", "project1", "dc-a", "aws"),
+		genCluster("c2", "project1", "dc-b", "aws"),
+		genCluster("c3", "project2", "dc-c", "openstack"),
+	).Build()
+
+	return NewQuerier(client, prometheus), prometheus
+}
+
+func TestClusterUsage(t *testing.T) {
+	to := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		opts          Options
+		expectedUsage []Usage
+		expectedErr   bool
+	}{
+		{
+			name: "all clusters, including deleted ones",
+			opts: Options{From: to.Add(-90 * time.Minute), To: to},
+			expectedUsage: []Usage{
+				{Project: "", Cluster: "deleted", CPUCoreSeconds: 60},
+				{Project: "project1", Cluster: "c1", Datacenter: "dc-a", Provider: "aws", CPUCoreSeconds: 3600, AverageMemoryBytes: 2e9, NodeHours: 3, VCPUHours: 6, MemoryGBHours: 3},
+				{Project: "project1", Cluster: "c2", Datacenter: "dc-b", Provider: "aws", CPUCoreSeconds: 1800, AverageMemoryBytes: 1e9, AverageStorageBytes: 60 * gibibyte, NodeHours: 1, StorageGBHours: 1},
+				{Project: "project2", Cluster: "c3", Datacenter: "dc-c", Provider: "openstack", CPUCoreSeconds: 7200, LoadBalancerHours: 2},
+			},
+		},
+		{
+			name: "clusters of a single project",
+			opts: Options{From: to.Add(-90 * time.Minute), To: to, Project: "project1"},
+			expectedUsage: []Usage{
+				{Project: "project1", Cluster: "c1", Datacenter: "dc-a", Provider: "aws", CPUCoreSeconds: 3600, AverageMemoryBytes: 2e9, NodeHours: 3, VCPUHours: 6, MemoryGBHours: 3},
+				{Project: "project1", Cluster: "c2", Datacenter: "dc-b", Provider: "aws", CPUCoreSeconds: 1800, AverageMemoryBytes: 1e9, AverageStorageBytes: 60 * gibibyte, NodeHours: 1, StorageGBHours: 1},
+			},
+		},
+		{
+			name: "single cluster",
+			opts: Options{From: to.Add(-90 * time.Minute), To: to, Cluster: "c3"},
+			expectedUsage: []Usage{
+				{Project: "project2", Cluster: "c3", Datacenter: "dc-c", Provider: "openstack", CPUCoreSeconds: 7200, LoadBalancerHours: 2},
+			},
+		},
+		{
+			name:        "invalid time range",
+			opts:        Options{From: to, To: to.Add(-time.Hour)},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier, prometheus := newTestQuerier()
+
+			report, err := querier.ClusterUsage(context.Background(), tc.opts)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Expected error = %v, but got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if d := diff.ObjectDiff(tc.expectedUsage, report.Usage); d != "" {
+				t.Errorf("Got unexpected usage:\n%v", d)
+			}
+
+			expectedQuery := "sum by (Namespace) (increase(node_cpu_usage_seconds_total[1h30m]))"
+			if prometheus.queries[0] != expectedQuery {
+				t.Errorf("Expected query %q, but got %q", expectedQuery, prometheus.queries[0])
+			}
+		})
+	}
+}
+
+func TestProjectUsage(t *testing.T) {
+	to := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	querier, _ := newTestQuerier()
+
+	report, err := querier.ProjectUsage(context.Background(), Options{From: to.Add(-90 * time.Minute), To: to})
+	if err != nil {
+		t.Fatalf("Failed to query project usage: %v", err)
+	}
+
+	expectedUsage := []Usage{
+		{Project: "project1", CPUCoreSeconds: 5400, AverageMemoryBytes: 3e9, AverageStorageBytes: 60 * gibibyte, NodeHours: 4, VCPUHours: 6, MemoryGBHours: 3, StorageGBHours: 1},
+		{Project: "project2", CPUCoreSeconds: 7200, LoadBalancerHours: 2},
+	}
+
+	if d := diff.ObjectDiff(expectedUsage, report.Usage); d != "" {
+		t.Errorf("Got unexpected usage:\n%v", d)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		url              string
+		accept           string
+		expectedStatus   int
+		expectedContains []string
+	}{
+		{
+			name:           "project usage as JSON",
+			url:            "/api/v1/usage/projects?project=project2",
+			expectedStatus: http.StatusOK,
+			expectedContains: []string{
+				`"from":"2024-04-30T12:00:00Z"`,
+				`{"project":"project2","cpuCoreSeconds":7200,"averageMemoryBytes":0,"averageStorageBytes":0,"nodeHours":0,"vcpuHours":0,"memoryGBHours":0,"storageGBHours":0,"loadBalancerHours":2}`,
+			},
+		},
+		{
+			name:           "cluster usage as OpenMetrics",
+			url:            "/api/v1/usage/clusters?from=2024-05-01T10:30:00Z&cluster=c1",
+			accept:         "application/openmetrics-text",
+			expectedStatus: http.StatusOK,
+			expectedContains: []string{
+				`kubermatic_metering_node_hours{cluster="c1",project="project1"} 3.0`,
+				"# EOF",
+			},
+		},
+		{
+			name:           "unsupported format",
+			url:            "/api/v1/usage/clusters?format=csv",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid time range",
+			url:            "/api/v1/usage/clusters?from=2024-05-02T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown path",
+			url:            "/api/v1/usage/namespaces",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier, _ := newTestQuerier()
+			handler := newHandler(querier, kubermaticlog.Logger)
+			handler.now = func() time.Time { return now }
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, but got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+
+			for _, s := range tc.expectedContains {
+				if !strings.Contains(rec.Body.String(), s) {
+					t.Errorf("Expected response to contain %q, but got:\n%s", s, rec.Body.String())
+				}
+			}
+		})
+	}
+}
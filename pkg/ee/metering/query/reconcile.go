@@ -0,0 +1,251 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/ee/metering/prometheus"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	Name = "metering-query"
+
+	// Port is the port the metering query service listens on.
+	Port = 8080
+)
+
+// ReconcileQueryService reconciles the metering query service, or removes it if
+// it is disabled in the seed's metering configuration. The ServiceAccount and its
+// RBAC are always reconciled, as they are also used by the cost report CronJobs.
+func ReconcileQueryService(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions kubermaticversion.Versions) error {
+	seedOwner := common.OwnershipModifierFactory(seed, scheme)
+
+	if err := reconciling.ReconcileServiceAccounts(ctx, []reconciling.NamedServiceAccountReconcilerFactory{
+		serviceAccountReconciler(),
+	}, seed.Namespace, client, seedOwner); err != nil {
+		return fmt.Errorf("failed to reconcile ServiceAccount: %w", err)
+	}
+
+	if err := reconciling.ReconcileClusterRoles(ctx, []reconciling.NamedClusterRoleReconcilerFactory{
+		clusterRoleReconciler(),
+	}, "", client); err != nil {
+		return fmt.Errorf("failed to reconcile ClusterRole: %w", err)
+	}
+
+	if err := reconciling.ReconcileClusterRoleBindings(ctx, []reconciling.NamedClusterRoleBindingReconcilerFactory{
+		clusterRoleBindingReconciler(seed.Namespace),
+	}, "", client); err != nil {
+		return fmt.Errorf("failed to reconcile ClusterRoleBinding: %w", err)
+	}
+
+	if qs := seed.Spec.Metering.QueryService; qs == nil || !qs.Enabled {
+		return cleanup(ctx, client, seed.Namespace, &corev1.Service{}, &appsv1.Deployment{})
+	}
+
+	if err := reconciling.ReconcileDeployments(ctx, []reconciling.NamedDeploymentReconcilerFactory{
+		deploymentReconciler(cfg, seed, versions),
+	}, seed.Namespace, client, seedOwner); err != nil {
+		return fmt.Errorf("failed to reconcile Deployment: %w", err)
+	}
+
+	if err := reconciling.ReconcileServices(ctx, []reconciling.NamedServiceReconcilerFactory{
+		serviceReconciler(),
+	}, seed.Namespace, client, seedOwner); err != nil {
+		return fmt.Errorf("failed to reconcile Service: %w", err)
+	}
+
+	return nil
+}
+
+// Undeploy removes all resources of the metering query service.
+func Undeploy(ctx context.Context, client ctrlruntimeclient.Client, namespace string) error {
+	return cleanup(ctx, client, namespace,
+		&corev1.Service{},
+		&appsv1.Deployment{},
+		&rbacv1.ClusterRoleBinding{},
+		&rbacv1.ClusterRole{},
+		&corev1.ServiceAccount{},
+	)
+}
+
+func cleanup(ctx context.Context, client ctrlruntimeclient.Client, namespace string, objects ...ctrlruntimeclient.Object) error {
+	for _, obj := range objects {
+		obj.SetNamespace(namespace)
+		obj.SetName(Name)
+
+		if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, obj)); err != nil {
+			return fmt.Errorf("failed to cleanup metering query service %T: %w", obj, err)
+		}
+	}
+
+	return nil
+}
+
+func serviceAccountReconciler() reconciling.NamedServiceAccountReconcilerFactory {
+	return func() (string, reconciling.ServiceAccountReconciler) {
+		return Name, func(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+			kubernetes.EnsureLabels(sa, map[string]string{common.NameLabel: Name})
+			return sa, nil
+		}
+	}
+}
+
+// clusterRoleReconciler allows the query service to map cluster namespaces to projects.
+func clusterRoleReconciler() reconciling.NamedClusterRoleReconcilerFactory {
+	return func() (string, reconciling.ClusterRoleReconciler) {
+		return Name, func(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+			kubernetes.EnsureLabels(cr, map[string]string{common.NameLabel: Name})
+			cr.Rules = []rbacv1.PolicyRule{
+				{
+					Verbs:     []string{"get", "list", "watch"},
+					APIGroups: []string{kubermaticv1.GroupName},
+					Resources: []string{"clusters"},
+				},
+			}
+
+			return cr, nil
+		}
+	}
+}
+
+func clusterRoleBindingReconciler(namespace string) reconciling.NamedClusterRoleBindingReconcilerFactory {
+	return func() (string, reconciling.ClusterRoleBindingReconciler) {
+		return Name, func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			kubernetes.EnsureLabels(crb, map[string]string{common.NameLabel: Name})
+			crb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     Name,
+			}
+			crb.Subjects = []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      Name,
+					Namespace: namespace,
+				},
+			}
+
+			return crb, nil
+		}
+	}
+}
+
+func deploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions kubermaticversion.Versions) reconciling.NamedDeploymentReconcilerFactory {
+	return func() (string, reconciling.DeploymentReconciler) {
+		return Name, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+			basicLabels := map[string]string{common.NameLabel: Name}
+			kubernetes.EnsureLabels(d, basicLabels)
+
+			d.Spec.Replicas = ptr.To[int32](1)
+			d.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: basicLabels,
+			}
+
+			kubernetes.EnsureLabels(&d.Spec.Template, basicLabels)
+			d.Spec.Template.Spec.ServiceAccountName = Name
+			d.Spec.Template.Spec.SecurityContext = &common.PodSecurityContext
+
+			d.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:    Name,
+					Image:   cfg.Spec.SeedController.DockerRepository + ":" + versions.Kubermatic,
+					Command: []string{"metering-query-server"},
+					Args: []string{
+						fmt.Sprintf("-listen-address=0.0.0.0:%d", Port),
+						fmt.Sprintf("-prometheus-url=http://%s.%s.svc", prometheus.Name, seed.Namespace),
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "http",
+							ContainerPort: Port,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/healthz",
+								Port:   intstr.FromInt(Port),
+								Scheme: corev1.URISchemeHTTP,
+							},
+						},
+						PeriodSeconds: 10,
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("50m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("250m"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+					SecurityContext: &common.ContainerSecurityContext,
+				},
+			}
+
+			return d, nil
+		}
+	}
+}
+
+func serviceReconciler() reconciling.NamedServiceReconcilerFactory {
+	return func() (string, reconciling.ServiceReconciler) {
+		return Name, func(svc *corev1.Service) (*corev1.Service, error) {
+			kubernetes.EnsureLabels(svc, map[string]string{common.NameLabel: Name})
+
+			svc.Spec.Type = corev1.ServiceTypeClusterIP
+			svc.Spec.Selector = map[string]string{common.NameLabel: Name}
+			svc.Spec.Ports = []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(Port),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			}
+
+			return svc, nil
+		}
+	}
+}
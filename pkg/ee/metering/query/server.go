@@ -0,0 +1,266 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+const (
+	// FormatJSON returns the usage report as JSON.
+	FormatJSON = "json"
+	// FormatOpenMetrics returns the usage report in the OpenMetrics text format.
+	FormatOpenMetrics = "openmetrics"
+
+	// defaultRange is used if no start of the time range is given.
+	defaultRange = 24 * time.Hour
+)
+
+// usageQuerier is implemented by Querier.
+type usageQuerier interface {
+	ClusterUsage(ctx context.Context, opts Options) (*Report, error)
+	ProjectUsage(ctx context.Context, opts Options) (*Report, error)
+}
+
+// Handler serves usage reports via HTTP:
+//
+//	GET /api/v1/usage/clusters?from=<RFC3339>&to=<RFC3339>&project=<id>&cluster=<name>&format=<json|openmetrics>
+//	GET /api/v1/usage/projects?from=<RFC3339>&to=<RFC3339>&project=<id>&format=<json|openmetrics>
+//
+// All parameters are optional. The time range defaults to the last 24 hours and the
+// format to JSON, unless the Accept header asks for OpenMetrics.
+type Handler struct {
+	querier usageQuerier
+	log     *zap.SugaredLogger
+	now     func() time.Time
+}
+
+// NewHandler returns a new HTTP handler for the given querier.
+func NewHandler(querier *Querier, log *zap.SugaredLogger) http.Handler {
+	return newHandler(querier, log)
+}
+
+func newHandler(querier usageQuerier, log *zap.SugaredLogger) *Handler {
+	return &Handler{
+		querier: querier,
+		log:     log,
+		now:     time.Now,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var query func(context.Context, Options) (*Report, error)
+	switch r.URL.Path {
+	case "/api/v1/usage/clusters":
+		query = h.querier.ClusterUsage
+	case "/api/v1/usage/projects":
+		query = h.querier.ProjectUsage
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	opts, err := h.parseOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format, err := parseFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := query(r.Context(), opts)
+	if err != nil {
+		h.log.Errorw("Failed to query usage", "path", r.URL.Path, zap.Error(err))
+		http.Error(w, "failed to query usage", http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case FormatOpenMetrics:
+		err = writeOpenMetrics(w, report)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(report)
+	}
+
+	if err != nil {
+		h.log.Errorw("Failed to write response", zap.Error(err))
+	}
+}
+
+func (h *Handler) parseOptions(r *http.Request) (Options, error) {
+	params := r.URL.Query()
+
+	opts := Options{
+		To:      h.now(),
+		Project: params.Get("project"),
+		Cluster: params.Get("cluster"),
+	}
+
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return opts, fmt.Errorf("invalid end of time range: %w", err)
+		}
+		opts.To = t
+	}
+
+	opts.From = opts.To.Add(-defaultRange)
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return opts, fmt.Errorf("invalid start of time range: %w", err)
+		}
+		opts.From = t
+	}
+
+	return opts, opts.validate()
+}
+
+func parseFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case FormatJSON, FormatOpenMetrics:
+		return format, nil
+	case "":
+		if strings.Contains(r.Header.Get("Accept"), expfmt.OpenMetricsType) {
+			return FormatOpenMetrics, nil
+		}
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, must be one of %s, %s", format, FormatJSON, FormatOpenMetrics)
+	}
+}
+
+func writeOpenMetrics(w http.ResponseWriter, report *Report) error {
+	labels := []string{"project", "cluster"}
+	gauges := []struct {
+		gauge *prometheus.GaugeVec
+		value func(u Usage) float64
+	}{
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_cpu_core_seconds",
+				Help: "CPU time consumed by all nodes within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.CPUCoreSeconds },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_average_memory_bytes",
+				Help: "Average working set memory of all nodes within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.AverageMemoryBytes },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_average_storage_bytes",
+				Help: "Average capacity of all persistent volumes within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.AverageStorageBytes },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_node_hours",
+				Help: "Running time of all nodes within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.NodeHours },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_vcpu_hours",
+				Help: "vCPUs of all nodes multiplied by their running time within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.VCPUHours },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_memory_gb_hours",
+				Help: "Memory (in GiB) of all nodes multiplied by their running time within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.MemoryGBHours },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_storage_gb_hours",
+				Help: "Capacity (in GiB) of all persistent volumes multiplied by their lifetime within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.StorageGBHours },
+		},
+		{
+			gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "kubermatic_metering_load_balancer_hours",
+				Help: "Lifetime of all Services of type LoadBalancer within the time range",
+			}, labels),
+			value: func(u Usage) float64 { return u.LoadBalancerHours },
+		},
+	}
+
+	registry := prometheus.NewRegistry()
+	for _, g := range gauges {
+		registry.MustRegister(g.gauge)
+		for _, u := range report.Usage {
+			g.gauge.WithLabelValues(u.Project, u.Cluster).Set(g.value(u))
+		}
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	format := expfmt.NewFormat(expfmt.TypeOpenMetrics)
+	w.Header().Set("Content-Type", string(format))
+
+	encoder := expfmt.NewEncoder(w, format)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+	}
+
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -34,9 +34,11 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	"k8c.io/kubermatic/v2/pkg/ee/metering/prometheus"
+	"k8c.io/kubermatic/v2/pkg/ee/metering/query"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
 	"k8c.io/kubermatic/v2/pkg/util/s3"
+	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -47,6 +49,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -60,7 +63,7 @@ func getMeteringImage(overwriter registry.ImageRewriter) string {
 }
 
 // ReconcileMeteringResources reconciles the metering related resources.
-func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) error {
+func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, masterClient ctrlruntimeclient.Reader, scheme *runtime.Scheme, recorder record.EventRecorder, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions kubermaticversion.Versions) error {
 	overwriter := registry.GetImageRewriterFunc(cfg.Spec.UserCluster.OverwriteRegistry)
 
 	if seed.Spec.Metering == nil || !seed.Spec.Metering.Enabled {
@@ -72,19 +75,32 @@ func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Cl
 		return fmt.Errorf("failed to reconcile metering prometheus: %w", err)
 	}
 
+	if err := query.ReconcileQueryService(ctx, client, scheme, cfg, seed, versions); err != nil {
+		return fmt.Errorf("failed to reconcile metering query service: %w", err)
+	}
+
 	modifiers := []reconciling.ObjectModifier{
 		common.VolumeRevisionLabelsModifierFactory(ctx, client),
 		common.OwnershipModifierFactory(seed, scheme),
 	}
 
-	if err := reconcileMeteringReportConfigurations(ctx, client, seed, cfg.Spec.CABundle, overwriter, modifiers...); err != nil {
+	missingPriceModels, err := reconcilePriceModels(ctx, client, masterClient, recorder, seed, modifiers...)
+	if err != nil {
+		return err
+	}
+
+	kubermaticImage := cfg.Spec.SeedController.DockerRepository + ":" + versions.Kubermatic
+
+	if err := reconcileMeteringReportConfigurations(ctx, client, seed, cfg.Spec.CABundle, overwriter, kubermaticImage, missingPriceModels, modifiers...); err != nil {
 		return fmt.Errorf("failed to reconcile metering report configurations: %w", err)
 	}
 
 	return nil
 }
 
-func reconcileMeteringReportConfigurations(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed, caBundle corev1.TypedLocalObjectReference, overwriter registry.ImageRewriter, modifiers ...reconciling.ObjectModifier) error {
+// reconcileMeteringReportConfigurations reconciles the CronJobs and the bucket lifecycle of the reports. The CronJobs
+// of reports referencing a missing PriceModel are left untouched.
+func reconcileMeteringReportConfigurations(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed, caBundle corev1.TypedLocalObjectReference, overwriter registry.ImageRewriter, kubermaticImage string, missingPriceModels sets.Set[string], modifiers ...reconciling.ObjectModifier) error {
 	if err := cleanupOrphanedReportingCronJobs(ctx, client, seed.Spec.Metering.ReportConfigurations, seed.Namespace); err != nil {
 		return fmt.Errorf("failed to cleanup orphaned reporting cronjobs: %w", err)
 	}
@@ -93,7 +109,9 @@ func reconcileMeteringReportConfigurations(ctx context.Context, client ctrlrunti
 	var cronJobs []reconciling.NamedCronJobReconcilerFactory
 
 	for reportName, reportConf := range seed.Spec.Metering.ReportConfigurations {
-		cronJobs = append(cronJobs, CronJobReconciler(reportName, reportConf, caBundle.Name, overwriter, seed))
+		if !missingPriceModels.Has(reportConf.PriceModel) {
+			cronJobs = append(cronJobs, CronJobReconciler(reportName, reportConf, caBundle.Name, overwriter, kubermaticImage, seed))
+		}
 
 		if reportConf.Retention != nil {
 			config.Rules = append(config.Rules, lifecycle.Rule{
@@ -199,6 +217,14 @@ func undeploy(ctx context.Context, client ctrlruntimeclient.Client, namespace st
 		}
 	}
 
+	if err := query.Undeploy(ctx, client, namespace); err != nil {
+		return err
+	}
+
+	if err := cleanupOrphanedPriceModels(ctx, client, namespace, nil); err != nil {
+		return err
+	}
+
 	// prometheus resources
 	key := types.NamespacedName{Name: prometheus.Name, Namespace: namespace}
 	if err := cleanupResource(ctx, client, key, &corev1.Service{}); err != nil {
@@ -118,6 +118,7 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 
 	// for all related resource quotas on seeds, calculate global usage
 	globalUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+	globalScopedUsage := newScopedUsage(resourceQuota.Spec.ScopedQuotas)
 	for seed, seedClient := range r.seedClients {
 		seedResourceQuota := &kubermaticv1.ResourceQuota{}
 		err := seedClient.Get(ctx, types.NamespacedName{Namespace: resourceQuota.Namespace, Name: resourceQuota.Name},
@@ -128,28 +129,51 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 			}
 			return fmt.Errorf("error getting seed %q resource quota: %w", seed, err)
 		}
-		localUsage := seedResourceQuota.Status.LocalUsage
-		if localUsage.CPU != nil {
-			globalUsage.CPU.Add(*localUsage.CPU)
-		}
-		if localUsage.Memory != nil {
-			globalUsage.Memory.Add(*localUsage.Memory)
-		}
-		if localUsage.Storage != nil {
-			globalUsage.Storage.Add(*localUsage.Storage)
-		}
+		globalUsage.Add(seedResourceQuota.Status.LocalUsage)
+		addScopedUsage(globalScopedUsage, seedResourceQuota.Status.LocalScopedUsage)
 	}
 
-	if err := r.ensureGlobalUsage(ctx, log, resourceQuota, globalUsage); err != nil {
+	if err := r.ensureGlobalUsage(ctx, log, resourceQuota, globalUsage, globalScopedUsage); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// newScopedUsage returns zero usage for each of the scoped quotas.
+func newScopedUsage(scopedQuotas []kubermaticv1.ScopedResourceDetails) []kubermaticv1.ScopedResourceDetails {
+	if len(scopedQuotas) == 0 {
+		return nil
+	}
+
+	scopedUsage := make([]kubermaticv1.ScopedResourceDetails, 0, len(scopedQuotas))
+	for _, scopedQuota := range scopedQuotas {
+		scopedUsage = append(scopedUsage, kubermaticv1.ScopedResourceDetails{
+			ResourceQuotaScope: scopedQuota.ResourceQuotaScope,
+			Resources:          *kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{}),
+		})
+	}
+
+	return scopedUsage
+}
+
+// addScopedUsage adds the seed's usage to the scopes of the global usage. Seed usage for scopes
+// that are not part of the global usage (anymore) is ignored.
+func addScopedUsage(globalScopedUsage, seedScopedUsage []kubermaticv1.ScopedResourceDetails) {
+	for i := range globalScopedUsage {
+		for _, seedUsage := range seedScopedUsage {
+			if seedUsage.ResourceQuotaScope == globalScopedUsage[i].ResourceQuotaScope {
+				globalScopedUsage[i].Resources.Add(seedUsage.Resources)
+				break
+			}
+		}
+	}
+}
+
 func (r *reconciler) ensureGlobalUsage(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
-	globalUsage *kubermaticv1.ResourceDetails) error {
-	if k8cequality.Semantic.DeepEqual(*globalUsage, resourceQuota.Status.GlobalUsage) {
+	globalUsage *kubermaticv1.ResourceDetails, globalScopedUsage []kubermaticv1.ScopedResourceDetails) error {
+	if k8cequality.Semantic.DeepEqual(*globalUsage, resourceQuota.Status.GlobalUsage) &&
+		k8cequality.Semantic.DeepEqual(globalScopedUsage, resourceQuota.Status.GlobalScopedUsage) {
 		log.Debugw("global usage for resource quota is the same, not updating",
 			"cpu", globalUsage.CPU.String(),
 			"memory", globalUsage.Memory.String(),
@@ -163,5 +187,6 @@ func (r *reconciler) ensureGlobalUsage(ctx context.Context, log *zap.SugaredLogg
 
 	return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.masterClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
 		rq.Status.GlobalUsage = *globalUsage
+		rq.Status.GlobalScopedUsage = globalScopedUsage
 	})
 }
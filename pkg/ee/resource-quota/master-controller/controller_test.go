@@ -45,11 +45,12 @@ const rqName = "resourceQuota"
 
 func TestReconcile(t *testing.T) {
 	testCases := []struct {
-		name          string
-		requestName   string
-		expectedUsage kubermaticv1.ResourceDetails
-		masterClient  ctrlruntimeclient.Client
-		seedClients   map[string]ctrlruntimeclient.Client
+		name                string
+		requestName         string
+		expectedUsage       kubermaticv1.ResourceDetails
+		expectedScopedUsage []kubermaticv1.ScopedResourceDetails
+		masterClient        ctrlruntimeclient.Client
+		seedClients         map[string]ctrlruntimeclient.Client
 	}{
 		{
 			name:          "scenario 1: calculate rq global usage",
@@ -70,6 +71,32 @@ func TestReconcile(t *testing.T) {
 					Build(),
 			},
 		},
+		{
+			name:          "scenario 2: calculate rq global scoped usage",
+			requestName:   rqName,
+			expectedUsage: *genResourceDetails("7", "7G", "18G"),
+			expectedScopedUsage: []kubermaticv1.ScopedResourceDetails{
+				genScopedResourceDetails("aws", *genResourceDetails("7", "7G", "18G")),
+			},
+			masterClient: fake.
+				NewClientBuilder().
+				WithObjects(genResourceQuota(rqName, kubermaticv1.ResourceDetails{},
+					genScopedResourceDetails("aws", kubermaticv1.ResourceDetails{})), generator.GenTestSeed()).
+				Build(),
+			seedClients: map[string]ctrlruntimeclient.Client{
+				"first": fake.
+					NewClientBuilder().
+					WithObjects(genResourceQuota(rqName, *genResourceDetails("2", "5G", "10G"),
+						genScopedResourceDetails("aws", *genResourceDetails("2", "5G", "10G")))).
+					Build(),
+				"second": fake.
+					NewClientBuilder().
+					WithObjects(genResourceQuota(rqName, *genResourceDetails("5", "2G", "8G"),
+						genScopedResourceDetails("aws", *genResourceDetails("5", "2G", "8G")),
+						genScopedResourceDetails("gcp", *genResourceDetails("1", "1G", "1G")))).
+					Build(),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -97,11 +124,17 @@ func TestReconcile(t *testing.T) {
 			if !diff.SemanticallyEqual(tc.expectedUsage, rq.Status.GlobalUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedUsage, rq.Status.GlobalUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedScopedUsage, rq.Status.GlobalScopedUsage) {
+				t.Fatalf("Scoped usage differs:\n%v", diff.ObjectDiff(tc.expectedScopedUsage, rq.Status.GlobalScopedUsage))
+			}
 		})
 	}
 }
 
-func genResourceQuota(name string, localUsage kubermaticv1.ResourceDetails) *kubermaticv1.ResourceQuota {
+// genResourceQuota creates a resource quota with the given local usage. The scopes of the given
+// local scoped usage are also set as scoped quotas.
+func genResourceQuota(name string, localUsage kubermaticv1.ResourceDetails, localScopedUsage ...kubermaticv1.ScopedResourceDetails) *kubermaticv1.ResourceQuota {
 	rq := &kubermaticv1.ResourceQuota{}
 	rq.Name = name
 	rq.Spec = kubermaticv1.ResourceQuotaSpec{
@@ -111,10 +144,24 @@ func genResourceQuota(name string, localUsage kubermaticv1.ResourceDetails) *kub
 		},
 	}
 
+	for _, scopedUsage := range localScopedUsage {
+		rq.Spec.ScopedQuotas = append(rq.Spec.ScopedQuotas, kubermaticv1.ScopedResourceDetails{
+			ResourceQuotaScope: scopedUsage.ResourceQuotaScope,
+		})
+	}
+
 	rq.Status.LocalUsage = localUsage
+	rq.Status.LocalScopedUsage = localScopedUsage
 	return rq
 }
 
+func genScopedResourceDetails(provider string, resources kubermaticv1.ResourceDetails) kubermaticv1.ScopedResourceDetails {
+	return kubermaticv1.ScopedResourceDetails{
+		ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: provider},
+		Resources:          resources,
+	}
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
@@ -132,9 +132,10 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, reso
 		}
 
 		// ensure status
-		globalUsage := resourceQuota.Status.GlobalUsage.DeepCopy()
+		status := resourceQuota.Status.DeepCopy()
 		return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, seedClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
-			rq.Status.GlobalUsage = *globalUsage
+			rq.Status.GlobalUsage = status.GlobalUsage
+			rq.Status.GlobalScopedUsage = status.GlobalScopedUsage
 		})
 	})
 }
@@ -135,29 +135,47 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 	localUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
 	for _, cluster := range clusterList.Items {
 		if cluster.Status.ResourceUsage != nil {
-			clusterUsage := cluster.Status.ResourceUsage
-			if clusterUsage.CPU != nil {
-				localUsage.CPU.Add(*clusterUsage.CPU)
-			}
-			if clusterUsage.Memory != nil {
-				localUsage.Memory.Add(*clusterUsage.Memory)
-			}
-			if clusterUsage.Storage != nil {
-				localUsage.Storage.Add(*clusterUsage.Storage)
-			}
+			localUsage.Add(*cluster.Status.ResourceUsage)
 		}
 	}
 
-	if err = r.ensureLocalUsage(ctx, log, resourceQuota, localUsage); err != nil {
+	localScopedUsage := getScopedUsage(resourceQuota.Spec.ScopedQuotas, clusterList.Items)
+
+	if err = r.ensureLocalUsage(ctx, log, resourceQuota, localUsage, localScopedUsage); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// getScopedUsage calculates the resource usage of the clusters for each of the scoped quotas.
+func getScopedUsage(scopedQuotas []kubermaticv1.ScopedResourceDetails, clusters []kubermaticv1.Cluster) []kubermaticv1.ScopedResourceDetails {
+	if len(scopedQuotas) == 0 {
+		return nil
+	}
+
+	scopedUsage := make([]kubermaticv1.ScopedResourceDetails, 0, len(scopedQuotas))
+	for _, scopedQuota := range scopedQuotas {
+		usage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+		for _, cluster := range clusters {
+			if cluster.Status.ResourceUsage != nil && scopedQuota.Matches(cluster.Spec.Cloud.DatacenterName, cluster.Spec.Cloud.ProviderName) {
+				usage.Add(*cluster.Status.ResourceUsage)
+			}
+		}
+
+		scopedUsage = append(scopedUsage, kubermaticv1.ScopedResourceDetails{
+			ResourceQuotaScope: scopedQuota.ResourceQuotaScope,
+			Resources:          *usage,
+		})
+	}
+
+	return scopedUsage
+}
+
 func (r *reconciler) ensureLocalUsage(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
-	localUsage *kubermaticv1.ResourceDetails) error {
-	if k8cequality.Semantic.DeepEqual(localUsage, resourceQuota.Status.LocalUsage) {
+	localUsage *kubermaticv1.ResourceDetails, localScopedUsage []kubermaticv1.ScopedResourceDetails) error {
+	if k8cequality.Semantic.DeepEqual(*localUsage, resourceQuota.Status.LocalUsage) &&
+		k8cequality.Semantic.DeepEqual(localScopedUsage, resourceQuota.Status.LocalScopedUsage) {
 		log.Debugw("local usage for resource quota is the same, not updating",
 			"cpu", localUsage.CPU.String(),
 			"memory", localUsage.Memory.String(),
@@ -171,6 +189,7 @@ func (r *reconciler) ensureLocalUsage(ctx context.Context, log *zap.SugaredLogge
 
 	return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.seedClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
 		rq.Status.LocalUsage = *localUsage
+		rq.Status.LocalScopedUsage = localScopedUsage
 	})
 }
 
@@ -45,11 +45,12 @@ const projectId = "project1"
 
 func TestReconcile(t *testing.T) {
 	testCases := []struct {
-		name          string
-		requestName   string
-		resourceQuota *kubermaticv1.ResourceQuota
-		seedClient    ctrlruntimeclient.Client
-		expectedUsage kubermaticv1.ResourceDetails
+		name                string
+		requestName         string
+		resourceQuota       *kubermaticv1.ResourceQuota
+		seedClient          ctrlruntimeclient.Client
+		expectedUsage       kubermaticv1.ResourceDetails
+		expectedScopedUsage []kubermaticv1.ScopedResourceDetails
 	}{
 		{
 			name:          "scenario 1: calculate rq local usage",
@@ -64,6 +65,28 @@ func TestReconcile(t *testing.T) {
 				Build(),
 			expectedUsage: *genResourceDetails("7", "7G", "18G"),
 		},
+		{
+			name:        "scenario 2: calculate rq local scoped usage",
+			requestName: rqName,
+			seedClient: fake.
+				NewClientBuilder().
+				WithObjects(genScopedResourceQuota(rqName),
+					genClusterInDatacenter("c1", projectId, "dc1", "aws", "2", "5G", "10G"),
+					genClusterInDatacenter("c2", projectId, "dc2", "aws", "5", "2G", "8G"),
+					genClusterInDatacenter("c3", projectId, "dc3", "gcp", "1", "1G", "1G")).
+				Build(),
+			expectedUsage: *genResourceDetails("8", "8G", "19G"),
+			expectedScopedUsage: []kubermaticv1.ScopedResourceDetails{
+				{
+					ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Datacenter: "dc1"},
+					Resources:          *genResourceDetails("2", "5G", "10G"),
+				},
+				{
+					ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: "aws"},
+					Resources:          *genResourceDetails("7", "7G", "18G"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -90,6 +113,10 @@ func TestReconcile(t *testing.T) {
 			if !diff.SemanticallyEqual(tc.expectedUsage, rq.Status.LocalUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedUsage, rq.Status.LocalUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedScopedUsage, rq.Status.LocalScopedUsage) {
+				t.Fatalf("Scoped usage differs:\n%v", diff.ObjectDiff(tc.expectedScopedUsage, rq.Status.LocalScopedUsage))
+			}
 		})
 	}
 }
@@ -107,6 +134,16 @@ func genResourceQuota(name string) *kubermaticv1.ResourceQuota {
 	return rq
 }
 
+func genScopedResourceQuota(name string) *kubermaticv1.ResourceQuota {
+	rq := genResourceQuota(name)
+	rq.Spec.ScopedQuotas = []kubermaticv1.ScopedResourceDetails{
+		{ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Datacenter: "dc1"}},
+		{ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: "aws"}},
+	}
+
+	return rq
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
@@ -119,3 +156,11 @@ func genCluster(name, projectId, cpu, mem, storage string) *kubermaticv1.Cluster
 
 	return cluster
 }
+
+func genClusterInDatacenter(name, projectId, datacenter, provider, cpu, mem, storage string) *kubermaticv1.Cluster {
+	cluster := genCluster(name, projectId, cpu, mem, storage)
+	cluster.Spec.Cloud.DatacenterName = datacenter
+	cluster.Spec.Cloud.ProviderName = provider
+
+	return cluster
+}
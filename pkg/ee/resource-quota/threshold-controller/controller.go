@@ -0,0 +1,290 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package thresholdcontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-resource-quota-threshold-controller"
+
+	// ThresholdReachedReason is used for the events on the project and the condition on the
+	// resource quota when a warning threshold is reached.
+	ThresholdReachedReason = "ResourceQuotaThresholdReached"
+	belowThresholdsReason  = "BelowThresholds"
+)
+
+type reconciler struct {
+	masterClient ctrlruntimeclient.Client
+	log          *zap.SugaredLogger
+	recorder     record.EventRecorder
+	namespace    string
+	notifiers    notifierFactory
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	namespace string,
+) error {
+	reconciler := &reconciler{
+		log:          log.Named(ControllerName),
+		recorder:     mgr.GetEventRecorderFor(ControllerName),
+		masterClient: mgr.GetClient(),
+		namespace:    namespace,
+		notifiers:    newNotifiers,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.ResourceQuota{}).
+		Build(reconciler)
+
+	return err
+}
+
+// Reconcile compares the global usage of a resource quota with its warning thresholds.
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Reconciling")
+
+	resourceQuota := &kubermaticv1.ResourceQuota{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, resourceQuota); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	err := r.reconcile(ctx, resourceQuota, log)
+	if err != nil {
+		r.recorder.Event(resourceQuota, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.ResourceQuota, log *zap.SugaredLogger) error {
+	// skip reconcile if resourceQuota is in delete state
+	if !resourceQuota.DeletionTimestamp.IsZero() {
+		log.Debug("resource quota is in deletion, skipping")
+		return nil
+	}
+
+	if len(resourceQuota.Spec.WarningThresholds) == 0 {
+		return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.masterClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
+			rq.Status.ExceededThresholds = nil
+			delete(rq.Status.Conditions, kubermaticv1.ResourceQuotaConditionThresholdsNotReached)
+		})
+	}
+
+	exceeded := map[string]int{}
+
+	// only notify once per threshold, i.e. when a resource reaches a higher threshold than before
+	var reached []ReachedThreshold
+	for _, threshold := range getReachedThresholds(resourceQuota) {
+		key := threshold.key()
+		exceeded[key] = threshold.Threshold
+		if threshold.Threshold > resourceQuota.Status.ExceededThresholds[key] {
+			reached = append(reached, threshold)
+		}
+	}
+
+	if err := kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.masterClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
+		rq.Status.ExceededThresholds = exceeded
+		if len(exceeded) == 0 {
+			rq.Status.ExceededThresholds = nil
+			kubermaticv1helper.SetResourceQuotaCondition(rq, kubermaticv1.ResourceQuotaConditionThresholdsNotReached,
+				corev1.ConditionTrue, belowThresholdsReason, "Usage of all resources is below the warning thresholds.")
+		} else {
+			kubermaticv1helper.SetResourceQuotaCondition(rq, kubermaticv1.ResourceQuotaConditionThresholdsNotReached,
+				corev1.ConditionFalse, ThresholdReachedReason, thresholdsMessage(exceeded))
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to update resource quota status: %w", err)
+	}
+
+	if len(reached) == 0 || resourceQuota.Spec.Subject.Kind != kubermaticv1.ProjectSubjectKind {
+		return nil
+	}
+
+	project := &kubermaticv1.Project{}
+	if err := r.masterClient.Get(ctx, types.NamespacedName{Name: resourceQuota.Spec.Subject.Name}, project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get project %q: %w", resourceQuota.Spec.Subject.Name, err)
+	}
+
+	for _, threshold := range reached {
+		r.recorder.Event(project, corev1.EventTypeWarning, ThresholdReachedReason, threshold.String())
+	}
+
+	notifiers, err := r.notifiers(ctx, r.masterClient, r.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to set up notifications: %w", err)
+	}
+
+	notification := Notification{
+		Project:       project.Name,
+		ProjectName:   project.Spec.Name,
+		ResourceQuota: resourceQuota.Name,
+		Thresholds:    reached,
+	}
+
+	// Failed notifications are not retried, as the thresholds have already been
+	// persisted in the status; this prevents flooding the recipients. A failing
+	// notifier must not prevent the others from being notified, though.
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, project, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send notification: %w", err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// getReachedThresholds returns the highest threshold that is reached by the usage of each resource,
+// both for the overall quota and for each of the scoped quotas.
+func getReachedThresholds(resourceQuota *kubermaticv1.ResourceQuota) []ReachedThreshold {
+	thresholds := resourceQuota.Spec.WarningThresholds
+	reached := getScopeReachedThresholds(kubermaticv1.ResourceQuotaScope{}, resourceQuota.Spec.Quota, resourceQuota.Status.GlobalUsage, thresholds)
+
+	for _, scopedQuota := range resourceQuota.Spec.ScopedQuotas {
+		used := kubermaticv1.ResourceDetails{}
+		for _, scopedUsage := range resourceQuota.Status.GlobalScopedUsage {
+			if scopedUsage.ResourceQuotaScope == scopedQuota.ResourceQuotaScope {
+				used = scopedUsage.Resources
+				break
+			}
+		}
+
+		reached = append(reached, getScopeReachedThresholds(scopedQuota.ResourceQuotaScope, scopedQuota.Resources, used, thresholds)...)
+	}
+
+	return reached
+}
+
+func getScopeReachedThresholds(scope kubermaticv1.ResourceQuotaScope, quota, usage kubermaticv1.ResourceDetails, thresholds []int) []ReachedThreshold {
+	quotas := resourcesByName(quota)
+	usages := resourcesByName(usage)
+
+	names := make([]string, 0, len(quotas))
+	for name := range quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var reached []ReachedThreshold
+	for _, name := range names {
+		q := quotas[name]
+		if q == nil || q.IsZero() {
+			continue
+		}
+
+		used := usages[name]
+		if used == nil {
+			continue
+		}
+
+		percentage := usagePercentage(used, q)
+		highest := 0
+		for _, threshold := range thresholds {
+			if percentage >= float64(threshold) && threshold > highest {
+				highest = threshold
+			}
+		}
+
+		if highest > 0 {
+			reached = append(reached, ReachedThreshold{
+				Resource:   name,
+				Scope:      scope.String(),
+				Threshold:  highest,
+				Percentage: int(percentage),
+				Usage:      used.String(),
+				Quota:      q.String(),
+			})
+		}
+	}
+
+	return reached
+}
+
+func resourcesByName(r kubermaticv1.ResourceDetails) map[string]*resource.Quantity {
+	return map[string]*resource.Quantity{
+		"cpu":           r.CPU,
+		"memory":        r.Memory,
+		"storage":       r.Storage,
+		"loadBalancers": r.LoadBalancers,
+		"publicIPs":     r.PublicIPs,
+		"nodes":         r.Nodes,
+	}
+}
+
+func usagePercentage(used, quota *resource.Quantity) float64 {
+	return used.AsApproximateFloat64() / quota.AsApproximateFloat64() * 100
+}
+
+func thresholdsMessage(exceeded map[string]int) string {
+	parts := []string{}
+	for _, name := range sortedKeys(exceeded) {
+		parts = append(parts, fmt.Sprintf("%s reached %d%%", name, exceeded[name]))
+	}
+
+	return fmt.Sprintf("Warning thresholds reached: %s.", strings.Join(parts, ", "))
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
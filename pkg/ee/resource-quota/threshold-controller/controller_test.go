@@ -0,0 +1,279 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package thresholdcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/test/generator"
+	"k8c.io/kubermatic/v2/pkg/util/email"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const rqName = "resourceQuota"
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		resourceQuota              *kubermaticv1.ResourceQuota
+		expectedExceededThresholds map[string]int
+		expectedConditionStatus    corev1.ConditionStatus
+		expectedNotifications      []Notification
+	}{
+		{
+			name:                       "scenario 1: reaching a threshold sends a notification",
+			resourceQuota:              genResourceQuota("85", nil),
+			expectedExceededThresholds: map[string]int{"cpu": 80},
+			expectedConditionStatus:    corev1.ConditionFalse,
+			expectedNotifications: []Notification{
+				genNotification(ReachedThreshold{Resource: "cpu", Threshold: 80, Percentage: 85, Usage: "85", Quota: "100"}),
+			},
+		},
+		{
+			name:                       "scenario 2: already reached threshold is not notified again",
+			resourceQuota:              genResourceQuota("90", map[string]int{"cpu": 80}),
+			expectedExceededThresholds: map[string]int{"cpu": 80},
+			expectedConditionStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:                       "scenario 3: reaching a higher threshold sends a notification",
+			resourceQuota:              genResourceQuota("96", map[string]int{"cpu": 80}),
+			expectedExceededThresholds: map[string]int{"cpu": 95},
+			expectedConditionStatus:    corev1.ConditionFalse,
+			expectedNotifications: []Notification{
+				genNotification(ReachedThreshold{Resource: "cpu", Threshold: 95, Percentage: 96, Usage: "96", Quota: "100"}),
+			},
+		},
+		{
+			name:                    "scenario 4: usage below thresholds resets the status",
+			resourceQuota:           genResourceQuota("50", map[string]int{"cpu": 95}),
+			expectedConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "scenario 5: reaching a threshold of a scoped quota sends a notification",
+			resourceQuota: func() *kubermaticv1.ResourceQuota {
+				rq := genResourceQuota("50", nil)
+				scope := kubermaticv1.ResourceQuotaScope{Datacenter: "dc1"}
+				rq.Spec.ScopedQuotas = []kubermaticv1.ScopedResourceDetails{{
+					ResourceQuotaScope: scope,
+					Resources:          kubermaticv1.ResourceDetails{CPU: ptr.To(resource.MustParse("40"))},
+				}}
+				rq.Status.GlobalScopedUsage = []kubermaticv1.ScopedResourceDetails{{
+					ResourceQuotaScope: scope,
+					Resources:          kubermaticv1.ResourceDetails{CPU: ptr.To(resource.MustParse("34"))},
+				}}
+				return rq
+			}(),
+			expectedExceededThresholds: map[string]int{"cpu (datacenter dc1)": 80},
+			expectedConditionStatus:    corev1.ConditionFalse,
+			expectedNotifications: []Notification{
+				genNotification(ReachedThreshold{Resource: "cpu", Scope: "datacenter dc1", Threshold: 80, Percentage: 85, Usage: "34", Quota: "40"}),
+			},
+		},
+		{
+			name: "scenario 6: no thresholds configured",
+			resourceQuota: func() *kubermaticv1.ResourceQuota {
+				rq := genResourceQuota("96", map[string]int{"cpu": 95})
+				rq.Spec.WarningThresholds = nil
+				return rq
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var notifications []Notification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := Notification{}
+				if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+					t.Errorf("failed to decode notification: %v", err)
+				}
+				notifications = append(notifications, n)
+			}))
+			defer server.Close()
+
+			settings := &kubermaticv1.KubermaticSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: kubermaticv1.GlobalSettingsName},
+				Spec: kubermaticv1.SettingSpec{
+					ResourceQuotaNotifications: &kubermaticv1.ResourceQuotaNotificationSettings{
+						WebhookURL: server.URL,
+					},
+				},
+			}
+
+			masterClient := fake.NewClientBuilder().WithObjects(tc.resourceQuota, generator.GenDefaultProject(), settings).Build()
+
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				recorder:     &record.FakeRecorder{},
+				masterClient: masterClient,
+				notifiers:    newNotifiers,
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rqName}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			rq := &kubermaticv1.ResourceQuota{}
+			if err := masterClient.Get(ctx, request.NamespacedName, rq); err != nil {
+				t.Fatalf("failed to get resource quota: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedExceededThresholds, rq.Status.ExceededThresholds) {
+				t.Errorf("Exceeded thresholds differ:\n%v", diff.ObjectDiff(tc.expectedExceededThresholds, rq.Status.ExceededThresholds))
+			}
+
+			condition, ok := rq.Status.Conditions[kubermaticv1.ResourceQuotaConditionThresholdsNotReached]
+			if tc.expectedConditionStatus == "" {
+				if ok {
+					t.Errorf("Expected no condition, but got %v", condition)
+				}
+			} else if condition.Status != tc.expectedConditionStatus {
+				t.Errorf("Expected condition status %q, but got %q", tc.expectedConditionStatus, condition.Status)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedNotifications, notifications) {
+				t.Errorf("Notifications differ:\n%v", diff.ObjectDiff(tc.expectedNotifications, notifications))
+			}
+		})
+	}
+}
+
+type fakeNotifier struct {
+	err           error
+	notifications []Notification
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, _ *kubermaticv1.Project, notification Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return n.err
+}
+
+func TestReconcileFailingNotifier(t *testing.T) {
+	ctx := context.Background()
+
+	failing := &fakeNotifier{err: errors.New("smtp server unavailable")}
+	working := &fakeNotifier{}
+
+	masterClient := fake.NewClientBuilder().WithObjects(genResourceQuota("85", nil), generator.GenDefaultProject()).Build()
+
+	r := &reconciler{
+		log:          kubermaticlog.Logger,
+		recorder:     &record.FakeRecorder{},
+		masterClient: masterClient,
+		notifiers: func(_ context.Context, _ ctrlruntimeclient.Client, _ string) ([]notifier, error) {
+			return []notifier{failing, working}, nil
+		},
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rqName}}
+	if _, err := r.Reconcile(ctx, request); err == nil {
+		t.Fatal("Expected reconciling to fail because of the failing notifier")
+	}
+
+	expected := []Notification{
+		genNotification(ReachedThreshold{Resource: "cpu", Threshold: 80, Percentage: 85, Usage: "85", Quota: "100"}),
+	}
+	if !diff.SemanticallyEqual(expected, working.notifications) {
+		t.Errorf("Notifications differ:\n%v", diff.ObjectDiff(expected, working.notifications))
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	project := generator.GenDefaultProject()
+	client := fake.NewClientBuilder().WithObjects(
+		generator.GenBinding(project.Name, "owner@example.com", "owners"),
+		generator.GenBinding(project.Name, "viewer@example.com", "viewers"),
+		generator.GenBinding("other-project", "other@example.com", "owners"),
+	).Build()
+
+	var sent []email.Message
+	n := &emailNotifier{
+		client: client,
+		from:   "kkp@example.com",
+		send: func(msg email.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+
+	notification := genNotification(ReachedThreshold{Resource: "cpu", Threshold: 80, Percentage: 85, Usage: "85", Quota: "100"})
+	if err := n.Notify(context.Background(), project, notification); err != nil {
+		t.Fatalf("failed to notify: %v", err)
+	}
+
+	if len(sent) != 1 {
+		t.Fatalf("Expected 1 email, but got %d", len(sent))
+	}
+
+	if !diff.SemanticallyEqual([]string{"owner@example.com"}, sent[0].To) {
+		t.Errorf("Expected email to be sent to the project owner only, but got %v", sent[0].To)
+	}
+}
+
+func genResourceQuota(cpuUsage string, exceeded map[string]int) *kubermaticv1.ResourceQuota {
+	project := generator.GenDefaultProject()
+
+	rq := &kubermaticv1.ResourceQuota{}
+	rq.Name = rqName
+	rq.Spec.Subject = kubermaticv1.Subject{Name: project.Name, Kind: kubermaticv1.ProjectSubjectKind}
+	rq.Spec.Quota.CPU = ptr.To(resource.MustParse("100"))
+	rq.Spec.WarningThresholds = []int{80, 95}
+	rq.Status.GlobalUsage.CPU = ptr.To(resource.MustParse(cpuUsage))
+	rq.Status.ExceededThresholds = exceeded
+
+	return rq
+}
+
+func genNotification(thresholds ...ReachedThreshold) Notification {
+	project := generator.GenDefaultProject()
+
+	return Notification{
+		Project:       project.Name,
+		ProjectName:   project.Spec.Name,
+		ResourceQuota: rqName,
+		Thresholds:    thresholds,
+	}
+}
@@ -0,0 +1,210 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package thresholdcontroller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	"k8c.io/kubermatic/v2/pkg/util/email"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReachedThreshold describes a resource whose usage reached a warning threshold. Scope is
+// empty for the overall quota and set for a scoped quota.
+type ReachedThreshold struct {
+	Resource   string `json:"resource"`
+	Scope      string `json:"scope,omitempty"`
+	Threshold  int    `json:"threshold"`
+	Percentage int    `json:"percentage"`
+	Usage      string `json:"usage"`
+	Quota      string `json:"quota"`
+}
+
+// key returns the key of the threshold in the ExceededThresholds of the resource quota status.
+func (t ReachedThreshold) key() string {
+	if t.Scope == "" {
+		return t.Resource
+	}
+
+	return fmt.Sprintf("%s (%s)", t.Resource, t.Scope)
+}
+
+func (t ReachedThreshold) String() string {
+	return fmt.Sprintf("Usage of %s reached %d%% of the quota (used/quota %s/%s), exceeding the warning threshold of %d%%.",
+		t.key(), t.Percentage, t.Usage, t.Quota, t.Threshold)
+}
+
+// Notification is sent when resources of a quota reached a warning threshold. It is
+// also the JSON document sent to webhooks.
+type Notification struct {
+	Project       string             `json:"project"`
+	ProjectName   string             `json:"projectName"`
+	ResourceQuota string             `json:"resourceQuota"`
+	Thresholds    []ReachedThreshold `json:"thresholds"`
+}
+
+type notifier interface {
+	Notify(ctx context.Context, project *kubermaticv1.Project, notification Notification) error
+}
+
+// notifierFactory returns the notifiers configured in the KubermaticSettings.
+type notifierFactory func(ctx context.Context, client ctrlruntimeclient.Client, namespace string) ([]notifier, error)
+
+func newNotifiers(ctx context.Context, client ctrlruntimeclient.Client, namespace string) ([]notifier, error) {
+	settings := &kubermaticv1.KubermaticSetting{}
+	if err := client.Get(ctx, types.NamespacedName{Name: kubermaticv1.GlobalSettingsName}, settings); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get global settings %q: %w", kubermaticv1.GlobalSettingsName, err)
+	}
+
+	config := settings.Spec.ResourceQuotaNotifications
+	if config == nil {
+		return nil, nil
+	}
+
+	var notifiers []notifier
+
+	if config.WebhookURL != "" {
+		notifiers = append(notifiers, &webhookNotifier{
+			url:    config.WebhookURL,
+			client: &http.Client{Timeout: 10 * time.Second},
+		})
+	}
+
+	if config.Email != nil {
+		sender := &email.Sender{Server: config.Email.SMTPServer}
+
+		if config.Email.CredentialsSecret != "" {
+			secret := &corev1.Secret{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: config.Email.CredentialsSecret}, secret); err != nil {
+				return nil, fmt.Errorf("failed to get SMTP credentials: %w", err)
+			}
+
+			sender.Username = string(secret.Data["username"])
+			sender.Password = string(secret.Data["password"])
+		}
+
+		notifiers = append(notifiers, &emailNotifier{
+			client: client,
+			from:   config.Email.From,
+			send:   sender.Send,
+		})
+	}
+
+	return notifiers, nil
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, _ *kubermaticv1.Project, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type emailNotifier struct {
+	client ctrlruntimeclient.Client
+	from   string
+	send   func(email.Message) error
+}
+
+func (n *emailNotifier) Notify(ctx context.Context, project *kubermaticv1.Project, notification Notification) error {
+	owners, err := getProjectOwners(ctx, n.client, project)
+	if err != nil {
+		return err
+	}
+
+	if len(owners) == 0 {
+		return nil
+	}
+
+	lines := []string{
+		fmt.Sprintf("The usage of resources in the project %q (%s) reached the warning thresholds of its resource quota:", notification.ProjectName, notification.Project),
+		"",
+	}
+	for _, threshold := range notification.Thresholds {
+		lines = append(lines, "  - "+threshold.String())
+	}
+	lines = append(lines, "", "Once the quota is exhausted, new Machines and LoadBalancers in this project will be rejected.")
+
+	return n.send(email.Message{
+		From:    n.from,
+		To:      owners,
+		Subject: fmt.Sprintf("Resource quota warning for project %s", notification.ProjectName),
+		Body:    strings.Join(lines, "\n"),
+	})
+}
+
+func getProjectOwners(ctx context.Context, client ctrlruntimeclient.Client, project *kubermaticv1.Project) ([]string, error) {
+	bindings := &kubermaticv1.UserProjectBindingList{}
+	if err := client.List(ctx, bindings); err != nil {
+		return nil, fmt.Errorf("failed to list user project bindings: %w", err)
+	}
+
+	var owners []string
+	for _, binding := range bindings.Items {
+		if binding.Spec.ProjectID == project.Name && rbac.ExtractGroupPrefix(binding.Spec.Group) == rbac.OwnerGroupNamePrefix {
+			owners = append(owners, binding.Spec.UserEmail)
+		}
+	}
+
+	return owners, nil
+}
@@ -34,8 +34,10 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	"k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	machinevalidation "k8c.io/kubermatic/v2/pkg/ee/validation/machine"
+	servicevalidation "k8c.io/kubermatic/v2/pkg/ee/validation/service"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
 	corev1 "k8s.io/api/core/v1"
@@ -78,6 +80,7 @@ func Add(log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterName s
 	_, err := builder.ControllerManagedBy(userMgr).
 		Named(controllerName).
 		For(&clusterv1alpha1.Machine{}, builder.WithPredicates(predicate.ByNamespace(metav1.NamespaceSystem))).
+		Watches(&corev1.Service{}, controllerutil.EnqueueConst(""), builder.WithPredicates(predicate.Factory(isLoadBalancer))).
 		Build(r)
 
 	return err
@@ -107,7 +110,12 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %w", err)
 	}
 
-	err = r.reconcile(ctx, cluster, machines)
+	services := &corev1.ServiceList{}
+	if err := r.userClient.List(ctx, services); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	err = r.reconcile(ctx, cluster, machines, services)
 	if err != nil {
 		r.recorder.Event(cluster, corev1.EventTypeWarning, "ClusterResourceUsageReconcileFailed", err.Error())
 	}
@@ -115,17 +123,23 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return reconcile.Result{}, err
 }
 
-func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster, machines *clusterv1alpha1.MachineList) error {
+func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster, machines *clusterv1alpha1.MachineList, services *corev1.ServiceList) error {
 	resourceUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+	resourceUsage.LoadBalancers = &resource.Quantity{}
+	resourceUsage.PublicIPs = &resource.Quantity{}
+	resourceUsage.Nodes = &resource.Quantity{}
+
 	for _, machine := range machines.Items {
-		resourceDetails, err := machinevalidation.GetMachineResourceUsage(ctx, r.userClient, &machine, r.caBundle)
+		machineUsage, err := machinevalidation.GetMachineQuotaUsage(ctx, r.userClient, &machine, r.caBundle)
 		if err != nil {
 			return fmt.Errorf("error getting machine resource usage for machine %q: %w", machine.Name, err)
 		}
 
-		resourceUsage.CPU.Add(*resourceDetails.Cpu())
-		resourceUsage.Memory.Add(*resourceDetails.Memory())
-		resourceUsage.Storage.Add(*resourceDetails.Storage())
+		resourceUsage.Add(*machineUsage)
+	}
+
+	for _, service := range services.Items {
+		resourceUsage.Add(servicevalidation.GetServiceQuotaUsage(&service))
 	}
 
 	cluster.Status.ResourceUsage = resourceUsage
@@ -134,3 +148,8 @@ func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluste
 		c.Status.ResourceUsage = resourceUsage
 	})
 }
+
+func isLoadBalancer(obj ctrlruntimeclient.Object) bool {
+	service, ok := obj.(*corev1.Service)
+	return ok && service.Spec.Type == corev1.ServiceTypeLoadBalancer
+}
@@ -36,7 +36,9 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/test/generator"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
@@ -48,6 +50,7 @@ func TestReconcile(t *testing.T) {
 		name                  string
 		cluster               *kubermaticv1.Cluster
 		machines              []*clusterv1alpha1.Machine
+		services              []*corev1.Service
 		expectedResourceUsage *kubermaticv1.ResourceDetails
 	}{
 		{
@@ -55,9 +58,12 @@ func TestReconcile(t *testing.T) {
 			cluster:  generator.GenDefaultCluster(),
 			machines: []*clusterv1alpha1.Machine{genFakeMachine("m1", "5", "5G", "10G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("5"),
-				Memory:  getQuantity("5G"),
-				Storage: getQuantity("10G"),
+				CPU:           getQuantity("5"),
+				Memory:        getQuantity("5G"),
+				Storage:       getQuantity("10G"),
+				LoadBalancers: getQuantity("0"),
+				PublicIPs:     getQuantity("0"),
+				Nodes:         getQuantity("1"),
 			},
 		},
 		{
@@ -69,9 +75,12 @@ func TestReconcile(t *testing.T) {
 			}(),
 			machines: []*clusterv1alpha1.Machine{genFakeMachine("m1", "5", "5G", "10G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("5"),
-				Memory:  getQuantity("5G"),
-				Storage: getQuantity("10G"),
+				CPU:           getQuantity("5"),
+				Memory:        getQuantity("5G"),
+				Storage:       getQuantity("10G"),
+				LoadBalancers: getQuantity("0"),
+				PublicIPs:     getQuantity("0"),
+				Nodes:         getQuantity("1"),
 			},
 		},
 		{
@@ -81,9 +90,12 @@ func TestReconcile(t *testing.T) {
 				genFakeMachine("m1", "5", "5G", "10G"),
 				genFakeMachine("m2", "2", "3G", "5G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("7"),
-				Memory:  getQuantity("8G"),
-				Storage: getQuantity("15G"),
+				CPU:           getQuantity("7"),
+				Memory:        getQuantity("8G"),
+				Storage:       getQuantity("15G"),
+				LoadBalancers: getQuantity("0"),
+				PublicIPs:     getQuantity("0"),
+				Nodes:         getQuantity("2"),
 			},
 		},
 		{
@@ -94,9 +106,32 @@ func TestReconcile(t *testing.T) {
 				return c
 			}(),
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("0"),
-				Memory:  getQuantity("0"),
-				Storage: getQuantity("0"),
+				CPU:           getQuantity("0"),
+				Memory:        getQuantity("0"),
+				Storage:       getQuantity("0"),
+				LoadBalancers: getQuantity("0"),
+				PublicIPs:     getQuantity("0"),
+				Nodes:         getQuantity("0"),
+			},
+		},
+		{
+			name:    "scenario 5: count nodes, public IPs and LoadBalancers outside of kube-system",
+			cluster: generator.GenDefaultCluster(),
+			machines: []*clusterv1alpha1.Machine{
+				genFakeMachine("m1", "5", "5G", "10G"),
+				genFakePublicMachine("m2", "2", "3G", "5G")},
+			services: []*corev1.Service{
+				genService("lb", corev1.ServiceTypeLoadBalancer),
+				genService("cluster-ip", corev1.ServiceTypeClusterIP),
+				genSystemService("system-lb", corev1.ServiceTypeLoadBalancer),
+			},
+			expectedResourceUsage: &kubermaticv1.ResourceDetails{
+				CPU:           getQuantity("7"),
+				Memory:        getQuantity("8G"),
+				Storage:       getQuantity("15G"),
+				LoadBalancers: getQuantity("1"),
+				PublicIPs:     getQuantity("2"),
+				Nodes:         getQuantity("2"),
 			},
 		},
 	}
@@ -115,6 +150,9 @@ func TestReconcile(t *testing.T) {
 			for _, m := range tc.machines {
 				userClientBuilder.WithObjects(m)
 			}
+			for _, s := range tc.services {
+				userClientBuilder.WithObjects(s)
+			}
 
 			seedClient := seedClientBuilder.Build()
 			userClient := userClientBuilder.Build()
@@ -156,7 +194,32 @@ func genFakeMachine(name, cpu, memory, storage string) *clusterv1alpha1.Machine
 		nil, nil)
 }
 
+func genFakePublicMachine(name, cpu, memory, storage string) *clusterv1alpha1.Machine {
+	return generator.GenTestMachine(name,
+		fmt.Sprintf(`{"cloudProvider":"fake", "cloudProviderSpec":{"cpu":"%s","memory":"%s","storage":"%s","publicIP":true}}`, cpu, memory, storage),
+		nil, nil)
+}
+
+func genService(name string, serviceType corev1.ServiceType) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: serviceType,
+		},
+	}
+}
+
 func getQuantity(q string) *resource.Quantity {
 	res := resource.MustParse(q)
 	return &res
 }
+
+func genSystemService(name string, serviceType corev1.ServiceType) *corev1.Service {
+	s := genService(name, serviceType)
+	s.Namespace = metav1.NamespaceSystem
+
+	return s
+}
@@ -56,8 +56,18 @@ func getFakeQuotaRequest(config *providerconfig.Config) (*ResourceDetails, error
 	return NewResourceDetails(cpu, mem, storage), nil
 }
 
+func getFakePublicIP(config *providerconfig.Config) (bool, error) {
+	spec := &FakeProviderSpec{}
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, spec); err != nil {
+		return false, fmt.Errorf("error unmarshalling fake raw config: %w", err)
+	}
+
+	return spec.PublicIP, nil
+}
+
 type FakeProviderSpec struct {
-	Cpu     string `json:"cpu"`
-	Memory  string `json:"memory"`
-	Storage string `json:"storage"`
+	Cpu      string `json:"cpu"`
+	Memory   string `json:"memory"`
+	Storage  string `json:"storage"`
+	PublicIP bool   `json:"publicIP,omitempty"`
 }
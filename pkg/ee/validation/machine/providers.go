@@ -138,6 +138,77 @@ func GetMachineResourceUsage(ctx context.Context, userClient ctrlruntimeclient.C
 	return quotaUsage, err
 }
 
+// MachineHasPublicIP returns whether the Machine gets a public IPv4 address (e.g. a floating IP) assigned by
+// its cloud provider. Providers which do not assign public addresses to nodes return false.
+func MachineHasPublicIP(ctx context.Context, userClient ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine) (bool, error) {
+	config, err := types.GetConfig(machine.Spec.ProviderSpec)
+	if err != nil {
+		return false, fmt.Errorf("failed to read machine.spec.providerSpec: %w", err)
+	}
+
+	configVarResolver := providerconfig.NewConfigVarResolver(ctx, userClient)
+
+	switch config.CloudProvider {
+	case types.CloudProviderFake:
+		return getFakePublicIP(config)
+	case types.CloudProviderAWS:
+		rawConfig, err := awstypes.GetConfig(*config)
+		if err != nil {
+			return false, fmt.Errorf("error getting aws raw config: %w", err)
+		}
+		// the machine-controller assigns a public IP unless explicitly disabled
+		return rawConfig.AssignPublicIP == nil || *rawConfig.AssignPublicIP, nil
+	case types.CloudProviderGoogle:
+		rawConfig, err := gcptypes.GetConfig(*config)
+		if err != nil {
+			return false, fmt.Errorf("error getting gcp raw config: %w", err)
+		}
+		if rawConfig.AssignPublicIPAddress == nil {
+			return true, nil
+		}
+		assign, _, err := configVarResolver.GetConfigVarBoolValue(*rawConfig.AssignPublicIPAddress)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the value of gcp \"assignPublicIPAddress\" field: %w", err)
+		}
+		return assign, nil
+	case types.CloudProviderAzure:
+		rawConfig, err := azuretypes.GetConfig(*config)
+		if err != nil {
+			return false, fmt.Errorf("failed to get azure raw config: %w", err)
+		}
+		assign, _, err := configVarResolver.GetConfigVarBoolValue(rawConfig.AssignPublicIP)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the value of azure \"assignPublicIP\" field: %w", err)
+		}
+		return assign, nil
+	case types.CloudProviderOpenstack:
+		rawConfig, err := openstacktypes.GetConfig(*config)
+		if err != nil {
+			return false, fmt.Errorf("failed to get openstack raw config: %w", err)
+		}
+		floatingIPPool, err := configVarResolver.GetConfigVarStringValue(rawConfig.FloatingIPPool)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the value of openstack \"floatingIPPool\" field: %w", err)
+		}
+		return floatingIPPool != "", nil
+	case types.CloudProviderHetzner:
+		rawConfig, err := hetznertypes.GetConfig(*config)
+		if err != nil {
+			return false, fmt.Errorf("failed to get hetzner raw config: %w", err)
+		}
+		assign, set, err := configVarResolver.GetConfigVarBoolValue(rawConfig.AssignPublicIPv4)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the value of hetzner \"assignPublicIPv4\" field: %w", err)
+		}
+		// the machine-controller assigns a public IPv4 address if not configured otherwise
+		return assign || !set, nil
+	case types.CloudProviderDigitalocean, types.CloudProviderEquinixMetal, types.CloudProviderPacket:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
 func getAWSResourceRequirements(ctx context.Context, userClient ctrlruntimeclient.Client, config *types.Config) (*ResourceDetails, error) {
 	configVarResolver := providerconfig.NewConfigVarResolver(ctx, userClient)
 	rawConfig, err := awstypes.GetConfig(*config)
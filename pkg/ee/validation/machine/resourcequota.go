@@ -33,70 +33,116 @@ import (
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	resourcequotavalidation "k8c.io/kubermatic/v2/pkg/ee/validation/resourcequota"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ValidateQuota validates if the requested Machine resource consumption fits in the quota of the clusters project.
+// datacenter and provider are the ones of the cluster and used to check the matching scoped quotas.
 func ValidateQuota(ctx context.Context,
 	log *zap.SugaredLogger,
 	userClient ctrlruntimeclient.Client,
 	machine *clusterv1alpha1.Machine,
 	caBundle *certificates.CABundle,
 	resourceQuota *kubermaticv1.ResourceQuota,
+	datacenter, provider string,
 ) error {
-	machineResourceUsage, err := GetMachineResourceUsage(ctx, userClient, machine, caBundle)
+	request, err := GetMachineQuotaUsage(ctx, userClient, machine, caBundle)
 	if err != nil {
-		return fmt.Errorf("error getting machine resource request: %w", err)
+		return err
+	}
+
+	return resourcequotavalidation.ValidateUsage(log, resourceQuota, datacenter, provider, *request)
+}
+
+// ValidateMachineDeploymentQuota validates if the Machines added by creating or scaling up a MachineDeployment fit in
+// the quota of the clusters project. oldMD is nil when the MachineDeployment is created.
+func ValidateMachineDeploymentQuota(ctx context.Context,
+	log *zap.SugaredLogger,
+	userClient ctrlruntimeclient.Client,
+	oldMD, newMD *clusterv1alpha1.MachineDeployment,
+	caBundle *certificates.CABundle,
+	resourceQuota *kubermaticv1.ResourceQuota,
+	datacenter, provider string,
+) error {
+	addedReplicas := getReplicas(newMD)
+	if oldMD != nil {
+		addedReplicas -= getReplicas(oldMD)
+	}
+	if addedReplicas <= 0 {
+		return nil
+	}
+
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      newMD.Name,
+			Namespace: newMD.Namespace,
+		},
+		Spec: newMD.Spec.Template.Spec,
 	}
 
-	var currentCPU = resource.Quantity{}
-	if resourceQuota.Status.GlobalUsage.CPU != nil {
-		currentCPU = *resourceQuota.Status.GlobalUsage.CPU
+	request, err := GetMachineQuotaUsage(ctx, userClient, machine, caBundle)
+	if err != nil {
+		return err
 	}
 
-	var currentMem = resource.Quantity{}
-	if resourceQuota.Status.GlobalUsage.Memory != nil {
-		currentMem = *resourceQuota.Status.GlobalUsage.Memory
+	return resourcequotavalidation.ValidateUsage(log, resourceQuota, datacenter, provider, multiplyResourceDetails(*request, int64(addedReplicas)))
+}
+
+// GetMachineQuotaUsage returns the quota relevant resources consumed by a single Machine.
+func GetMachineQuotaUsage(ctx context.Context, userClient ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine,
+	caBundle *certificates.CABundle) (*kubermaticv1.ResourceDetails, error) {
+	machineResourceUsage, err := GetMachineResourceUsage(ctx, userClient, machine, caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("error getting machine resource request: %w", err)
 	}
 
-	var currentStorage = resource.Quantity{}
-	if resourceQuota.Status.GlobalUsage.Storage != nil {
-		currentStorage = *resourceQuota.Status.GlobalUsage.Storage
+	publicIP, err := MachineHasPublicIP(ctx, userClient, machine)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if machine requests a public IP: %w", err)
 	}
 
-	// add requested resources to current usage and compare
-	combinedUsage := NewResourceDetails(currentCPU, currentMem, currentStorage)
-	combinedUsage.Cpu().Add(*machineResourceUsage.Cpu())
-	combinedUsage.Memory().Add(*machineResourceUsage.Memory())
-	combinedUsage.Storage().Add(*machineResourceUsage.Storage())
-
-	quota := resourceQuota.Spec.Quota
-	if quota.CPU != nil && quota.CPU.Cmp(*combinedUsage.Cpu()) < 0 {
-		log.Debugw("requested CPU would exceed current quota", "request",
-			machineResourceUsage.Cpu(), "quota", quota.CPU, "used", currentCPU.String())
-		return fmt.Errorf("requested CPU %q would exceed current quota (quota/used %q/%q)",
-			machineResourceUsage.Cpu(), quota.CPU, currentCPU.String())
+	var publicIPs int64
+	if publicIP {
+		publicIPs = 1
 	}
 
-	if quota.Memory != nil && quota.Memory.Cmp(*combinedUsage.Memory()) < 0 {
-		log.Debugw("requested Memory would exceed current quota", "request",
-			machineResourceUsage.Memory(), "quota", quota.Memory, "used", currentMem.String())
-		return fmt.Errorf("requested Memory %q would exceed current quota (quota/used %q/%q)",
-			machineResourceUsage.Memory(), quota.Memory, currentMem.String())
+	request := kubermaticv1.NewResourceDetails(*machineResourceUsage.Cpu(), *machineResourceUsage.Memory(), *machineResourceUsage.Storage())
+	request.Nodes = resource.NewQuantity(1, resource.DecimalSI)
+	request.PublicIPs = resource.NewQuantity(publicIPs, resource.DecimalSI)
+
+	return request, nil
+}
+
+func getReplicas(md *clusterv1alpha1.MachineDeployment) int32 {
+	// the machine-controller defaults unset replicas to 1
+	if md.Spec.Replicas == nil {
+		return 1
 	}
+	return *md.Spec.Replicas
+}
 
-	if quota.Storage != nil && quota.Storage.Cmp(*combinedUsage.Storage()) < 0 {
-		log.Debugw("requested disk size would exceed current quota", "request",
-			machineResourceUsage.Storage(), "quota", quota.Storage, "used", currentStorage.String())
-		return fmt.Errorf("requested disk size %q would exceed current quota (quota/used %q/%q)",
-			machineResourceUsage.Storage(), quota.Storage, currentStorage.String())
+func multiplyResourceDetails(r kubermaticv1.ResourceDetails, factor int64) kubermaticv1.ResourceDetails {
+	multiply := func(q *resource.Quantity) *resource.Quantity {
+		if q == nil {
+			return nil
+		}
+		return resource.NewMilliQuantity(q.MilliValue()*factor, q.Format)
 	}
 
-	return nil
+	return kubermaticv1.ResourceDetails{
+		CPU:           multiply(r.CPU),
+		Memory:        multiply(r.Memory),
+		Storage:       multiply(r.Storage),
+		LoadBalancers: multiply(r.LoadBalancers),
+		PublicIPs:     multiply(r.PublicIPs),
+		Nodes:         multiply(r.Nodes),
+	}
 }
 
 type ResourceDetails struct {
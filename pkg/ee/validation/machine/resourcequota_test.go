@@ -36,6 +36,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/generator"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 )
 
 func TestResourceQuotaValidation(t *testing.T) {
@@ -44,6 +45,7 @@ func TestResourceQuotaValidation(t *testing.T) {
 	testCases := []struct {
 		name        string
 		machine     *clusterv1alpha1.Machine
+		datacenter  string
 		expectedErr bool
 	}{
 		{
@@ -66,11 +68,83 @@ func TestResourceQuotaValidation(t *testing.T) {
 			machine:     genFakeMachine("2", "2G", "5000G"),
 			expectedErr: true,
 		},
+		{
+			name:        "should fail with public IP quota exceeded",
+			machine:     genFakePublicMachine("2", "2G", "10G"),
+			expectedErr: true,
+		},
+		{
+			name:        "should fail with CPU quota of the datacenter exceeded",
+			machine:     genFakeMachine("2", "2G", "10G"),
+			datacenter:  "expensive-dc",
+			expectedErr: true,
+		},
+		{
+			name:        "quota that fits in the datacenter should succeed",
+			machine:     genFakeMachine("1", "2G", "10G"),
+			datacenter:  "expensive-dc",
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := machine.ValidateQuota(context.Background(), l, nil, tc.machine, nil, genResourceQuota(), tc.datacenter, "fake")
+			if err != nil {
+				if !tc.expectedErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if err == nil && tc.expectedErr {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestMachineDeploymentResourceQuotaValidation(t *testing.T) {
+	l := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	testCases := []struct {
+		name        string
+		oldMD       *clusterv1alpha1.MachineDeployment
+		newMD       *clusterv1alpha1.MachineDeployment
+		expectedErr bool
+	}{
+		{
+			name:        "creating a MachineDeployment that fits should succeed",
+			newMD:       genFakeMachineDeployment(2),
+			expectedErr: false,
+		},
+		{
+			name:        "creating a MachineDeployment exceeding the node quota should fail",
+			newMD:       genFakeMachineDeployment(3),
+			expectedErr: true,
+		},
+		{
+			name:        "scaling up within the quota should succeed",
+			oldMD:       genFakeMachineDeployment(1),
+			newMD:       genFakeMachineDeployment(3),
+			expectedErr: false,
+		},
+		{
+			name:        "scaling up beyond the node quota should fail",
+			oldMD:       genFakeMachineDeployment(1),
+			newMD:       genFakeMachineDeployment(4),
+			expectedErr: true,
+		},
+		{
+			name:        "scaling down should always succeed",
+			oldMD:       genFakeMachineDeployment(10),
+			newMD:       genFakeMachineDeployment(8),
+			expectedErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := machine.ValidateQuota(context.Background(), l, nil, tc.machine, nil, genResourceQuota())
+			err := machine.ValidateMachineDeploymentQuota(context.Background(), l, nil, tc.oldMD, tc.newMD, nil, genResourceQuota(), "cheap-dc", "fake")
 			if err != nil {
 				if !tc.expectedErr {
 					t.Fatalf("unexpected error: %v", err)
@@ -90,10 +164,39 @@ func genFakeMachine(cpu, memory, storage string) *clusterv1alpha1.Machine {
 		nil, nil)
 }
 
+func genFakePublicMachine(cpu, memory, storage string) *clusterv1alpha1.Machine {
+	return generator.GenTestMachine("fake",
+		fmt.Sprintf(`{"cloudProvider":"fake", "cloudProviderSpec":{"cpu":"%s","memory":"%s","storage":"%s","publicIP":true}}`, cpu, memory, storage),
+		nil, nil)
+}
+
+func genFakeMachineDeployment(replicas int32) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Name = "fake"
+	md.Spec.Replicas = &replicas
+	md.Spec.Template.Spec = genFakeMachine("2", "2G", "10G").Spec
+
+	return md
+}
+
 func genResourceQuota() *kubermaticv1.ResourceQuota {
 	rq := &kubermaticv1.ResourceQuota{}
 	rq.Spec.Quota = *kubermaticv1.NewResourceDetails(resource.MustParse("50"), resource.MustParse("50G"), resource.MustParse("1000G"))
+	rq.Spec.Quota.Nodes = ptr.To(resource.MustParse("5"))
+	rq.Spec.Quota.PublicIPs = ptr.To(resource.MustParse("2"))
 	rq.Status.GlobalUsage = *kubermaticv1.NewResourceDetails(resource.MustParse("3"), resource.MustParse("3G"), resource.MustParse("60G"))
+	rq.Status.GlobalUsage.Nodes = ptr.To(resource.MustParse("3"))
+	rq.Status.GlobalUsage.PublicIPs = ptr.To(resource.MustParse("2"))
+
+	scope := kubermaticv1.ResourceQuotaScope{Datacenter: "expensive-dc"}
+	rq.Spec.ScopedQuotas = []kubermaticv1.ScopedResourceDetails{{
+		ResourceQuotaScope: scope,
+		Resources:          kubermaticv1.ResourceDetails{CPU: ptr.To(resource.MustParse("4"))},
+	}}
+	rq.Status.GlobalScopedUsage = []kubermaticv1.ScopedResourceDetails{{
+		ResourceQuotaScope: scope,
+		Resources:          kubermaticv1.ResourceDetails{CPU: ptr.To(resource.MustParse("3"))},
+	}}
 
 	return rq
 }
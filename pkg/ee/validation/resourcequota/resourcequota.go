@@ -54,6 +54,14 @@ func ValidateCreate(ctx context.Context,
 		return fmt.Errorf("failed to list resource quotas: %w", err)
 	}
 
+	if err := validateWarningThresholds(incomingQuota.Spec.WarningThresholds); err != nil {
+		return err
+	}
+
+	if err := validateScopedQuotas(incomingQuota.Spec.ScopedQuotas); err != nil {
+		return err
+	}
+
 	incomingSubject := incomingQuota.Spec.Subject
 	for _, currentQuota := range currentQuotaList.Items {
 		currentSubject := currentQuota.Spec.Subject
@@ -89,6 +97,36 @@ func ValidateUpdate(ctx context.Context,
 		return fmt.Errorf("Operation not permitted: updating ResourceQuota Subject is not allowed!")
 	}
 
+	if err := validateWarningThresholds(newQuota.Spec.WarningThresholds); err != nil {
+		return err
+	}
+
+	return validateScopedQuotas(newQuota.Spec.ScopedQuotas)
+}
+
+func validateWarningThresholds(thresholds []int) error {
+	for _, threshold := range thresholds {
+		if threshold < 1 || threshold > 100 {
+			return fmt.Errorf("ResourceQuota: warning threshold %d must be a percentage between 1 and 100", threshold)
+		}
+	}
+
+	return nil
+}
+
+func validateScopedQuotas(scopedQuotas []kubermaticv1.ScopedResourceDetails) error {
+	seen := map[kubermaticv1.ResourceQuotaScope]struct{}{}
+	for _, scopedQuota := range scopedQuotas {
+		scope := scopedQuota.ResourceQuotaScope
+		if scope.Datacenter == "" && scope.Provider == "" {
+			return errors.New("ResourceQuota: scoped quotas must specify a datacenter, a provider or both")
+		}
+		if _, ok := seen[scope]; ok {
+			return fmt.Errorf("ResourceQuota: scoped quota for %s must be unique", scope.String())
+		}
+		seen[scope] = struct{}{}
+	}
+
 	return nil
 }
 
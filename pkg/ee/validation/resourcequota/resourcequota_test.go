@@ -175,6 +175,172 @@ func TestValidateUpdate(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "Update ResourceQuota with invalid warning threshold",
+			oldResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+				},
+			},
+			newResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota:             kubermaticv1.ResourceDetails{},
+					WarningThresholds: []int{80, 120},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Update ResourceQuota warning thresholds",
+			oldResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+				},
+			},
+			newResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota:             kubermaticv1.ResourceDetails{},
+					WarningThresholds: []int{80, 95},
+				},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Update ResourceQuota scoped quotas",
+			oldResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+				},
+			},
+			newResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+					ScopedQuotas: []kubermaticv1.ScopedResourceDetails{
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Datacenter: "europe-west3-c"},
+						},
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: "aws"},
+						},
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Datacenter: "europe-west3-c", Provider: "gcp"},
+						},
+					},
+				},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Update ResourceQuota with empty scope",
+			oldResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+				},
+			},
+			newResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+					ScopedQuotas: []kubermaticv1.ScopedResourceDetails{
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Update ResourceQuota with duplicate scopes",
+			oldResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+				},
+			},
+			newResourceQuota: &kubermaticv1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "existing-quota",
+				},
+				Spec: kubermaticv1.ResourceQuotaSpec{
+					Subject: kubermaticv1.Subject{
+						Name: "wwqrvcccq6",
+						Kind: "project",
+					},
+					Quota: kubermaticv1.ResourceDetails{},
+					ScopedQuotas: []kubermaticv1.ScopedResourceDetails{
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: "aws"},
+						},
+						{
+							ResourceQuotaScope: kubermaticv1.ResourceQuotaScope{Provider: "aws"},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,104 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package resourcequota
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ValidateUsage validates if the requested resources fit in the quota, taking the current global usage
+// into account. Besides the overall quota, all scoped quotas matching the datacenter and provider of
+// the cluster are checked. Resources which are not requested or not limited by a quota are not checked.
+func ValidateUsage(log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota, datacenter, provider string, request kubermaticv1.ResourceDetails) error {
+	if err := validateUsage(log, "", resourceQuota.Spec.Quota, resourceQuota.Status.GlobalUsage, request); err != nil {
+		return err
+	}
+
+	for _, scopedQuota := range resourceQuota.Spec.ScopedQuotas {
+		if !scopedQuota.Matches(datacenter, provider) {
+			continue
+		}
+
+		used := kubermaticv1.ResourceDetails{}
+		for _, scopedUsage := range resourceQuota.Status.GlobalScopedUsage {
+			if scopedUsage.ResourceQuotaScope == scopedQuota.ResourceQuotaScope {
+				used = scopedUsage.Resources
+				break
+			}
+		}
+
+		if err := validateUsage(log, fmt.Sprintf(" for %s", scopedQuota.ResourceQuotaScope), scopedQuota.Resources, used, request); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateUsage(log *zap.SugaredLogger, scope string, quota, used, request kubermaticv1.ResourceDetails) error {
+	checks := []struct {
+		name      string
+		quota     *resource.Quantity
+		used      *resource.Quantity
+		requested *resource.Quantity
+	}{
+		{name: "CPU", quota: quota.CPU, used: used.CPU, requested: request.CPU},
+		{name: "Memory", quota: quota.Memory, used: used.Memory, requested: request.Memory},
+		{name: "disk size", quota: quota.Storage, used: used.Storage, requested: request.Storage},
+		{name: "number of LoadBalancers", quota: quota.LoadBalancers, used: used.LoadBalancers, requested: request.LoadBalancers},
+		{name: "number of public IPs", quota: quota.PublicIPs, used: used.PublicIPs, requested: request.PublicIPs},
+		{name: "number of nodes", quota: quota.Nodes, used: used.Nodes, requested: request.Nodes},
+	}
+
+	for _, check := range checks {
+		if check.quota == nil || check.requested == nil || check.requested.IsZero() {
+			continue
+		}
+
+		current := resource.Quantity{}
+		if check.used != nil {
+			current = check.used.DeepCopy()
+		}
+
+		// add requested resources to current usage and compare
+		combined := current.DeepCopy()
+		combined.Add(*check.requested)
+
+		if check.quota.Cmp(combined) < 0 {
+			log.Debugw(fmt.Sprintf("requested %s would exceed current quota%s", check.name, scope), "request",
+				check.requested.String(), "quota", check.quota.String(), "used", current.String())
+			return fmt.Errorf("requested %s %q would exceed current quota%s (quota/used %q/%q)",
+				check.name, check.requested.String(), scope, check.quota.String(), current.String())
+		}
+	}
+
+	return nil
+}
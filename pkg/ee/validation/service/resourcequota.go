@@ -0,0 +1,65 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package service
+
+import (
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	resourcequotavalidation "k8c.io/kubermatic/v2/pkg/ee/validation/resourcequota"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidateQuota validates if a new LoadBalancer Service fits in the quota of the clusters project. Every
+// LoadBalancer is counted as one public IP. oldService is nil when the Service is created.
+func ValidateQuota(log *zap.SugaredLogger, oldService, newService *corev1.Service, resourceQuota *kubermaticv1.ResourceQuota, datacenter, provider string) error {
+	if newService.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return nil
+	}
+
+	// the LoadBalancer is already accounted for
+	if oldService != nil && oldService.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		return nil
+	}
+
+	return resourcequotavalidation.ValidateUsage(log, resourceQuota, datacenter, provider, GetServiceQuotaUsage(newService))
+}
+
+// GetServiceQuotaUsage returns the quota relevant resources consumed by a Service. LoadBalancers in
+// kube-system are not counted, as the Service webhook does not enforce the quota for them either.
+func GetServiceQuotaUsage(service *corev1.Service) kubermaticv1.ResourceDetails {
+	var loadBalancers int64
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer && service.Namespace != metav1.NamespaceSystem {
+		loadBalancers = 1
+	}
+
+	return kubermaticv1.ResourceDetails{
+		LoadBalancers: resource.NewQuantity(loadBalancers, resource.DecimalSI),
+		PublicIPs:     resource.NewQuantity(loadBalancers, resource.DecimalSI),
+	}
+}
@@ -0,0 +1,135 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package service_test
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/ee/validation/service"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestResourceQuotaValidation(t *testing.T) {
+	l := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	testCases := []struct {
+		name          string
+		oldService    *corev1.Service
+		newService    *corev1.Service
+		resourceQuota *kubermaticv1.ResourceQuota
+		expectedErr   bool
+	}{
+		{
+			name:          "LoadBalancer that fits should succeed",
+			newService:    genService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("3", "1", "10", "5"),
+			expectedErr:   false,
+		},
+		{
+			name:          "should fail with LoadBalancer quota exceeded",
+			newService:    genService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("1", "1", "10", "5"),
+			expectedErr:   true,
+		},
+		{
+			name:          "should fail with public IP quota exceeded",
+			newService:    genService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("3", "1", "5", "5"),
+			expectedErr:   true,
+		},
+		{
+			name:          "should fail when changing the type to LoadBalancer with quota exceeded",
+			oldService:    genService(corev1.ServiceTypeClusterIP),
+			newService:    genService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("1", "1", "10", "5"),
+			expectedErr:   true,
+		},
+		{
+			name:          "updating an existing LoadBalancer should succeed",
+			oldService:    genService(corev1.ServiceTypeLoadBalancer),
+			newService:    genService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("1", "1", "5", "5"),
+			expectedErr:   false,
+		},
+		{
+			name:          "LoadBalancer in kube-system should succeed with quota exceeded",
+			newService:    genSystemService(corev1.ServiceTypeLoadBalancer),
+			resourceQuota: genResourceQuota("1", "1", "5", "5"),
+			expectedErr:   false,
+		},
+		{
+			name:          "ClusterIP Service should always succeed",
+			newService:    genService(corev1.ServiceTypeClusterIP),
+			resourceQuota: genResourceQuota("1", "1", "5", "5"),
+			expectedErr:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := service.ValidateQuota(l, tc.oldService, tc.newService, tc.resourceQuota, "dc", "fake")
+			if err != nil {
+				if !tc.expectedErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if err == nil && tc.expectedErr {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func genService(serviceType corev1.ServiceType) *corev1.Service {
+	s := &corev1.Service{}
+	s.Name = "test"
+	s.Spec.Type = serviceType
+
+	return s
+}
+
+func genSystemService(serviceType corev1.ServiceType) *corev1.Service {
+	s := genService(serviceType)
+	s.Namespace = metav1.NamespaceSystem
+
+	return s
+}
+
+func genResourceQuota(lbQuota, lbUsage, ipQuota, ipUsage string) *kubermaticv1.ResourceQuota {
+	rq := &kubermaticv1.ResourceQuota{}
+	rq.Spec.Quota.LoadBalancers = ptr.To(resource.MustParse(lbQuota))
+	rq.Spec.Quota.PublicIPs = ptr.To(resource.MustParse(ipQuota))
+	rq.Status.GlobalUsage.LoadBalancers = ptr.To(resource.MustParse(lbUsage))
+	rq.Status.GlobalUsage.PublicIPs = ptr.To(resource.MustParse(ipUsage))
+
+	return rq
+}
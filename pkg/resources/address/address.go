@@ -21,17 +21,21 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilnet "k8s.io/utils/net"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 type lookupFunction func(host string) ([]net.IP, error)
@@ -95,7 +99,7 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 		subdomain = m.seed.Spec.SeedDNSOverwrite
 	}
 
-	frontProxyLBServiceIP := ""
+	var frontProxyLBServiceIPs []string
 	frontProxyLBServiceHostname := ""
 	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
 		frontProxyLoadBalancerService := &corev1.Service{}
@@ -103,14 +107,14 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 		if err := m.client.Get(ctx, nn, frontProxyLoadBalancerService); err != nil {
 			return nil, fmt.Errorf("failed to get the front-loadbalancer service: %w", err)
 		}
-		frontProxyLBServiceIP, frontProxyLBServiceHostname = m.getFrontProxyLBServiceData(frontProxyLoadBalancerService)
+		frontProxyLBServiceIPs, frontProxyLBServiceHostname = m.getFrontProxyLBServiceData(frontProxyLoadBalancerService)
 	}
 
 	// External Name
 	externalName := ""
 	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
-		if frontProxyLBServiceIP != "" {
-			externalName = frontProxyLBServiceIP
+		if len(frontProxyLBServiceIPs) > 0 {
+			externalName = frontProxyLBServiceIPs[0]
 		} else {
 			externalName = frontProxyLBServiceHostname
 		}
@@ -135,38 +139,56 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	// IP
-	ip := ""
+	var ips []string
 	// When using the Tunneling expose strategy we disable KAS endpoints
 	// reconciliation, and we reconcile them with the agent IPs in the user
 	// controller manager.
 	switch m.cluster.Spec.ExposeStrategy {
 	case kubermaticv1.ExposeStrategyLoadBalancer:
-		if frontProxyLBServiceIP != "" {
-			ip = frontProxyLBServiceIP
+		if len(frontProxyLBServiceIPs) > 0 {
+			ips = frontProxyLBServiceIPs
 		} else if frontProxyLBServiceHostname != "" {
 			var err error
 			// Always lookup IP address, in case it changes
-			ip, err = m.getExternalIP(frontProxyLBServiceHostname)
+			ips, err = m.getExternalIPs(frontProxyLBServiceHostname)
 			if err != nil {
 				return nil, err
 			}
 		}
-	case kubermaticv1.ExposeStrategyNodePort:
-		fallthrough
-	case kubermaticv1.ExposeStrategyTunneling:
+	case kubermaticv1.ExposeStrategyGateway:
+		// The apiserver is routed by its SNI hostname on the shared Gateway, so the
+		// address always points to the Gateway. The external name has to resolve to
+		// the Gateway as well, e.g. by a wildcard DNS record for the seed.
+		var err error
+		ips, err = m.getGatewayIPs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	case kubermaticv1.ExposeStrategyNodePort, kubermaticv1.ExposeStrategyTunneling:
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
-		ip, err = m.getExternalIP(externalName)
+		ips, err = m.getExternalIPs(externalName)
 		if err != nil {
 			return nil, err
 		}
 	}
+
+	ip := ""
+	if len(ips) > 0 {
+		ip = ips[0]
+	}
 	if m.cluster.Status.Address.IP != ip {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Status.Address.IP = ip
 		})
 		m.log.Debugw("Set IP for cluster", "ip", ip)
 	}
+	if !slices.Equal(m.cluster.Status.Address.IPs, ips) {
+		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
+			c.Status.Address.IPs = ips
+		})
+		m.log.Debugw("Set IPs for cluster", "ips", ips)
+	}
 
 	service := &corev1.Service{}
 	serviceKey := types.NamespacedName{Namespace: m.cluster.Status.NamespaceName, Name: resources.ApiserverServiceName}
@@ -178,8 +200,13 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	// Port
-	var port int32 = service.Spec.Ports[0].TargetPort.IntVal
-	if m.cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling {
+	var port int32
+	switch m.cluster.Spec.ExposeStrategy {
+	case kubermaticv1.ExposeStrategyTunneling:
+		port = service.Spec.Ports[0].TargetPort.IntVal
+	case kubermaticv1.ExposeStrategyGateway:
+		port = resources.ExposeGatewayTLSPort
+	default:
 		port = service.Spec.Ports[0].NodePort
 	}
 
@@ -205,14 +232,14 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	return modifiers, nil
 }
 
-func (m *ModifiersBuilder) getFrontProxyLBServiceData(frontProxyLoadBalancerService *corev1.Service) (string, string) {
-	//  frontProxyLBServiceIP is set according to below priority
+func (m *ModifiersBuilder) getFrontProxyLBServiceData(frontProxyLoadBalancerService *corev1.Service) ([]string, string) {
+	//  frontProxyLBServiceIPs contain at most one IP per family, in below priority
 	// 1. First public IPv4 from the status list
 	// 2. First private IPv4 from the status list
 	// 3. First public IPv6 from the status list
 	// 4. First private IPv6 from the status list
 	// 5. Default IP as per configured spec if status is not populated
-	serviceIP := ""
+	var serviceIPs []string
 	serviceHostname := ""
 	var publicIPv4, privateIPv4, privateIPv6, publicIPv6 []string
 
@@ -242,53 +269,99 @@ func (m *ModifiersBuilder) getFrontProxyLBServiceData(frontProxyLoadBalancerServ
 
 	switch {
 	case len(publicIPv4) > 0:
-		serviceIP = publicIPv4[0]
+		serviceIPs = append(serviceIPs, publicIPv4[0])
 	case len(privateIPv4) > 0:
-		serviceIP = privateIPv4[0]
+		serviceIPs = append(serviceIPs, privateIPv4[0])
+	}
+
+	switch {
 	case len(publicIPv6) > 0:
-		serviceIP = publicIPv6[0]
+		serviceIPs = append(serviceIPs, publicIPv6[0])
 	case len(privateIPv6) > 0:
-		serviceIP = privateIPv6[0]
+		serviceIPs = append(serviceIPs, privateIPv6[0])
 	}
 
-	m.log.Debugw("From the ingress values in LB status, the following values will be used", "ip", serviceIP, "hostname", serviceHostname)
+	m.log.Debugw("From the ingress values in LB status, the following values will be used", "ips", serviceIPs, "hostname", serviceHostname)
 
 	// default in case the implementation doesn't populate the status
-	if len(frontProxyLoadBalancerService.Status.LoadBalancer.Ingress) == 0 {
-		serviceIP = frontProxyLoadBalancerService.Spec.LoadBalancerIP
+	if len(frontProxyLoadBalancerService.Status.LoadBalancer.Ingress) == 0 && frontProxyLoadBalancerService.Spec.LoadBalancerIP != "" {
+		serviceIPs = []string{frontProxyLoadBalancerService.Spec.LoadBalancerIP}
 	}
 
-	return serviceIP, serviceHostname
+	return serviceIPs, serviceHostname
 }
 
-func (m *ModifiersBuilder) getExternalIP(hostname string) (string, error) {
+// getGatewayIPs returns the IPs of the shared expose Gateway of the seed. Hostname
+// addresses are resolved. If the Gateway has no address yet, no IPs are returned.
+func (m *ModifiersBuilder) getGatewayIPs(ctx context.Context) ([]string, error) {
+	gateway := &gatewayapiv1.Gateway{}
+	key := types.NamespacedName{Namespace: m.seed.Namespace, Name: exposegateway.GatewayName}
+	if err := m.client.Get(ctx, key, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the expose Gateway: %w", err)
+	}
+
+	for _, address := range gateway.Status.Addresses {
+		if address.Type != nil && *address.Type == gatewayapiv1.HostnameAddressType {
+			return m.getExternalIPs(address.Value)
+		}
+	}
+
+	var ipv4, ipv6 []string
+	for _, address := range gateway.Status.Addresses {
+		switch {
+		case utilnet.IsIPv4String(address.Value):
+			ipv4 = append(ipv4, address.Value)
+		case utilnet.IsIPv6String(address.Value):
+			ipv6 = append(ipv6, address.Value)
+		}
+	}
+
+	// Like for the other strategies, use at most one IP per family.
+	var ips []string
+	for _, familyIPs := range [][]string{ipv4, ipv6} {
+		if len(familyIPs) > 0 {
+			ips = append(ips, familyIPs[0])
+		}
+	}
+
+	return ips, nil
+}
+
+// getExternalIPs returns at most one IP address per IP family for the given
+// hostname, the IPv4 address first.
+func (m *ModifiersBuilder) getExternalIPs(hostname string) ([]string, error) {
 	resolvedIPs, err := m.lookupFunction(hostname)
 	if err != nil {
-		return "", fmt.Errorf("failed to lookup ip for %s: %w", hostname, err)
+		return nil, fmt.Errorf("failed to lookup ip for %s: %w", hostname, err)
 	}
-	ipList := sets.New[string]()
+	ipv4List := sets.New[string]()
+	ipv6List := sets.New[string]()
 	for _, ip := range resolvedIPs {
 		if ip.To4() != nil {
-			ipList.Insert(ip.String())
+			ipv4List.Insert(ip.String())
+		} else if ip.To16() != nil && len(ip.To16()) == net.IPv6len {
+			ipv6List.Insert(ip.String())
 		}
 	}
 
-	// If no IPv4 address was found, look for IPv6 addresses.
-	if len(ipList) == 0 {
-		for _, ip := range resolvedIPs {
-			if ip.To16() != nil && len(ip.To16()) == net.IPv6len {
-				ipList.Insert(ip.String())
-			}
+	var ips []string
+	for _, ipList := range []sets.Set[string]{ipv4List, ipv6List} {
+		if ipList.Len() == 0 {
+			continue
 		}
+		// Use the first IP address.
+		familyIPs := sets.List(ipList)
+		if len(familyIPs) > 1 {
+			m.log.Debugw("Lookup returned multiple IP addresses. Picking the first one after sorting", "hostname", hostname, "foundAddresses", familyIPs, "pickedAddress", familyIPs[0])
+		}
+		ips = append(ips, familyIPs[0])
 	}
 
-	ips := sets.List(ipList)
 	if len(ips) == 0 {
-		return "", fmt.Errorf("no ip addresses found for %s: %w", hostname, err)
-	}
-	// Return the first IP address.
-	if len(ips) > 1 {
-		m.log.Debugw("Lookup returned multiple IP addresses. Picking the first one after sorting", "hostname", hostname, "foundAddresses", ips, "pickedAddress", ips[0])
+		return nil, fmt.Errorf("no ip addresses found for %s", hostname)
 	}
-	return ips[0], nil
+	return ips, nil
 }
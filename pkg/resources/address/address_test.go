@@ -20,17 +20,20 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/exposegateway"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 const (
@@ -39,9 +42,11 @@ const (
 	fakeDCName               = "europe-west3-c"
 	fakeExternalURL          = "dev.kubermatic.io"
 	fakeClusterNamespaceName = "cluster-ns"
+	fakeSeedNamespace        = "kubermatic"
 	externalIP               = "34.89.181.151"
 	loadbBalancerHostName    = "xyz.eu-central-1.cloudprovider.test"
 	testDomain               = "dns-test.kubermatic.io"
+	testDualStackDomain      = "dual-stack-test.kubermatic.io"
 	ipv6Address              = "2a01:4f8:1c0c:4b1d::1"
 )
 
@@ -49,6 +54,8 @@ func testLookupFunction(host string) ([]net.IP, error) {
 	switch host {
 	case testDomain:
 		return []net.IP{net.IPv4(192, 168, 1, 1), net.IPv4(192, 168, 1, 2)}, nil
+	case testDualStackDomain:
+		return []net.IP{net.ParseIP("2001:db8::1"), net.IPv4(192, 168, 1, 1), net.ParseIP("2001:db8::2")}, nil
 	case "fake-cluster.europe-west3-c.dev.kubermatic.io":
 		fallthrough
 	case "fake-cluster.alias-europe-west3-c.dev.kubermatic.io":
@@ -62,16 +69,34 @@ func testLookupFunction(host string) ([]net.IP, error) {
 	}
 }
 
-func TestGetExternalIP(t *testing.T) {
-	ip, err := NewModifiersBuilder(kubermaticlog.Logger).
-		lookupFunc(testLookupFunction).
-		getExternalIP(testDomain)
-	if err != nil {
-		t.Fatalf("failed to get the external IP address for %s: %v", testDomain, err)
+func TestGetExternalIPs(t *testing.T) {
+	testCases := []struct {
+		hostname    string
+		expectedIPs []string
+	}{
+		{
+			hostname:    testDomain,
+			expectedIPs: []string{"192.168.1.1"},
+		},
+		{
+			hostname:    testDualStackDomain,
+			expectedIPs: []string{"192.168.1.1", "2001:db8::1"},
+		},
 	}
 
-	if ip != "192.168.1.1" {
-		t.Fatalf("expected to get 192.168.1.1. Got: %s", ip)
+	for _, tc := range testCases {
+		t.Run(tc.hostname, func(t *testing.T) {
+			ips, err := NewModifiersBuilder(kubermaticlog.Logger).
+				lookupFunc(testLookupFunction).
+				getExternalIPs(tc.hostname)
+			if err != nil {
+				t.Fatalf("failed to get the external IP addresses for %s: %v", tc.hostname, err)
+			}
+
+			if !slices.Equal(ips, tc.expectedIPs) {
+				t.Fatalf("expected to get %v. Got: %v", tc.expectedIPs, ips)
+			}
+		})
 	}
 }
 
@@ -82,9 +107,11 @@ func TestSyncClusterAddress(t *testing.T) {
 		apiserverService     corev1.Service
 		frontproxyService    corev1.Service
 		exposeStrategy       kubermaticv1.ExposeStrategy
+		gatewayAddresses     []gatewayapiv1.GatewayStatusAddress
 		seedDNSOverwrite     string
 		expectedExternalName string
 		expectedIP           string
+		expectedIPs          []string
 		expectedPort         int32
 		expectedURL          string
 		errExpected          bool
@@ -229,6 +256,7 @@ func TestSyncClusterAddress(t *testing.T) {
 			exposeStrategy:       kubermaticv1.ExposeStrategyLoadBalancer,
 			expectedExternalName: "3.67.176.129",
 			expectedIP:           "3.67.176.129",
+			expectedIPs:          []string{"3.67.176.129", "2a01::1"},
 			expectedPort:         int32(443),
 			expectedURL:          "https://3.67.176.129:443",
 		},
@@ -271,6 +299,7 @@ func TestSyncClusterAddress(t *testing.T) {
 			exposeStrategy:       kubermaticv1.ExposeStrategyNodePort,
 			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
 			expectedIP:           externalIP,
+			expectedIPs:          []string{externalIP, ipv6Address},
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.%s.%s:32000", fakeClusterName, fakeDCName, fakeExternalURL),
 		},
@@ -291,6 +320,7 @@ func TestSyncClusterAddress(t *testing.T) {
 			seedDNSOverwrite:     "alias-europe-west3-c",
 			expectedExternalName: fmt.Sprintf("%s.alias-europe-west3-c.%s", fakeClusterName, fakeExternalURL),
 			expectedIP:           externalIP,
+			expectedIPs:          []string{externalIP, ipv6Address},
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
@@ -310,6 +340,7 @@ func TestSyncClusterAddress(t *testing.T) {
 			exposeStrategy:       kubermaticv1.ExposeStrategyTunneling,
 			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
 			expectedIP:           externalIP,
+			expectedIPs:          []string{externalIP, ipv6Address},
 			expectedPort:         int32(6443),
 			expectedURL:          fmt.Sprintf("https://%s.%s.%s:6443", fakeClusterName, fakeDCName, fakeExternalURL),
 		},
@@ -333,6 +364,50 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(6443),
 			expectedURL:          fmt.Sprintf("https://%s.%s.%s:6443", fakeClusterNameIPv6, fakeDCName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for Gateway expose strategy",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(6443),
+						}},
+				},
+			},
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			gatewayAddresses: []gatewayapiv1.GatewayStatusAddress{
+				{Type: ptr.To(gatewayapiv1.IPAddressType), Value: "1.2.3.4"},
+				{Type: ptr.To(gatewayapiv1.IPAddressType), Value: ipv6Address},
+			},
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           "1.2.3.4",
+			expectedIPs:          []string{"1.2.3.4", ipv6Address},
+			expectedPort:         int32(443),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:443", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
+		{
+			name: "Verify properties for Gateway expose strategy with hostname address",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(6443),
+						}},
+				},
+			},
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			gatewayAddresses: []gatewayapiv1.GatewayStatusAddress{
+				{Type: ptr.To(gatewayapiv1.HostnameAddressType), Value: loadbBalancerHostName},
+			},
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(443),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:443", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
@@ -371,11 +446,21 @@ func TestSyncClusterAddress(t *testing.T) {
 			lbService := &tc.frontproxyService
 			lbService.Name = resources.FrontLoadBalancerServiceName
 			lbService.Namespace = fakeClusterNamespaceName
-			client := fake.NewClientBuilder().WithObjects(apiserverService, lbService).Build()
+			gateway := &gatewayapiv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      exposegateway.GatewayName,
+					Namespace: fakeSeedNamespace,
+				},
+				Status: gatewayapiv1.GatewayStatus{
+					Addresses: tc.gatewayAddresses,
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(apiserverService, lbService, gateway).Build()
 
 			seed := &kubermaticv1.Seed{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: fakeDCName,
+					Name:      fakeDCName,
+					Namespace: fakeSeedNamespace,
 				},
 				Spec: kubermaticv1.SeedSpec{
 					SeedDNSOverwrite: tc.seedDNSOverwrite,
@@ -412,6 +497,14 @@ func TestSyncClusterAddress(t *testing.T) {
 				t.Errorf("Expected IP to be %q but was %q", tc.expectedIP, cluster.Status.Address.IP)
 			}
 
+			expectedIPs := tc.expectedIPs
+			if expectedIPs == nil {
+				expectedIPs = []string{tc.expectedIP}
+			}
+			if !slices.Equal(cluster.Status.Address.IPs, expectedIPs) {
+				t.Errorf("Expected IPs to be %v but were %v", expectedIPs, cluster.Status.Address.IPs)
+			}
+
 			if cluster.Status.Address.Port != tc.expectedPort {
 				t.Errorf("Expected Port to be %d but was %d", tc.expectedPort, cluster.Status.Address.Port)
 			}
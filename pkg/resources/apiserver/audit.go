@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -108,18 +109,44 @@ rules:
 `,
 }
 
+const (
+	// auditLogsMetricsPort is the port the audit-logs sidecar exposes its metrics on if it
+	// forwards the audit events to the seed-level audit log aggregator.
+	auditLogsMetricsPort = 2020
+	// auditLogsMetricsPortName is the name of the audit-logs sidecar's metrics container port.
+	auditLogsMetricsPortName = "audit-metrics"
+)
+
 const fluentBitConfigTemplate = `
-{{- if .Service }}
+{{- if or .Service .Aggregator }}
 [SERVICE]
 {{- range $key, $value := .Service }}
     {{ $key }}      {{ $value }}
 {{- end }}
+{{- with .Aggregator }}
+    HTTP_Server      On
+    HTTP_Listen      0.0.0.0
+    HTTP_Port        {{ .MetricsPort }}
+{{- end }}
 {{- end }}
 
 [INPUT]
     Name    tail
     Path    /var/log/kubernetes/audit/audit.log
     DB      /var/log/kubernetes/audit/fluentbit.db
+{{- if .Aggregator }}
+    Mem_Buf_Limit   5MB
+{{- end }}
+
+{{- with .Aggregator }}
+
+[FILTER]
+    Name      record_modifier
+    Match     *
+    Record    cluster {{ .ClusterName }}
+    Record    project {{ .ProjectID }}
+
+{{- end }}
 
 {{- if .Filters }}
 {{- range $filter := .Filters }}
@@ -131,6 +158,20 @@ const fluentBitConfigTemplate = `
 {{- end }}
 {{- end }}
 
+{{- with .Aggregator }}
+
+[OUTPUT]
+    Name          forward
+    Alias         audit-log-aggregator
+    Match         *
+    Tag           {{ .Tag }}
+    Host          {{ .Host }}
+    Port          {{ .Port }}
+    Shared_Key    {{ .SharedKey }}
+    Retry_Limit   False
+
+{{- end }}
+
 {{- if .Outputs }}
 {{- range $output := .Outputs }}
 [OUTPUT]
@@ -139,7 +180,7 @@ const fluentBitConfigTemplate = `
 {{- end }}
 
 {{- end }}
-{{- else }}
+{{- else if not .Aggregator }}
 [OUTPUT]
     Name    stdout
     Match   *
@@ -147,6 +188,29 @@ const fluentBitConfigTemplate = `
 
 `
 
+type fluentBitConfig struct {
+	*kubermaticv1.AuditSidecarConfiguration
+
+	// Aggregator is set if the audit events are forwarded to the seed-level audit log aggregator.
+	Aggregator *fluentBitAggregatorConfig
+}
+
+type fluentBitAggregatorConfig struct {
+	Tag         string
+	ClusterName string
+	ProjectID   string
+	Host        string
+	Port        int
+	SharedKey   string
+	MetricsPort int
+}
+
+// AuditLogAggregatorTag returns the tag of the audit events forwarded by the audit-logs sidecar of
+// the given cluster, which the seed-level audit log aggregator uses to route the events per project.
+func AuditLogAggregatorTag(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("audit.%s.%s", cluster.Labels[kubermaticv1.ProjectIDLabelKey], cluster.Name)
+}
+
 func AuditConfigMapReconciler(data *resources.TemplateData) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
@@ -182,9 +246,29 @@ func FluentBitSecretReconciler(data *resources.TemplateData) reconciling.NamedSe
 				secret.Data = map[string][]byte{}
 			}
 
-			config := &kubermaticv1.AuditSidecarConfiguration{}
+			config := fluentBitConfig{
+				AuditSidecarConfiguration: &kubermaticv1.AuditSidecarConfiguration{},
+			}
 			if data.Cluster().Spec.AuditLogging.SidecarSettings != nil && data.Cluster().Spec.AuditLogging.SidecarSettings.Config != nil {
-				config = data.Cluster().Spec.AuditLogging.SidecarSettings.Config
+				config.AuditSidecarConfiguration = data.Cluster().Spec.AuditLogging.SidecarSettings.Config
+			}
+
+			if data.IsAuditLogAggregatorEnabled() {
+				// the aggregator only accepts audit events from clients knowing the shared key
+				sharedKeySecret, err := data.GetSeedSecret(resources.AuditLogAggregatorSharedKeySecretName)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get audit log aggregator shared key: %w", err)
+				}
+
+				config.Aggregator = &fluentBitAggregatorConfig{
+					Tag:         AuditLogAggregatorTag(data.Cluster()),
+					ClusterName: data.Cluster().Name,
+					ProjectID:   data.Cluster().Labels[kubermaticv1.ProjectIDLabelKey],
+					Host:        fmt.Sprintf("%s.%s.svc.cluster.local", resources.AuditLogAggregatorName, data.Seed().Namespace),
+					Port:        resources.AuditLogAggregatorForwardPort,
+					SharedKey:   string(sharedKeySecret.Data[resources.AuditLogAggregatorSharedKeySecretKey]),
+					MetricsPort: auditLogsMetricsPort,
+				}
 			}
 
 			t, err := template.New("fluent-bit.conf").Parse(fluentBitConfigTemplate)
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFluentBitSecretReconciler(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "abcd1234",
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: "project1",
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			AuditLogging: &kubermaticv1.AuditLoggingSettings{
+				Enabled: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		seed        *kubermaticv1.Seed
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name:        "aggregator disabled",
+			seed:        &kubermaticv1.Seed{},
+			expected:    []string{"Name    stdout"},
+			notExpected: []string{"forward", "record_modifier"},
+		},
+		{
+			name: "aggregator enabled",
+			seed: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "kubermatic",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					AuditLogging: &kubermaticv1.AuditLogAggregatorSettings{
+						Enabled: true,
+					},
+				},
+			},
+			expected: []string{
+				"Record    cluster abcd1234",
+				"Record    project project1",
+				"Tag           audit.project1.abcd1234",
+				"Host          audit-log-aggregator.kubermatic.svc.cluster.local",
+				"HTTP_Port        2020",
+				"Shared_Key    s3cr3t",
+			},
+			notExpected: []string{"stdout"},
+		},
+	}
+
+	sharedKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.AuditLogAggregatorSharedKeySecretName,
+			Namespace: "kubermatic",
+		},
+		Data: map[string][]byte{
+			resources.AuditLogAggregatorSharedKeySecretKey: []byte("s3cr3t"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := resources.NewTemplateDataBuilder().
+				WithContext(context.Background()).
+				WithClient(fake.NewClientBuilder().WithObjects(sharedKeySecret).Build()).
+				WithCluster(cluster).
+				WithSeed(tc.seed).
+				Build()
+
+			_, reconciler := FluentBitSecretReconciler(data)()
+
+			secret, err := reconciler(&corev1.Secret{})
+			if err != nil {
+				t.Fatalf("Failed to reconcile Secret: %v", err)
+			}
+
+			config := string(secret.Data["fluent-bit.conf"])
+
+			for _, s := range tc.expected {
+				if !strings.Contains(config, s) {
+					t.Errorf("Expected config to contain %q, but it did not:\n%s", s, config)
+				}
+			}
+
+			for _, s := range tc.notExpected {
+				if strings.Contains(config, s) {
+					t.Errorf("Expected config not to contain %q, but it did:\n%s", s, config)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	authenticationConfigurationKey       = "authentication-configuration.yaml"
+	authenticationConfigurationMountPath = "/etc/kubernetes/authentication"
+)
+
+// AuthenticationConfiguration converts the structured authentication configuration of a cluster into
+// the configuration file format of kube-apiserver.
+func AuthenticationConfiguration(authn *kubermaticv1.AuthenticationConfiguration) *apiserverv1beta1.AuthenticationConfiguration {
+	config := &apiserverv1beta1.AuthenticationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1beta1.SchemeGroupVersion.String(),
+			Kind:       "AuthenticationConfiguration",
+		},
+		JWT: []apiserverv1beta1.JWTAuthenticator{},
+	}
+
+	for _, jwt := range authn.JWT {
+		authenticator := apiserverv1beta1.JWTAuthenticator{
+			Issuer: apiserverv1beta1.Issuer{
+				URL:                  jwt.Issuer.URL,
+				CertificateAuthority: jwt.Issuer.CertificateAuthority,
+				Audiences:            jwt.Issuer.Audiences,
+				AudienceMatchPolicy:  apiserverv1beta1.AudienceMatchPolicyType(jwt.Issuer.AudienceMatchPolicy),
+			},
+			ClaimMappings: apiserverv1beta1.ClaimMappings{
+				Username: apiserverv1beta1.PrefixedClaimOrExpression{
+					Claim:      jwt.ClaimMappings.Username.Claim,
+					Prefix:     jwt.ClaimMappings.Username.Prefix,
+					Expression: jwt.ClaimMappings.Username.Expression,
+				},
+				Groups: apiserverv1beta1.PrefixedClaimOrExpression{
+					Claim:      jwt.ClaimMappings.Groups.Claim,
+					Prefix:     jwt.ClaimMappings.Groups.Prefix,
+					Expression: jwt.ClaimMappings.Groups.Expression,
+				},
+				UID: apiserverv1beta1.ClaimOrExpression{
+					Claim:      jwt.ClaimMappings.UID.Claim,
+					Expression: jwt.ClaimMappings.UID.Expression,
+				},
+			},
+		}
+
+		if jwt.Issuer.DiscoveryURL != "" {
+			authenticator.Issuer.DiscoveryURL = ptr.To(jwt.Issuer.DiscoveryURL)
+		}
+
+		for _, extra := range jwt.ClaimMappings.Extra {
+			authenticator.ClaimMappings.Extra = append(authenticator.ClaimMappings.Extra, apiserverv1beta1.ExtraMapping{
+				Key:             extra.Key,
+				ValueExpression: extra.ValueExpression,
+			})
+		}
+
+		for _, rule := range jwt.ClaimValidationRules {
+			authenticator.ClaimValidationRules = append(authenticator.ClaimValidationRules, apiserverv1beta1.ClaimValidationRule{
+				Claim:         rule.Claim,
+				RequiredValue: rule.RequiredValue,
+				Expression:    rule.Expression,
+				Message:       rule.Message,
+			})
+		}
+
+		for _, rule := range jwt.UserValidationRules {
+			authenticator.UserValidationRules = append(authenticator.UserValidationRules, apiserverv1beta1.UserValidationRule{
+				Expression: rule.Expression,
+				Message:    rule.Message,
+			})
+		}
+
+		config.JWT = append(config.JWT, authenticator)
+	}
+
+	return config
+}
+
+// AuthenticationConfigurationReconciler returns a ConfigMap containing the AuthenticationConfiguration file
+// for kube-apiserver. If OIDC authentication is enabled for all clusters in the seed, the KKP issuer is
+// added to the configured issuers, as the `--oidc-*` flags cannot be used together with the file.
+// Issuers without a certificate authority are validated using the CA bundle of the cluster.
+func AuthenticationConfigurationReconciler(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedConfigMapReconcilerFactory {
+	return func() (string, reconciling.ConfigMapReconciler) {
+		return resources.AuthenticationConfigurationConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			config := AuthenticationConfiguration(data.Cluster().Spec.AuthenticationConfiguration)
+
+			if enableOIDCAuthentication && !hasIssuer(config, data.OIDCIssuerURL()) {
+				config.JWT = append(config.JWT, apiserverv1beta1.JWTAuthenticator{
+					Issuer: apiserverv1beta1.Issuer{
+						URL:       data.OIDCIssuerURL(),
+						Audiences: []string{data.OIDCIssuerClientID()},
+					},
+					ClaimMappings: apiserverv1beta1.ClaimMappings{
+						Username: apiserverv1beta1.PrefixedClaimOrExpression{
+							Claim:  "email",
+							Prefix: ptr.To(""),
+						},
+						Groups: apiserverv1beta1.PrefixedClaimOrExpression{
+							Claim:  "groups",
+							Prefix: ptr.To("oidc:"),
+						},
+					},
+				})
+			}
+
+			// kube-apiserver would otherwise only trust the system CAs of its image, while the
+			// `--oidc-ca-file` flag has always pointed to the CA bundle of the cluster
+			for i := range config.JWT {
+				if config.JWT[i].Issuer.CertificateAuthority == "" {
+					config.JWT[i].Issuer.CertificateAuthority = data.CABundle().String()
+				}
+			}
+
+			rendered, err := yaml.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+
+			cm.Data = map[string]string{
+				authenticationConfigurationKey: string(rendered),
+			}
+
+			return cm, nil
+		}
+	}
+}
+
+func hasIssuer(config *apiserverv1beta1.AuthenticationConfiguration, issuerURL string) bool {
+	for _, jwt := range config.JWT {
+		if jwt.Issuer.URL == issuerURL {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+
+	corev1 "k8s.io/api/core/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+func TestAuthenticationConfigurationReconcilerAddsKKPIssuer(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+				JWT: []kubermaticv1.JWTAuthenticator{
+					{
+						Issuer: kubermaticv1.JWTIssuer{
+							URL:       "https://gitlab.example.com",
+							Audiences: []string{"kubernetes"},
+						},
+						ClaimMappings: kubermaticv1.JWTClaimMappings{
+							Username: kubermaticv1.JWTPrefixedClaimOrExpression{
+								Claim:  "sub",
+								Prefix: ptr.To("gitlab:"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                     string
+		enableOIDCAuthentication bool
+		expectedIssuers          []string
+	}{
+		{
+			name:            "OIDC authentication disabled",
+			expectedIssuers: []string{"https://gitlab.example.com"},
+		},
+		{
+			name:                     "OIDC authentication enabled",
+			enableOIDCAuthentication: true,
+			expectedIssuers:          []string{"https://gitlab.example.com", "https://dev.kubermatic.io/dex"},
+		},
+	}
+
+	caBundle := certificates.NewFakeCABundle()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := resources.NewTemplateDataBuilder().
+				WithCluster(cluster).
+				WithOIDCIssuerURL("https://dev.kubermatic.io/dex").
+				WithOIDCIssuerClientID("kubermaticIssuer").
+				WithCABundle(caBundle).
+				Build()
+
+			_, reconciler := AuthenticationConfigurationReconciler(data, tc.enableOIDCAuthentication)()
+
+			cm, err := reconciler(&corev1.ConfigMap{})
+			if err != nil {
+				t.Fatalf("Failed to reconcile ConfigMap: %v", err)
+			}
+
+			config := apiserverv1beta1.AuthenticationConfiguration{}
+			if err := yaml.Unmarshal([]byte(cm.Data[authenticationConfigurationKey]), &config); err != nil {
+				t.Fatalf("Failed to parse AuthenticationConfiguration: %v", err)
+			}
+
+			if len(config.JWT) != len(tc.expectedIssuers) {
+				t.Fatalf("Expected %d authenticators, but got %d", len(tc.expectedIssuers), len(config.JWT))
+			}
+
+			for i, issuer := range tc.expectedIssuers {
+				if config.JWT[i].Issuer.URL != issuer {
+					t.Errorf("Expected authenticator %d to use issuer %q, but got %q", i, issuer, config.JWT[i].Issuer.URL)
+				}
+
+				if config.JWT[i].Issuer.CertificateAuthority != caBundle.String() {
+					t.Errorf("Expected authenticator %d to use the CA bundle of the cluster", i)
+				}
+			}
+		})
+	}
+}
@@ -87,6 +87,36 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 			volumes := getVolumes(data.IsKonnectivityEnabled(), enableEncryptionConfiguration, auditLogEnabled)
 			volumeMounts := getVolumeMounts(data.IsKonnectivityEnabled(), enableEncryptionConfiguration)
 
+			if data.Cluster().Spec.AuthenticationConfiguration != nil {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.AuthenticationConfigurationConfigMapName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.AuthenticationConfigurationConfigMapName,
+							},
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.AuthenticationConfigurationConfigMapName,
+					MountPath: authenticationConfigurationMountPath,
+					ReadOnly:  true,
+				})
+			}
+
+			kms, err := getKMSPlugin(data.Cluster(), data.DC())
+			if err != nil {
+				return nil, err
+			}
+			if kms != nil {
+				volumes = append(volumes, getKMSVolumes(kms)...)
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      kmsPluginSocketVolumeName,
+					MountPath: kmsPluginSocketDir,
+				})
+			}
+
 			version := data.Cluster().Status.Versions.Apiserver.Semver()
 
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, map[string]string{
@@ -96,7 +126,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				return nil, err
 			}
 
-			address := data.Cluster().Status.Address
+			port := securePort(data.Cluster())
 
 			// these volumes should not block the autoscaler from evicting the pod
 			safeToEvictVolumes := []string{resources.AuditLogVolumeName, resources.KonnectivityUDS}
@@ -105,7 +135,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 			kubernetes.EnsureAnnotations(&dep.Spec.Template, map[string]string{
 				"prometheus.io/scrape_with_kube_cert":                   "true",
 				"prometheus.io/path":                                    "/metrics",
-				"prometheus.io/port":                                    fmt.Sprint(address.Port),
+				"prometheus.io/port":                                    fmt.Sprint(port),
 				resources.ClusterAutoscalerSafeToEvictVolumesAnnotation: strings.Join(safeToEvictVolumes, ","),
 			})
 
@@ -139,7 +169,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				dnatControllerSidecar, err = vpnsidecar.DnatControllerContainer(
 					data,
 					"dnat-controller",
-					fmt.Sprintf("https://127.0.0.1:%d", address.Port),
+					fmt.Sprintf("https://127.0.0.1:%d", port),
 				)
 				if err != nil {
 					return nil, fmt.Errorf("failed to get dnat-controller sidecar: %w", err)
@@ -164,7 +194,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				Args:    flags,
 				Ports: []corev1.ContainerPort{
 					{
-						ContainerPort: address.Port,
+						ContainerPort: port,
 						Protocol:      corev1.ProtocolTCP,
 					},
 				},
@@ -172,7 +202,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 					ProbeHandler: corev1.ProbeHandler{
 						HTTPGet: &corev1.HTTPGetAction{
 							Path:   "/healthz",
-							Port:   intstr.FromInt(int(address.Port)),
+							Port:   intstr.FromInt(int(port)),
 							Scheme: "HTTPS",
 						},
 					},
@@ -185,7 +215,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 					ProbeHandler: corev1.ProbeHandler{
 						HTTPGet: &corev1.HTTPGetAction{
 							Path:   "/healthz",
-							Port:   intstr.FromInt(int(address.Port)),
+							Port:   intstr.FromInt(int(port)),
 							Scheme: "HTTPS",
 						},
 					},
@@ -224,6 +254,20 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 
 			overrides := resources.GetOverrides(data.Cluster().Spec.ComponentsOverride)
 
+			if kms != nil {
+				sidecar, err := kmsPluginSidecar(data, kms)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get kms-plugin sidecar: %w", err)
+				}
+
+				defResourceRequirements[kmsPluginSidecarName] = defaultKMSPluginResourceRequirements.DeepCopy()
+				if kms.Resources != nil {
+					overrides[kmsPluginSidecarName] = kms.Resources
+				}
+
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *sidecar)
+			}
+
 			if auditLogEnabled {
 				defResourceRequirements[auditLogsSidecarName] = &corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
@@ -240,26 +284,38 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 					overrides[auditLogsSidecarName] = data.Cluster().Spec.AuditLogging.SidecarSettings.Resources
 				}
 
-				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers,
-					corev1.Container{
-						Name:    auditLogsSidecarName,
-						Image:   registry.Must(data.RewriteImage(resources.RegistryDocker + "/fluent/fluent-bit:1.9.5")),
-						Command: []string{"/fluent-bit/bin/fluent-bit"},
-						Args:    []string{"-c", "/etc/fluent-bit/fluent-bit.conf"},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      resources.AuditLogVolumeName,
-								MountPath: "/var/log/kubernetes/audit",
-								ReadOnly:  false,
-							},
-							{
-								Name:      resources.FluentBitSecretName,
-								MountPath: "/etc/fluent-bit/",
-								ReadOnly:  true,
-							},
+				auditLogsSidecar := corev1.Container{
+					Name:    auditLogsSidecarName,
+					Image:   registry.Must(data.RewriteImage(resources.RegistryDocker + "/fluent/fluent-bit:1.9.5")),
+					Command: []string{"/fluent-bit/bin/fluent-bit"},
+					Args:    []string{"-c", "/etc/fluent-bit/fluent-bit.conf"},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      resources.AuditLogVolumeName,
+							MountPath: "/var/log/kubernetes/audit",
+							ReadOnly:  false,
+						},
+						{
+							Name:      resources.FluentBitSecretName,
+							MountPath: "/etc/fluent-bit/",
+							ReadOnly:  true,
 						},
 					},
-				)
+				}
+
+				// the sidecar only exposes its metrics when forwarding to the seed-level aggregator,
+				// so that dropped and retried audit events can be tracked per cluster
+				if data.IsAuditLogAggregatorEnabled() {
+					auditLogsSidecar.Ports = []corev1.ContainerPort{
+						{
+							Name:          auditLogsMetricsPortName,
+							ContainerPort: auditLogsMetricsPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					}
+				}
+
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, auditLogsSidecar)
 			}
 
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, overrides, dep.Annotations)
@@ -309,7 +365,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 
 	address := data.Cluster().Status.Address
 
-	serviceAccountKeyFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeySecretKey)
+	// The public key file contains all trusted keys, which allows to rotate
+	// the signing key without invalidating existing tokens.
+	serviceAccountKeyFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeyPublicKey)
+	serviceAccountSigningKeyFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeySecretKey)
 	flags := []string{
 		"--etcd-servers", strings.Join(etcdEndpoints, ","),
 		"--etcd-cafile", "/etc/etcd/pki/client/ca.crt",
@@ -365,8 +424,8 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--advertise-address", address.IP,
 		// The port on which apiserver is serving.
 		// For Nodeport / LoadBalancer expose strategies we use the apiserver-external service NodePort value.
-		// For Tunneling expose strategy we use a fixed port.
-		"--secure-port", fmt.Sprint(address.Port),
+		// For Tunneling and Gateway expose strategies we use a fixed port.
+		"--secure-port", fmt.Sprint(securePort(cluster)),
 	}, flags...)
 
 	if auditLogEnabled {
@@ -398,7 +457,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 
 	flags = append(flags,
 		"--service-account-issuer", issuer,
-		"--service-account-signing-key-file", serviceAccountKeyFile,
+		"--service-account-signing-key-file", serviceAccountSigningKeyFile,
 		"--api-audiences", strings.Join(audiences, ","),
 	)
 
@@ -411,7 +470,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	}
 
 	oidcSettings := cluster.Spec.OIDC
-	if oidcSettings.IssuerURL != "" && oidcSettings.ClientID != "" {
+	if cluster.Spec.AuthenticationConfiguration != nil {
+		// the KKP issuer is part of the configuration file if OIDC authentication is enabled
+		flags = append(flags, "--authentication-config", filepath.Join(authenticationConfigurationMountPath, authenticationConfigurationKey))
+	} else if oidcSettings.IssuerURL != "" && oidcSettings.ClientID != "" {
 		flags = append(flags,
 			"--oidc-ca-file", fmt.Sprintf("/etc/kubernetes/pki/ca-bundle/%s", resources.CABundleConfigMapKey),
 			"--oidc-issuer-url", oidcSettings.IssuerURL,
@@ -815,3 +877,14 @@ func GetEnvVars(data kubeAPIServerEnvData) ([]corev1.EnvVar, error) {
 func intPtr(n int32) *int32 {
 	return &n
 }
+
+// securePort returns the port on which the apiserver is serving. This is the port of the
+// cluster address, except for the Gateway expose strategy, where the address points to the
+// Gateway's TLS listener instead.
+func securePort(cluster *kubermaticv1.Cluster) int32 {
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		return resources.APIServerSecurePort
+	}
+
+	return cluster.Status.Address.Port
+}
@@ -47,6 +47,12 @@ func EncryptionResourcesForDeletion(namespace string) []ctrlruntimeclient.Object
 				Namespace: namespace,
 			},
 		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resources.KMSPluginConfigSecretName,
+				Namespace: namespace,
+			},
+		},
 	}
 }
 
@@ -91,9 +97,8 @@ func EncryptionConfigurationSecretReconciler(data encryptionData) reconciling.Na
 				if data.Cluster().Spec.EncryptionConfiguration.Secretbox != nil {
 					var existingKeys, secretboxKeys []apiserverconfigv1.Key
 
-					if len(existingConfig.Resources) == 1 && len(existingConfig.Resources[0].Providers) == 2 &&
-						existingConfig.Resources[0].Providers[0].Secretbox != nil {
-						existingKeys = existingConfig.Resources[0].Providers[0].Secretbox.Keys
+					if provider := getProvider(existingConfig, isSecretbox); provider != nil {
+						existingKeys = provider.Secretbox.Keys
 					}
 
 					for _, key := range data.Cluster().Spec.EncryptionConfiguration.Secretbox.Keys {
@@ -130,6 +135,33 @@ func EncryptionConfigurationSecretReconciler(data encryptionData) reconciling.Na
 					})
 				}
 
+				if kms := data.Cluster().Spec.EncryptionConfiguration.KMS; kms != nil {
+					timeout := kms.Timeout
+					if timeout == nil {
+						timeout = &metav1.Duration{Duration: defaultKMSTimeout}
+					}
+
+					providerList = append(providerList, apiserverconfigv1.ProviderConfiguration{
+						KMS: &apiserverconfigv1.KMSConfiguration{
+							APIVersion: "v2",
+							Name:       kms.Name,
+							Endpoint:   "unix://" + kmsPluginSocketPath,
+							Timeout:    timeout,
+						},
+					})
+				}
+
+				// Providers that have been replaced in the ClusterSpec are kept for reading until all resources
+				// have been re-encrypted with the new primary provider, otherwise kube-apiserver would not be
+				// able to read them anymore.
+				if len(providerList) > 0 && !isReencrypted(data.Cluster(), providerList[0]) {
+					for _, provider := range getProviders(existingConfig) {
+						if isReplaced(provider, providerList) {
+							providerList = append(providerList, provider)
+						}
+					}
+				}
+
 				// always append the "unencrypted" provider.
 				providerList = append(providerList, apiserverconfigv1.ProviderConfiguration{
 					Identity: &apiserverconfigv1.IdentityConfiguration{},
@@ -206,3 +238,58 @@ func getKeyByName(keys []apiserverconfigv1.Key, name string) *apiserverconfigv1.
 
 	return nil
 }
+
+func getProviders(config apiserverconfigv1.EncryptionConfiguration) []apiserverconfigv1.ProviderConfiguration {
+	if len(config.Resources) != 1 {
+		return nil
+	}
+
+	return config.Resources[0].Providers
+}
+
+func getProvider(config apiserverconfigv1.EncryptionConfiguration, match func(apiserverconfigv1.ProviderConfiguration) bool) *apiserverconfigv1.ProviderConfiguration {
+	for _, provider := range getProviders(config) {
+		if match(provider) {
+			return &provider
+		}
+	}
+
+	return nil
+}
+
+func isSecretbox(provider apiserverconfigv1.ProviderConfiguration) bool {
+	return provider.Secretbox != nil
+}
+
+// isReplaced returns true if an existing provider is not part of the given provider list anymore.
+// Secretbox keys are managed through the ClusterSpec, so a Secretbox provider only counts as replaced
+// if no Secretbox provider is configured at all.
+func isReplaced(existing apiserverconfigv1.ProviderConfiguration, providers []apiserverconfigv1.ProviderConfiguration) bool {
+	switch {
+	case existing.Secretbox != nil:
+		for _, provider := range providers {
+			if provider.Secretbox != nil {
+				return false
+			}
+		}
+
+	case existing.KMS != nil:
+		for _, provider := range providers {
+			if provider.KMS != nil && provider.KMS.Name == existing.KMS.Name {
+				return false
+			}
+		}
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// isReencrypted returns true if all resources have been encrypted with the given primary provider.
+func isReencrypted(cluster *kubermaticv1.Cluster, primary apiserverconfigv1.ProviderConfiguration) bool {
+	status := cluster.Status.Encryption
+
+	return status != nil && status.Phase == kubermaticv1.ClusterEncryptionPhaseActive && status.ActiveKey == encryptionresources.KeyHint(primary)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	encryptionresources "k8c.io/kubermatic/v2/pkg/resources/encryption"
+
+	corev1 "k8s.io/api/core/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeEncryptionData struct {
+	cluster *kubermaticv1.Cluster
+}
+
+func (d *fakeEncryptionData) Cluster() *kubermaticv1.Cluster {
+	return d.cluster
+}
+
+func (d *fakeEncryptionData) GetSecretKeyValue(_ *corev1.SecretKeySelector) ([]byte, error) {
+	return nil, nil
+}
+
+func TestEncryptionConfigurationMigrationToKMS(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Features: map[string]bool{
+				kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+			},
+			EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+				Enabled:   true,
+				Resources: []string{"secrets"},
+				Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{
+					Keys: []kubermaticv1.SecretboxKey{
+						{
+							Name:  "old-key",
+							Value: "RGolflgAc+eBbm1lys87pTNQZVf0i67rlpPZGtTkVjQ=",
+						},
+					},
+				},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			Conditions: map[kubermaticv1.ClusterConditionType]kubermaticv1.ClusterCondition{
+				kubermaticv1.ClusterConditionEncryptionInitialized: {
+					Status: corev1.ConditionTrue,
+				},
+			},
+			Encryption: &kubermaticv1.ClusterEncryptionStatus{
+				ActiveKey:          "secretbox/old-key",
+				EncryptedResources: []string{"secrets"},
+				Phase:              kubermaticv1.ClusterEncryptionPhaseActive,
+			},
+		},
+	}
+
+	secret := reconcileEncryptionSecret(t, cluster, &corev1.Secret{})
+	assertKeyHints(t, secret, "secretbox/old-key", encryptionresources.IdentityKey)
+
+	// switch to a KMS plugin; the Secretbox key must be kept until all data has been re-encrypted
+	cluster.Spec.EncryptionConfiguration.Secretbox = nil
+	cluster.Spec.EncryptionConfiguration.KMS = &kubermaticv1.KMSEncryptionConfiguration{
+		Name:   "vault",
+		Plugin: "vault",
+	}
+	cluster.Status.Encryption.Phase = kubermaticv1.ClusterEncryptionPhasePending
+
+	secret = reconcileEncryptionSecret(t, cluster, secret)
+	assertKeyHints(t, secret, "kms/vault", "secretbox/old-key", encryptionresources.IdentityKey)
+
+	// reconciling again before the re-encryption has finished must not drop the Secretbox key
+	secret = reconcileEncryptionSecret(t, cluster, secret)
+	assertKeyHints(t, secret, "kms/vault", "secretbox/old-key", encryptionresources.IdentityKey)
+
+	cluster.Status.Encryption.ActiveKey = "kms/vault"
+	cluster.Status.Encryption.Phase = kubermaticv1.ClusterEncryptionPhaseActive
+
+	secret = reconcileEncryptionSecret(t, cluster, secret)
+	assertKeyHints(t, secret, "kms/vault", encryptionresources.IdentityKey)
+}
+
+func reconcileEncryptionSecret(t *testing.T, cluster *kubermaticv1.Cluster, secret *corev1.Secret) *corev1.Secret {
+	t.Helper()
+
+	_, reconciler := EncryptionConfigurationSecretReconciler(&fakeEncryptionData{cluster: cluster})()
+
+	secret, err := reconciler(secret.DeepCopy())
+	if err != nil {
+		t.Fatalf("Failed to reconcile Secret: %v", err)
+	}
+
+	return secret
+}
+
+func assertKeyHints(t *testing.T, secret *corev1.Secret, expected ...string) {
+	t.Helper()
+
+	config := apiserverconfigv1.EncryptionConfiguration{}
+	if err := yaml.Unmarshal(secret.Data[resources.EncryptionConfigurationKeyName], &config); err != nil {
+		t.Fatalf("Failed to parse EncryptionConfiguration: %v", err)
+	}
+
+	if len(config.Resources) != 1 {
+		t.Fatalf("Expected exactly one resource configuration, but got %d", len(config.Resources))
+	}
+
+	var hints []string
+	for _, provider := range config.Resources[0].Providers {
+		hints = append(hints, encryptionresources.KeyHint(provider))
+	}
+
+	if len(hints) != len(expected) {
+		t.Fatalf("Expected providers %v, but got %v", expected, hints)
+	}
+
+	for i := range expected {
+		if hints[i] != expected[i] {
+			t.Fatalf("Expected providers %v, but got %v", expected, hints)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	kmsPluginSidecarName      = "kms-plugin"
+	kmsPluginSocketVolumeName = "kms-plugin-socket"
+	kmsPluginConfigVolumeName = "kms-plugin-config"
+	kmsPluginSocketDir        = "/var/run/kms-plugin"
+	kmsPluginConfigDir        = "/etc/kms-plugin"
+
+	defaultKMSTimeout = 3 * time.Second
+)
+
+var (
+	kmsPluginSocketPath = filepath.Join(kmsPluginSocketDir, "socket.sock")
+
+	defaultKMSPluginResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+	}
+)
+
+// getKMSPlugin returns the KMS plugin that needs to run next to kube-apiserver, i.e. as long as
+// encryption is enabled or data might still be encrypted with it. The plugin definition is taken
+// from the datacenter, the cluster only selects it by name.
+func getKMSPlugin(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter) (*kubermaticv1.KMSPlugin, error) {
+	if !(cluster.IsEncryptionEnabled() || cluster.IsEncryptionActive()) || cluster.Spec.EncryptionConfiguration == nil {
+		return nil, nil
+	}
+
+	kms := cluster.Spec.EncryptionConfiguration.KMS
+	if kms == nil {
+		return nil, nil
+	}
+
+	plugin := dc.Spec.GetKMSPlugin(kms.Plugin)
+	if plugin == nil {
+		return nil, fmt.Errorf("KMS plugin %q is not configured in the datacenter", kms.Plugin)
+	}
+
+	return plugin, nil
+}
+
+func kmsPluginSidecar(data *resources.TemplateData, plugin *kubermaticv1.KMSPlugin) (*corev1.Container, error) {
+	image, err := data.RewriteImage(plugin.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      kmsPluginSocketVolumeName,
+			MountPath: kmsPluginSocketDir,
+		},
+	}
+
+	if plugin.ConfigSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      kmsPluginConfigVolumeName,
+			MountPath: kmsPluginConfigDir,
+			ReadOnly:  true,
+		})
+	}
+
+	env := append([]corev1.EnvVar{
+		{
+			Name:  "KMS_PLUGIN_SOCKET",
+			Value: kmsPluginSocketPath,
+		},
+	}, plugin.Env...)
+
+	return &corev1.Container{
+		Name:         kmsPluginSidecarName,
+		Image:        image,
+		Command:      plugin.Command,
+		Args:         plugin.Args,
+		Env:          env,
+		VolumeMounts: volumeMounts,
+	}, nil
+}
+
+func getKMSVolumes(plugin *kubermaticv1.KMSPlugin) []corev1.Volume {
+	vs := []corev1.Volume{
+		{
+			Name: kmsPluginSocketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	if plugin.ConfigSecretRef != nil {
+		vs = append(vs, corev1.Volume{
+			Name: kmsPluginConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.KMSPluginConfigSecretName,
+				},
+			},
+		})
+	}
+
+	return vs
+}
+
+type kmsPluginConfigData interface {
+	Cluster() *kubermaticv1.Cluster
+	DC() *kubermaticv1.Datacenter
+	GetSeedSecret(name string) (*corev1.Secret, error)
+}
+
+// KMSPluginConfigSecretReconciler copies the configuration Secret of the KMS plugin from the
+// namespace of the Seed into the cluster namespace, so that it can be mounted into the plugin.
+func KMSPluginConfigSecretReconciler(data kmsPluginConfigData) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.KMSPluginConfigSecretName, func(secret *corev1.Secret) (*corev1.Secret, error) {
+			plugin, err := getKMSPlugin(data.Cluster(), data.DC())
+			if err != nil {
+				return nil, err
+			}
+
+			if plugin == nil || plugin.ConfigSecretRef == nil {
+				secret.Data = nil
+				return secret, nil
+			}
+
+			source, err := data.GetSeedSecret(plugin.ConfigSecretRef.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get KMS plugin config secret: %w", err)
+			}
+
+			secret.Data = source.Data
+
+			return secret, nil
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeKMSPluginConfigData struct {
+	cluster *kubermaticv1.Cluster
+	dc      *kubermaticv1.Datacenter
+	secrets map[string]*corev1.Secret
+}
+
+func (d *fakeKMSPluginConfigData) Cluster() *kubermaticv1.Cluster {
+	return d.cluster
+}
+
+func (d *fakeKMSPluginConfigData) DC() *kubermaticv1.Datacenter {
+	return d.dc
+}
+
+func (d *fakeKMSPluginConfigData) GetSeedSecret(name string) (*corev1.Secret, error) {
+	secret, ok := d.secrets[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+
+	return secret, nil
+}
+
+func TestKMSPluginConfigSecretReconciler(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Features: map[string]bool{
+				kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+			},
+			EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+				Enabled: true,
+				KMS: &kubermaticv1.KMSEncryptionConfiguration{
+					Name:   "vault",
+					Plugin: "vault",
+				},
+			},
+		},
+	}
+
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			KMSPlugins: []kubermaticv1.KMSPlugin{
+				{
+					Name:            "vault",
+					Image:           "example.com/vault-kms-plugin:v1",
+					ConfigSecretRef: &corev1.LocalObjectReference{Name: "vault-kms-plugin"},
+				},
+			},
+		},
+	}
+
+	data := &fakeKMSPluginConfigData{
+		cluster: cluster,
+		dc:      dc,
+		secrets: map[string]*corev1.Secret{
+			"vault-kms-plugin": {
+				Data: map[string][]byte{"config.yaml": []byte("token: secret")},
+			},
+		},
+	}
+
+	_, reconciler := KMSPluginConfigSecretReconciler(data)()
+
+	secret, err := reconciler(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile Secret: %v", err)
+	}
+
+	if !diff.SemanticallyEqual(data.secrets["vault-kms-plugin"].Data, secret.Data) {
+		t.Errorf("Secret data differs:\n%v", diff.ObjectDiff(data.secrets["vault-kms-plugin"].Data, secret.Data))
+	}
+
+	// selecting a plugin that is not configured by the administrator must fail
+	cluster.Spec.EncryptionConfiguration.KMS.Plugin = "unknown"
+
+	if _, err := reconciler(&corev1.Secret{}); err == nil {
+		t.Error("Expected reconciling to fail for an unknown KMS plugin")
+	}
+}
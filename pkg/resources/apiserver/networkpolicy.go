@@ -388,6 +388,54 @@ func SeedApiServerAllowReconciler(endpoints []net.IP) reconciling.NamedNetworkPo
 	}
 }
 
+// AuditLogAggregatorAllowReconciler returns a func to create/update the apiserver egress policy that allows
+// the audit-logs sidecar to forward audit events to the seed-level audit log aggregator in the given namespace.
+func AuditLogAggregatorAllowReconciler(namespace string) reconciling.NamedNetworkPolicyReconcilerFactory {
+	return func() (string, reconciling.NetworkPolicyReconciler) {
+		return resources.NetworkPolicyAuditLogAggregatorAllow, func(np *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+			port := intstr.FromInt(resources.AuditLogAggregatorForwardPort)
+			protocol := corev1.ProtocolTCP
+
+			np.Spec = networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeEgress,
+				},
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						resources.AppLabelKey: name,
+					},
+				},
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{
+						To: []networkingv1.NetworkPolicyPeer{
+							{
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										corev1.LabelMetadataName: namespace,
+									},
+								},
+								PodSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										resources.AppLabelKey: resources.AuditLogAggregatorName,
+									},
+								},
+							},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{
+							{
+								Protocol: &protocol,
+								Port:     &port,
+							},
+						},
+					},
+				},
+			}
+
+			return np, nil
+		}
+	}
+}
+
 func ipListToPeers(ips []net.IP) []networkingv1.NetworkPolicyPeer {
 	result := []networkingv1.NetworkPolicyPeer{}
 
@@ -21,8 +21,11 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
+	"fmt"
 
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,33 +35,82 @@ import (
 func ServiceAccountKeyReconciler() reconciling.NamedSecretReconcilerFactory {
 	return func() (string, reconciling.SecretReconciler) {
 		return resources.ServiceAccountKeySecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
-			if _, exists := se.Data[resources.ServiceAccountKeySecretKey]; exists {
-				return se, nil
-			}
-			priv, err := rsa.GenerateKey(cryptorand.Reader, 2048)
-			if err != nil {
-				return nil, err
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
 			}
-			saKey := x509.MarshalPKCS1PrivateKey(priv)
-			privKeyBlock := pem.Block{
-				Type:  "RSA PRIVATE KEY",
-				Bytes: saKey,
+
+			if privateKey, exists := se.Data[resources.ServiceAccountKeySecretKey]; exists {
+				// Secrets created by older versions might lack the public key.
+				if _, exists := se.Data[resources.ServiceAccountKeyPublicKey]; !exists {
+					publicKey, err := ServiceAccountPublicKeyPEM(privateKey)
+					if err != nil {
+						return nil, err
+					}
+					se.Data[resources.ServiceAccountKeyPublicKey] = publicKey
+				}
+
+				return se, nil
 			}
-			publicKeyDer, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+
+			privateKey, publicKey, err := GenerateServiceAccountKey()
 			if err != nil {
 				return nil, err
 			}
-			publicKeyBlock := pem.Block{
-				Type:    "PUBLIC KEY",
-				Headers: nil,
-				Bytes:   publicKeyDer,
-			}
-			if se.Data == nil {
-				se.Data = map[string][]byte{}
-			}
-			se.Data[resources.ServiceAccountKeySecretKey] = pem.EncodeToMemory(&privKeyBlock)
-			se.Data[resources.ServiceAccountKeyPublicKey] = pem.EncodeToMemory(&publicKeyBlock)
+
+			se.Data[resources.ServiceAccountKeySecretKey] = privateKey
+			se.Data[resources.ServiceAccountKeyPublicKey] = publicKey
 			return se, nil
 		}
 	}
 }
+
+// GenerateServiceAccountKey creates a new RSA key to sign ServiceAccount tokens
+// and returns the PEM-encoded private and public key.
+func GenerateServiceAccountKey() ([]byte, []byte, error) {
+	priv, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privKeyBlock := pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+	}
+
+	publicKey, err := encodePublicKeyPEM(&priv.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&privKeyBlock), publicKey, nil
+}
+
+// ServiceAccountPublicKeyPEM returns the PEM-encoded public key for the given
+// PEM-encoded ServiceAccount signing key.
+func ServiceAccountPublicKeyPEM(privateKeyPEM []byte) ([]byte, error) {
+	key, err := triple.ParsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("service account key is not a RSA key")
+	}
+
+	return encodePublicKeyPEM(&rsaKey.PublicKey)
+}
+
+func encodePublicKeyPEM(key *rsa.PublicKey) ([]byte, error) {
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyBlock := pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyDer,
+	}
+
+	return pem.EncodeToMemory(&publicKeyBlock), nil
+}
@@ -59,6 +59,11 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL s
 				// We map the secure port to the internal name for SNI routing.
 				se.Annotations[nodeportproxy.PortHostMappingAnnotationKey] = fmt.Sprintf(`{"secure": %q}`, externalURL)
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				// The apiserver is routed by its SNI hostname via a TLSRoute on the shared Gateway.
+				se.Spec.Type = corev1.ServiceTypeClusterIP
+				delete(se.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
@@ -81,7 +86,7 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL s
 			se.Spec.Ports[0].Name = "secure"
 			se.Spec.Ports[0].Protocol = corev1.ProtocolTCP
 			se.Spec.Ports[0].Port = 443
-			if exposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+			if exposeStrategy == kubermaticv1.ExposeStrategyTunneling || exposeStrategy == kubermaticv1.ExposeStrategyGateway {
 				se.Spec.Ports[0].TargetPort = intstr.FromInt(resources.APIServerSecurePort)
 				se.Spec.Ports[0].NodePort = 0 // allows switching from other expose strategies
 			} else {
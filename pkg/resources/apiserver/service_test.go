@@ -128,6 +128,26 @@ func TestServiceReconcilerSetsPort(t *testing.T) {
 			expectedPort:       int32(443),
 			expectedTargetPort: intstr.FromInt(6443),
 		},
+		{
+			name:           "With gateway strategy KAS uses 6443 as secure port",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			inService: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "my-fancy-port",
+							Port:       int32(8080),
+							TargetPort: intstr.FromInt(8080),
+							Protocol:   corev1.ProtocolUDP,
+							NodePort:   int32(32000),
+						},
+					},
+				},
+			},
+			expectedPort:       int32(443),
+			expectedTargetPort: intstr.FromInt(6443),
+		},
 	}
 
 	for _, tc := range testCases {
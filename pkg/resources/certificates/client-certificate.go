@@ -42,6 +42,10 @@ func GetClientCertificateReconciler(name, commonName string, organizations []str
 				se.Data = map[string][]byte{}
 			}
 
+			// Include the CA for simplicity; during a CA rotation this contains
+			// all currently trusted CAs.
+			se.Data[resources.CACertSecretKey] = ca.TrustBundlePEM()
+
 			if b, exists := se.Data[dataCertKey]; exists {
 				certs, err := certutil.ParseCertsPEM(b)
 				if err != nil {
@@ -60,8 +64,6 @@ func GetClientCertificateReconciler(name, commonName string, organizations []str
 
 			se.Data[dataKeyKey] = triple.EncodePrivateKeyPEM(newKP.Key)
 			se.Data[dataCertKey] = triple.EncodeCertPEM(newKP.Cert)
-			// Include the CA for simplicity
-			se.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(ca.Cert)
 
 			return se, nil
 		}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto/x509"
+	"sort"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// SecretCertificate is a certificate stored in a Secret.
+type SecretCertificate struct {
+	// Key is the key in the Secret's data that contains the certificate.
+	Key  string
+	Cert *x509.Certificate
+}
+
+// SecretCertificates returns the certificates stored in the given Secret, sorted by key. For
+// PEM-encoded values, only the first certificate is returned, as further certificates are
+// usually the CA bundle. For kubeconfigs, the client certificates are returned.
+func SecretCertificates(secret *corev1.Secret) []SecretCertificate {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []SecretCertificate
+	for _, key := range keys {
+		var certPEMs [][]byte
+
+		if key == resources.KubeconfigSecretKey {
+			kubeconfig, err := clientcmd.Load(secret.Data[key])
+			if err != nil {
+				continue
+			}
+
+			for _, authInfo := range kubeconfig.AuthInfos {
+				if len(authInfo.ClientCertificateData) > 0 {
+					certPEMs = append(certPEMs, authInfo.ClientCertificateData)
+				}
+			}
+		} else {
+			certPEMs = append(certPEMs, secret.Data[key])
+		}
+
+		for _, certPEM := range certPEMs {
+			certs, err := triple.ParseCertsPEM(certPEM)
+			if err != nil {
+				continue
+			}
+
+			result = append(result, SecretCertificate{Key: key, Cert: certs[0]})
+		}
+	}
+
+	return result
+}
+
+// NearestExpiry returns the certificate that expires next from all given Secrets, or nil if
+// none of the Secrets contains a certificate.
+func NearestExpiry(secrets []corev1.Secret) (*corev1.Secret, *SecretCertificate) {
+	var (
+		nearestSecret *corev1.Secret
+		nearest       *SecretCertificate
+	)
+
+	for i := range secrets {
+		for _, cert := range SecretCertificates(&secrets[i]) {
+			if nearest == nil || cert.Cert.NotAfter.Before(nearest.Cert.NotAfter) {
+				nearestSecret = &secrets[i]
+				nearest = &cert
+			}
+		}
+	}
+
+	return nearestSecret, nearest
+}
@@ -49,6 +49,19 @@ const (
 type KeyPair struct {
 	Key  *rsa.PrivateKey
 	Cert *x509.Certificate
+	// Bundle contains further CA certificates which are trusted next to Cert,
+	// e.g. the previous or next CA during a CA rotation.
+	Bundle []*x509.Certificate
+}
+
+// TrustBundlePEM returns the PEM-encoded certificate of the key pair,
+// followed by all certificates of its bundle.
+func (kp *KeyPair) TrustBundlePEM() []byte {
+	certPEM := EncodeCertPEM(kp.Cert)
+	for _, cert := range kp.Bundle {
+		certPEM = append(certPEM, EncodeCertPEM(cert)...)
+	}
+	return certPEM
 }
 
 func NewCA(name string) (*KeyPair, error) {
@@ -323,6 +323,14 @@ func (d *TemplateData) IsKonnectivityEnabled() bool {
 	return d.isKonnectivityEnabled
 }
 
+// IsAuditLogAggregatorEnabled returns true if the cluster has audit logging enabled and its
+// audit-logs sidecar forwards the audit events to the seed-level audit log aggregator.
+func (d *TemplateData) IsAuditLogAggregatorEnabled() bool {
+	auditLogging := d.cluster.Spec.AuditLogging
+
+	return auditLogging != nil && auditLogging.Enabled && d.seed != nil && d.seed.IsAuditLogAggregatorEnabled()
+}
+
 // NodeAccessNetwork returns the node access network.
 func (d *TemplateData) NodeAccessNetwork() string {
 	return d.nodeAccessNetwork
@@ -475,6 +483,10 @@ func (d *TemplateData) GetKonnectivityServerPort() (int32, error) {
 	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
 		return d.Cluster().Status.Address.Port, nil
 	}
+	// When using gateway expose strategy, Konnectivity is reached via the Gateway's TLS listener
+	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		return ExposeGatewayTLSPort, nil
+	}
 	service := &corev1.Service{}
 	key := types.NamespacedName{Namespace: d.cluster.Status.NamespaceName, Name: KonnectivityProxyServiceName}
 	if err := d.client.Get(d.ctx, key, service); err != nil {
@@ -507,6 +519,10 @@ func (d *TemplateData) GetMLAGatewayPort() (int32, error) {
 	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
 		return d.Cluster().Status.Address.Port, nil
 	}
+	// When using gateway expose strategy, the MLA Gateway is reached via the Gateway's TLS listener
+	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		return ExposeGatewayTLSPort, nil
+	}
 	service := &corev1.Service{}
 	key := types.NamespacedName{Namespace: d.cluster.Status.NamespaceName, Name: MLAGatewayExternalServiceName}
 	if err := d.client.Get(d.ctx, key, service); err != nil {
@@ -567,6 +583,16 @@ func (d *TemplateData) GetSecretKeyValue(ref *corev1.SecretKeySelector) ([]byte,
 	return val, nil
 }
 
+// GetSeedSecret returns the Secret with the given name from the namespace of the Seed.
+func (d *TemplateData) GetSeedSecret(name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := d.client.Get(d.ctx, ctrlruntimeclient.ObjectKey{Name: name, Namespace: d.seed.Namespace}, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
 func (d *TemplateData) GetCloudProviderName() (string, error) {
 	return kubermaticv1helper.ClusterCloudProviderName(d.Cluster().Spec.Cloud)
 }
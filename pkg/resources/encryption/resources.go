@@ -16,10 +16,32 @@ limitations under the License.
 
 package encryption
 
+import (
+	"fmt"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+)
+
 const (
 	ApiserverEncryptionRevisionLabelKey = "apiserver-encryption-configuration-secret-revision"
 	ApiserverEncryptionHashLabelKey     = "kubermatic.k8c.io/encryption-spec-hash"
 
 	SecretboxPrefix = "secretbox"
+	KMSPrefix       = "kms"
 	IdentityKey     = "identity"
 )
+
+// KeyHint returns a key "hint" for the given provider, which identifies the key used for
+// encrypting data without revealing secret data.
+func KeyHint(provider apiserverconfigv1.ProviderConfiguration) string {
+	switch {
+	case provider.Secretbox != nil && len(provider.Secretbox.Keys) > 0:
+		return fmt.Sprintf("%s/%s", SecretboxPrefix, provider.Secretbox.Keys[0].Name)
+	case provider.KMS != nil:
+		return fmt.Sprintf("%s/%s", KMSPrefix, provider.KMS.Name)
+	case provider.Identity != nil:
+		return IdentityKey
+	}
+
+	return ""
+}
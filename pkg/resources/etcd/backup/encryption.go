@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	encryptionKeysVolumeName    = "encryption-keys"
+	encryptionDataKeyVolumeName = "encryption-data-key"
+	encryptionKeysMountPath     = "/etc/backup-encryption/keys"
+	encryptionDataKeyMountPath  = "/etc/backup-encryption/data-key"
+	wrappedDataKeyFileName      = "wrapped-key.json"
+)
+
+// encryptionFlags returns the etcd-launcher flags to encrypt backups with the
+// cluster's data key, or nil if the destination does not use encryption.
+func encryptionFlags(destination *kubermaticv1.BackupDestination) []string {
+	if destination == nil || destination.Encryption == nil {
+		return nil
+	}
+
+	return []string{
+		fmt.Sprintf("--encryption-keys-dir=%s", encryptionKeysMountPath),
+		fmt.Sprintf("--encryption-data-key=%s/%s", encryptionDataKeyMountPath, wrappedDataKeyFileName),
+	}
+}
+
+// encryptionVolumeMounts returns the volume mounts for the volumes returned by encryptionVolumes.
+func encryptionVolumeMounts(destination *kubermaticv1.BackupDestination) []corev1.VolumeMount {
+	if destination == nil || destination.Encryption == nil {
+		return nil
+	}
+
+	return []corev1.VolumeMount{
+		{
+			Name:      encryptionKeysVolumeName,
+			MountPath: encryptionKeysMountPath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      encryptionDataKeyVolumeName,
+			MountPath: encryptionDataKeyMountPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+// encryptionVolumes returns the volumes holding the key encryption keys of the destination
+// and the cluster's data key for it, wrapped by one of these keys.
+func encryptionVolumes(cluster *kubermaticv1.Cluster, destinationName string, destination *kubermaticv1.BackupDestination) []corev1.Volume {
+	if destination == nil || destination.Encryption == nil {
+		return nil
+	}
+
+	return []corev1.Volume{
+		{
+			Name: encryptionKeysVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: destination.Encryption.KeyEncryptionKeys.Name,
+				},
+			},
+		},
+		{
+			Name: encryptionDataKeyVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: backupcrypto.DataKeySecretName(cluster),
+					Items: []corev1.KeyToPath{
+						{
+							Key:  destinationName,
+							Path: wrappedDataKeyFileName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
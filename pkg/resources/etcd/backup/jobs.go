@@ -73,33 +73,31 @@ type etcdBackupData interface {
 	EtcdLauncherImage() string
 	EtcdLauncherTag() string
 	GetClusterRef() metav1.OwnerReference
+	RewriteImage(string) (string, error)
 }
 
 func BackupJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
-	storeContainer := data.EtcdBackupStoreContainer().DeepCopy()
+	chunked := config.GetBackupFormat() == kubermaticv1.EtcdBackupFormatChunked
+	destination := data.EtcdBackupDestination()
 
-	// If destination is set, we need to set the credentials and backup bucket details to match the destination
-	if data.EtcdBackupDestination() != nil {
-		storeContainer.Env = setEnvVar(storeContainer.Env, GenSecretEnvVar(AccessKeyIdEnvVarKey, AccessKeyIdEnvVarKey, data.EtcdBackupDestination()))
-		storeContainer.Env = setEnvVar(storeContainer.Env, GenSecretEnvVar(SecretAccessKeyEnvVarKey, SecretAccessKeyEnvVarKey, data.EtcdBackupDestination()))
-		storeContainer.Env = setEnvVar(storeContainer.Env, corev1.EnvVar{
-			Name:  BucketNameEnvVarKey,
-			Value: data.EtcdBackupDestination().BucketName,
-		})
-		storeContainer.Env = setEnvVar(storeContainer.Env, corev1.EnvVar{
-			Name:  BackupEndpointEnvVarKey,
-			Value: data.EtcdBackupDestination().Endpoint,
+	var storeContainer *corev1.Container
+	if chunked {
+		storeContainer = chunkedBackupContainer(data, "store-container", "store-chunked", status.BackupName)
+		storeContainer.VolumeMounts = append(storeContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      SharedVolumeName,
+			MountPath: "/backup",
 		})
 
-		insecure := "false"
-		if isInsecureURL(data.EtcdBackupDestination().Endpoint) {
-			insecure = "true"
-		}
+		// chunks are encrypted individually while uploading
+		storeContainer.Command = append(storeContainer.Command, encryptionFlags(destination)...)
+		storeContainer.VolumeMounts = append(storeContainer.VolumeMounts, encryptionVolumeMounts(destination)...)
+	} else {
+		storeContainer = data.EtcdBackupStoreContainer().DeepCopy()
+	}
 
-		storeContainer.Env = setEnvVar(storeContainer.Env, corev1.EnvVar{
-			Name:  BackupInsecureEnvVarKey,
-			Value: insecure,
-		})
+	// If destination is set, we need to set the credentials and backup bucket details to match the destination
+	if data.EtcdBackupDestination() != nil {
+		storeContainer.Env = setDestinationEnvVars(storeContainer.Env, data.EtcdBackupDestination())
 	}
 
 	storeContainer.Env = append(
@@ -135,29 +133,36 @@ func BackupJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, statu
 
 	job.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", rbac.EtcdLauncherServiceAccountName, data.Cluster().Name)
 	job.Spec.Template.Spec.Containers = []corev1.Container{*storeContainer}
-	job.Spec.Template.Spec.InitContainers = []corev1.Container{
-		{
-			Name:    "backup-creator",
-			Image:   fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
-			Command: snapshotCommand(data.Cluster()),
-			VolumeMounts: []corev1.VolumeMount{
-				{
-					Name:      SharedVolumeName,
-					MountPath: "/backup",
-				},
-				{
-					Name:      GetEtcdBackupSecretName(data.Cluster()),
-					MountPath: "/etc/etcd/pki/client",
-				},
-				{
-					Name:      "ca-bundle",
-					MountPath: "/etc/ca-bundle/",
-					ReadOnly:  true,
-				},
+
+	backupCreator := corev1.Container{
+		Name:    "backup-creator",
+		Image:   fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+		Command: snapshotCommand(data.Cluster(), chunked),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      SharedVolumeName,
+				MountPath: "/backup",
+			},
+			{
+				Name:      GetEtcdBackupSecretName(data.Cluster()),
+				MountPath: "/etc/etcd/pki/client",
+			},
+			{
+				Name:      "ca-bundle",
+				MountPath: "/etc/ca-bundle/",
+				ReadOnly:  true,
 			},
 		},
 	}
 
+	// regular snapshots are encrypted as a whole before the store container uploads them
+	if !chunked {
+		backupCreator.Command = append(backupCreator.Command, encryptionFlags(destination)...)
+		backupCreator.VolumeMounts = append(backupCreator.VolumeMounts, encryptionVolumeMounts(destination)...)
+	}
+
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{backupCreator}
+
 	job.Spec.Template.Spec.Volumes = []corev1.Volume{
 		{
 			Name: SharedVolumeName,
@@ -185,19 +190,43 @@ func BackupJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, statu
 		},
 	}
 
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, encryptionVolumes(data.Cluster(), config.Spec.Destination, destination)...)
+
 	return job
 }
 
-func snapshotCommand(cluster *kubermaticv1.Cluster) []string {
-	return []string{
+func snapshotCommand(cluster *kubermaticv1.Cluster, chunked bool) []string {
+	command := []string{
 		"/etcd-launcher",
 		"snapshot",
 		"--etcd-ca-file=/etc/etcd/pki/client/ca.crt",
 		"--etcd-client-cert-file=/etc/etcd/pki/client/backup-etcd-client.crt",
 		"--etcd-client-key-file=/etc/etcd/pki/client/backup-etcd-client.key",
 		fmt.Sprintf("--cluster=%s", cluster.Name),
-		"--file=/backup/snapshot.db.gz",
-		"--compress=gzip",
+	}
+
+	// chunks are compressed individually, compressing the whole snapshot
+	// would prevent any deduplication
+	if chunked {
+		return append(command, "--file=/backup/snapshot.db")
+	}
+
+	return append(command, "--file=/backup/snapshot.db.gz", "--compress=gzip")
+}
+
+// chunkedBackupContainer returns a container running the given etcd-launcher command for
+// chunked backups, which replaces the configured store and delete containers.
+func chunkedBackupContainer(data etcdBackupData, name, command, backupName string) *corev1.Container {
+	return &corev1.Container{
+		Name:  name,
+		Image: fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+		Command: []string{
+			"/etcd-launcher",
+			command,
+			fmt.Sprintf("--cluster=%s", data.Cluster().Name),
+			fmt.Sprintf("--backup-name=%s", backupName),
+			"--ca-bundle=/etc/ca-bundle/ca-bundle.pem",
+		},
 	}
 }
 
@@ -212,31 +241,41 @@ func setEnvVar(envVars []corev1.EnvVar, newEnvVar corev1.EnvVar) []corev1.EnvVar
 	return envVars
 }
 
+// setDestinationEnvVars sets the credentials and bucket details of the given destination.
+func setDestinationEnvVars(envVars []corev1.EnvVar, destination *kubermaticv1.BackupDestination) []corev1.EnvVar {
+	envVars = setEnvVar(envVars, GenSecretEnvVar(AccessKeyIdEnvVarKey, AccessKeyIdEnvVarKey, destination))
+	envVars = setEnvVar(envVars, GenSecretEnvVar(SecretAccessKeyEnvVarKey, SecretAccessKeyEnvVarKey, destination))
+	envVars = setEnvVar(envVars, corev1.EnvVar{
+		Name:  BucketNameEnvVarKey,
+		Value: destination.BucketName,
+	})
+	envVars = setEnvVar(envVars, corev1.EnvVar{
+		Name:  BackupEndpointEnvVarKey,
+		Value: destination.Endpoint,
+	})
+
+	insecure := "false"
+	if isInsecureURL(destination.Endpoint) {
+		insecure = "true"
+	}
+
+	return setEnvVar(envVars, corev1.EnvVar{
+		Name:  BackupInsecureEnvVarKey,
+		Value: insecure,
+	})
+}
+
 func BackupDeleteJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
-	deleteContainer := data.EtcdBackupDeleteContainer().DeepCopy()
+	var deleteContainer *corev1.Container
+	if config.GetBackupFormat() == kubermaticv1.EtcdBackupFormatChunked {
+		deleteContainer = chunkedBackupContainer(data, "delete-container", "delete-chunked", status.BackupName)
+	} else {
+		deleteContainer = data.EtcdBackupDeleteContainer().DeepCopy()
+	}
 
 	// If destination is set, we need to set the credentials and backup bucket details to match the destination
 	if data.EtcdBackupDestination() != nil {
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, GenSecretEnvVar(AccessKeyIdEnvVarKey, AccessKeyIdEnvVarKey, data.EtcdBackupDestination()))
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, GenSecretEnvVar(SecretAccessKeyEnvVarKey, SecretAccessKeyEnvVarKey, data.EtcdBackupDestination()))
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, corev1.EnvVar{
-			Name:  BucketNameEnvVarKey,
-			Value: data.EtcdBackupDestination().BucketName,
-		})
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, corev1.EnvVar{
-			Name:  BackupEndpointEnvVarKey,
-			Value: data.EtcdBackupDestination().Endpoint,
-		})
-
-		insecure := "false"
-		if isInsecureURL(data.EtcdBackupDestination().Endpoint) {
-			insecure = "true"
-		}
-
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, corev1.EnvVar{
-			Name:  BackupInsecureEnvVarKey,
-			Value: insecure,
-		})
+		deleteContainer.Env = setDestinationEnvVars(deleteContainer.Env, data.EtcdBackupDestination())
 	}
 
 	deleteContainer.Env = append(
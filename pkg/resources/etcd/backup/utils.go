@@ -58,3 +58,14 @@ func isInsecureURL(u string) bool {
 
 	return strings.ToLower(parsed.Scheme) == "http" && parsed.Host != ""
 }
+
+// WALArchivePrefix returns the object name prefix below which the WAL archiver of the given
+// EtcdBackupConfig stores the segments of the given epoch.
+func WALArchivePrefix(cluster *kubermaticv1.Cluster, backupConfigName string, epoch int64) string {
+	// the first epoch keeps the prefix used before epochs were introduced
+	if epoch == 0 {
+		return fmt.Sprintf("%s-%s-wal/", cluster.Name, backupConfigName)
+	}
+
+	return fmt.Sprintf("%s-%s-wal-%d/", cluster.Name, backupConfigName, epoch)
+}
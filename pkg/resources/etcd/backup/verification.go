@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// VerificationContainerName is the name of the container verifying a backup. Its
+	// termination message contains the BackupVerificationResult.
+	VerificationContainerName = "backup-verifier"
+
+	launcherVolumeName = "launcher"
+)
+
+// BackupVerificationResult is the outcome of a backup verification. The verification job writes
+// it as JSON into its termination message, from where the etcdbackup controller picks it up.
+type BackupVerificationResult struct {
+	// Revision is the revision of the etcd restored from the snapshot.
+	Revision int64 `json:"revision"`
+	// KeyCount is the number of keys in the etcd restored from the snapshot.
+	KeyCount int64 `json:"keyCount"`
+	// SourceKeyCount is the number of keys the cluster's etcd had at the snapshot's revision.
+	// It is nil if that revision has been compacted already and could not be compared.
+	SourceKeyCount *int64 `json:"sourceKeyCount,omitempty"`
+	// Error describes why the verification failed. It is empty for successful verifications.
+	Error string `json:"error,omitempty"`
+}
+
+// BackupVerificationJob returns a job that downloads the given backup, checks the integrity of the
+// snapshot, restores it into a throwaway single-member etcd and compares it with the cluster's etcd.
+// Like the etcd StatefulSet, the job runs the etcd-launcher inside the etcd image, as the launcher
+// needs the etcd binary to start the throwaway etcd.
+func BackupVerificationJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
+	destination := data.EtcdBackupDestination()
+
+	var env []corev1.EnvVar
+	if destination != nil {
+		env = setDestinationEnvVars(env, destination)
+	}
+
+	command := []string{
+		"/opt/bin/etcd-launcher",
+		"verify-backup",
+		"--etcd-ca-file=/etc/etcd/pki/client/ca.crt",
+		"--etcd-client-cert-file=/etc/etcd/pki/client/backup-etcd-client.crt",
+		"--etcd-client-key-file=/etc/etcd/pki/client/backup-etcd-client.key",
+		fmt.Sprintf("--cluster=%s", data.Cluster().Name),
+		fmt.Sprintf("--backup-name=%s", status.BackupName),
+		"--ca-bundle=/etc/ca-bundle/ca-bundle.pem",
+		"--data-dir=/scratch/etcd",
+	}
+
+	launcherInit := corev1.Container{
+		Name:    "etcd-launcher-init",
+		Image:   fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+		Command: []string{"/bin/cp", "/etcd-launcher", "/opt/bin/"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      launcherVolumeName,
+				MountPath: "/opt/bin/",
+			},
+		},
+	}
+
+	verifier := corev1.Container{
+		Name:    VerificationContainerName,
+		Image:   registry.Must(data.RewriteImage(resources.RegistryGCR + "/etcd-development/etcd:" + etcd.ImageTag(data.Cluster()))),
+		Command: append(command, encryptionFlags(destination)...),
+		Env:     env,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		VolumeMounts: append([]corev1.VolumeMount{
+			{
+				Name:      launcherVolumeName,
+				MountPath: "/opt/bin/",
+			},
+			{
+				Name:      SharedVolumeName,
+				MountPath: "/scratch",
+			},
+			{
+				Name:      GetEtcdBackupSecretName(data.Cluster()),
+				MountPath: "/etc/etcd/pki/client",
+				ReadOnly:  true,
+			},
+			{
+				Name:      "ca-bundle",
+				MountPath: "/etc/ca-bundle/",
+				ReadOnly:  true,
+			},
+		}, encryptionVolumeMounts(destination)...),
+	}
+
+	job := jobBase(config, data.Cluster(), status.VerificationJobName)
+
+	// a failed verification is a result, not something to retry
+	job.Spec.BackoffLimit = ptr.To[int32](0)
+	job.Spec.ActiveDeadlineSeconds = resources.Int64(int64(config.GetVerificationTimeout().Seconds()))
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	job.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", rbac.EtcdLauncherServiceAccountName, data.Cluster().Name)
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{launcherInit}
+	job.Spec.Template.Spec.Containers = []corev1.Container{verifier}
+	job.Spec.Template.Spec.Volumes = append([]corev1.Volume{
+		{
+			Name: launcherVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: SharedVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: GetEtcdBackupSecretName(data.Cluster()),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetEtcdBackupSecretName(data.Cluster()),
+				},
+			},
+		},
+		{
+			Name: "ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: caBundleConfigMapName(data.Cluster()),
+					},
+				},
+			},
+		},
+	}, encryptionVolumes(data.Cluster(), config.Spec.Destination, destination)...)
+
+	return job
+}
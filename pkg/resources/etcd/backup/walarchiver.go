@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WALArchiverLabel defines the label we use on all WAL archiver deployments.
+	WALArchiverLabel = "kubermatic-etcd-wal-archiver"
+)
+
+// WALArchiverDeploymentName returns the name of the deployment that archives the etcd WAL for the given EtcdBackupConfig.
+func WALArchiverDeploymentName(cluster *kubermaticv1.Cluster, config *kubermaticv1.EtcdBackupConfig) string {
+	return fmt.Sprintf("%s-%s-wal-archiver", cluster.Name, config.Name)
+}
+
+// WALArchiverDeploymentReconciler returns the reconciler for the deployment that continuously archives
+// the etcd WAL of a user cluster to the EtcdBackupConfig's destination. Like the backup jobs, it runs
+// in kube-system, where the destination credentials are located.
+func WALArchiverDeploymentReconciler(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig) reconciling.NamedDeploymentReconcilerFactory {
+	return func() (string, reconciling.DeploymentReconciler) {
+		return WALArchiverDeploymentName(data.Cluster(), config), func(dep *appsv1.Deployment) (*appsv1.Deployment, error) {
+			labels := map[string]string{
+				resources.AppLabelKey:     WALArchiverLabel,
+				BackupConfigNameLabelKey:  config.Name,
+				resources.ClusterLabelKey: data.Cluster().Name,
+			}
+
+			dep.Labels = labels
+
+			// never run two archivers at the same time, they would upload overlapping segments
+			dep.Spec.Replicas = resources.Int32(1)
+			dep.Spec.Strategy = appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			}
+			dep.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: labels,
+			}
+
+			dep.Spec.Template.Labels = labels
+			dep.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", rbac.EtcdLauncherServiceAccountName, data.Cluster().Name)
+
+			destination := data.EtcdBackupDestination()
+
+			var env []corev1.EnvVar
+			if destination != nil {
+				env = setDestinationEnvVars(env, destination)
+			}
+
+			dep.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:    "wal-archiver",
+					Image:   fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+					Command: append(archiveWALCommand(data.Cluster(), config), encryptionFlags(destination)...),
+					Env:     env,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+						},
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{
+							Name:      GetEtcdBackupSecretName(data.Cluster()),
+							MountPath: "/etc/etcd/pki/client",
+							ReadOnly:  true,
+						},
+						{
+							Name:      "ca-bundle",
+							MountPath: "/etc/ca-bundle/",
+							ReadOnly:  true,
+						},
+					}, encryptionVolumeMounts(destination)...),
+				},
+			}
+
+			dep.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: GetEtcdBackupSecretName(data.Cluster()),
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: GetEtcdBackupSecretName(data.Cluster()),
+						},
+					},
+				},
+				{
+					Name: "ca-bundle",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: caBundleConfigMapName(data.Cluster()),
+							},
+						},
+					},
+				},
+			}
+
+			dep.Spec.Template.Spec.Volumes = append(dep.Spec.Template.Spec.Volumes, encryptionVolumes(data.Cluster(), config.Spec.Destination, destination)...)
+
+			return dep, nil
+		}
+	}
+}
+
+func archiveWALCommand(cluster *kubermaticv1.Cluster, config *kubermaticv1.EtcdBackupConfig) []string {
+	return []string{
+		"/etcd-launcher",
+		"archive-wal",
+		"--etcd-ca-file=/etc/etcd/pki/client/ca.crt",
+		"--etcd-client-cert-file=/etc/etcd/pki/client/backup-etcd-client.crt",
+		"--etcd-client-key-file=/etc/etcd/pki/client/backup-etcd-client.key",
+		fmt.Sprintf("--cluster=%s", cluster.Name),
+		fmt.Sprintf("--backup-config=%s", config.Name),
+		fmt.Sprintf("--segment-interval=%s", config.GetWALSegmentInterval()),
+		fmt.Sprintf("--epoch=%d", config.Status.WALArchiveEpoch),
+		"--ca-bundle=/etc/ca-bundle/ca-bundle.pem",
+	}
+}
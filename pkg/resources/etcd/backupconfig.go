@@ -62,6 +62,7 @@ func BackupConfigReconciler(data etcdBackupConfigReconcilerData, seed *kubermati
 
 			if seed.IsDefaultEtcdAutomaticBackupEnabled() {
 				config.Spec.Destination = seed.Spec.EtcdBackupRestore.DefaultDestination
+				config.Spec.Retention = seed.Spec.EtcdBackupRestore.DefaultRetention.DeepCopy()
 			}
 
 			return config, nil
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exposegateway
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// GatewayName is the name of the Gateway in the seed's KKP namespace that is
+	// shared by all clusters using the Gateway expose strategy.
+	GatewayName = "kubermatic-expose-gateway"
+
+	// TLSListenerName is the name of the Gateway listener that routes TLS
+	// connections by their SNI hostname to the clusters.
+	TLSListenerName = "tls"
+	// TLSListenerPort is the port of the TLS listener.
+	TLSListenerPort = resources.ExposeGatewayTLSPort
+
+	APIServerRouteName    = resources.ApiserverServiceName
+	OpenVPNRouteName      = resources.OpenVPNServerServiceName
+	KonnectivityRouteName = resources.KonnectivityProxyServiceName
+	MLAGatewayRouteName   = resources.MLAGatewayExternalServiceName
+)
+
+// TLSListener returns the listener for all TLSRoutes.
+func TLSListener() gatewayapiv1.Listener {
+	return gatewayapiv1.Listener{
+		Name:     TLSListenerName,
+		Port:     TLSListenerPort,
+		Protocol: gatewayapiv1.TLSProtocolType,
+		TLS: &gatewayapiv1.GatewayTLSConfig{
+			Mode: ptr.To(gatewayapiv1.TLSModePassthrough),
+		},
+		AllowedRoutes: &gatewayapiv1.AllowedRoutes{
+			Namespaces: &gatewayapiv1.RouteNamespaces{
+				From: ptr.To(gatewayapiv1.NamespacesFromAll),
+			},
+			Kinds: []gatewayapiv1.RouteGroupKind{{
+				Group: ptr.To(gatewayapiv1.Group(gatewayapiv1.GroupName)),
+				Kind:  "TLSRoute",
+			}},
+		},
+	}
+}
+
+// GatewayReconciler returns the function to reconcile the shared Gateway. All clusters share
+// its single TLS listener, their routes are distinguished by the SNI hostname.
+func GatewayReconciler(config *kubermaticv1.ExposeGatewayConfig) reconciling.NamedGatewayReconcilerFactory {
+	return func() (string, reconciling.GatewayReconciler) {
+		return GatewayName, func(g *gatewayapiv1.Gateway) (*gatewayapiv1.Gateway, error) {
+			if g.Annotations == nil {
+				g.Annotations = map[string]string{}
+			}
+			for k, v := range config.Annotations {
+				g.Annotations[k] = v
+			}
+
+			g.Spec.GatewayClassName = gatewayapiv1.ObjectName(config.GatewayClassName)
+			g.Spec.Listeners = []gatewayapiv1.Listener{TLSListener()}
+
+			return g, nil
+		}
+	}
+}
+
+func parentRefs(gatewayNamespace string, listener gatewayapiv1.SectionName) []gatewayapiv1.ParentReference {
+	return []gatewayapiv1.ParentReference{{
+		Group:       ptr.To(gatewayapiv1.Group(gatewayapiv1.GroupName)),
+		Kind:        ptr.To(gatewayapiv1.Kind("Gateway")),
+		Namespace:   ptr.To(gatewayapiv1.Namespace(gatewayNamespace)),
+		Name:        GatewayName,
+		SectionName: ptr.To(listener),
+	}}
+}
+
+func backendRefs(service string, port int32) []gatewayapiv1alpha2.BackendRef {
+	return []gatewayapiv1alpha2.BackendRef{{
+		BackendObjectReference: gatewayapiv1.BackendObjectReference{
+			Name: gatewayapiv1.ObjectName(service),
+			Port: ptr.To(gatewayapiv1.PortNumber(port)),
+		},
+	}}
+}
+
+func tlsRouteReconciler(name string, hostname string, gatewayNamespace string, service string, port int32) reconciling.NamedTLSRouteReconcilerFactory {
+	return func() (string, reconciling.TLSRouteReconciler) {
+		return name, func(r *gatewayapiv1alpha2.TLSRoute) (*gatewayapiv1alpha2.TLSRoute, error) {
+			r.Spec.ParentRefs = parentRefs(gatewayNamespace, TLSListenerName)
+			r.Spec.Hostnames = []gatewayapiv1alpha2.Hostname{gatewayapiv1alpha2.Hostname(hostname)}
+			r.Spec.Rules = []gatewayapiv1alpha2.TLSRouteRule{{
+				BackendRefs: backendRefs(service, port),
+			}}
+
+			return r, nil
+		}
+	}
+}
+
+// APIServerTLSRouteReconciler returns the function to reconcile the TLSRoute for the apiserver.
+func APIServerTLSRouteReconciler(cluster *kubermaticv1.Cluster, gatewayNamespace string) reconciling.NamedTLSRouteReconcilerFactory {
+	return tlsRouteReconciler(APIServerRouteName, cluster.Status.Address.ExternalName, gatewayNamespace, resources.ApiserverServiceName, 443)
+}
+
+// KonnectivityTLSRouteReconciler returns the function to reconcile the TLSRoute for the Konnectivity server.
+func KonnectivityTLSRouteReconciler(cluster *kubermaticv1.Cluster, gatewayNamespace string) reconciling.NamedTLSRouteReconcilerFactory {
+	hostname := fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, cluster.Status.Address.ExternalName)
+	return tlsRouteReconciler(KonnectivityRouteName, hostname, gatewayNamespace, resources.KonnectivityProxyServiceName, 443)
+}
+
+// MLAGatewayTLSRouteReconciler returns the function to reconcile the TLSRoute for the MLA gateway.
+func MLAGatewayTLSRouteReconciler(cluster *kubermaticv1.Cluster, gatewayNamespace string) reconciling.NamedTLSRouteReconcilerFactory {
+	hostname := resources.MLAGatewaySNIPrefix + cluster.Status.Address.ExternalName
+	return tlsRouteReconciler(MLAGatewayRouteName, hostname, gatewayNamespace, resources.MLAGatewayExternalServiceName, 80)
+}
+
+// ResourcesForDeletion returns all routes that can be created for a cluster. This includes
+// the TCPRoutes that were used before the apiserver was routed by its SNI hostname.
+func ResourcesForDeletion(namespace string) []ctrlruntimeclient.Object {
+	return []ctrlruntimeclient.Object{
+		&gatewayapiv1alpha2.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: APIServerRouteName}},
+		&gatewayapiv1alpha2.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: OpenVPNRouteName}},
+		&gatewayapiv1alpha2.TLSRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: APIServerRouteName}},
+		&gatewayapiv1alpha2.TLSRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: KonnectivityRouteName}},
+		&gatewayapiv1alpha2.TLSRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: MLAGatewayRouteName}},
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package resources
 
 import (
-	"crypto/x509"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -57,7 +56,7 @@ func AdminKubeconfigReconciler(data adminKubeconfigReconcilerData) reconciling.N
 			}
 
 			address := data.Cluster().Status.Address
-			config := GetBaseKubeconfig(ca.Cert, address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfig(ca, address.URL, data.Cluster().Name)
 			config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
 				kubeconfigDefaultAuthInfoKey: {
 					Token: address.AdminToken,
@@ -89,7 +88,7 @@ func ViewerKubeconfigReconciler(data *TemplateData) reconciling.NamedSecretRecon
 				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
 			}
 
-			config := GetBaseKubeconfig(ca.Cert, data.Cluster().Status.Address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfig(ca, data.Cluster().Status.Address.URL, data.Cluster().Name)
 			token, err := data.GetViewerToken()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get token: %w", err)
@@ -132,7 +131,7 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 
 			b := se.Data[KubeconfigSecretKey]
 			apiserverURL := fmt.Sprintf("https://%s", data.Cluster().Status.Address.InternalName)
-			valid, err := IsValidKubeconfig(b, ca.Cert, apiserverURL, commonName, organizations, data.Cluster().Name)
+			valid, err := IsValidKubeconfig(b, ca, apiserverURL, commonName, organizations, data.Cluster().Name)
 			if err != nil || !valid {
 				objLogger := log.With("namespace", namespace, "name", name)
 				if err != nil {
@@ -163,7 +162,7 @@ func BuildNewKubeconfigAsByte(ca *triple.KeyPair, server, commonName string, org
 }
 
 func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) (*clientcmdapi.Config, error) {
-	baseKubconfig := GetBaseKubeconfig(ca.Cert, server, clusterName)
+	baseKubconfig := GetBaseKubeconfig(ca, server, clusterName)
 
 	kp, err := triple.NewClientKeyPair(ca, commonName, organizations)
 	if err != nil {
@@ -180,13 +179,15 @@ func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizat
 	return baseKubconfig, nil
 }
 
-func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *clientcmdapi.Config {
+// GetBaseKubeconfig returns a kubeconfig without any AuthInfo. The kubeconfig trusts
+// the CA as well as all certificates of its bundle.
+func GetBaseKubeconfig(ca *triple.KeyPair, server, clusterName string) *clientcmdapi.Config {
 	return &clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			// We use the actual cluster name here. It is later used in encodeKubeconfig()
 			// to set the filename of the kubeconfig downloaded from API to `kubeconfig-clusterName`.
 			clusterName: {
-				CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+				CertificateAuthorityData: ca.TrustBundlePEM(),
 				Server:                   server,
 			},
 		},
@@ -200,7 +201,7 @@ func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *cl
 	}
 }
 
-func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server, commonName string, organizations []string, clusterName string) (bool, error) {
+func IsValidKubeconfig(kubeconfigBytes []byte, ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) (bool, error) {
 	if len(kubeconfigBytes) == 0 {
 		return false, nil
 	}
@@ -210,7 +211,7 @@ func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server,
 		return false, err
 	}
 
-	baseKubeconfig := GetBaseKubeconfig(caCert, server, clusterName)
+	baseKubeconfig := GetBaseKubeconfig(ca, server, clusterName)
 
 	authInfo := existingKubeconfig.AuthInfos[kubeconfigDefaultAuthInfoKey]
 	if authInfo == nil {
@@ -230,7 +231,7 @@ func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server,
 		return false, err
 	}
 
-	if !IsClientCertificateValidForAllOf(certs[0], commonName, organizations, caCert) {
+	if !IsClientCertificateValidForAllOf(certs[0], commonName, organizations, ca.Cert) {
 		return false, nil
 	}
 
@@ -53,6 +53,16 @@ const (
 	// SNIType.
 	PortHostMappingAnnotationKey = "nodeport-proxy.k8s.io/port-mapping"
 
+	// MaxConnectionsAnnotationKey overrides the maximum number of concurrent
+	// connections to each port of the annotated Service.
+	MaxConnectionsAnnotationKey = "nodeport-proxy.k8s.io/max-connections"
+	// ConnectionRateAnnotationKey overrides the number of new connections per
+	// second accepted for the annotated Service.
+	ConnectionRateAnnotationKey = "nodeport-proxy.k8s.io/connection-rate"
+	// ConnectionBurstAnnotationKey overrides the number of new connections that
+	// can be accepted at once for the annotated Service.
+	ConnectionBurstAnnotationKey = "nodeport-proxy.k8s.io/connection-burst"
+
 	loadBalancerSourceRangesAnnotationKey = "service.beta.kubernetes.io/load-balancer-source-ranges"
 )
 
@@ -150,6 +160,37 @@ var (
 	}
 )
 
+// EnvoyManagerArgs returns the envoy-manager flags for the limits and IP
+// families configured in the given NodeportProxyConfig.
+func EnvoyManagerArgs(cfg kubermaticv1.NodeportProxyConfig) []string {
+	var args []string
+
+	if len(cfg.IPFamilies) > 0 {
+		families := make([]string, 0, len(cfg.IPFamilies))
+		for _, family := range cfg.IPFamilies {
+			families = append(families, string(family))
+		}
+		args = append(args, "-ip-families="+strings.Join(families, ","))
+	}
+
+	limits := cfg.Limits
+	if limits == nil {
+		return args
+	}
+
+	if limits.MaxConnections != nil {
+		args = append(args, fmt.Sprintf("-max-connections=%d", *limits.MaxConnections))
+	}
+	if limits.ConnectionRate != nil {
+		args = append(args, fmt.Sprintf("-connection-rate=%d", *limits.ConnectionRate))
+	}
+	if limits.ConnectionBurst != nil {
+		args = append(args, fmt.Sprintf("-connection-burst=%d", *limits.ConnectionBurst))
+	}
+
+	return args
+}
+
 type nodePortProxyData interface {
 	RewriteImage(string) (string, error)
 	NodePortProxyTag() string
@@ -178,6 +219,12 @@ func RoleReconciler() (string, reconciling.RoleReconciler) {
 				ResourceNames: []string{resources.FrontLoadBalancerServiceName},
 				Verbs:         []string{"update"},
 			},
+			// for the leader election of the lb-updater
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"get", "create", "update"},
+			},
 		}
 		return r, nil
 	}
@@ -240,16 +287,19 @@ func DeploymentEnvoyReconciler(data nodePortProxyData, versions kubermatic.Versi
 
 			seed := data.Seed()
 
+			command := []string{"/envoy-manager",
+				"-listen-address=:8001",
+				"-envoy-node-name=$(PODNAME)",
+				"-envoy-admin-port=9001",
+				"-envoy-stats-port=8002",
+				"-expose-annotation-key=" + NodePortProxyExposeNamespacedAnnotationKey,
+				"-namespace=$(PODNAMESPACE)"}
+			command = append(command, EnvoyManagerArgs(seed.Spec.NodeportProxy)...)
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{{
-				Name:  "envoy-manager",
-				Image: registry.Must(data.RewriteImage(fmt.Sprintf("%s/%s:%s", resources.RegistryQuay, imageName, data.NodePortProxyTag()))),
-				Command: []string{"/envoy-manager",
-					"-listen-address=:8001",
-					"-envoy-node-name=$(PODNAME)",
-					"-envoy-admin-port=9001",
-					"-envoy-stats-port=8002",
-					"-expose-annotation-key=" + NodePortProxyExposeNamespacedAnnotationKey,
-					"-namespace=$(PODNAMESPACE)"},
+				Name:    "envoy-manager",
+				Image:   registry.Must(data.RewriteImage(fmt.Sprintf("%s/%s:%s", resources.RegistryQuay, imageName, data.NodePortProxyTag()))),
+				Command: command,
 				Env: []corev1.EnvVar{
 					{
 						Name: "PODNAMESPACE",
@@ -358,6 +408,8 @@ func DeploymentLBUpdaterReconciler(data nodePortProxyData) reconciling.NamedDepl
 					"-lb-name=" + resources.FrontLoadBalancerServiceName,
 					"-expose-annotation-key=" + NodePortProxyExposeNamespacedAnnotationKey,
 					"-namespaced=true",
+					"-enable-leader-election",
+					"-leader-election-namespace=$(MY_NAMESPACE)",
 				},
 				Image: registry.Must(data.RewriteImage(fmt.Sprintf("%s/%s:%s", resources.RegistryQuay, imageName, data.NodePortProxyTag()))),
 				Env: []corev1.EnvVar{{
@@ -464,6 +516,14 @@ func FrontLoadBalancerServiceReconciler(data *resources.TemplateData) reconcilin
 				// Load-balance across nodes in all zones to ensure HA if nodes in a DNS-selected zone are not available
 				s.Annotations["service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled"] = "true"
 			}
+			if seed.Spec.NodeportProxy.IPFamilies != nil {
+				s.Spec.IPFamilies = seed.Spec.NodeportProxy.IPFamilies
+			}
+
+			if seed.Spec.NodeportProxy.IPFamilyPolicy != nil {
+				s.Spec.IPFamilyPolicy = seed.Spec.NodeportProxy.IPFamilyPolicy
+			}
+
 			s.Spec.Selector = resources.BaseAppLabels(envoyAppLabelValue, nil)
 			return s, nil
 		}
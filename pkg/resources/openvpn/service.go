@@ -52,6 +52,12 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy) reconciling.N
 				se.Spec.Type = corev1.ServiceTypeClusterIP
 				se.Annotations[nodeportproxy.DefaultExposeAnnotationKey] = nodeportproxy.TunnelingType.String()
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				// OpenVPN cannot be routed by SNI, so like the apiserver it gets its own listener
+				// on the shared Gateway, using the NodePort as a port that is unique within the seed.
+				se.Spec.Type = corev1.ServiceTypeNodePort
+				delete(se.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
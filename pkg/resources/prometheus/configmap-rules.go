@@ -329,6 +329,23 @@ groups:
     labels:
       severity: critical
 
+- name: kubermatic.auditlogs
+  rules:
+  - record: job:fluentbit_output_proc_records_total:rate5msum
+    expr: sum(rate(fluentbit_output_proc_records_total{job="audit-logs"}[5m]))
+    labels:
+      kubermatic: federate
+
+  - record: job:fluentbit_output_retries_total:rate5msum
+    expr: sum(rate(fluentbit_output_retries_total{job="audit-logs"}[5m]))
+    labels:
+      kubermatic: federate
+
+  - record: job:fluentbit_output_dropped_records_total:rate5msum
+    expr: sum(rate(fluentbit_output_dropped_records_total{job="audit-logs"}[5m]))
+    labels:
+      kubermatic: federate
+
 - name: kubernetes-nodes
   rules:
   - alert: KubernetesNodeNotReady
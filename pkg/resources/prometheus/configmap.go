@@ -309,6 +309,29 @@ scrape_configs:
   - source_labels: [__meta_kubernetes_pod_name]
     action: replace
     target_label: pod
+{{- if .TemplateData.IsAuditLogAggregatorEnabled }}
+
+# scrape the audit-logs sidecars forwarding to the seed-level audit log aggregator,
+# to track dropped and retried audit events of this cluster
+- job_name: audit-logs
+  metrics_path: /api/v1/metrics/prometheus
+  kubernetes_sd_configs:
+  - role: pod
+    namespaces:
+      names:
+      - "{{ $.TemplateData.Cluster.Status.NamespaceName }}"
+
+  relabel_configs:
+  - source_labels: [__meta_kubernetes_pod_label_app, __meta_kubernetes_pod_container_port_name]
+    regex: "apiserver;audit-metrics"
+    action: keep
+  - source_labels: [__meta_kubernetes_namespace]
+    action: replace
+    target_label: namespace
+  - source_labels: [__meta_kubernetes_pod_name]
+    action: replace
+    target_label: pod
+{{- end }}
 
 #######################################################################
 # These rules will scrape pods running inside the user cluster itself.
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
@@ -34,6 +35,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
 	"k8c.io/kubermatic/v2/pkg/util/s3"
 	"k8c.io/reconciler/pkg/reconciling"
 
@@ -115,6 +117,8 @@ const (
 	EncryptionConfigurationSecretName = "apiserver-encryption-configuration"
 	// EncryptionConfigurationKeyName is the name of the secret key that is used to store the configuration file for encryption-at-rest.
 	EncryptionConfigurationKeyName = "encryption-configuration.yaml"
+	// KMSPluginConfigSecretName is the name of the secret containing the configuration of the KMS plugin.
+	KMSPluginConfigSecretName = "kms-plugin-config"
 	// NodePortProxyEnvoyDeploymentName is the name of the nodeport-proxy deployment in the user cluster.
 	NodePortProxyEnvoyDeploymentName = "nodeport-proxy-envoy"
 	// NodePortProxyEnvoyContainerName is the name of the envoy container in the nodeport-proxy deployment.
@@ -259,8 +263,22 @@ const (
 	// FluentBitSecretName is the name of the secret that contains the fluent-bit configuration mounted
 	// into kube-apisever and used by the "audit-logs" sidecar to ship audit logs.
 	FluentBitSecretName = "audit-logs-fluentbit"
+	// AuditLogAggregatorName is the name of the seed-level audit log aggregator Deployment and Service
+	// the "audit-logs" sidecars forward audit events to, if it is enabled for the seed.
+	AuditLogAggregatorName = "audit-log-aggregator"
+	// AuditLogAggregatorForwardPort is the port the seed-level audit log aggregator receives audit
+	// events on, using the fluent forward protocol.
+	AuditLogAggregatorForwardPort = 24224
+	// AuditLogAggregatorSharedKeySecretName is the name of the Secret in the seed namespace that contains the
+	// key shared between the seed-level audit log aggregator and the "audit-logs" sidecars.
+	AuditLogAggregatorSharedKeySecretName = "audit-log-aggregator-shared-key"
+	// AuditLogAggregatorSharedKeySecretKey is the key of the shared key in the AuditLogAggregatorSharedKeySecretName Secret.
+	AuditLogAggregatorSharedKeySecretKey = "sharedKey"
 	// AdmissionControlConfigMapName is the name for the configmap that contains the Admission Controller config file.
 	AdmissionControlConfigMapName = "adm-control"
+	// AuthenticationConfigurationConfigMapName is the name for the configmap that contains the structured
+	// authentication configuration file that will be passed to the apiserver with the flag "--authentication-config".
+	AuthenticationConfigurationConfigMapName = "authentication-configuration"
 
 	// PrometheusServiceAccountName is the name for the Prometheus serviceaccount.
 	PrometheusServiceAccountName = "prometheus"
@@ -535,8 +553,14 @@ const (
 const (
 	// CAKeySecretKey ca.key.
 	CAKeySecretKey = "ca.key"
-	// CACertSecretKey ca.crt.
+	// CACertSecretKey ca.crt. For the cluster CAs, this contains the signing certificate first,
+	// followed by all further trusted CA certificates during a CA rotation.
 	CACertSecretKey = "ca.crt"
+	// NextCAKeySecretKey next-ca.key is the key of the CA that is going to be used for signing
+	// once the trust phase of a CA rotation has been completed.
+	NextCAKeySecretKey = "next-ca.key"
+	// NextCACertSecretKey next-ca.crt is the certificate belonging to NextCAKeySecretKey.
+	NextCACertSecretKey = "next-ca.crt"
 	// ApiserverTLSKeySecretKey apiserver-tls.key.
 	ApiserverTLSKeySecretKey = "apiserver-tls.key"
 	// ApiserverTLSCertSecretKey apiserver-tls.crt.
@@ -547,8 +571,12 @@ const (
 	KubeletClientCertSecretKey = "kubelet-client.crt"
 	// ServiceAccountKeySecretKey sa.key.
 	ServiceAccountKeySecretKey = "sa.key"
-	// ServiceAccountKeyPublicKey is the public key for the service account signer key.
+	// ServiceAccountKeyPublicKey contains the public keys of all trusted service account signing keys,
+	// starting with the one of the current signing key.
 	ServiceAccountKeyPublicKey = "sa.pub"
+	// NextServiceAccountKeySecretKey next-sa.key is the service account signing key that is going
+	// to be used once the trust phase of a rotation has been completed.
+	NextServiceAccountKeySecretKey = "next-sa.key"
 	// KubeconfigSecretKey kubeconfig.
 	KubeconfigSecretKey = "kubeconfig"
 	// TokensSecretKey tokens.csv.
@@ -580,6 +608,13 @@ const (
 	EtcdRestoreS3BucketNameKey    = "BUCKET_NAME"
 	EtcdRestoreS3EndpointKey      = "ENDPOINT"
 	EtcdRestoreDefaultS3SEndpoint = "s3.amazonaws.com"
+	// EtcdRestoreWrappedKeyKey is the key in the backup download secret holding the wrapped
+	// data key, if the backup to restore is encrypted.
+	EtcdRestoreWrappedKeyKey = "WRAPPED_ENCRYPTION_KEY"
+	// EtcdRestoreKeyEncryptionKeysKey is the key in the backup download secret holding the
+	// namespace/name reference to the Secret with the key encryption keys, which are required
+	// to unwrap the data key.
+	EtcdRestoreKeyEncryptionKeysKey = "KEY_ENCRYPTION_KEYS_SECRET"
 
 	// ApiserverEtcdClientCertificateCertSecretKey apiserver-etcd-client.crt.
 	ApiserverEtcdClientCertificateCertSecretKey = "apiserver-etcd-client.crt"
@@ -620,9 +655,44 @@ const (
 )
 
 const (
-	minimumCertValidity30d = 30 * 24 * time.Hour
+	// DefaultCertificateRenewalWindow is the default time before their expiry at which
+	// certificates are renewed.
+	DefaultCertificateRenewalWindow = 30 * 24 * time.Hour
+	// MaximumCertificateRenewalWindow is the largest supported renewal window. Leaf certificates
+	// are valid for one year, so larger windows would cause them to be renewed too often.
+	MaximumCertificateRenewalWindow = 180 * 24 * time.Hour
 )
 
+// certificateRenewalWindow is the time before their expiry at which certificates are renewed.
+var certificateRenewalWindow = DefaultCertificateRenewalWindow
+
+// ValidateCertificateRenewalWindow returns an error if the given window is not supported.
+func ValidateCertificateRenewalWindow(window time.Duration) error {
+	if window <= 0 || window > MaximumCertificateRenewalWindow {
+		return fmt.Errorf("certificate renewal window must be greater than 0 and at most %v", MaximumCertificateRenewalWindow)
+	}
+
+	return nil
+}
+
+// SetCertificateRenewalWindow configures the time before their expiry at which certificates
+// are considered to expire soon and are therefore renewed by their reconcilers. It must only
+// be called during startup.
+func SetCertificateRenewalWindow(window time.Duration) error {
+	if err := ValidateCertificateRenewalWindow(window); err != nil {
+		return err
+	}
+
+	certificateRenewalWindow = window
+
+	return nil
+}
+
+// CertificateRenewalWindow returns the configured certificate renewal window.
+func CertificateRenewalWindow() time.Duration {
+	return certificateRenewalWindow
+}
+
 const (
 	ExternalClusterKubeconfigPrefix = "kubeconfig-external-cluster"
 	// KubeOneNamespacePrefix is the kubeone namespace prefix.
@@ -756,10 +826,17 @@ const (
 	EnvoyAgentCreateInterfaceInitContainerName = "create-dummy-interface"
 	EnvoyAgentAssignAddressContainerName       = "assign-address"
 	EnvoyAgentDeviceSetupImage                 = "kubermatic/network-interface-manager"
+	EnvoyAgentServingCertSecretName            = "envoy-agent-serving-cert"
 	// Default tunneling agent IP address.
 	DefaultTunnelingAgentIP = "100.64.30.10"
 )
 
+const (
+	// ExposeGatewayTLSPort is the port of the TLS listener on the Gateway used by the
+	// Gateway expose strategy, through which all SNI-routed components are reached.
+	ExposeGatewayTLSPort = 443
+)
+
 const (
 	NodeLocalDNSServiceAccountName  = "node-local-dns"
 	NodeLocalDNSConfigMapName       = "node-local-dns"
@@ -1023,6 +1100,7 @@ const (
 	NetworkPolicyOIDCIssuerAllow                    = "oidc-issuer-allow"
 	NetworkPolicySeedApiserverAllow                 = "seed-apiserver-allow"
 	NetworkPolicyApiserverInternalAllow             = "apiserver-internal-allow"
+	NetworkPolicyAuditLogAggregatorAllow            = "audit-log-aggregator-allow"
 )
 
 const (
@@ -1257,9 +1335,10 @@ func AppClusterLabels(appName, clusterName string, additionalLabels map[string]s
 	return podLabels
 }
 
-// CertWillExpireSoon returns if the certificate will expire in the next 30 days.
+// CertWillExpireSoon returns if the certificate will expire within the certificate
+// renewal window (30 days by default).
 func CertWillExpireSoon(cert *x509.Certificate) bool {
-	return time.Until(cert.NotAfter) < minimumCertValidity30d
+	return time.Until(cert.NotAfter) < certificateRenewalWindow
 }
 
 // IsServerCertificateValidForAllOf validates if the given data is present in the given server certificate.
@@ -1341,19 +1420,22 @@ func IsClientCertificateValidForAllOf(cert *x509.Certificate, commonName string,
 }
 
 func getECDSAClusterCAFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) (*ECDSAKeyPair, error) {
-	cert, key, err := getClusterCAFromLister(ctx, namespace, name, client)
+	certs, key, err := getClusterCAFromLister(ctx, namespace, name, client)
 	if err != nil {
 		return nil, err
 	}
+	if len(certs) != 1 {
+		return nil, fmt.Errorf("did not find exactly one but %v certificates in the CA secret", len(certs))
+	}
 	ecdsaKey, isECDSAKey := key.(*ecdsa.PrivateKey)
 	if !isECDSAKey {
 		return nil, errors.New("key is not a ECDSA key")
 	}
-	return &ECDSAKeyPair{Cert: cert, Key: ecdsaKey}, nil
+	return &ECDSAKeyPair{Cert: certs[0], Key: ecdsaKey}, nil
 }
 
 func getRSAClusterCAFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
-	cert, key, err := getClusterCAFromLister(ctx, namespace, name, client)
+	certs, key, err := getClusterCAFromLister(ctx, namespace, name, client)
 	if err != nil {
 		return nil, err
 	}
@@ -1361,11 +1443,12 @@ func getRSAClusterCAFromLister(ctx context.Context, namespace, name string, clie
 	if !isRSAKey {
 		return nil, errors.New("key is not a RSA key")
 	}
-	return &triple.KeyPair{Cert: cert, Key: rsaKey}, nil
+	return &triple.KeyPair{Cert: certs[0], Key: rsaKey, Bundle: certs[1:]}, nil
 }
 
-// getClusterCAFromLister returns the CA of the cluster from the lister.
-func getClusterCAFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) (*x509.Certificate, interface{}, error) {
+// getClusterCAFromLister returns the CA of the cluster from the lister. The first returned certificate
+// belongs to the returned key, any further certificates are CAs which are trusted during a CA rotation.
+func getClusterCAFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) ([]*x509.Certificate, interface{}, error) {
 	caSecret := &corev1.Secret{}
 	caSecretKey := types.NamespacedName{Namespace: namespace, Name: name}
 	if err := client.Get(ctx, caSecretKey, caSecret); err != nil {
@@ -1377,16 +1460,12 @@ func getClusterCAFromLister(ctx context.Context, namespace, name string, client
 		return nil, nil, fmt.Errorf("got an invalid cert from the CA secret %s: %w", caSecretKey, err)
 	}
 
-	if len(certs) != 1 {
-		return nil, nil, fmt.Errorf("did not find exactly one but %v certificates in the CA secret", len(certs))
-	}
-
 	key, err := triple.ParsePrivateKeyPEM(caSecret.Data[CAKeySecretKey])
 	if err != nil {
 		return nil, nil, fmt.Errorf("got an invalid private key from the CA secret %s: %w", caSecretKey, err)
 	}
 
-	return certs[0], key, nil
+	return certs, key, nil
 }
 
 // GetCABundleFromFile returns the CA bundle from a file.
@@ -1702,6 +1781,22 @@ func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdResto
 		secretData[EtcdRestoreS3BucketNameKey] = destination.BucketName
 		secretData[EtcdRestoreS3EndpointKey] = destination.Endpoint
 
+		// only the wrapped data key is handed to the etcd-launcher, which unwraps it itself
+		if destination.Encryption != nil {
+			wrappedKey, err := backupcrypto.GetClusterWrappedDataKey(ctx, client, cluster, restore.Spec.Destination, destination)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to get backup encryption key: %w", err)
+			}
+
+			keysRef, err := backupcrypto.KeyEncryptionKeysRef(destination.Encryption)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to get backup encryption key: %w", err)
+			}
+
+			secretData[EtcdRestoreWrappedKeyKey] = string(wrappedKey)
+			secretData[EtcdRestoreKeyEncryptionKeysKey] = keysRef.String()
+		}
+
 		creator := func(se *corev1.Secret) (*corev1.Secret, error) {
 			if se.Data == nil {
 				se.Data = map[string][]byte{}
@@ -1767,6 +1862,32 @@ func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdResto
 	return s3Client, bucketName, nil
 }
 
+// GetEtcdRestoreEncryptionKey returns the data key to decrypt the backup of the given EtcdRestore
+// with, or nil if the backup download secret does not contain a key. The wrapped data key from the
+// backup download secret is unwrapped with the key encryption keys it references.
+func GetEtcdRestoreEncryptionKey(ctx context.Context, restore *kubermaticv1.EtcdRestore, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) ([]byte, error) {
+	if restore.Spec.BackupDownloadCredentialsSecret == "" {
+		return nil, fmt.Errorf("BackupDownloadCredentialsSecret not set")
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: restore.Spec.BackupDownloadCredentialsSecret}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %w", restore.Spec.BackupDownloadCredentialsSecret, err)
+	}
+
+	wrappedKey := secret.Data[EtcdRestoreWrappedKeyKey]
+	if len(wrappedKey) == 0 {
+		return nil, nil
+	}
+
+	namespace, name, found := strings.Cut(string(secret.Data[EtcdRestoreKeyEncryptionKeysKey]), "/")
+	if !found || namespace == "" || name == "" {
+		return nil, fmt.Errorf("BackupDownloadCredentialsSecret %v contains a wrapped key, but no valid reference to the key encryption keys", restore.Spec.BackupDownloadCredentialsSecret)
+	}
+
+	return backupcrypto.UnwrapDataKey(ctx, client, wrappedKey, types.NamespacedName{Namespace: namespace, Name: name})
+}
+
 // GetClusterNodeCIDRMaskSizeIPv4 returns effective mask size used to address the nodes within provided IPv4 Pods CIDR.
 func GetClusterNodeCIDRMaskSizeIPv4(cluster *kubermaticv1.Cluster) int32 {
 	if cluster.Spec.ClusterNetwork.NodeCIDRMaskSizeIPv4 != nil {
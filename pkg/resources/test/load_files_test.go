@@ -882,7 +882,7 @@ func generateAndVerifyResources(t *testing.T, data *resources.TemplateData, tc t
 	}
 
 	var namedConfigMapReconcilerFactories []reconciling.NamedConfigMapReconcilerFactory
-	namedConfigMapReconcilerFactories = append(namedConfigMapReconcilerFactories, kubernetescontroller.GetConfigMapReconcilers(data)...)
+	namedConfigMapReconcilerFactories = append(namedConfigMapReconcilerFactories, kubernetescontroller.GetConfigMapReconcilers(data, true)...)
 	namedConfigMapReconcilerFactories = append(namedConfigMapReconcilerFactories, monitoringcontroller.GetConfigMapReconcilers(data)...)
 	for _, namedGetter := range namedConfigMapReconcilerFactories {
 		name, create := namedGetter()
@@ -101,6 +101,8 @@ func DeploymentReconciler(data webhookData) reconciling.NamedDeploymentReconcile
 				fmt.Sprintf("-user-webhook-key-name=%s", resources.ServingCertKeySecretKey),
 				fmt.Sprintf("-ca-bundle=/opt/ca-bundle/%s", resources.CABundleConfigMapKey),
 				fmt.Sprintf("-project-id=%s", projectID),
+				fmt.Sprintf("-datacenter=%s", data.Cluster().Spec.Cloud.DatacenterName),
+				fmt.Sprintf("-provider=%s", data.Cluster().Spec.Cloud.ProviderName),
 			}
 
 			if data.Cluster().Spec.DebugLog {
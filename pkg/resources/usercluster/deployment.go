@@ -170,7 +170,7 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 				args = append(args, "-konnectivity-enabled=true")
 
 				kHost := address.ExternalName
-				if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+				if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 					kHost = fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, kHost)
 				}
 				kPort, err := data.GetKonnectivityServerPort()
@@ -192,7 +192,7 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 				args = append(args, "-enable-network-policies")
 			}
 
-			if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+			if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 				args = append(args, "-tunneling-agent-ip", data.GetTunnelingAgentIP())
 				args = append(args, "-kas-secure-port", fmt.Sprint(resources.APIServerSecurePort))
 			}
@@ -234,7 +234,7 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 						return nil, err
 					}
 					mlaEndpoint := net.JoinHostPort(address.ExternalName, fmt.Sprintf("%d", mlaGatewayPort))
-					if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+					if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 						mlaEndpoint = resources.MLAGatewaySNIPrefix + mlaEndpoint
 					}
 					args = append(args, "-mla-gateway-url", "https://"+mlaEndpoint)
@@ -249,6 +249,12 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 				args = append(args, "-ccm-migration-completed")
 			}
 
+			// the user cluster certificates must be renewed with the same window as the
+			// control plane certificates reconciled by the seed-controller-manager
+			if window := resources.CertificateRenewalWindow(); window != resources.DefaultCertificateRenewalWindow {
+				args = append(args, fmt.Sprintf("-certificate-renewal-window=%s", window))
+			}
+
 			labelArgsValue, err := getLabelsArgValue(data.Cluster())
 			if err != nil {
 				return nil, fmt.Errorf("failed to get label args value: %w", err)
@@ -118,6 +118,11 @@ func ClusterRole() reconciling.NamedClusterRoleReconcilerFactory {
 						"update",
 					},
 				},
+				{
+					APIGroups: []string{"kubermatic.k8c.io"},
+					Resources: []string{"resourcequotas"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
 				// This should be removed with KKP 2.23 when we remove the Migration for OSM.
 				{
 					APIGroups: []string{"apiextensions.k8s.io"},
@@ -26,6 +26,8 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 func NewScheme() *runtime.Scheme {
@@ -33,6 +35,8 @@ func NewScheme() *runtime.Scheme {
 	utilruntime.Must(kubermaticv1.AddToScheme(s))
 	utilruntime.Must(appskubermaticv1.AddToScheme(s))
 	utilruntime.Must(scheme.AddToScheme(s))
+	utilruntime.Must(gatewayapiv1.Install(s))
+	utilruntime.Must(gatewayapiv1alpha2.Install(s))
 
 	metav1.AddToGroupVersion(s, schema.GroupVersion{Version: "v1"})
 
@@ -49,9 +53,11 @@ func NewClientBuilder() *fakectrlruntimeclient.ClientBuilder {
 		WithScheme(NewScheme()).
 		WithStatusSubresource(
 			&appskubermaticv1.ApplicationInstallation{},
+			&appskubermaticv1.ApplicationRollout{},
 			&kubermaticv1.Addon{},
 			&kubermaticv1.Alertmanager{},
 			&kubermaticv1.Cluster{},
+			&kubermaticv1.ClusterMigration{},
 			&kubermaticv1.Seed{},
 			&kubermaticv1.EtcdBackupConfig{},
 			&kubermaticv1.EtcdRestore{},
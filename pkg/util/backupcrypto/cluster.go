@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupcrypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DataKeySecretName returns the name of the Secret in the kube-system namespace that holds
+// the wrapped data keys of a cluster. The Secret contains one wrapped key per backup
+// destination, keyed by the destination name.
+func DataKeySecretName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("cluster-%s-etcd-backup-data-key", cluster.Name)
+}
+
+// GetKeyEncryptionKeys loads and validates the key encryption keys of a backup destination.
+func GetKeyEncryptionKeys(ctx context.Context, client ctrlruntimeclient.Client, encryption *kubermaticv1.BackupEncryption) (KeyEncryptionKeys, error) {
+	keysRef, err := KeyEncryptionKeysRef(encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, keysRef, secret); err != nil {
+		return nil, fmt.Errorf("failed to get key encryption keys secret %s: %w", keysRef, err)
+	}
+
+	keys := KeyEncryptionKeys(secret.Data)
+	if err := keys.Validate(encryption.ActiveKey); err != nil {
+		return nil, fmt.Errorf("invalid key encryption keys secret %s: %w", keysRef, err)
+	}
+
+	return keys, nil
+}
+
+// KeyEncryptionKeysRef returns the reference to the Secret holding the key encryption keys of a backup destination.
+func KeyEncryptionKeysRef(encryption *kubermaticv1.BackupEncryption) (types.NamespacedName, error) {
+	if encryption.KeyEncryptionKeys == nil {
+		return types.NamespacedName{}, errors.New("no key encryption keys secret configured")
+	}
+
+	namespace := encryption.KeyEncryptionKeys.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: encryption.KeyEncryptionKeys.Name}, nil
+}
+
+// GetClusterWrappedDataKey returns the encoded, wrapped data key a cluster's backups at the
+// given destination are encrypted with.
+func GetClusterWrappedDataKey(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, destinationName string, destination *kubermaticv1.BackupDestination) ([]byte, error) {
+	if destination.Encryption == nil {
+		return nil, fmt.Errorf("encryption is not enabled for backup destination %q", destinationName)
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: DataKeySecretName(cluster)}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get data key secret: %w", err)
+	}
+
+	encoded, ok := secret.Data[destinationName]
+	if !ok {
+		return nil, fmt.Errorf("no data key exists for backup destination %q", destinationName)
+	}
+
+	if _, err := DecodeWrappedKey(encoded); err != nil {
+		return nil, err
+	}
+
+	return encoded, nil
+}
+
+// UnwrapDataKey unwraps the encoded data key with the key encryption key from the referenced Secret.
+// Only the Secret itself is read, so a client that is only allowed to get this single Secret suffices.
+func UnwrapDataKey(ctx context.Context, client ctrlruntimeclient.Client, encoded []byte, keysRef types.NamespacedName) ([]byte, error) {
+	wrapped, err := DecodeWrappedKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, keysRef, secret); err != nil {
+		return nil, fmt.Errorf("failed to get key encryption keys secret %s: %w", keysRef, err)
+	}
+
+	return KeyEncryptionKeys(secret.Data).Unwrap(wrapped)
+}
+
+// KeyEncryptionKeysAccessName returns the name of the Role and RoleBinding that allow the
+// etcd-launcher of a cluster to read the key encryption keys during a restore.
+func KeyEncryptionKeysAccessName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("etcd-launcher-%s-restore-kek", cluster.Name)
+}
+
+// GrantKeyEncryptionKeysAccess allows the etcd-launcher of the given cluster to read the Secret holding the
+// key encryption keys, so that it can unwrap the data key of the backup it restores. The access is limited
+// to this single Secret and must be revoked once the restore has finished.
+func GrantKeyEncryptionKeysAccess(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, encryption *kubermaticv1.BackupEncryption, serviceAccountName string) error {
+	keysRef, err := KeyEncryptionKeysRef(encryption)
+	if err != nil {
+		return err
+	}
+
+	name := KeyEncryptionKeysAccessName(cluster)
+
+	roleReconciler := func() (string, reconciling.RoleReconciler) {
+		return name, func(r *rbacv1.Role) (*rbacv1.Role, error) {
+			r.Rules = []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{""},
+					Resources:     []string{"secrets"},
+					ResourceNames: []string{keysRef.Name},
+					Verbs:         []string{"get"},
+				},
+			}
+
+			return r, nil
+		}
+	}
+
+	if err := reconciling.ReconcileRoles(ctx, []reconciling.NamedRoleReconcilerFactory{roleReconciler}, keysRef.Namespace, client); err != nil {
+		return fmt.Errorf("failed to reconcile Role: %w", err)
+	}
+
+	roleBindingReconciler := func() (string, reconciling.RoleBindingReconciler) {
+		return name, func(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+			rb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			}
+			rb.Subjects = []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      serviceAccountName,
+					Namespace: cluster.Status.NamespaceName,
+				},
+			}
+
+			return rb, nil
+		}
+	}
+
+	if err := reconciling.ReconcileRoleBindings(ctx, []reconciling.NamedRoleBindingReconcilerFactory{roleBindingReconciler}, keysRef.Namespace, client); err != nil {
+		return fmt.Errorf("failed to reconcile RoleBinding: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeKeyEncryptionKeysAccess removes the access granted by GrantKeyEncryptionKeysAccess.
+func RevokeKeyEncryptionKeysAccess(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, encryption *kubermaticv1.BackupEncryption) error {
+	keysRef, err := KeyEncryptionKeysRef(encryption)
+	if err != nil {
+		return err
+	}
+
+	meta := metav1.ObjectMeta{Namespace: keysRef.Namespace, Name: KeyEncryptionKeysAccessName(cluster)}
+
+	if err := client.Delete(ctx, &rbacv1.RoleBinding{ObjectMeta: meta}); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete RoleBinding: %w", err)
+	}
+
+	if err := client.Delete(ctx, &rbacv1.Role{ObjectMeta: meta}); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Role: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupcrypto
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WrappedKey is a data key encrypted with a key encryption key.
+type WrappedKey struct {
+	// KeyID identifies the key encryption key the data key is wrapped with.
+	KeyID string `json:"keyID"`
+	// Key is the encrypted data key.
+	Key []byte `json:"key"`
+}
+
+// KeyEncryptionKeys maps key IDs to key encryption keys.
+type KeyEncryptionKeys map[string][]byte
+
+// Validate ensures the active key exists and that all keys have the correct size.
+func (k KeyEncryptionKeys) Validate(activeKey string) error {
+	if _, ok := k[activeKey]; !ok {
+		return fmt.Errorf("active key %q does not exist", activeKey)
+	}
+
+	for id, key := range k {
+		if len(key) != KeySize {
+			return fmt.Errorf("key %q must be %d bytes long, but is %d bytes", id, KeySize, len(key))
+		}
+	}
+
+	return nil
+}
+
+// GenerateKey returns a new random data key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Wrap encrypts the data key with the key encryption key identified by keyID.
+func (k KeyEncryptionKeys) Wrap(dataKey []byte, keyID string) (*WrappedKey, error) {
+	kek, ok := k[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key encryption key %q does not exist", keyID)
+	}
+
+	encrypted, err := Encrypt(dataKey, kek)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WrappedKey{
+		KeyID: keyID,
+		Key:   encrypted,
+	}, nil
+}
+
+// Unwrap decrypts the given data key.
+func (k KeyEncryptionKeys) Unwrap(wrapped *WrappedKey) ([]byte, error) {
+	kek, ok := k[wrapped.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("data key is wrapped with key encryption key %q, which does not exist", wrapped.KeyID)
+	}
+
+	dataKey, err := Decrypt(wrapped.Key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	if len(dataKey) != KeySize {
+		return nil, errors.New("unwrapped data key has an invalid size")
+	}
+
+	return dataKey, nil
+}
+
+// EnsureWrappedKey returns the encoded data key, wrapped with the active key encryption key.
+// If no data key exists yet (encoded is empty), a new one is generated. An existing data key
+// which is wrapped with a different key is re-wrapped with the active key; the data key itself
+// never changes, so all existing backups remain readable.
+func (k KeyEncryptionKeys) EnsureWrappedKey(encoded []byte, activeKey string) ([]byte, error) {
+	if err := k.Validate(activeKey); err != nil {
+		return nil, err
+	}
+
+	var (
+		dataKey []byte
+		err     error
+	)
+
+	if len(encoded) == 0 {
+		dataKey, err = GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		wrapped, err := DecodeWrappedKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		if wrapped.KeyID == activeKey {
+			return encoded, nil
+		}
+
+		dataKey, err = k.Unwrap(wrapped)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wrapped, err := k.Wrap(dataKey, activeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(wrapped)
+}
+
+// DecodeWrappedKey decodes a wrapped key as stored in the data key secret.
+func DecodeWrappedKey(encoded []byte) (*WrappedKey, error) {
+	wrapped := &WrappedKey{}
+	if err := json.Unmarshal(encoded, wrapped); err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped key: %w", err)
+	}
+
+	if wrapped.KeyID == "" || len(wrapped.Key) == 0 {
+		return nil, errors.New("wrapped key is incomplete")
+	}
+
+	return wrapped, nil
+}
+
+// LoadDataKey reads the wrapped data key from wrappedKeyFile and unwraps it using the key
+// encryption keys found in keysDir, where every file is one key. This is how backup jobs,
+// which have both secrets mounted, obtain the data key.
+func LoadDataKey(keysDir, wrappedKeyFile string) ([]byte, error) {
+	encoded, err := os.ReadFile(wrappedKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wrapped data key: %w", err)
+	}
+
+	wrapped, err := DecodeWrappedKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	// secret volumes contain hidden files and symlinks for atomic updates,
+	// so the key is read directly instead of listing the directory
+	kek, err := os.ReadFile(filepath.Join(keysDir, filepath.Base(wrapped.KeyID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key encryption key %q: %w", wrapped.KeyID, err)
+	}
+
+	return KeyEncryptionKeys{wrapped.KeyID: kek}.Unwrap(wrapped)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupcrypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEnsureWrappedKey(t *testing.T) {
+	keys := KeyEncryptionKeys{
+		"old": randomData(1, KeySize),
+		"new": randomData(2, KeySize),
+	}
+
+	// generate a new data key
+	encoded, err := keys.EnsureWrappedKey(nil, "old")
+	if err != nil {
+		t.Fatalf("failed to generate data key: %v", err)
+	}
+
+	wrapped, err := DecodeWrappedKey(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode wrapped key: %v", err)
+	}
+
+	if wrapped.KeyID != "old" {
+		t.Fatalf("expected data key to be wrapped with %q, but got %q", "old", wrapped.KeyID)
+	}
+
+	dataKey, err := keys.Unwrap(wrapped)
+	if err != nil {
+		t.Fatalf("failed to unwrap data key: %v", err)
+	}
+
+	// an up-to-date data key is returned as-is
+	unchanged, err := keys.EnsureWrappedKey(encoded, "old")
+	if err != nil {
+		t.Fatalf("failed to ensure data key: %v", err)
+	}
+
+	if !bytes.Equal(unchanged, encoded) {
+		t.Fatal("expected wrapped data key to not change")
+	}
+
+	// rotating the key encryption key must not change the data key itself
+	rotated, err := keys.EnsureWrappedKey(encoded, "new")
+	if err != nil {
+		t.Fatalf("failed to rotate data key: %v", err)
+	}
+
+	rewrapped, err := DecodeWrappedKey(rotated)
+	if err != nil {
+		t.Fatalf("failed to decode wrapped key: %v", err)
+	}
+
+	if rewrapped.KeyID != "new" {
+		t.Fatalf("expected data key to be wrapped with %q, but got %q", "new", rewrapped.KeyID)
+	}
+
+	rotatedDataKey, err := KeyEncryptionKeys{"new": keys["new"]}.Unwrap(rewrapped)
+	if err != nil {
+		t.Fatalf("failed to unwrap rotated data key: %v", err)
+	}
+
+	if !bytes.Equal(rotatedDataKey, dataKey) {
+		t.Fatal("expected data key to remain unchanged by the rotation")
+	}
+}
+
+func TestEnsureWrappedKeyErrors(t *testing.T) {
+	keys := KeyEncryptionKeys{
+		"a": randomData(1, KeySize),
+	}
+
+	wrappedWithB, err := KeyEncryptionKeys{"b": randomData(2, KeySize)}.EnsureWrappedKey(nil, "b")
+	if err != nil {
+		t.Fatalf("failed to generate data key: %v", err)
+	}
+
+	testcases := []struct {
+		name      string
+		keys      KeyEncryptionKeys
+		encoded   []byte
+		activeKey string
+	}{
+		{
+			name:      "active key does not exist",
+			keys:      keys,
+			activeKey: "b",
+		},
+		{
+			name:      "key has an invalid size",
+			keys:      KeyEncryptionKeys{"a": []byte("too-short")},
+			activeKey: "a",
+		},
+		{
+			name:      "data key is wrapped with a removed key",
+			keys:      keys,
+			encoded:   wrappedWithB,
+			activeKey: "a",
+		},
+		{
+			name:      "wrapped key is invalid",
+			keys:      keys,
+			encoded:   []byte("not json"),
+			activeKey: "a",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.keys.EnsureWrappedKey(tc.encoded, tc.activeKey); err == nil {
+				t.Fatal("expected an error, but got none")
+			}
+		})
+	}
+}
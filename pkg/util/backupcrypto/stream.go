@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backupcrypto implements the client-side envelope encryption of etcd backups.
+// Files are encrypted with a per-cluster data key, which in turn is stored wrapped by
+// a key encryption key that is configured on the backup destination.
+package backupcrypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// Magic is the header every encrypted file starts with. It allows to tell encrypted and
+	// plaintext backups apart, so that restores can decrypt transparently.
+	Magic = "KKPENC01"
+
+	// KeySize is the size of data keys and key encryption keys in bytes (AES-256).
+	KeySize = 32
+
+	// segmentSize is the amount of plaintext encrypted as one unit. Each segment is
+	// authenticated on its own, so files can be encrypted and decrypted as a stream.
+	segmentSize = 64 * 1024
+
+	noncePrefixSize = 7
+)
+
+// IsEncrypted returns true if the given data starts with the header of an encrypted file.
+func IsEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(Magic))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, but is %d bytes", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// segmentNonce returns the nonce for the given segment. The last segment is flagged, so
+// that truncating the file at a segment boundary is detected during decryption.
+func segmentNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, noncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+
+	if last {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buffer  []byte
+	closed  bool
+}
+
+// NewEncryptWriter returns a writer that encrypts everything written to it with the given
+// key and writes the result to w. Close must be called to write the final segment; it does
+// not close w.
+func NewEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	if _, err := w.Write(append([]byte(Magic), prefix...)); err != nil {
+		return nil, err
+	}
+
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		prefix: prefix,
+		buffer: make([]byte, 0, segmentSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed writer")
+	}
+
+	written := 0
+
+	for len(p) > 0 {
+		// a full segment is only sealed once more data follows, as the
+		// last segment must be sealed differently
+		if len(e.buffer) == segmentSize {
+			if err := e.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(e.buffer[len(e.buffer):segmentSize], p)
+		e.buffer = e.buffer[:len(e.buffer)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	if e.counter == math.MaxUint32 {
+		return errors.New("file is too large to be encrypted")
+	}
+
+	sealed := e.aead.Seal(nil, segmentNonce(e.prefix, e.counter, last), e.buffer, nil)
+	if _, err := e.w.Write(sealed); err != nil {
+		return err
+	}
+
+	e.counter++
+	e.buffer = e.buffer[:0]
+
+	return nil
+}
+
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+
+	return e.seal(true)
+}
+
+type decryptReader struct {
+	r         *bufio.Reader
+	aead      cipher.AEAD
+	prefix    []byte
+	counter   uint32
+	segment   []byte
+	plaintext []byte
+	done      bool
+}
+
+// NewDecryptReader returns a reader that decrypts the content of r, which must have been
+// written by an encrypt writer using the same key. Reads fail if the content has been
+// tampered with or truncated.
+func NewDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(Magic)+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	if !IsEncrypted(header) {
+		return nil, errors.New("data is not encrypted")
+	}
+
+	return &decryptReader{
+		r:       bufio.NewReader(r),
+		aead:    aead,
+		prefix:  header[len(Magic):],
+		segment: make([]byte, segmentSize+aead.Overhead()),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plaintext) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plaintext)
+	d.plaintext = d.plaintext[n:]
+
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	n, err := io.ReadFull(d.r, d.segment)
+
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF):
+		d.done = true
+	case err != nil:
+		return err
+	default:
+		// a full segment is the last one if nothing follows it
+		if _, err := d.r.Peek(1); errors.Is(err, io.EOF) {
+			d.done = true
+		}
+	}
+
+	plaintext, err := d.aead.Open(d.segment[:0], segmentNonce(d.prefix, d.counter, d.done), d.segment[:n], nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt segment %d, the data is corrupted or the wrong key was used: %w", d.counter, err)
+	}
+
+	d.counter++
+	d.plaintext = plaintext
+
+	return nil
+}
+
+// Encrypt encrypts the given data in memory.
+func Encrypt(data, key []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := NewEncryptWriter(&buf, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decrypt decrypts the given data in memory.
+func Decrypt(data, key []byte) ([]byte, error) {
+	r, err := NewDecryptReader(bytes.NewReader(data), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
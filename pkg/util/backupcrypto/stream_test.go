@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupcrypto
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func randomData(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+
+	return data
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := randomData(1, KeySize)
+
+	testcases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "empty input",
+			data: nil,
+		},
+		{
+			name: "input smaller than a segment",
+			data: randomData(2, 100),
+		},
+		{
+			name: "input of exactly one segment",
+			data: randomData(3, segmentSize),
+		},
+		{
+			name: "input spanning multiple segments",
+			data: randomData(4, 3*segmentSize+42),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			encrypted, err := Encrypt(tc.data, key)
+			if err != nil {
+				t.Fatalf("failed to encrypt: %v", err)
+			}
+
+			if !IsEncrypted(encrypted) {
+				t.Fatal("encrypted data does not start with the magic header")
+			}
+
+			decrypted, err := Decrypt(encrypted, key)
+			if err != nil {
+				t.Fatalf("failed to decrypt: %v", err)
+			}
+
+			if !bytes.Equal(decrypted, tc.data) {
+				t.Fatal("decrypted data does not match the input")
+			}
+		})
+	}
+}
+
+func TestDecryptDetectsTampering(t *testing.T) {
+	key := randomData(1, KeySize)
+	data := randomData(2, 2*segmentSize+100)
+
+	encrypted, err := Encrypt(data, key)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	header := len(Magic) + noncePrefixSize
+	sealedSegment := segmentSize + 16
+
+	testcases := []struct {
+		name string
+		data []byte
+		key  []byte
+	}{
+		{
+			name: "wrong key",
+			data: encrypted,
+			key:  randomData(3, KeySize),
+		},
+		{
+			name: "modified content",
+			data: func() []byte {
+				modified := bytes.Clone(encrypted)
+				modified[header+10] ^= 0xff
+				return modified
+			}(),
+			key: key,
+		},
+		{
+			name: "truncated at a segment boundary",
+			data: encrypted[:header+2*sealedSegment],
+			key:  key,
+		},
+		{
+			name: "truncated within a segment",
+			data: encrypted[:len(encrypted)-5],
+			key:  key,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Decrypt(tc.data, tc.key); err == nil {
+				t.Fatal("expected decryption to fail, but it succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunkstore
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	// MinChunkSize is the smallest chunk the Chunker emits (except for the last chunk of a stream).
+	MinChunkSize = 256 * 1024
+	// AvgChunkSize is the chunk size the Chunker aims for.
+	AvgChunkSize = 1024 * 1024
+	// MaxChunkSize is the largest chunk the Chunker emits.
+	MaxChunkSize = 4 * 1024 * 1024
+
+	// Before reaching AvgChunkSize, a stricter mask is used to make cuts less likely,
+	// afterwards a looser one. This "normalized chunking" narrows the distribution of
+	// chunk sizes around the average. The masks use the most significant bits of the
+	// gear hash, as those depend on the largest window of input bytes.
+	maskSmall = uint64(1<<22-1) << (64 - 22)
+	maskLarge = uint64(1<<18-1) << (64 - 18)
+)
+
+// gearTable maps every byte value to a pseudo-random 64 bit number. The table must
+// never change, otherwise chunk boundaries shift and previously stored chunks cannot
+// be deduplicated anymore.
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+
+	// splitmix64 with a fixed seed
+	state := uint64(0x6b6b702d63686b73)
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+
+	return table
+}()
+
+// Chunker splits a stream into content-defined chunks, i.e. the chunk boundaries depend
+// on the data itself and not on its offset. Inserting or removing data in the stream
+// therefore only changes the chunks around the modification, all other chunks stay
+// identical and can be deduplicated.
+type Chunker struct {
+	r     io.Reader
+	buf   []byte
+	start int
+	end   int
+	eof   bool
+}
+
+// NewChunker returns a Chunker reading from r.
+func NewChunker(r io.Reader) *Chunker {
+	return &Chunker{
+		r:   r,
+		buf: make([]byte, MaxChunkSize),
+	}
+}
+
+// Next returns the next chunk of the stream. After the last chunk, io.EOF is returned.
+// The returned slice is owned by the caller.
+func (c *Chunker) Next() ([]byte, error) {
+	if err := c.fill(); err != nil {
+		return nil, err
+	}
+
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+
+	n := cutPoint(c.buf[c.start:c.end])
+
+	chunk := make([]byte, n)
+	copy(chunk, c.buf[c.start:c.start+n])
+	c.start += n
+
+	return chunk, nil
+}
+
+// fill makes sure that the buffer contains at least MaxChunkSize bytes, unless the
+// underlying reader is exhausted.
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= MaxChunkSize {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cutPoint returns the length of the first chunk in data, using a gear hash based
+// rolling checksum (as described in the FastCDC paper).
+func cutPoint(data []byte) int {
+	n := len(data)
+	if n <= MinChunkSize {
+		return n
+	}
+	if n > MaxChunkSize {
+		n = MaxChunkSize
+	}
+
+	normal := min(n, AvgChunkSize)
+
+	var hash uint64
+
+	i := MinChunkSize
+	for ; i < normal; i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&maskSmall == 0 {
+			return i + 1
+		}
+	}
+
+	for ; i < n; i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&maskLarge == 0 {
+			return i + 1
+		}
+	}
+
+	return n
+}
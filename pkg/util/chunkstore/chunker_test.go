@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunkstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func randomData(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+
+	return data
+}
+
+func splitIntoChunks(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+
+	var chunks [][]byte
+
+	chunker := NewChunker(bytes.NewReader(data))
+	for {
+		chunk, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read chunk: %v", err)
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+func TestChunker(t *testing.T) {
+	testcases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "empty input",
+			data: nil,
+		},
+		{
+			name: "input smaller than the minimum chunk size",
+			data: randomData(1, MinChunkSize/2),
+		},
+		{
+			name: "random input",
+			data: randomData(2, 20*1024*1024),
+		},
+		{
+			name: "input without any cut points",
+			data: make([]byte, 3*MaxChunkSize+42),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := splitIntoChunks(t, tc.data)
+
+			if !bytes.Equal(bytes.Join(chunks, nil), tc.data) {
+				t.Fatal("concatenated chunks do not match the input")
+			}
+
+			for i, chunk := range chunks {
+				if len(chunk) > MaxChunkSize {
+					t.Errorf("chunk %d is %d bytes large, larger than the maximum of %d bytes", i, len(chunk), MaxChunkSize)
+				}
+				if len(chunk) < MinChunkSize && i < len(chunks)-1 {
+					t.Errorf("chunk %d is %d bytes large, smaller than the minimum of %d bytes", i, len(chunk), MinChunkSize)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkerIsContentDefined(t *testing.T) {
+	original := randomData(3, 20*1024*1024)
+
+	// insert a few bytes near the beginning, which shifts all following data
+	modified := append([]byte{}, original[:1000]...)
+	modified = append(modified, []byte("hello world")...)
+	modified = append(modified, original[1000:]...)
+
+	originalChunks := map[[32]byte]bool{}
+	for _, chunk := range splitIntoChunks(t, original) {
+		originalChunks[sha256.Sum256(chunk)] = true
+	}
+
+	modifiedChunks := splitIntoChunks(t, modified)
+
+	var changed int
+	for _, chunk := range modifiedChunks {
+		if !originalChunks[sha256.Sum256(chunk)] {
+			changed++
+		}
+	}
+
+	// only the chunk containing the insertion (and maybe its successor) should differ
+	if changed > 2 {
+		t.Errorf("expected at most 2 of %d chunks to change, but %d did", len(modifiedChunks), changed)
+	}
+}
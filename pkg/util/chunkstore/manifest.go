@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunkstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format written by this package.
+	ManifestVersion = 1
+
+	// ManifestSuffix is the suffix of all manifest object names. It is used to tell
+	// chunked backups apart from regular snapshots.
+	ManifestSuffix = ".manifest.json"
+
+	// chunkPrefixSuffix is appended to the cluster name to form the prefix of all
+	// chunk objects of a cluster.
+	chunkPrefixSuffix = "-chunks/"
+)
+
+// Manifest describes a single chunked file, usually an etcd snapshot. Concatenating
+// the (uncompressed) chunks in order yields the original file.
+type Manifest struct {
+	// Version is the version of the manifest format.
+	Version int `json:"version"`
+	// Size is the total size of the original file in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex-encoded SHA256 checksum of the original file. For encrypted
+	// files, this is an HMAC-SHA256 keyed with the data key instead.
+	SHA256 string `json:"sha256"`
+	// Encrypted is true if the chunks are encrypted with the cluster's data key.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Chunks are the chunks the original file consists of, in order.
+	Chunks []ChunkRef `json:"chunks"`
+}
+
+// ChunkRef references a single chunk.
+type ChunkRef struct {
+	// ID is the hex-encoded SHA256 checksum of the chunk's uncompressed content. For
+	// encrypted chunks, this is an HMAC-SHA256 keyed with the data key instead, so that
+	// the ID does not allow to confirm guesses about the content.
+	ID string `json:"id"`
+	// Size is the uncompressed size of the chunk in bytes.
+	Size int64 `json:"size"`
+}
+
+// IsManifest returns true if the given object name refers to a manifest.
+func IsManifest(objectName string) bool {
+	return strings.HasSuffix(objectName, ManifestSuffix)
+}
+
+// ChunkPrefix returns the prefix under which all chunks of the given cluster are stored.
+// Chunks are only shared among backups of the same cluster.
+func ChunkPrefix(cluster string) string {
+	return cluster + chunkPrefixSuffix
+}
+
+// IsChunk returns true if the given object name refers to a chunk of the given cluster.
+func IsChunk(cluster, objectName string) bool {
+	return strings.HasPrefix(objectName, ChunkPrefix(cluster))
+}
+
+// ChunkObjectName returns the name of the object storing the chunk with the given ID.
+// Chunks are fanned out into sub-directories to keep listings manageable.
+func ChunkObjectName(cluster, id string) string {
+	return fmt.Sprintf("%s%s/%s", ChunkPrefix(cluster), id[:2], id)
+}
+
+// ReadManifest decodes and validates a manifest.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	var size int64
+	for _, chunk := range manifest.Chunks {
+		if len(chunk.ID) != 64 {
+			return nil, fmt.Errorf("invalid chunk ID %q", chunk.ID)
+		}
+		size += chunk.Size
+	}
+
+	if size != manifest.Size {
+		return nil, fmt.Errorf("chunks add up to %d bytes, but manifest specifies %d bytes", size, manifest.Size)
+	}
+
+	return manifest, nil
+}
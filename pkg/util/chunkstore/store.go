@@ -0,0 +1,382 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunkstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// GracePeriod is the minimum age of an unreferenced chunk before it is garbage collected.
+// Uploads refresh existing chunks that are older than half the grace period, so an upload
+// must finish within GracePeriod/2 to not have its chunks collected underneath it.
+const GracePeriod = 6 * time.Hour
+
+// Store stores files as manifests plus deduplicated chunks in an S3 bucket. All chunks of
+// a cluster are shared by all of its manifests.
+type Store struct {
+	client  *minio.Client
+	bucket  string
+	cluster string
+	dataKey []byte
+}
+
+// UploadStats summarizes an upload.
+type UploadStats struct {
+	// Chunks is the number of chunks the uploaded file consists of.
+	Chunks int
+	// NewChunks is the number of chunks that did not exist yet and had to be uploaded.
+	NewChunks int
+	// UploadedBytes is the compressed size of all newly uploaded chunks.
+	UploadedBytes int64
+}
+
+// New returns a Store for the given cluster's chunks in the given bucket.
+func New(client *minio.Client, bucket, cluster string) *Store {
+	return &Store{
+		client:  client,
+		bucket:  bucket,
+		cluster: cluster,
+	}
+}
+
+// WithEncryption makes the store encrypt all chunks it uploads with the given data key.
+// Unencrypted manifests can still be downloaded.
+func (s *Store) WithEncryption(dataKey []byte) *Store {
+	s.dataKey = dataKey
+	return s
+}
+
+// newHash returns the hash used for chunk IDs and file checksums. Encrypted chunks use
+// a keyed hash, as plain checksums would leak information about their content.
+func (s *Store) newHash(encrypted bool) hash.Hash {
+	if encrypted {
+		return hmac.New(sha256.New, s.dataKey)
+	}
+
+	return sha256.New()
+}
+
+// Upload splits the content of r into chunks, uploads all chunks which are not stored yet
+// and finally writes the manifest to manifestName. The manifest is only written once all of
+// its chunks are stored, so a manifest never references missing chunks.
+func (s *Store) Upload(ctx context.Context, r io.Reader, manifestName string) (*Manifest, *UploadStats, error) {
+	if !IsManifest(manifestName) {
+		return nil, nil, fmt.Errorf("manifest name %q must end in %q", manifestName, ManifestSuffix)
+	}
+
+	encrypted := s.dataKey != nil
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		Encrypted: encrypted,
+	}
+	stats := &UploadStats{}
+
+	fileHash := s.newHash(encrypted)
+	chunker := NewChunker(io.TeeReader(r, fileHash))
+	stored := sets.New[string]()
+
+	for {
+		chunk, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read chunk: %w", err)
+		}
+
+		chunkHash := s.newHash(encrypted)
+		chunkHash.Write(chunk)
+		id := hex.EncodeToString(chunkHash.Sum(nil))
+
+		if !stored.Has(id) {
+			uploaded, err := s.storeChunk(ctx, id, chunk)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to store chunk %s: %w", id, err)
+			}
+
+			if uploaded > 0 {
+				stats.NewChunks++
+				stats.UploadedBytes += uploaded
+			}
+
+			stored.Insert(id)
+		}
+
+		manifest.Chunks = append(manifest.Chunks, ChunkRef{
+			ID:   id,
+			Size: int64(len(chunk)),
+		})
+		manifest.Size += int64(len(chunk))
+	}
+
+	manifest.SHA256 = hex.EncodeToString(fileHash.Sum(nil))
+	stats.Chunks = len(manifest.Chunks)
+
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, manifestName, bytes.NewReader(encoded), int64(len(encoded)), minio.PutObjectOptions{
+		ContentType: "application/json",
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to upload manifest: %w", err)
+	}
+
+	return manifest, stats, nil
+}
+
+// storeChunk uploads a chunk unless it exists already. Existing chunks that are close to
+// being garbage collected are refreshed. It returns the number of uploaded bytes.
+func (s *Store) storeChunk(ctx context.Context, id string, chunk []byte) (int64, error) {
+	objectName := ChunkObjectName(s.cluster, id)
+
+	info, err := s.client.StatObject(ctx, s.bucket, objectName, minio.StatObjectOptions{})
+	if err == nil {
+		if time.Since(info.LastModified) > GracePeriod/2 {
+			// copying the object onto itself updates its modification time
+			if _, err := s.client.CopyObject(ctx, minio.CopyDestOptions{
+				Bucket:          s.bucket,
+				Object:          objectName,
+				ReplaceMetadata: true,
+			}, minio.CopySrcOptions{
+				Bucket: s.bucket,
+				Object: objectName,
+			}); err != nil {
+				return 0, fmt.Errorf("failed to refresh chunk: %w", err)
+			}
+		}
+
+		return 0, nil
+	}
+
+	if minio.ToErrorResponse(err).StatusCode != http.StatusNotFound {
+		return 0, err
+	}
+
+	var compressed bytes.Buffer
+
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(chunk); err != nil {
+		return 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return 0, err
+	}
+
+	content := compressed.Bytes()
+	contentType := "application/gzip"
+
+	if s.dataKey != nil {
+		content, err = backupcrypto.Encrypt(content, s.dataKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt chunk: %w", err)
+		}
+
+		contentType = "application/octet-stream"
+	}
+
+	size := int64(len(content))
+
+	if _, err := s.client.PutObject(ctx, s.bucket, objectName, bytes.NewReader(content), size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// GetManifest downloads and decodes the manifest with the given name.
+func (s *Store) GetManifest(ctx context.Context, manifestName string) (*Manifest, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, manifestName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return ReadManifest(object)
+}
+
+// Download reassembles the file described by the given manifest and writes it to w.
+// All chunks and the file as a whole are verified against their checksums.
+func (s *Store) Download(ctx context.Context, manifestName string, w io.Writer) error {
+	manifest, err := s.GetManifest(ctx, manifestName)
+	if err != nil {
+		return fmt.Errorf("failed to get manifest: %w", err)
+	}
+
+	if manifest.Encrypted && s.dataKey == nil {
+		return errors.New("backup is encrypted, but no data key is available")
+	}
+
+	fileHash := s.newHash(manifest.Encrypted)
+	out := io.MultiWriter(w, fileHash)
+
+	for i, chunk := range manifest.Chunks {
+		if err := s.downloadChunk(ctx, chunk, manifest.Encrypted, out); err != nil {
+			return fmt.Errorf("failed to download chunk %d (%s): %w", i, chunk.ID, err)
+		}
+	}
+
+	if sum := hex.EncodeToString(fileHash.Sum(nil)); sum != manifest.SHA256 {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", manifest.SHA256, sum)
+	}
+
+	return nil
+}
+
+func (s *Store) downloadChunk(ctx context.Context, chunk ChunkRef, encrypted bool, w io.Writer) error {
+	object, err := s.client.GetObject(ctx, s.bucket, ChunkObjectName(s.cluster, chunk.ID), minio.GetObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	var compressed io.Reader = object
+	if encrypted {
+		compressed, err = backupcrypto.NewDecryptReader(object, s.dataKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	chunkHash := s.newHash(encrypted)
+
+	n, err := io.Copy(io.MultiWriter(w, chunkHash), gz)
+	if err != nil {
+		return err
+	}
+
+	if n != chunk.Size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d bytes", chunk.Size, n)
+	}
+
+	if sum := hex.EncodeToString(chunkHash.Sum(nil)); sum != chunk.ID {
+		return fmt.Errorf("checksum mismatch: got %s", sum)
+	}
+
+	return nil
+}
+
+// Delete removes the given manifest and afterwards garbage collects all chunks which are
+// not referenced by any remaining manifest of the cluster anymore.
+func (s *Store) Delete(ctx context.Context, log *zap.SugaredLogger, manifestName string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, manifestName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete manifest: %w", err)
+	}
+
+	deleted, err := s.GarbageCollect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to garbage collect chunks: %w", err)
+	}
+
+	log.Infow("garbage collected chunks", "deleted", deleted)
+
+	return nil
+}
+
+// GarbageCollect deletes all chunks of the cluster which are not referenced by any of its
+// manifests and which are older than GracePeriod. It returns the number of deleted chunks.
+func (s *Store) GarbageCollect(ctx context.Context) (int, error) {
+	referenced := sets.New[string]()
+
+	// manifests must be read before listing the chunks: chunks of manifests written in the
+	// meantime are then either listed as referenced or protected by the grace period
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.cluster + "-"}) {
+		if object.Err != nil {
+			return 0, fmt.Errorf("failed to list manifests: %w", object.Err)
+		}
+
+		if !IsManifest(object.Key) {
+			continue
+		}
+
+		manifest, err := s.GetManifest(ctx, object.Key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get manifest %s: %w", object.Key, err)
+		}
+
+		for _, chunk := range manifest.Chunks {
+			referenced.Insert(chunk.ID)
+		}
+	}
+
+	var chunks []minio.ObjectInfo
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: ChunkPrefix(s.cluster), Recursive: true}) {
+		if object.Err != nil {
+			return 0, fmt.Errorf("failed to list chunks: %w", object.Err)
+		}
+
+		chunks = append(chunks, object)
+	}
+
+	unreferenced := getUnreferencedChunks(chunks, referenced, time.Now().Add(-GracePeriod))
+
+	for _, objectName := range unreferenced {
+		if err := s.client.RemoveObject(ctx, s.bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+			return 0, fmt.Errorf("failed to delete chunk %s: %w", objectName, err)
+		}
+	}
+
+	return len(unreferenced), nil
+}
+
+// getUnreferencedChunks returns the names of all chunk objects which are not referenced
+// and were last modified before the given cutoff.
+func getUnreferencedChunks(chunks []minio.ObjectInfo, referenced sets.Set[string], cutoff time.Time) []string {
+	var result []string
+
+	for _, chunk := range chunks {
+		id := chunk.Key[strings.LastIndex(chunk.Key, "/")+1:]
+
+		if referenced.Has(id) || chunk.LastModified.After(cutoff) {
+			continue
+		}
+
+		result = append(result, chunk.Key)
+	}
+
+	return result
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunkstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestGetUnreferencedChunks(t *testing.T) {
+	now := time.Now()
+	cutoff := now.Add(-GracePeriod)
+
+	idA := strings.Repeat("a", 64)
+	idB := strings.Repeat("b", 64)
+	idC := strings.Repeat("c", 64)
+
+	tests := []struct {
+		name       string
+		chunks     []minio.ObjectInfo
+		referenced sets.Set[string]
+		expected   []string
+	}{
+		{
+			name: "referenced chunks are kept",
+			chunks: []minio.ObjectInfo{
+				{Key: ChunkObjectName("abcd", idA), LastModified: now.Add(-24 * time.Hour)},
+			},
+			referenced: sets.New(idA),
+			expected:   nil,
+		},
+		{
+			name: "unreferenced old chunks are deleted",
+			chunks: []minio.ObjectInfo{
+				{Key: ChunkObjectName("abcd", idA), LastModified: now.Add(-24 * time.Hour)},
+				{Key: ChunkObjectName("abcd", idB), LastModified: now.Add(-24 * time.Hour)},
+			},
+			referenced: sets.New(idA),
+			expected:   []string{ChunkObjectName("abcd", idB)},
+		},
+		{
+			name: "unreferenced chunks within the grace period are kept",
+			chunks: []minio.ObjectInfo{
+				{Key: ChunkObjectName("abcd", idB), LastModified: now.Add(-24 * time.Hour)},
+				{Key: ChunkObjectName("abcd", idC), LastModified: now.Add(-time.Minute)},
+			},
+			referenced: sets.New[string](),
+			expected:   []string{ChunkObjectName("abcd", idB)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := getUnreferencedChunks(test.chunks, test.referenced, cutoff)
+			if d := diff.ObjectDiff(test.expected, result); d != "" {
+				t.Errorf("Unexpected chunks to delete:\n%v", d)
+			}
+		})
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	id := strings.Repeat("a", 64)
+
+	tests := []struct {
+		name        string
+		manifest    string
+		expectedErr bool
+	}{
+		{
+			name:     "valid manifest",
+			manifest: `{"version":1,"size":10,"sha256":"x","chunks":[{"id":"` + id + `","size":4},{"id":"` + id + `","size":6}]}`,
+		},
+		{
+			name:        "unknown version",
+			manifest:    `{"version":2,"size":0,"sha256":"x","chunks":[]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "size mismatch",
+			manifest:    `{"version":1,"size":11,"sha256":"x","chunks":[{"id":"` + id + `","size":4}]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid chunk ID",
+			manifest:    `{"version":1,"size":4,"sha256":"x","chunks":[{"id":"../../etc","size":4}]}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadManifest(strings.NewReader(test.manifest))
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected error = %v, but got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/validation/openapi"
@@ -36,6 +37,25 @@ func ValidateApplicationDefinitionSpec(ad appskubermaticv1.ApplicationDefinition
 	allErrs = append(allErrs, ValidateApplicationVersions(ad.Spec.Versions, parentFieldPath.Child("spec"))...)
 	allErrs = append(allErrs, ValidateDeployOpts(ad.Spec.DefaultDeployOptions, parentFieldPath.Child("spec.defaultDeployOptions"))...)
 	allErrs = append(allErrs, ValidateApplicationValues(ad.Spec, parentFieldPath.Child("spec"))...)
+	allErrs = append(allErrs, validateMethodSources(ad.Spec, parentFieldPath.Child("spec"))...)
+	return allErrs
+}
+
+// validateMethodSources ensures that the sources of all versions can be used with the template method.
+// Helm repositories only provide charts, which can only be installed with the helm method.
+func validateMethodSources(spec appskubermaticv1.ApplicationDefinitionSpec, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	if spec.Method != appskubermaticv1.KustomizeTemplateMethod && spec.Method != appskubermaticv1.ManifestsTemplateMethod {
+		return allErrs
+	}
+
+	for i, v := range spec.Versions {
+		if v.Template.Source.Helm != nil {
+			allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child(fmt.Sprintf("versions[%d].template.source.helm", i)), "helm source can not be used with method "+string(spec.Method)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -77,8 +97,15 @@ func ValidateApplicationVersions(vs []appskubermaticv1.ApplicationVersion, paren
 func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 
+	sources := 0
+	for _, defined := range []bool{source.Helm != nil, source.Git != nil, source.OCI != nil} {
+		if defined {
+			sources++
+		}
+	}
+
 	switch {
-	case source.Helm != nil && source.Git != nil:
+	case sources > 1:
 		allErrs = append(allErrs, field.Forbidden(f, "only source type can be provided"))
 	case source.Git != nil:
 		allErrs = append(allErrs, validateGitSource(source.Git, f.Child("git"))...)
@@ -86,6 +113,8 @@ func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []
 		if e := validateHelmCredentials(source.Helm.Credentials, f.Child("helm.credentials")); e != nil {
 			allErrs = append(allErrs, e)
 		}
+	case source.OCI != nil:
+		allErrs = append(allErrs, validateOCISource(source.OCI, f.Child("oci"))...)
 
 	default:
 		allErrs = append(allErrs, field.Required(f, "no source provided"))
@@ -110,6 +139,33 @@ func validateHelmCredentials(credential *appskubermaticv1.HelmCredentials, f *fi
 	return nil
 }
 
+func validateOCISource(ociSource *appskubermaticv1.OCISource, f *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	if len(ociSource.Tag) == 0 && len(ociSource.Digest) == 0 {
+		allErrs = append(allErrs, field.Required(f, "at least a tag or a digest must be defined"))
+	}
+
+	if strings.Contains(ociSource.Repository, "://") {
+		allErrs = append(allErrs, field.Invalid(f.Child("repository"), ociSource.Repository, "repository must not contain a scheme"))
+	}
+
+	if credentials := ociSource.Credentials; credentials != nil {
+		if credentials.RegistryConfigFile != nil && (credentials.Username != nil || credentials.Password != nil) {
+			allErrs = append(allErrs, field.Forbidden(f.Child("credentials.registryConfigFile"), "registryConfigFile can not be used in conjunction with username / password"))
+		}
+
+		if credentials.Username != nil && credentials.Password == nil {
+			allErrs = append(allErrs, field.Forbidden(f.Child("credentials.password"), "password must be specified with username"))
+		}
+		if credentials.Password != nil && credentials.Username == nil {
+			allErrs = append(allErrs, field.Forbidden(f.Child("credentials.username"), "username must be specified  with password"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateGitSource(gitSource *appskubermaticv1.GitSource, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 
@@ -179,6 +179,27 @@ func TestValidateApplicationDefinitionSpec(t *testing.T) {
 			},
 			0,
 		},
+		"valid kustomize method with git source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.KustomizeTemplateMethod
+					s.Versions = []appskubermaticv1.ApplicationVersion{gitv}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid manifests method with helm source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.ManifestsTemplateMethod
+					return *s
+				}(),
+			},
+			1,
+		},
 		"invalid missing source": {
 			appskubermaticv1.ApplicationDefinition{
 				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
@@ -774,6 +795,73 @@ func TestValidateHelmCredentials(t *testing.T) {
 	}
 }
 
+func TestValidateOCISource(t *testing.T) {
+	digest := "sha256:0f9e4a1b0e0d7e9a3a4c1e8d9c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b"
+
+	tt := map[string]struct {
+		source    *appskubermaticv1.OCISource
+		expErrLen int
+	}{
+		"valid: tag": {
+			&appskubermaticv1.OCISource{Repository: "localhost:5000/charts/apache", Tag: "9.1.3"},
+			0,
+		},
+		"valid: digest with credentials and signature verification": {
+			&appskubermaticv1.OCISource{
+				Repository:   "localhost:5000/charts/apache",
+				Digest:       digest,
+				Credentials:  &appskubermaticv1.OCICredentials{Username: secretKeySelector, Password: secretKeySelector},
+				Verification: &appskubermaticv1.OCISignatureVerification{PublicKey: *secretKeySelector},
+			},
+			0,
+		},
+		"invalid: neither tag nor digest": {
+			&appskubermaticv1.OCISource{Repository: "localhost:5000/charts/apache"},
+			1,
+		},
+		"invalid: digest is not sha256": {
+			&appskubermaticv1.OCISource{Repository: "localhost:5000/charts/apache", Digest: "md5:1234"},
+			1,
+		},
+		"invalid: repository with scheme": {
+			&appskubermaticv1.OCISource{Repository: "oci://localhost:5000/charts/apache", Tag: "9.1.3"},
+			1,
+		},
+		"invalid: username and RegistryConfigFile are defined": {
+			&appskubermaticv1.OCISource{
+				Repository:  "localhost:5000/charts/apache",
+				Tag:         "9.1.3",
+				Credentials: &appskubermaticv1.OCICredentials{Username: secretKeySelector, Password: secretKeySelector, RegistryConfigFile: secretKeySelector},
+			},
+			1,
+		},
+		"invalid: helm and oci source are defined": {
+			&appskubermaticv1.OCISource{Repository: "localhost:5000/charts/apache", Tag: "9.1.3"},
+			1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			s := spec.DeepCopy()
+			s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{OCI: tc.source}
+			if name == "invalid: helm and oci source are defined" {
+				s.Versions[0].Template.Source.Helm = validHelmSource()
+			}
+
+			ad := appskubermaticv1.ApplicationDefinition{
+				TypeMeta: metav1.TypeMeta{Kind: "ApplicationDefinition", APIVersion: "apps.kubermatic.k8c.io/v1"},
+				Spec:     *s,
+			}
+
+			errl := ValidateApplicationDefinitionSpec(ad)
+			if len(errl) != tc.expErrLen {
+				t.Errorf("expected errLen %d, got %d. Errors are %q", tc.expErrLen, len(errl), errl)
+			}
+		})
+	}
+}
+
 func TestValidateGitCredentials(t *testing.T) {
 	tt := map[string]struct {
 		ad        appskubermaticv1.ApplicationDefinition
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var auditLogRedactionPathRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// ValidateAuditLogAggregatorSettings validates the seed-level audit log aggregator settings.
+func ValidateAuditLogAggregatorSettings(settings *kubermaticv1.AuditLogAggregatorSettings) error {
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	if settings.Replicas != nil && *settings.Replicas < 0 {
+		return fmt.Errorf("invalid audit log aggregator replicas (%d): must not be negative", *settings.Replicas)
+	}
+
+	if settings.BufferSize != nil && settings.BufferSize.Sign() <= 0 {
+		return fmt.Errorf("invalid audit log aggregator bufferSize (%s): must be positive", settings.BufferSize.String())
+	}
+
+	for _, rule := range settings.RedactionRules {
+		if !auditLogRedactionPathRegex.MatchString(rule.Path) {
+			return fmt.Errorf("invalid audit log redaction path %q: must be a dot-separated path matching %s", rule.Path, auditLogRedactionPathRegex.String())
+		}
+
+		switch rule.Action {
+		case "", kubermaticv1.AuditLogRedactionRemove, kubermaticv1.AuditLogRedactionMask:
+		default:
+			return fmt.Errorf("invalid audit log redaction action %q for path %q", rule.Action, rule.Path)
+		}
+	}
+
+	if err := validateAuditLogSink(settings.DefaultSink); err != nil {
+		return fmt.Errorf("invalid audit log default sink: %w", err)
+	}
+
+	s3CredentialsSecret := ""
+	if s3 := settings.DefaultSink.S3; s3 != nil && s3.CredentialsSecretRef != nil {
+		s3CredentialsSecret = s3.CredentialsSecretRef.Name
+	}
+
+	for project, sink := range settings.ProjectSinks {
+		if errs := validation.IsDNS1123Label(project); len(errs) != 0 {
+			return fmt.Errorf("invalid audit log project sink %q: project ID must be a valid DNS label: %s", project, strings.Join(errs, ","))
+		}
+
+		if err := validateAuditLogSink(sink); err != nil {
+			return fmt.Errorf("invalid audit log sink for project %q: %w", project, err)
+		}
+
+		// the S3 sinks use the AWS credential chain of the aggregator, so they cannot use different credentials
+		if sink.S3 != nil && sink.S3.CredentialsSecretRef != nil {
+			if s3CredentialsSecret != "" && s3CredentialsSecret != sink.S3.CredentialsSecretRef.Name {
+				return fmt.Errorf("invalid audit log sink for project %q: all S3 sinks must use the same credentials Secret", project)
+			}
+			s3CredentialsSecret = sink.S3.CredentialsSecretRef.Name
+		}
+	}
+
+	// as soon as one S3 sink has credentials, they are used by all S3 sinks, so none may rely on
+	// the default credential chain instead
+	if s3CredentialsSecret != "" {
+		if s3 := settings.DefaultSink.S3; s3 != nil && s3.CredentialsSecretRef == nil {
+			return errors.New("invalid audit log default sink: S3 credentials Secret must be set because other S3 sinks use credentials")
+		}
+
+		for project, sink := range settings.ProjectSinks {
+			if sink.S3 != nil && sink.S3.CredentialsSecretRef == nil {
+				return fmt.Errorf("invalid audit log sink for project %q: S3 credentials Secret must be set because other S3 sinks use credentials", project)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateAuditLogSink(sink kubermaticv1.AuditLogSink) error {
+	configured := 0
+
+	if s3 := sink.S3; s3 != nil {
+		configured++
+
+		if s3.Bucket == "" {
+			return errors.New("S3 bucket must not be empty")
+		}
+		if s3.Region == "" {
+			return errors.New("S3 region must not be empty")
+		}
+		if s3.Endpoint != "" {
+			if err := validateAuditLogSinkURL(s3.Endpoint); err != nil {
+				return fmt.Errorf("invalid S3 endpoint: %w", err)
+			}
+		}
+		if s3.CredentialsSecretRef != nil && s3.CredentialsSecretRef.Name == "" {
+			return errors.New("S3 credentials Secret name must not be empty")
+		}
+	}
+
+	if loki := sink.Loki; loki != nil {
+		configured++
+
+		if err := validateAuditLogSinkURL(loki.URL); err != nil {
+			return fmt.Errorf("invalid Loki URL: %w", err)
+		}
+		if loki.CredentialsSecretRef != nil && loki.CredentialsSecretRef.Name == "" {
+			return errors.New("Loki credentials Secret name must not be empty")
+		}
+	}
+
+	if syslog := sink.Syslog; syslog != nil {
+		configured++
+
+		if syslog.Host == "" {
+			return errors.New("syslog host must not be empty")
+		}
+		if errs := validation.IsValidPortNum(int(syslog.Port)); len(errs) != 0 {
+			return fmt.Errorf("invalid syslog port: %s", strings.Join(errs, ","))
+		}
+
+		switch syslog.Mode {
+		case "", kubermaticv1.AuditLogSyslogModeTCP, kubermaticv1.AuditLogSyslogModeUDP, kubermaticv1.AuditLogSyslogModeTLS:
+		default:
+			return fmt.Errorf("invalid syslog mode %q", syslog.Mode)
+		}
+	}
+
+	if http := sink.HTTP; http != nil {
+		configured++
+
+		if err := validateAuditLogSinkURL(http.URL); err != nil {
+			return fmt.Errorf("invalid HTTP URL: %w", err)
+		}
+		if http.CredentialsSecretRef != nil && http.CredentialsSecretRef.Name == "" {
+			return errors.New("HTTP credentials Secret name must not be empty")
+		}
+	}
+
+	if configured != 1 {
+		return fmt.Errorf("exactly one of s3, loki, syslog or http must be configured, found %d", configured)
+	}
+
+	return nil
+}
+
+func validateAuditLogSinkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return errors.New("host must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateAuditLogAggregatorSettings(t *testing.T) {
+	lokiSink := kubermaticv1.AuditLogSink{
+		Loki: &kubermaticv1.AuditLogLokiSink{URL: "https://loki.example.com"},
+	}
+
+	testcases := []struct {
+		name     string
+		settings *kubermaticv1.AuditLogAggregatorSettings
+		valid    bool
+	}{
+		{
+			name:  "not configured",
+			valid: true,
+		},
+		{
+			name: "disabled settings are not validated",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: false,
+			},
+			valid: true,
+		},
+		{
+			name: "default sink with project sinks and redaction rules",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				RedactionRules: []kubermaticv1.AuditLogRedactionRule{
+					{Path: "requestObject.data"},
+					{Path: "user.extra", Action: kubermaticv1.AuditLogRedactionRemove},
+				},
+				DefaultSink: lokiSink,
+				ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+					"abcd1234": {
+						Syslog: &kubermaticv1.AuditLogSyslogSink{Host: "syslog.example.com", Port: 6514, Mode: kubermaticv1.AuditLogSyslogModeTLS},
+					},
+					"efgh5678": {
+						HTTP: &kubermaticv1.AuditLogHTTPSink{URL: "http://collector.example.com:8080/audit"},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "missing default sink",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+			},
+			valid: false,
+		},
+		{
+			name: "multiple sinks in one",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				DefaultSink: kubermaticv1.AuditLogSink{
+					Loki: lokiSink.Loki,
+					HTTP: &kubermaticv1.AuditLogHTTPSink{URL: "https://collector.example.com"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid redaction path",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				RedactionRules: []kubermaticv1.AuditLogRedactionRule{
+					{Path: "requestObject..data"},
+				},
+				DefaultSink: lokiSink,
+			},
+			valid: false,
+		},
+		{
+			name: "invalid project ID",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled:     true,
+				DefaultSink: lokiSink,
+				ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+					"Not_A_Project": lokiSink,
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "Loki URL without scheme",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				DefaultSink: kubermaticv1.AuditLogSink{
+					Loki: &kubermaticv1.AuditLogLokiSink{URL: "loki.example.com"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "S3 sinks with different credentials",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				DefaultSink: kubermaticv1.AuditLogSink{
+					S3: &kubermaticv1.AuditLogS3Sink{
+						Bucket:               "audit",
+						Region:               "eu-central-1",
+						CredentialsSecretRef: &corev1.LocalObjectReference{Name: "s3-credentials"},
+					},
+				},
+				ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+					"abcd1234": {
+						S3: &kubermaticv1.AuditLogS3Sink{
+							Bucket:               "project-audit",
+							Region:               "eu-central-1",
+							CredentialsSecretRef: &corev1.LocalObjectReference{Name: "other-credentials"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "default S3 sink without credentials",
+			settings: &kubermaticv1.AuditLogAggregatorSettings{
+				Enabled: true,
+				DefaultSink: kubermaticv1.AuditLogSink{
+					S3: &kubermaticv1.AuditLogS3Sink{
+						Bucket: "audit",
+						Region: "eu-central-1",
+					},
+				},
+				ProjectSinks: map[string]kubermaticv1.AuditLogSink{
+					"abcd1234": {
+						S3: &kubermaticv1.AuditLogS3Sink{
+							Bucket:               "project-audit",
+							Region:               "eu-central-1",
+							CredentialsSecretRef: &corev1.LocalObjectReference{Name: "s3-credentials"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuditLogAggregatorSettings(tt.settings)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Expected settings to be valid, but got err: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("Expected settings to be invalid, but they were accepted.")
+				}
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/gcp"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	encryptionresources "k8c.io/kubermatic/v2/pkg/resources/encryption"
 	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/version"
 	clusterversion "k8c.io/kubermatic/v2/pkg/version/cluster"
@@ -107,9 +108,9 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("APIServerAllowedIPRanges"), "Access control for API server is supported only for LoadBalancer expose strategy"))
 	}
 
-	// Validate TunnelingAgentIP for Tunneling Expose strategy
-	if spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling && spec.ClusterNetwork.TunnelingAgentIP != "" {
-		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("TunnelingAgentIP"), "Tunneling agent IP can be configured only for Tunneling Expose strategy"))
+	// Validate TunnelingAgentIP for Tunneling and Gateway Expose strategies
+	if spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling && spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway && spec.ClusterNetwork.TunnelingAgentIP != "" {
+		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("TunnelingAgentIP"), "Tunneling agent IP can be configured only for Tunneling and Gateway Expose strategies"))
 	}
 
 	// External CCM is not supported for all providers and all Kubernetes versions.
@@ -162,7 +163,7 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, err)
 	}
 
-	if errs := validateEncryptionConfiguration(spec, parentFieldPath.Child("encryptionConfiguration")); len(errs) > 0 {
+	if errs := validateEncryptionConfiguration(spec, dc, parentFieldPath.Child("encryptionConfiguration")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
@@ -295,7 +296,7 @@ func ValidateClusterUpdate(ctx context.Context, newCluster, oldCluster *kubermat
 		allErrs = append(allErrs, err)
 	}
 
-	if errs := validateEncryptionUpdate(newCluster, oldCluster); len(errs) > 0 {
+	if errs := validateEncryptionUpdate(oldCluster, newCluster); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
@@ -466,7 +467,7 @@ func validateProxyMode(n *kubermaticv1.ClusterNetworkingConfig, cni *kubermaticv
 	return allErrs
 }
 
-func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *field.Path) field.ErrorList {
+func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datacenter, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if spec.EncryptionConfiguration != nil && spec.EncryptionConfiguration.Enabled {
@@ -475,12 +476,16 @@ func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *
 				fmt.Sprintf("cannot enable encryption configuration if feature gate '%s' is not set", kubermaticv1.ClusterFeatureEncryptionAtRest)))
 		}
 
-		// TODO: Update with implementations of other encryption providers (KMS)
-
-		if spec.EncryptionConfiguration.Secretbox == nil {
+		switch {
+		case spec.EncryptionConfiguration.Secretbox == nil && spec.EncryptionConfiguration.KMS == nil:
 			allErrs = append(allErrs, field.Required(fieldPath.Child("secretbox"),
 				"exactly one encryption provider (secretbox, kms) needs to be configured"))
-		} else {
+		case spec.EncryptionConfiguration.Secretbox != nil && spec.EncryptionConfiguration.KMS != nil:
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("kms"),
+				"exactly one encryption provider (secretbox, kms) needs to be configured"))
+		}
+
+		if spec.EncryptionConfiguration.Secretbox != nil {
 			for i, key := range spec.EncryptionConfiguration.Secretbox.Keys {
 				childPath := fieldPath.Child("secretbox", "keys").Index(i)
 				if key.Name == "" {
@@ -506,7 +511,24 @@ func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *
 			}
 		}
 
-		// END TODO
+		if kms := spec.EncryptionConfiguration.KMS; kms != nil {
+			if kms.Name == "" {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("kms", "name"),
+					"KMS provider name is required"))
+			}
+
+			if kms.Plugin == "" {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("kms", "plugin"),
+					"KMS plugin is required"))
+			} else if dc.Spec.GetKMSPlugin(kms.Plugin) == nil {
+				allErrs = append(allErrs, field.NotFound(fieldPath.Child("kms", "plugin"), kms.Plugin))
+			}
+
+			if kms.Timeout != nil && kms.Timeout.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("kms", "timeout"), kms.Timeout.Duration.String(),
+					"timeout must be positive"))
+			}
+		}
 	}
 
 	return allErrs
@@ -561,6 +583,20 @@ func validateEncryptionUpdate(oldCluster *kubermaticv1.Cluster, newCluster *kube
 		}
 	}
 
+	// the KMS plugin is required for reading resources as long as they are encrypted with it
+	if status := oldCluster.Status.Encryption; status != nil && strings.HasPrefix(status.ActiveKey, encryptionresources.KMSPrefix+"/") {
+		if newCluster.Spec.EncryptionConfiguration == nil || newCluster.Spec.EncryptionConfiguration.KMS == nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "encryptionConfiguration", "kms"),
+				"cannot remove KMS plugin while data is encrypted with it, disable encryption and wait for the data to be decrypted first",
+			))
+		} else if old := oldCluster.Spec.EncryptionConfiguration; old != nil && old.KMS != nil && old.KMS.Plugin != newCluster.Spec.EncryptionConfiguration.KMS.Plugin {
+			// kube-apiserver runs a single KMS plugin, so the data encrypted by the old plugin could not be read anymore
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "encryptionConfiguration", "kms", "plugin"),
+				"cannot change KMS plugin while data is encrypted with it, disable encryption and wait for the data to be decrypted first",
+			))
+		}
+	}
+
 	// prevent removing the feature flag while the cluster is still in some encryption-active configuration or state
 	if enabled, ok := newCluster.Spec.Features[kubermaticv1.ClusterFeatureEncryptionAtRest]; (!ok || !enabled) && (newCluster.IsEncryptionEnabled() || newCluster.IsEncryptionActive()) {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("features"),
@@ -1106,11 +1106,175 @@ func TestValidateEncryptionConfiguration(t *testing.T) {
 			},
 			expectErr: field.ErrorList{},
 		},
+		{
+			name: "kms plugin",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Name:   "vault",
+						Plugin: "vault",
+					},
+				},
+			},
+			expectErr: field.ErrorList{},
+		},
+		{
+			name: "kms plugin not selected",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Name: "vault",
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				&field.Error{
+					Type:     "FieldValueRequired",
+					Field:    "spec.encryptionConfiguration.kms.plugin",
+					BadValue: "",
+					Detail:   "KMS plugin is required",
+				},
+			},
+		},
+		{
+			name: "kms plugin not configured in datacenter",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Name:   "vault",
+						Plugin: "softhsm",
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				&field.Error{
+					Type:     "FieldValueNotFound",
+					Field:    "spec.encryptionConfiguration.kms.plugin",
+					BadValue: "softhsm",
+				},
+			},
+		},
+		{
+			name: "secretbox and kms plugin",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{
+						Keys: []kubermaticv1.SecretboxKey{
+							{
+								Name:  "good-key",
+								Value: "RGolflgAc+eBbm1lys87pTNQZVf0i67rlpPZGtTkVjQ=",
+							},
+						},
+					},
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Name:   "vault",
+						Plugin: "vault",
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				&field.Error{
+					Type:     "FieldValueForbidden",
+					Field:    "spec.encryptionConfiguration.kms",
+					BadValue: "",
+					Detail:   "exactly one encryption provider (secretbox, kms) needs to be configured",
+				},
+			},
+		},
+	}
+
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			KMSPlugins: []kubermaticv1.KMSPlugin{
+				{
+					Name:  "vault",
+					Image: "example.com/vault-kms-plugin:v1",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateEncryptionConfiguration(test.clusterSpec, dc, field.NewPath("spec", "encryptionConfiguration"))
+			assert.Equal(t, test.expectErr, err)
+		})
+	}
+}
+
+func TestValidateEncryptionUpdate(t *testing.T) {
+	kmsCluster := func(plugin string, activeKey string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled:   true,
+					Resources: []string{"secrets"},
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Name:   "vault",
+						Plugin: plugin,
+					},
+				},
+			},
+			Status: kubermaticv1.ClusterStatus{
+				Encryption: &kubermaticv1.ClusterEncryptionStatus{
+					Phase:     kubermaticv1.ClusterEncryptionPhaseActive,
+					ActiveKey: activeKey,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		oldCluster *kubermaticv1.Cluster
+		newCluster *kubermaticv1.Cluster
+		expectErr  field.ErrorList
+	}{
+		{
+			name:       "unchanged KMS plugin",
+			oldCluster: kmsCluster("vault", "kms/vault"),
+			newCluster: kmsCluster("vault", "kms/vault"),
+			expectErr:  field.ErrorList{},
+		},
+		{
+			name:       "changed KMS plugin while data is encrypted with it",
+			oldCluster: kmsCluster("vault", "kms/vault"),
+			newCluster: kmsCluster("aws", "kms/vault"),
+			expectErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "encryptionConfiguration", "kms", "plugin"),
+					"cannot change KMS plugin while data is encrypted with it, disable encryption and wait for the data to be decrypted first"),
+			},
+		},
+		{
+			name:       "changed KMS plugin after data has been decrypted",
+			oldCluster: kmsCluster("vault", "identity"),
+			newCluster: kmsCluster("aws", "identity"),
+			expectErr:  field.ErrorList{},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := validateEncryptionConfiguration(test.clusterSpec, field.NewPath("spec", "encryptionConfiguration"))
+			err := validateEncryptionUpdate(test.oldCluster, test.newCluster)
 			assert.Equal(t, test.expectErr, err)
 		})
 	}
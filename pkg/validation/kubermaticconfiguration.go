@@ -25,6 +25,7 @@ import (
 	semverlib "github.com/Masterminds/semver/v3"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/version"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,6 +40,12 @@ func ValidateKubermaticConfigurationSpec(spec *kubermaticv1.KubermaticConfigurat
 		allErrs = append(allErrs, errs...)
 	}
 
+	if window := spec.SeedController.CertificateRenewalWindow; window != nil {
+		if err := resources.ValidateCertificateRenewalWindow(window.Duration); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "seedController", "certificateRenewalWindow"), window.Duration.String(), err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
@@ -18,9 +18,12 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestValidateKubermaticConfigurationVersions(t *testing.T) {
@@ -105,3 +108,57 @@ func TestValidateKubermaticConfigurationVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateKubermaticConfigurationCertificateRenewalWindow(t *testing.T) {
+	testcases := []struct {
+		name   string
+		window *metav1.Duration
+		valid  bool
+	}{
+		{
+			name:  "no window configured",
+			valid: true,
+		},
+		{
+			name:   "valid window",
+			window: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+			valid:  true,
+		},
+		{
+			name:   "window longer than 180 days",
+			window: &metav1.Duration{Duration: 181 * 24 * time.Hour},
+			valid:  false,
+		},
+		{
+			name:   "negative window",
+			window: &metav1.Duration{Duration: -time.Hour},
+			valid:  false,
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			version := semver.NewSemverOrDie("v1.30.0")
+			spec := &kubermaticv1.KubermaticConfigurationSpec{
+				Versions: kubermaticv1.KubermaticVersioningConfiguration{
+					Versions: []semver.Semver{*version},
+					Default:  version,
+				},
+				SeedController: kubermaticv1.KubermaticSeedControllerConfiguration{
+					CertificateRenewalWindow: tt.window,
+				},
+			}
+
+			errs := ValidateKubermaticConfigurationSpec(spec)
+			if tt.valid {
+				if len(errs) > 0 {
+					t.Fatalf("Expected configuration to be valid, but got err: %v", errs.ToAggregate())
+				}
+			} else {
+				if len(errs) == 0 {
+					t.Fatal("Expected configuration to be invalid, but it was accepted.")
+				}
+			}
+		})
+	}
+}
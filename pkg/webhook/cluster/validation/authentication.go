@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	apiserverconfig "k8s.io/apiserver/pkg/apis/apiserver"
+	apiserverconfigv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	apiserverconfigvalidation "k8s.io/apiserver/pkg/apis/apiserver/validation"
+)
+
+// minimumAuthenticationConfigurationVersion is the first Kubernetes version in which structured
+// authentication configuration is enabled by default.
+var minimumAuthenticationConfigurationVersion = semver.NewSemverOrDie("1.30.0")
+
+// validateAuthenticationConfiguration validates the structured authentication configuration with the
+// same rules kube-apiserver applies when loading the configuration file, which includes compiling
+// all CEL expressions.
+func validateAuthenticationConfiguration(cluster *kubermaticv1.Cluster) field.ErrorList {
+	authn := cluster.Spec.AuthenticationConfiguration
+	if authn == nil {
+		return nil
+	}
+
+	fieldPath := field.NewPath("spec", "authenticationConfiguration")
+	allErrs := field.ErrorList{}
+
+	if cluster.Spec.OIDC.IssuerURL != "" || cluster.Spec.OIDC.ClientID != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "cannot be used together with spec.oidc"))
+	}
+
+	if cluster.Spec.Version.LessThan(minimumAuthenticationConfigurationVersion) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("requires Kubernetes %s or newer", minimumAuthenticationConfigurationVersion)))
+	}
+
+	if len(authn.JWT) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("jwt"), "at least one JWT authenticator is required"))
+	}
+
+	config := &apiserverconfig.AuthenticationConfiguration{}
+	if err := apiserverconfigv1beta1.Convert_v1beta1_AuthenticationConfiguration_To_apiserver_AuthenticationConfiguration(apiserver.AuthenticationConfiguration(authn), config, nil); err != nil {
+		return append(allErrs, field.InternalError(fieldPath, err))
+	}
+
+	// tokens of the cluster's own service account issuer are handled by the service account authenticator
+	for _, err := range apiserverconfigvalidation.ValidateAuthenticationConfiguration(config, serviceAccountIssuers(cluster)) {
+		err.Field = fieldPath.String() + "." + err.Field
+		allErrs = append(allErrs, err)
+	}
+
+	return allErrs
+}
+
+func serviceAccountIssuers(cluster *kubermaticv1.Cluster) []string {
+	var issuers []string
+
+	if cluster.Status.Address.URL != "" {
+		issuers = append(issuers, cluster.Status.Address.URL)
+	}
+
+	if cluster.Spec.ServiceAccount != nil && cluster.Spec.ServiceAccount.Issuer != "" {
+		issuers = append(issuers, cluster.Spec.ServiceAccount.Issuer)
+	}
+
+	return issuers
+}
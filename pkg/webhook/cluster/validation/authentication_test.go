@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateAuthenticationConfiguration(t *testing.T) {
+	gitlab := func() kubermaticv1.JWTAuthenticator {
+		return kubermaticv1.JWTAuthenticator{
+			Issuer: kubermaticv1.JWTIssuer{
+				URL:       "https://gitlab.example.com",
+				Audiences: []string{"kubernetes"},
+			},
+			ClaimValidationRules: []kubermaticv1.JWTClaimValidationRule{
+				{
+					Expression: `claims.project_path.startsWith("platform/")`,
+					Message:    "only projects of the platform group are allowed",
+				},
+			},
+			ClaimMappings: kubermaticv1.JWTClaimMappings{
+				Username: kubermaticv1.JWTPrefixedClaimOrExpression{
+					Claim:  "sub",
+					Prefix: ptr.To("gitlab:"),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		version     string
+		oidc        kubermaticv1.OIDCSettings
+		jwt         []kubermaticv1.JWTAuthenticator
+		expectedErr string
+	}{
+		{
+			name:    "valid configuration with multiple issuers",
+			version: "1.30.2",
+			jwt: []kubermaticv1.JWTAuthenticator{
+				gitlab(),
+				{
+					Issuer: kubermaticv1.JWTIssuer{
+						URL:       "https://idp.example.com",
+						Audiences: []string{"kubernetes"},
+					},
+					ClaimMappings: kubermaticv1.JWTClaimMappings{
+						Username: kubermaticv1.JWTPrefixedClaimOrExpression{
+							Expression: `"idp:" + claims.email`,
+						},
+					},
+					ClaimValidationRules: []kubermaticv1.JWTClaimValidationRule{
+						{
+							Expression: "claims.email_verified == true",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "combined with OIDC settings",
+			version: "1.30.2",
+			oidc: kubermaticv1.OIDCSettings{
+				IssuerURL: "https://idp.example.com",
+				ClientID:  "kubernetes",
+			},
+			jwt:         []kubermaticv1.JWTAuthenticator{gitlab()},
+			expectedErr: "spec.authenticationConfiguration: Forbidden: cannot be used together with spec.oidc",
+		},
+		{
+			name:        "unsupported Kubernetes version",
+			version:     "1.29.6",
+			jwt:         []kubermaticv1.JWTAuthenticator{gitlab()},
+			expectedErr: "spec.authenticationConfiguration: Forbidden: requires Kubernetes 1.30.0 or newer",
+		},
+		{
+			name:        "duplicate issuer",
+			version:     "1.30.2",
+			jwt:         []kubermaticv1.JWTAuthenticator{gitlab(), gitlab()},
+			expectedErr: `spec.authenticationConfiguration.jwt[1].issuer.url: Duplicate value: "https://gitlab.example.com"`,
+		},
+		{
+			name:    "invalid CEL expression",
+			version: "1.30.2",
+			jwt: []kubermaticv1.JWTAuthenticator{
+				func() kubermaticv1.JWTAuthenticator {
+					authenticator := gitlab()
+					authenticator.ClaimValidationRules[0].Expression = "claims.project_path.startsWith("
+					return authenticator
+				}(),
+			},
+			expectedErr: "spec.authenticationConfiguration.jwt[0].claimValidationRules[0].expression: Invalid value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie(tc.version),
+					OIDC:    tc.oidc,
+					AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+						JWT: tc.jwt,
+					},
+				},
+			}
+
+			errs := validateAuthenticationConfiguration(cluster)
+
+			if tc.expectedErr == "" {
+				if len(errs) > 0 {
+					t.Fatalf("Expected no errors, but got: %v", errs.ToAggregate())
+				}
+				return
+			}
+
+			if len(errs) == 0 {
+				t.Fatalf("Expected error %q, but got none", tc.expectedErr)
+			}
+
+			if !strings.HasPrefix(errs[0].Error(), tc.expectedErr) {
+				t.Fatalf("Expected error %q, but got: %v", tc.expectedErr, errs.ToAggregate())
+			}
+		})
+	}
+}
@@ -94,6 +94,7 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 	versionManager := version.NewFromConfiguration(config)
 
 	errs := validation.ValidateNewClusterSpec(ctx, &cluster.Spec, datacenter, cloudProvider, versionManager, v.features, nil)
+	errs = append(errs, validateAuthenticationConfiguration(cluster)...)
 
 	if err := v.validateProjectRelation(ctx, cluster, nil); err != nil {
 		errs = append(errs, err)
@@ -126,6 +127,7 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 	updateManager := version.NewFromConfiguration(config)
 
 	errs := validation.ValidateClusterUpdate(ctx, newCluster, oldCluster, datacenter, cloudProvider, updateManager, v.features)
+	errs = append(errs, validateAuthenticationConfiguration(newCluster)...)
 
 	if err := v.validateProjectRelation(ctx, newCluster, oldCluster); err != nil {
 		errs = append(errs, err)
@@ -69,35 +69,62 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 			continue
 		}
 
-		if dcOldConfig.PoolCIDR != dcNewConfig.PoolCIDR {
-			return nil, errors.New("it's not allowed to update the pool CIDR for a datacenter")
+		if err := v.validateDatacenterUpdate(ctx, oldIPAMPool.Name, dc, dcOldConfig, dcNewConfig, false); err != nil {
+			return nil, err
 		}
 
-		if dcOldConfig.Type != dcNewConfig.Type {
-			return nil, errors.New("it's not allowed to update the allocation type for a datacenter")
+		// the IPv6 part of a dual-stack pool can be added later on, but not be changed or removed afterwards
+		if dcOldConfig.IPv6 != nil {
+			if dcNewConfig.IPv6 == nil {
+				return nil, errors.New("it's not allowed to remove the IPv6 pool for a datacenter")
+			}
+			if err := v.validateDatacenterUpdate(ctx, oldIPAMPool.Name, dc, ipv6DatacenterSettings(dcOldConfig), ipv6DatacenterSettings(dcNewConfig), true); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		var addedExclusions []string
+	return nil, nil
+}
 
-		switch dcOldConfig.Type {
-		case kubermaticv1.IPAMPoolAllocationTypeRange:
-			addedExclusions = getSliceAdditions(dcOldConfig.ExcludeRanges, dcNewConfig.ExcludeRanges)
-		case kubermaticv1.IPAMPoolAllocationTypePrefix:
-			if dcOldConfig.AllocationPrefix != dcNewConfig.AllocationPrefix {
-				return nil, errors.New("it's not allowed to update the allocation prefix for a datacenter")
-			}
-			addedExclusions = getSliceAdditions(
-				subnetCIDRSliceToStringSlice(dcOldConfig.ExcludePrefixes),
-				subnetCIDRSliceToStringSlice(dcNewConfig.ExcludePrefixes),
-			)
-		}
+func (v *validator) validateDatacenterUpdate(ctx context.Context, ipamPoolName, dc string, dcOldConfig, dcNewConfig kubermaticv1.IPAMPoolDatacenterSettings, ipv6 bool) error {
+	if dcOldConfig.PoolCIDR != dcNewConfig.PoolCIDR {
+		return errors.New("it's not allowed to update the pool CIDR for a datacenter")
+	}
 
-		if err := v.checkExclusionsNotAllocated(ctx, addedExclusions, oldIPAMPool.Name, dc, dcOldConfig.Type); err != nil {
-			return nil, err
+	if dcOldConfig.Type != dcNewConfig.Type {
+		return errors.New("it's not allowed to update the allocation type for a datacenter")
+	}
+
+	var addedExclusions []string
+
+	switch dcOldConfig.Type {
+	case kubermaticv1.IPAMPoolAllocationTypeRange:
+		addedExclusions = getSliceAdditions(dcOldConfig.ExcludeRanges, dcNewConfig.ExcludeRanges)
+	case kubermaticv1.IPAMPoolAllocationTypePrefix:
+		if dcOldConfig.AllocationPrefix != dcNewConfig.AllocationPrefix {
+			return errors.New("it's not allowed to update the allocation prefix for a datacenter")
 		}
+		addedExclusions = getSliceAdditions(
+			subnetCIDRSliceToStringSlice(dcOldConfig.ExcludePrefixes),
+			subnetCIDRSliceToStringSlice(dcNewConfig.ExcludePrefixes),
+		)
 	}
 
-	return nil, nil
+	return v.checkExclusionsNotAllocated(ctx, addedExclusions, ipamPoolName, dc, dcOldConfig.Type, ipv6)
+}
+
+// ipv6DatacenterSettings returns the IPv6 part of a dual-stack datacenter configuration
+// as a single-stack configuration, so it can be validated like any other pool.
+func ipv6DatacenterSettings(dcConfig kubermaticv1.IPAMPoolDatacenterSettings) kubermaticv1.IPAMPoolDatacenterSettings {
+	return kubermaticv1.IPAMPoolDatacenterSettings{
+		Type:             dcConfig.Type,
+		PoolCIDR:         dcConfig.IPv6.PoolCIDR,
+		AllocationPrefix: dcConfig.IPv6.AllocationPrefix,
+		ExcludePrefixes:  dcConfig.IPv6.ExcludePrefixes,
+		AllocationRange:  dcConfig.IPv6.AllocationRange,
+		ExcludeRanges:    dcConfig.IPv6.ExcludeRanges,
+	}
 }
 
 func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
@@ -112,54 +139,74 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) error {
 	}
 
 	for _, dcConfig := range ipamPool.Spec.Datacenters {
-		_, poolSubnet, err := net.ParseCIDR(string(dcConfig.PoolCIDR))
-		if err != nil {
+		if err := validateDatacenterConfig(dcConfig); err != nil {
 			return err
 		}
-		poolPrefix, bits := poolSubnet.Mask.Size()
 
-		switch dcConfig.Type {
-		case kubermaticv1.IPAMPoolAllocationTypeRange:
-			if dcConfig.AllocationRange <= 0 {
-				return errors.New("allocation range should be greater than zero")
+		if dcConfig.IPv6 != nil {
+			if !isIPv4CIDR(dcConfig.PoolCIDR) {
+				return errors.New("pool CIDR must be an IPv4 CIDR when an IPv6 pool is configured")
 			}
-
-			numberOfPoolSubnetIPsFloat64 := math.Pow(2, float64(bits-poolPrefix))
-			numberOfPoolSubnetIPs := int(numberOfPoolSubnetIPsFloat64)
-			if float64(numberOfPoolSubnetIPs) != numberOfPoolSubnetIPsFloat64 {
-				return errors.New("the pool is too big to be processed")
+			if isIPv4CIDR(dcConfig.IPv6.PoolCIDR) {
+				return errors.New("IPv6 pool CIDR must be an IPv6 CIDR")
 			}
-
-			if bits-poolPrefix > 12 {
-				return errors.New("pool prefix is too low for range allocation type")
+			if err := validateDatacenterConfig(ipv6DatacenterSettings(dcConfig)); err != nil {
+				return fmt.Errorf("invalid IPv6 pool: %w", err)
 			}
+		}
+	}
 
-			if dcConfig.AllocationRange > numberOfPoolSubnetIPs {
-				return errors.New("allocation range cannot be greater than the pool subnet possible number of IP addresses")
-			}
+	return nil
+}
 
-			for _, rangeToExclude := range dcConfig.ExcludeRanges {
-				if err := validateRange(rangeToExclude); err != nil {
-					return err
-				}
-			}
-		case kubermaticv1.IPAMPoolAllocationTypePrefix:
-			if dcConfig.AllocationPrefix < poolPrefix {
-				return errors.New("allocation prefix cannot be smaller than the pool subnet mask size")
-			}
-			if dcConfig.AllocationPrefix > bits {
-				return errors.New("invalid allocation prefix for IP version")
+func validateDatacenterConfig(dcConfig kubermaticv1.IPAMPoolDatacenterSettings) error {
+	_, poolSubnet, err := net.ParseCIDR(string(dcConfig.PoolCIDR))
+	if err != nil {
+		return err
+	}
+	poolPrefix, bits := poolSubnet.Mask.Size()
+
+	switch dcConfig.Type {
+	case kubermaticv1.IPAMPoolAllocationTypeRange:
+		if dcConfig.AllocationRange <= 0 {
+			return errors.New("allocation range should be greater than zero")
+		}
+
+		numberOfPoolSubnetIPsFloat64 := math.Pow(2, float64(bits-poolPrefix))
+		numberOfPoolSubnetIPs := int(numberOfPoolSubnetIPsFloat64)
+		if float64(numberOfPoolSubnetIPs) != numberOfPoolSubnetIPsFloat64 {
+			return errors.New("the pool is too big to be processed")
+		}
+
+		if bits-poolPrefix > 12 {
+			return errors.New("pool prefix is too low for range allocation type")
+		}
+
+		if dcConfig.AllocationRange > numberOfPoolSubnetIPs {
+			return errors.New("allocation range cannot be greater than the pool subnet possible number of IP addresses")
+		}
+
+		for _, rangeToExclude := range dcConfig.ExcludeRanges {
+			if err := validateRange(rangeToExclude); err != nil {
+				return err
 			}
+		}
+	case kubermaticv1.IPAMPoolAllocationTypePrefix:
+		if dcConfig.AllocationPrefix < poolPrefix {
+			return errors.New("allocation prefix cannot be smaller than the pool subnet mask size")
+		}
+		if dcConfig.AllocationPrefix > bits {
+			return errors.New("invalid allocation prefix for IP version")
+		}
 
-			for _, subnetCIDRToExclude := range dcConfig.ExcludePrefixes {
-				_, subnet, err := net.ParseCIDR(string(subnetCIDRToExclude))
-				if err != nil {
-					return fmt.Errorf("invalid CIDR for subnet to exclude: %w", err)
-				}
-				subnetPrefix, _ := subnet.Mask.Size()
-				if dcConfig.AllocationPrefix != subnetPrefix {
-					return fmt.Errorf("invalid length for subnet to exclude \"%s\": must be the same as the pool allocation prefix (%d)", subnetCIDRToExclude, subnetPrefix)
-				}
+		for _, subnetCIDRToExclude := range dcConfig.ExcludePrefixes {
+			_, subnet, err := net.ParseCIDR(string(subnetCIDRToExclude))
+			if err != nil {
+				return fmt.Errorf("invalid CIDR for subnet to exclude: %w", err)
+			}
+			subnetPrefix, _ := subnet.Mask.Size()
+			if dcConfig.AllocationPrefix != subnetPrefix {
+				return fmt.Errorf("invalid length for subnet to exclude \"%s\": must be the same as the pool allocation prefix (%d)", subnetCIDRToExclude, subnetPrefix)
 			}
 		}
 	}
@@ -167,6 +214,11 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) error {
 	return nil
 }
 
+func isIPv4CIDR(cidr kubermaticv1.SubnetCIDR) bool {
+	ip, _, err := net.ParseCIDR(string(cidr))
+	return err == nil && ip.To4() != nil
+}
+
 func validateRange(r string) error {
 	splitRange := strings.Split(r, "-")
 	if len(splitRange) != 1 && len(splitRange) != 2 {
@@ -210,7 +262,7 @@ func (v *validator) getSeedClient(ctx context.Context) (ctrlruntimeclient.Client
 	return client, nil
 }
 
-func (v *validator) checkExclusionsNotAllocated(ctx context.Context, exclusions []string, ipamPoolName string, dc string, allocationType kubermaticv1.IPAMPoolAllocationType) error {
+func (v *validator) checkExclusionsNotAllocated(ctx context.Context, exclusions []string, ipamPoolName string, dc string, allocationType kubermaticv1.IPAMPoolAllocationType, ipv6 bool) error {
 	if len(exclusions) == 0 {
 		return nil
 	}
@@ -235,22 +287,31 @@ func (v *validator) checkExclusionsNotAllocated(ctx context.Context, exclusions
 
 		errExclusionConflict := fmt.Errorf("failed to add exclusion: there is an conflicted allocation in IPAM pool \"%s\" and datacenter \"%s\"", ipamPoolName, dc)
 
+		allocatedCIDR, allocatedAddresses := ipamAllocation.Spec.CIDR, ipamAllocation.Spec.Addresses
+		if ipv6 {
+			allocatedCIDR, allocatedAddresses = ipamAllocation.Spec.IPv6CIDR, ipamAllocation.Spec.IPv6Addresses
+		}
+
 		switch allocationType {
 		case kubermaticv1.IPAMPoolAllocationTypeRange:
-			if addressRangesConflict(ipamAllocation.Spec.Addresses, exclusions) {
+			if addressRangesConflict(allocatedAddresses, exclusions) {
 				return errExclusionConflict
 			}
 		case kubermaticv1.IPAMPoolAllocationTypePrefix:
+			if allocatedCIDR == "" {
+				// the allocation has no part for this IP family (yet)
+				continue
+			}
 			for _, exclusion := range exclusions {
 				excludePrefixIP, _, err := net.ParseCIDR(exclusion)
 				if err != nil {
 					return err
 				}
-				_, allocatedSubnet, err := net.ParseCIDR(string(ipamAllocation.Spec.CIDR))
+				_, allocatedSubnet, err := net.ParseCIDR(string(allocatedCIDR))
 				if err != nil {
 					return err
 				}
-				if string(ipamAllocation.Spec.CIDR) == exclusion || allocatedSubnet.Contains(excludePrefixIP) {
+				if string(allocatedCIDR) == exclusion || allocatedSubnet.Contains(excludePrefixIP) {
 					return errExclusionConflict
 				}
 			}
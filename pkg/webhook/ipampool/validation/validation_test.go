@@ -1018,6 +1018,170 @@ func TestValidator(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("it's not allowed to update the allocation prefix for a datacenter"),
 		},
+		{
+			name: "allowed dual-stack prefix creation",
+			op:   admissionv1.Create,
+			ipamPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 64,
+								ExcludePrefixes:  []kubermaticv1.SubnetCIDR{"fd00::/64"},
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "dual-stack pool with IPv6 pool CIDR",
+			op:   admissionv1.Create,
+			ipamPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "fd01::/48",
+							AllocationPrefix: 64,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 64,
+							},
+						},
+					},
+				},
+			},
+			expectedError: errors.New("pool CIDR must be an IPv4 CIDR when an IPv6 pool is configured"),
+		},
+		{
+			name: "dual-stack pool with invalid IPv6 allocation prefix",
+			op:   admissionv1.Create,
+			ipamPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 32,
+							},
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("invalid IPv6 pool: %w", errors.New("allocation prefix cannot be smaller than the pool subnet mask size")),
+		},
+		{
+			name: "not allowed to remove the IPv6 pool",
+			op:   admissionv1.Update,
+			ipamPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+						},
+					},
+				},
+			},
+			oldIPAMPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 64,
+							},
+						},
+					},
+				},
+			},
+			expectedError: errors.New("it's not allowed to remove the IPv6 pool for a datacenter"),
+		},
+		{
+			name: "added IPv6 prefix exclusions: conflict with allocation",
+			op:   admissionv1.Update,
+			ipamPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ipam-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 64,
+								ExcludePrefixes:  []kubermaticv1.SubnetCIDR{"fd00:0:0:1::/64"},
+							},
+						},
+					},
+				},
+			},
+			oldIPAMPool: &kubermaticv1.IPAMPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pool",
+				},
+				Spec: kubermaticv1.IPAMPoolSpec{
+					Datacenters: map[string]kubermaticv1.IPAMPoolDatacenterSettings{
+						"dc": {
+							Type:             "prefix",
+							PoolCIDR:         "192.168.1.0/28",
+							AllocationPrefix: 29,
+							IPv6: &kubermaticv1.IPAMPoolIPv6Settings{
+								PoolCIDR:         "fd00::/48",
+								AllocationPrefix: 64,
+							},
+						},
+					},
+				},
+			},
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.IPAMAllocation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-pool",
+						Namespace:       fmt.Sprintf("cluster-%s", "test-cluster-1"),
+						ResourceVersion: "1",
+					},
+					Spec: kubermaticv1.IPAMAllocationSpec{
+						Type:     kubermaticv1.IPAMPoolAllocationTypePrefix,
+						DC:       "dc",
+						CIDR:     "192.168.1.0/29",
+						IPv6CIDR: "fd00:0:0:1::/64",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("failed to add exclusion: there is an conflicted allocation in IPAM pool \"%s\" and datacenter \"%s\"", "test-pool", "dc"),
+		},
 	}
 
 	for _, tc := range testCases {
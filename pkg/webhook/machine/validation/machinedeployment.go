@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// machineDeploymentValidator for validating MachineDeployment CRD.
+type machineDeploymentValidator struct {
+	log             *zap.SugaredLogger
+	seedClient      ctrlruntimeclient.Client
+	userClient      ctrlruntimeclient.Client
+	caBundle        *certificates.CABundle
+	subjectSelector labels.Selector
+	datacenter      string
+	provider        string
+}
+
+// NewMachineDeploymentValidator returns a new MachineDeployment validator, which rejects scaling
+// up MachineDeployments beyond the project's resource quota.
+func NewMachineDeploymentValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *certificates.CABundle,
+	projectID, datacenter, provider string) (*machineDeploymentValidator, error) {
+	subjectSelector, err := newSubjectSelector(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &machineDeploymentValidator{
+		log:             log,
+		seedClient:      seedClient,
+		userClient:      userClient,
+		caBundle:        caBundle,
+		subjectSelector: subjectSelector,
+		datacenter:      datacenter,
+		provider:        provider,
+	}, nil
+}
+
+var _ admission.CustomValidator = &machineDeploymentValidator{}
+
+func (v *machineDeploymentValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	md, ok := obj.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return nil, errors.New("object is not a MachineDeployment")
+	}
+
+	return nil, v.validate(ctx, nil, md)
+}
+
+func (v *machineDeploymentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldMD, ok := oldObj.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return nil, errors.New("existing object is not a MachineDeployment")
+	}
+
+	newMD, ok := newObj.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return nil, errors.New("updated object is not a MachineDeployment")
+	}
+
+	return nil, v.validate(ctx, oldMD, newMD)
+}
+
+func (v *machineDeploymentValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *machineDeploymentValidator) validate(ctx context.Context, oldMD, newMD *clusterv1alpha1.MachineDeployment) error {
+	log := v.log.With("machinedeployment", newMD.Name)
+	log.Debug("validating quota")
+
+	quota, err := getResourceQuota(ctx, v.seedClient, v.subjectSelector)
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		return nil
+	}
+
+	return validateMachineDeploymentQuota(ctx, log, v.userClient, oldMD, newMD, v.caBundle, quota, v.datacenter, v.provider)
+}
@@ -41,20 +41,18 @@ type validator struct {
 	userClient      ctrlruntimeclient.Client
 	caBundle        *certificates.CABundle
 	subjectSelector labels.Selector
+	datacenter      string
+	provider        string
 }
 
-// NewValidator returns a new Machine validator.
+// NewValidator returns a new Machine validator. datacenter and provider are the ones of the
+// cluster and used to select the applicable scoped quotas.
 func NewValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *certificates.CABundle,
-	projectID string) (*validator, error) {
-	subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectID})
-	if err != nil {
-		return nil, fmt.Errorf("error creating resource quota subject name requirement: %w", err)
-	}
-	subjectKindReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectKindLabelKey, selection.Equals, []string{kubermaticv1.ProjectSubjectKind})
+	projectID, datacenter, provider string) (*validator, error) {
+	subjectSelector, err := newSubjectSelector(projectID)
 	if err != nil {
-		return nil, fmt.Errorf("error creating resource quota subject kind requirement: %w", err)
+		return nil, err
 	}
-	subjectSelector := labels.NewSelector().Add(*subjectNameReq, *subjectKindReq)
 
 	return &validator{
 		log:             log,
@@ -62,6 +60,8 @@ func NewValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.Suga
 		userClient:      userClient,
 		caBundle:        caBundle,
 		subjectSelector: subjectSelector,
+		datacenter:      datacenter,
+		provider:        provider,
 	}, nil
 }
 
@@ -81,7 +81,7 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, err
 	}
 	if quota != nil {
-		return nil, validateQuota(ctx, log, v.userClient, machine, v.caBundle, quota)
+		return nil, validateQuota(ctx, log, v.userClient, machine, v.caBundle, quota, v.datacenter, v.provider)
 	}
 	return nil, nil
 }
@@ -95,3 +95,17 @@ func (v *validator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admi
 func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// newSubjectSelector returns a selector for the ResourceQuota of the given project.
+func newSubjectSelector(projectID string) (labels.Selector, error) {
+	subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectID})
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource quota subject name requirement: %w", err)
+	}
+	subjectKindReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectKindLabelKey, selection.Equals, []string{kubermaticv1.ProjectSubjectKind})
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource quota subject kind requirement: %w", err)
+	}
+
+	return labels.NewSelector().Add(*subjectNameReq, *subjectKindReq), nil
+}
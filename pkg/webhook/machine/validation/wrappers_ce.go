@@ -32,7 +32,12 @@ import (
 )
 
 func validateQuota(_ context.Context, _ *zap.SugaredLogger, _ ctrlruntimeclient.Client, _ *clusterv1alpha1.Machine,
-	_ *certificates.CABundle, _ *kubermaticv1.ResourceQuota) error {
+	_ *certificates.CABundle, _ *kubermaticv1.ResourceQuota, _, _ string) error {
+	return nil
+}
+
+func validateMachineDeploymentQuota(_ context.Context, _ *zap.SugaredLogger, _ ctrlruntimeclient.Client, _, _ *clusterv1alpha1.MachineDeployment,
+	_ *certificates.CABundle, _ *kubermaticv1.ResourceQuota, _, _ string) error {
 	return nil
 }
 
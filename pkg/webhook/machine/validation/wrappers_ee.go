@@ -34,8 +34,13 @@ import (
 )
 
 func validateQuota(ctx context.Context, log *zap.SugaredLogger, userClient ctrlruntimeclient.Client,
-	machine *clusterv1alpha1.Machine, caBundle *certificates.CABundle, resourceQuota *kubermaticv1.ResourceQuota) error {
-	return eemachinevalidation.ValidateQuota(ctx, log, userClient, machine, caBundle, resourceQuota)
+	machine *clusterv1alpha1.Machine, caBundle *certificates.CABundle, resourceQuota *kubermaticv1.ResourceQuota, datacenter, provider string) error {
+	return eemachinevalidation.ValidateQuota(ctx, log, userClient, machine, caBundle, resourceQuota, datacenter, provider)
+}
+
+func validateMachineDeploymentQuota(ctx context.Context, log *zap.SugaredLogger, userClient ctrlruntimeclient.Client,
+	oldMD, newMD *clusterv1alpha1.MachineDeployment, caBundle *certificates.CABundle, resourceQuota *kubermaticv1.ResourceQuota, datacenter, provider string) error {
+	return eemachinevalidation.ValidateMachineDeploymentQuota(ctx, log, userClient, oldMD, newMD, caBundle, resourceQuota, datacenter, provider)
 }
 
 func getResourceQuota(ctx context.Context, seedClient ctrlruntimeclient.Client, subjectSelector labels.Selector) (*kubermaticv1.ResourceQuota, error) {
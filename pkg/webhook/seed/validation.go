@@ -27,6 +27,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/backupcrypto"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
@@ -159,6 +160,10 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object, isDelete b
 			}
 		}
 
+		if err := validateKMSPlugins(dc.Spec.KMSPlugins); err != nil {
+			return fmt.Errorf("datacenter %q is invalid: %w", dcName, err)
+		}
+
 		if existingSeed == nil {
 			continue
 		}
@@ -186,6 +191,14 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object, isDelete b
 		return err
 	}
 
+	if err := validation.ValidateAuditLogAggregatorSettings(subject.Spec.AuditLogging); err != nil {
+		return err
+	}
+
+	if !isDelete && subject.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway && subject.Spec.ExposeGateway == nil {
+		return fmt.Errorf("the %s expose strategy requires an exposeGateway configuration", kubermaticv1.ExposeStrategyGateway)
+	}
+
 	return nil
 }
 
@@ -248,6 +261,12 @@ func validateEtcdBackupConfiguration(ctx context.Context, seedClient ctrlruntime
 					return fmt.Errorf("invalid etcd backup configuration: invalid destination %q credentials %s: %w", name, dest.Credentials.Name, err)
 				}
 			}
+
+			if dest.Encryption != nil {
+				if _, err := backupcrypto.GetKeyEncryptionKeys(ctx, seedClient, dest.Encryption); err != nil {
+					return fmt.Errorf("invalid etcd backup configuration: invalid destination %q encryption: %w", name, err)
+				}
+			}
 		}
 	}
 
@@ -264,3 +283,22 @@ func validateKubeVirtSupportedOS(datacenterSpec *kubermaticv1.DatacenterSpecKube
 	}
 	return nil
 }
+
+func validateKMSPlugins(plugins []kubermaticv1.KMSPlugin) error {
+	names := sets.New[string]()
+	for _, plugin := range plugins {
+		if plugin.Name == "" {
+			return errors.New("KMS plugin name must not be empty")
+		}
+		if names.Has(plugin.Name) {
+			return fmt.Errorf("KMS plugin %q is defined more than once", plugin.Name)
+		}
+		if plugin.Image == "" {
+			return fmt.Errorf("KMS plugin %q has no image defined", plugin.Name)
+		}
+
+		names.Insert(plugin.Name)
+	}
+
+	return nil
+}
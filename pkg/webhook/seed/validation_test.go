@@ -486,6 +486,32 @@ func TestValidate(t *testing.T) {
 			features:    features.FeatureGate{},
 			errExpected: true,
 		},
+		{
+			name: "Gateway expose strategy requires a Gateway configuration",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Gateway expose strategy with a Gateway configuration should succeed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+					ExposeGateway: &kubermaticv1.ExposeGatewayConfig{
+						GatewayClassName: "envoy",
+					},
+				},
+			},
+		},
 	}
 
 	scheme := fake.NewScheme()
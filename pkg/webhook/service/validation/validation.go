@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validator for validating Services against the project's resource quota.
+type validator struct {
+	log             *zap.SugaredLogger
+	seedClient      ctrlruntimeclient.Client
+	subjectSelector labels.Selector
+	datacenter      string
+	provider        string
+}
+
+// NewValidator returns a new Service validator. datacenter and provider are the ones of the
+// cluster and used to select the applicable scoped quotas.
+func NewValidator(seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger, projectID, datacenter, provider string) (*validator, error) {
+	subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectID})
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource quota subject name requirement: %w", err)
+	}
+	subjectKindReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectKindLabelKey, selection.Equals, []string{kubermaticv1.ProjectSubjectKind})
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource quota subject kind requirement: %w", err)
+	}
+
+	return &validator{
+		log:             log,
+		seedClient:      seedClient,
+		subjectSelector: labels.NewSelector().Add(*subjectNameReq, *subjectKindReq),
+		datacenter:      datacenter,
+		provider:        provider,
+	}, nil
+}
+
+var _ admission.CustomValidator = &validator{}
+
+func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	service, ok := obj.(*corev1.Service)
+	if !ok {
+		return nil, errors.New("object is not a Service")
+	}
+
+	return nil, v.validate(ctx, nil, service)
+}
+
+func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldService, ok := oldObj.(*corev1.Service)
+	if !ok {
+		return nil, errors.New("existing object is not a Service")
+	}
+
+	newService, ok := newObj.(*corev1.Service)
+	if !ok {
+		return nil, errors.New("updated object is not a Service")
+	}
+
+	return nil, v.validate(ctx, oldService, newService)
+}
+
+func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *validator) validate(ctx context.Context, oldService, newService *corev1.Service) error {
+	// only LoadBalancer Services are subject to quotas
+	if newService.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return nil
+	}
+
+	log := v.log.With("service", ctrlruntimeclient.ObjectKeyFromObject(newService))
+	log.Debug("validating quota")
+
+	quota, err := getResourceQuota(ctx, v.seedClient, v.subjectSelector)
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		return nil
+	}
+
+	return validateQuota(log, oldService, newService, quota, v.datacenter, v.provider)
+}
@@ -0,0 +1,52 @@
+//go:build ee
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	eeservicevalidation "k8c.io/kubermatic/v2/pkg/ee/validation/service"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func validateQuota(log *zap.SugaredLogger, oldService, newService *corev1.Service, resourceQuota *kubermaticv1.ResourceQuota, datacenter, provider string) error {
+	return eeservicevalidation.ValidateQuota(log, oldService, newService, resourceQuota, datacenter, provider)
+}
+
+func getResourceQuota(ctx context.Context, seedClient ctrlruntimeclient.Client, subjectSelector labels.Selector) (*kubermaticv1.ResourceQuota, error) {
+	quotaList := &kubermaticv1.ResourceQuotaList{}
+	if err := seedClient.List(ctx, quotaList, &ctrlruntimeclient.ListOptions{
+		LabelSelector: subjectSelector,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	if len(quotaList.Items) == 0 {
+		return nil, nil
+	}
+
+	return &quotaList.Items[0], nil
+}